@@ -0,0 +1,180 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Weekday is the abbreviation of a day of the week
+// +kubebuilder:validation:Enum=Sun;Mon;Tue;Wed;Thu;Fri;Sat
+type Weekday string
+
+var weekdays = map[Weekday]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// ActiveWindow is a recurring range of time in which the chaos is allowed to be active
+type ActiveWindow struct {
+	// Start is the time of day when the window begins, in the form of "15:04".
+	Start string `json:"start"`
+
+	// End is the time of day when the window ends, in the form of "15:04".
+	// The window which doesn't end after it begins crosses the midnight.
+	End string `json:"end"`
+
+	// Days are the days of the week on which the window begins, e.g. Mon, Tue.
+	// The window begins every day if it's empty.
+	// +optional
+	Days []Weekday `json:"days,omitempty" faker:"weekdays"`
+
+	// TimeZone is the IANA time zone of the window, e.g. "Asia/Shanghai". UTC by default.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ActiveWindows are the windows in which the chaos is active. The chaos is recovered outside all of
+// the windows, and applied again once a window begins. The chaos without windows is always active.
+type ActiveWindows []ActiveWindow
+
+// timeRange is a range of time in [Begin, End)
+type timeRange struct {
+	Begin time.Time
+	End   time.Time
+}
+
+// Active returns whether the time is in any of the windows, and how long it is until that changes.
+func (in ActiveWindows) Active(now time.Time) (bool, time.Duration, error) {
+	if len(in) == 0 {
+		return true, 0, nil
+	}
+
+	var ranges []timeRange
+	for _, window := range in {
+		occurrences, err := window.occurrences(now)
+		if err != nil {
+			return true, 0, err
+		}
+		ranges = append(ranges, occurrences...)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Begin.Before(ranges[j].Begin)
+	})
+
+	// the overlapping and the adjacent ranges are merged, so the end of a range is a real change
+	var merged []timeRange
+	for _, r := range ranges {
+		if last := len(merged) - 1; last >= 0 && !r.Begin.After(merged[last].End) {
+			if r.End.After(merged[last].End) {
+				merged[last].End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	for _, r := range merged {
+		if now.Before(r.Begin) {
+			return false, r.Begin.Sub(now), nil
+		}
+		if now.Before(r.End) {
+			return true, r.End.Sub(now), nil
+		}
+	}
+	return false, 0, nil
+}
+
+// occurrences returns the ranges of the window which begin from the day before to a week after the time.
+// The day before is included for the window crossing the midnight.
+func (in *ActiveWindow) occurrences(now time.Time) ([]timeRange, error) {
+	location, err := time.LoadLocation(in.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	start, err := time.Parse("15:04", in.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse("15:04", in.End)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[time.Weekday]struct{}, len(in.Days))
+	for _, day := range in.Days {
+		weekday, ok := weekdays[day]
+		if !ok {
+			return nil, fmt.Errorf("unknown day %s", day)
+		}
+		days[weekday] = struct{}{}
+	}
+
+	local := now.In(location)
+	var ranges []timeRange
+	for offset := -1; offset <= 7; offset++ {
+		begin := time.Date(local.Year(), local.Month(), local.Day()+offset, start.Hour(), start.Minute(), 0, 0, location)
+		if _, ok := days[begin.Weekday()]; len(days) > 0 && !ok {
+			continue
+		}
+
+		endDay := begin.Day()
+		if !end.After(start) {
+			endDay++
+		}
+		ranges = append(ranges, timeRange{
+			Begin: begin,
+			End:   time.Date(begin.Year(), begin.Month(), endDay, end.Hour(), end.Minute(), 0, 0, location),
+		})
+	}
+	return ranges, nil
+}
+
+// Validate validates the time of day, the days and the time zone of the windows
+func (in ActiveWindows) Validate(path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, window := range in {
+		windowField := path.Index(i)
+		if _, err := time.Parse("15:04", window.Start); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowField.Child("start"), window.Start,
+				fmt.Sprintf("parse start field error:%s", err)))
+		}
+		if _, err := time.Parse("15:04", window.End); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowField.Child("end"), window.End,
+				fmt.Sprintf("parse end field error:%s", err)))
+		}
+		for j, day := range window.Days {
+			if _, ok := weekdays[day]; !ok {
+				allErrs = append(allErrs, field.NotSupported(windowField.Child("days").Index(j), day,
+					[]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}))
+			}
+		}
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowField.Child("timeZone"), window.TimeZone,
+				fmt.Sprintf("load time zone error:%s", err)))
+		}
+	}
+
+	return allErrs
+}
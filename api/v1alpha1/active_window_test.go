@@ -0,0 +1,82 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("ActiveWindows", func() {
+	Context("Active", func() {
+		It("is always active without windows", func() {
+			active, untilChange, err := ActiveWindows{}.Active(time.Now())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(active).To(BeTrue())
+			Expect(untilChange).To(BeZero())
+		})
+
+		It("crosses the midnight", func() {
+			windows := ActiveWindows{{Start: "22:00", End: "02:00", Days: []Weekday{"Fri"}}}
+
+			// it's 1:00 on Saturday
+			active, untilChange, err := windows.Active(time.Date(2021, time.June, 19, 1, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(active).To(BeTrue())
+			Expect(untilChange).To(Equal(time.Hour))
+
+			// it's 3:00 on Saturday, and the next window begins on Friday
+			active, untilChange, err = windows.Active(time.Date(2021, time.June, 19, 3, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(active).To(BeFalse())
+			Expect(untilChange).To(Equal(6*24*time.Hour + 19*time.Hour))
+		})
+
+		It("merges the adjacent windows", func() {
+			windows := ActiveWindows{
+				{Start: "09:00", End: "12:00"},
+				{Start: "12:00", End: "17:00"},
+			}
+
+			active, untilChange, err := windows.Active(time.Date(2021, time.June, 16, 10, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(active).To(BeTrue())
+			Expect(untilChange).To(Equal(7 * time.Hour))
+		})
+
+		It("returns the error of the invalid window", func() {
+			_, _, err := ActiveWindows{{Start: "9am", End: "17:00"}}.Active(time.Now())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Validate", func() {
+		It("validates the time of day, the days and the time zone", func() {
+			windows := ActiveWindows{
+				{Start: "09:00", End: "17:00", Days: []Weekday{"Mon"}, TimeZone: "Asia/Shanghai"},
+				{Start: "9am", End: "25:00", Days: []Weekday{"Monday"}, TimeZone: "Mars/Olympus"},
+			}
+
+			errs := windows.Validate(field.NewPath("spec", "activeWindows"))
+			Expect(errs).To(HaveLen(4))
+			Expect(errs[0].Field).To(Equal("spec.activeWindows[1].start"))
+			Expect(errs[1].Field).To(Equal("spec.activeWindows[1].end"))
+			Expect(errs[2].Field).To(Equal("spec.activeWindows[1].days[0]"))
+			Expect(errs[3].Field).To(Equal("spec.activeWindows[1].timeZone"))
+		})
+	})
+})
@@ -20,6 +20,11 @@ import (
 )
 
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="action",type=string,JSONPath=`.status.action`
+// +kubebuilder:printcolumn:name="phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="selected",type=integer,JSONPath=`.status.selected`
+// +kubebuilder:printcolumn:name="duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +chaos-mesh:base
 // +chaos-mesh:oneshot=in.Spec.Action==Ec2Restart
 
@@ -54,7 +59,22 @@ type AWSChaosSpec struct {
 
 	// Duration represents the duration of the chaos action.
 	// +optional
-	Duration *string `json:"duration,omitempty"`
+	Duration *Duration `json:"duration,omitempty"`
+
+	// RecoverTimeout represents how long to wait for the chaos to be recovered after the duration ends,
+	// the chaos which is not recovered in time is escalated with a warning event and the RecoverTimedOut condition.
+	// +optional
+	RecoverTimeout *string `json:"recoverTimeout,omitempty"`
+
+	// ActiveWindows are the recurring windows in which the chaos is active, e.g. 9:00-17:00 on weekdays.
+	// The chaos is recovered outside the windows, and applied again inside them.
+	// +optional
+	ActiveWindows ActiveWindows `json:"activeWindows,omitempty"`
+
+	// ManualRecover keeps the chaos injected after the duration is exceeded, until it's paused or deleted.
+	// It's not supported by the one-shot actions.
+	// +optional
+	ManualRecover bool `json:"manualRecover,omitempty"`
 
 	// SecretName defines the name of kubernetes secret.
 	// +optional
@@ -15,7 +15,6 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -54,7 +53,7 @@ func (in *AWSChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *AWSChaos) ValidateUpdate(old runtime.Object) error {
 	awschaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*AWSChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*AWSChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -71,6 +70,8 @@ func (in *AWSChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *AWSChaos) Validate() error {
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
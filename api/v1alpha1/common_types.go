@@ -25,6 +25,21 @@ import (
 const (
 	// PauseAnnotationKey defines the annotation used to pause a chaos
 	PauseAnnotationKey = "experiment.chaos-mesh.org/pause"
+	// IdempotencyKeyAnnotationKey defines the annotation used to create a chaos at most once through the
+	// chaos dashboard API, the creation of a chaos with the same key and name in the same namespace returns
+	// the existing one. It's ignored by the webhook, so the chaos created by kubectl isn't deduplicated.
+	IdempotencyKeyAnnotationKey = "experiment.chaos-mesh.org/idempotency-key"
+	// ClusterWideSelectorAnnotationKey defines the annotation used to select the pods in the whole cluster
+	// when the selector omits the namespaces, it only takes effect in the cluster scoped mode
+	ClusterWideSelectorAnnotationKey = "experiment.chaos-mesh.org/cluster-wide-selector"
+	// ArchiveAnnotationKey defines the annotation used to archive the chaos managed by a schedule when it's removed
+	ArchiveAnnotationKey = "experiment.chaos-mesh.org/archive"
+	// RecoverOrderAnnotationKey defines the annotation used to recover the records in the reverse order of being
+	// injected when it's set to "reverse", otherwise the records are recovered in no particular order
+	RecoverOrderAnnotationKey = "experiment.chaos-mesh.org/recover-order"
+	// NetemDelayAnnotationKey defines the pod annotation which requests a network delay on the pod itself,
+	// e.g. `chaos-mesh.org/netem-delay: "100ms"`, it's turned into a NetworkChaos controlled by the pod
+	NetemDelayAnnotationKey = "chaos-mesh.org/netem-delay"
 )
 
 type ChaosStatus struct {
@@ -34,8 +49,36 @@ type ChaosStatus struct {
 
 	// Experiment records the last experiment state.
 	Experiment ExperimentStatus `json:"experiment"`
+
+	// Phase is a concise summary of the records, e.g. "Running" when all of them are injected
+	// +optional
+	Phase ChaosPhase `json:"phase,omitempty"`
+
+	// Action is the action of the chaos, it's empty for the chaos without actions
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// Selected is the number of the selected targets
+	// +optional
+	Selected int `json:"selected,omitempty"`
 }
 
+// ChaosPhase is the summary of the records of a chaos, it's shown by `kubectl get`
+type ChaosPhase string
+
+const (
+	// ChaosPhaseInjecting means some records are still being injected
+	ChaosPhaseInjecting ChaosPhase = "Injecting"
+	// ChaosPhaseRunning means all records are injected
+	ChaosPhaseRunning ChaosPhase = "Running"
+	// ChaosPhaseRecovering means some records are still being recovered
+	ChaosPhaseRecovering ChaosPhase = "Recovering"
+	// ChaosPhaseRecovered means all records are recovered
+	ChaosPhaseRecovered ChaosPhase = "Recovered"
+	// ChaosPhasePaused means all records are recovered as the chaos is paused
+	ChaosPhasePaused ChaosPhase = "Paused"
+)
+
 type ChaosConditionType string
 
 const (
@@ -43,6 +86,13 @@ const (
 	ConditionAllInjected  ChaosConditionType = "AllInjected"
 	ConditionAllRecovered ChaosConditionType = "AllRecovered"
 	ConditionPaused       ChaosConditionType = "Paused"
+	// ConditionRecoverTimedOut is true when the chaos is not recovered in the recover timeout after the duration ends
+	ConditionRecoverTimedOut ChaosConditionType = "RecoverTimedOut"
+	// ConditionDaemonUnreachable is true when some records are quarantined as the chaos daemon serving them is unreachable
+	ConditionDaemonUnreachable ChaosConditionType = "DaemonUnreachable"
+	// ConditionDurationExceeded is true when the duration of the chaos is exceeded. The chaos with manualRecover
+	// keeps its injected records then, but doesn't apply any other one.
+	ConditionDurationExceeded ChaosConditionType = "DurationExceeded"
 )
 
 type ChaosCondition struct {
@@ -67,12 +117,40 @@ type ExperimentStatus struct {
 	// +optional
 	// Records are used to track the running status
 	Records []*Record `json:"containerRecords,omitempty"`
+	// +optional
+	// Selections are how the records are chosen by each selector, which are used to reproduce or audit the selection
+	Selections []Selection `json:"selections,omitempty"`
+}
+
+// Selection is how the targets are chosen by a selector
+type Selection struct {
+	SelectorKey string `json:"selectorKey"`
+	// Seed is the seed of choosing the targets randomly, it could be set to the spec of selector to replay the selection
+	Seed int64 `json:"seed"`
+	// Targets are the ids of the chosen targets
+	// +optional
+	Targets []string `json:"targets,omitempty"`
 }
 
 type Record struct {
 	Id          string `json:"id"`
 	SelectorKey string `json:"selectorKey"`
 	Phase       Phase  `json:"phase"`
+	// Message is the reason of the last failure of this record
+	// +optional
+	Message string `json:"message,omitempty"`
+	// ApplySequence is the order of this record being injected among the records,
+	// it's used to recover the records in the reverse order
+	// +optional
+	ApplySequence int64 `json:"applySequence,omitempty"`
+	// DaemonUnreachable is true if the chaos daemon serving the target is unreachable,
+	// the record is quarantined until the daemon is back
+	// +optional
+	DaemonUnreachable bool `json:"daemonUnreachable,omitempty"`
+	// InjectedAt is the time this record is injected for the first time, it's kept after recovery
+	// so that how long the chaos has been active is measured by the wall clock
+	// +optional
+	InjectedAt *metav1.Time `json:"injectedAt,omitempty"`
 }
 
 type Phase string
@@ -84,6 +162,15 @@ const (
 	Injected Phase = "Injected"
 )
 
+// Duration is the string form of a duration, e.g. "300ms", "1.5h" or "2h45m". It's validated by the webhook
+// rather than at unmarshal, so that the chaos stored with an invalid duration could still be listed and deleted.
+type Duration string
+
+// Parse returns the parsed time.Duration
+func (in Duration) Parse() (time.Duration, error) {
+	return time.ParseDuration(string(in))
+}
+
 var log = ctrl.Log.WithName("api")
 
 // +kubebuilder:object:generate=false
@@ -94,6 +181,9 @@ type InnerObject interface {
 	IsPaused() bool
 	GetChaos() *ChaosInstance
 	DurationExceeded(time.Time) (bool, time.Duration, error)
+	RecoverTimeoutExceeded(time.Time) (bool, time.Duration, error)
+	OutOfActiveWindows(time.Time) (bool, time.Duration, error)
+	IsManualRecover() bool
 	IsOneShot() bool
 	StatefulObject
 }
@@ -127,6 +217,8 @@ type ChaosInstance struct {
 	Duration  string
 	Status    ChaosStatus
 	UID       string
+
+	RecoverTimeout string
 }
 
 // +kubebuilder:object:generate=false
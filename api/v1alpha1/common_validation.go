@@ -14,11 +14,21 @@
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strconv"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -26,9 +36,30 @@ const (
 	ValidateValueParseError = "parse value field error:%s"
 )
 
+var (
+	// RequireDuration makes the webhook reject the chaos without a duration, except the one-shot chaos.
+	// It's a cluster-wide guardrail configured by the controller manager.
+	RequireDuration = false
+
+	// MaxDuration is the upper bound of the duration of chaos, zero means unlimited.
+	// It's a cluster-wide guardrail configured by the controller manager.
+	MaxDuration time.Duration
+
+	// ownerReader reads the workflow node or the pod which a chaos claims to be controlled by.
+	// It's registered by the controller manager, no chaos is exempted by its owner without it.
+	ownerReader client.Reader
+)
+
+// RegisterOwnerReader registers the reader used by the webhook to check the owner of a chaos
+func RegisterOwnerReader(reader client.Reader) {
+	ownerReader = reader
+}
+
 // +kubebuilder:object:generate=false
 type CommonSpec interface {
 	GetDuration() (*time.Duration, error)
+	GetRecoverTimeout() (*time.Duration, error)
+	GetActiveWindows() ActiveWindows
 	Validate() field.ErrorList
 	Default()
 }
@@ -37,15 +68,201 @@ func validateDuration(spec CommonSpec, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	durationField := path.Child("duration")
-	_, err := spec.GetDuration()
+	duration, err := spec.GetDuration()
 	if err != nil {
 		allErrs = append(allErrs, field.Invalid(durationField, nil,
 			fmt.Sprintf("parse duration field error:%s", err)))
+	} else if duration != nil && MaxDuration > 0 && *duration > MaxDuration {
+		allErrs = append(allErrs, field.Invalid(durationField, duration.String(),
+			fmt.Sprintf("duration should not exceed %s", MaxDuration)))
+	}
+
+	recoverTimeoutField := path.Child("recoverTimeout")
+	recoverTimeout, err := spec.GetRecoverTimeout()
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(recoverTimeoutField, nil,
+			fmt.Sprintf("parse recoverTimeout field error:%s", err)))
+	} else if recoverTimeout != nil && *recoverTimeout <= 0 {
+		allErrs = append(allErrs, field.Invalid(recoverTimeoutField, recoverTimeout.String(),
+			"recoverTimeout should be positive"))
+	}
+
+	allErrs = append(allErrs, spec.GetActiveWindows().Validate(path.Child("activeWindows"))...)
+
+	return allErrs
+}
+
+// validateDurationRequired rejects the spec without a duration if RequireDuration is enabled.
+// The chaos spawned by a workflow node is exempted, as its lifetime is bounded by the deadline of the node,
+// so is the chaos requested by the netem-delay annotation of a pod, whose lifetime is bounded by the annotation.
+func validateDurationRequired(obj runtime.Object, spec CommonSpec, oneShot bool, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !RequireDuration || oneShot || controlledByWorkflowNode(obj) || controlledByAnnotatedPod(obj) {
+		return allErrs
+	}
+
+	duration, err := spec.GetDuration()
+	if err == nil && duration == nil {
+		allErrs = append(allErrs, field.Required(path.Child("duration"),
+			"duration is required by the controller manager"))
+	}
+
+	return allErrs
+}
+
+// validateManualRecover rejects the manual recover of the one-shot chaos, which is never recovered at all
+func validateManualRecover(obj InnerObject, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if obj.IsManualRecover() && obj.IsOneShot() {
+		allErrs = append(allErrs, field.Invalid(path.Child("manualRecover"), true,
+			"manual recover is not supported by the one-shot action"))
 	}
 
 	return allErrs
 }
 
+// controlledByWorkflowNode returns whether the object is spawned by a workflow node. The controller reference
+// could be set by anyone, so the referenced node must exist with the same UID and spawn the same kind of chaos.
+func controlledByWorkflowNode(obj runtime.Object) bool {
+	if ownerReader == nil {
+		return false
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	owner := metav1.GetControllerOf(accessor)
+	if owner == nil || owner.Kind != KindWorkflowNode {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil || gv.Group != GroupVersion.Group {
+		return false
+	}
+
+	node := &WorkflowNode{}
+	key := types.NamespacedName{Namespace: accessor.GetNamespace(), Name: owner.Name}
+	if err := ownerReader.Get(context.TODO(), key, node); err != nil {
+		log.Info("fail to get the workflow node of chaos", "node", key, "error", err)
+		return false
+	}
+	if node.UID != owner.UID || !IsChaosTemplateType(node.Spec.Type) {
+		return false
+	}
+	return string(node.Spec.Type) == reflect.TypeOf(obj).Elem().Name()
+}
+
+// controlledByAnnotatedPod returns whether the object is the NetworkChaos requested by the netem-delay annotation
+// of a pod. The controller reference could be set by anyone, so the referenced pod must exist with the same UID
+// and the annotation.
+func controlledByAnnotatedPod(obj runtime.Object) bool {
+	if ownerReader == nil {
+		return false
+	}
+	if _, ok := obj.(*NetworkChaos); !ok {
+		return false
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	owner := metav1.GetControllerOf(accessor)
+	if owner == nil || owner.Kind != "Pod" || owner.APIVersion != corev1.SchemeGroupVersion.String() {
+		return false
+	}
+
+	pod := &corev1.Pod{}
+	key := types.NamespacedName{Namespace: accessor.GetNamespace(), Name: owner.Name}
+	if err := ownerReader.Get(context.TODO(), key, pod); err != nil {
+		log.Info("fail to get the pod of chaos", "pod", key, "error", err)
+		return false
+	}
+	if pod.UID != owner.UID {
+		return false
+	}
+	_, annotated := pod.Annotations[NetemDelayAnnotationKey]
+	return annotated
+}
+
+// equalExceptDuration returns whether two specs are the same while ignoring their `Duration` field.
+// The duration is the only field of a running chaos which is allowed to be updated.
+func equalExceptDuration(spec, oldSpec interface{}) bool {
+	specVal := reflect.New(reflect.TypeOf(spec)).Elem()
+	specVal.Set(reflect.ValueOf(spec))
+	oldSpecVal := reflect.New(reflect.TypeOf(oldSpec)).Elem()
+	oldSpecVal.Set(reflect.ValueOf(oldSpec))
+
+	for _, val := range []reflect.Value{specVal, oldSpecVal} {
+		duration := val.FieldByName("Duration")
+		if duration.IsValid() && duration.CanSet() {
+			duration.Set(reflect.Zero(duration.Type()))
+		}
+	}
+
+	return reflect.DeepEqual(specVal.Interface(), oldSpecVal.Interface())
+}
+
+// validateContainerSelector validates the image pattern of the container selector
+func validateContainerSelector(selector *ContainerSelector, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(selector.ContainerImage) != 0 {
+		if _, err := regexp.Compile(selector.ContainerImage); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("containerImage"), selector.ContainerImage,
+				fmt.Sprintf("parse container image error: %s", err)))
+		}
+	}
+	allErrs = append(allErrs, validatePodSelectorSpec(&selector.Selector, path.Child("selector"))...)
+
+	return allErrs
+}
+
+// validatePodSelectorSpec validates the ordinals of the workloads in the pod selector
+func validatePodSelectorSpec(selector *PodSelectorSpec, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, workload := range selector.Workloads {
+		if len(workload.Ordinals) == 0 {
+			continue
+		}
+
+		ordinalsField := path.Child("workloads").Index(i).Child("ordinals")
+		if workload.Kind != "StatefulSet" {
+			allErrs = append(allErrs, field.Invalid(ordinalsField, workload.Ordinals,
+				fmt.Sprintf("ordinals are not supported by %s", workload.Kind)))
+			continue
+		}
+		if _, err := parseOrdinals(workload.Ordinals); err != nil {
+			allErrs = append(allErrs, field.Invalid(ordinalsField, workload.Ordinals, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateMinMatches rejects the minimum matches which can never be met by the pods chosen by the mode
+func validateMinMatches(selector *PodSelector, path *field.Path) field.ErrorList {
+	var chosen int
+	switch selector.Mode {
+	case OnePodMode:
+		chosen = 1
+	case FixedPodMode:
+		num, err := strconv.Atoi(selector.Value)
+		if err != nil {
+			// the malformed value is rejected by validatePodSelector
+			return nil
+		}
+		chosen = num
+	default:
+		// the pods chosen by the other modes depend on how many pods are matched
+		return nil
+	}
+
+	if selector.MinMatches > chosen {
+		return field.ErrorList{field.Invalid(path.Child("minMatches"), selector.MinMatches,
+			fmt.Sprintf("at most %d pods are chosen with mode:%s, the min matches can never be met", chosen, selector.Mode))}
+	}
+	return nil
+}
+
 // validatePodSelector validates the value with podmode
 func validatePodSelector(value string, mode PodMode, valueField *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -65,17 +282,19 @@ func validatePodSelector(value string, mode PodMode, valueField *field.Path) fie
 		}
 
 	case RandomMaxPercentPodMode, FixedPercentPodMode:
-		percentage, err := strconv.Atoi(value)
+		// the percentage could be fractional, e.g. "0.5"
+		percentage, err := strconv.ParseFloat(value, 64)
 		if err != nil {
 			allErrs = append(allErrs, field.Invalid(valueField, value,
 				fmt.Sprintf(ValidateValueParseError, err)))
 			break
 		}
 
-		if percentage <= 0 || percentage > 100 {
+		// the NaN is rejected as well
+		if !(percentage > 0 && percentage <= 100) {
 			allErrs = append(allErrs, field.Invalid(valueField, value,
-				fmt.Sprintf("value of %d is invalid, Must be (0,100] with mode:%s",
-					percentage, mode)))
+				fmt.Sprintf("value of %s is invalid, Must be (0,100] with mode:%s",
+					value, mode)))
 		}
 	}
 
@@ -14,17 +14,444 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+	"reflect"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var _ = Describe("common_webhook", func() {
 	Context("Defaulter", func() {
 		It("set default namespace selector", func() {
 			selector := &PodSelectorSpec{}
-			selector.DefaultNamespace(metav1.NamespaceDefault)
+			selector.DefaultNamespace(&metav1.ObjectMeta{Namespace: metav1.NamespaceDefault})
 			Expect(selector.Namespaces[0]).To(Equal(metav1.NamespaceDefault))
 		})
+
+		It("scopes the omitted namespaces to the namespace of the chaos", func() {
+			chaos := &PodChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "foo"},
+				Spec: PodChaosSpec{
+					Action: PodKillAction,
+					ContainerSelector: ContainerSelector{
+						PodSelector: PodSelector{
+							Selector: PodSelectorSpec{
+								LabelSelectors: map[string]string{"app": "foo"},
+							},
+						},
+					},
+				},
+			}
+			chaos.Default()
+			Expect(chaos.Spec.Selector.Namespaces).To(Equal([]string{"team-a"}))
+			Expect(chaos.Spec.Selector.ClusterScoped()).To(BeFalse())
+
+			chaos.Spec.Selector.Namespaces = []string{"team-b"}
+			chaos.Default()
+			Expect(chaos.Spec.Selector.Namespaces).To(Equal([]string{"team-b"}))
+		})
+
+		It("keeps the omitted namespaces of the chaos which opts in to select the whole cluster", func() {
+			chaos := &NetworkChaos{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "team-a",
+					Name:        "foo",
+					Annotations: map[string]string{ClusterWideSelectorAnnotationKey: "true"},
+				},
+				Spec: NetworkChaosSpec{
+					Action: PartitionAction,
+					Target: &PodSelector{},
+				},
+			}
+			chaos.Default()
+			Expect(chaos.Spec.Selector.Namespaces).To(BeEmpty())
+			Expect(chaos.Spec.Target.Selector.Namespaces).To(BeEmpty())
+			Expect(chaos.Spec.Selector.ClusterScoped()).To(BeTrue())
+		})
+	})
+	Context("ValidateUpdate", func() {
+		It("only allows updating the duration", func() {
+			duration := Duration("1h")
+			shorterDuration := Duration("5m")
+			old := &TimeChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+				Spec: TimeChaosSpec{
+					TimeOffset: "100ms",
+					Duration:   &duration,
+				},
+			}
+
+			updated := old.DeepCopy()
+			updated.Spec.Duration = &shorterDuration
+			Expect(equalExceptDuration(updated.Spec, old.Spec)).To(BeTrue())
+			Expect(updated.ValidateUpdate(old)).To(Succeed())
+
+			updated = old.DeepCopy()
+			updated.Spec.TimeOffset = "200ms"
+			Expect(equalExceptDuration(updated.Spec, old.Spec)).To(BeFalse())
+			Expect(updated.ValidateUpdate(old)).To(Equal(ErrCanNotUpdateChaos))
+		})
+	})
+
+	Context("ManualRecover", func() {
+		It("rejects the manual recover of the one-shot chaos", func() {
+			chaos := &PodChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+				Spec: PodChaosSpec{
+					Action:        PodKillAction,
+					ManualRecover: true,
+				},
+			}
+			err := chaos.ValidateCreate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.manualRecover"))
+
+			chaos.Spec.Action = PodFailureAction
+			Expect(chaos.ValidateCreate()).To(Succeed())
+		})
+	})
+
+	Context("RequireDuration", func() {
+		AfterEach(func() {
+			RequireDuration = false
+		})
+
+		It("rejects the chaos without duration only if it's enabled", func() {
+			chaos := &TimeChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+				Spec: TimeChaosSpec{
+					TimeOffset: "100ms",
+				},
+			}
+			Expect(chaos.ValidateCreate()).To(Succeed())
+
+			RequireDuration = true
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			duration := Duration("1h")
+			chaos.Spec.Duration = &duration
+			Expect(chaos.ValidateCreate()).To(Succeed())
+		})
+
+		It("allows the one-shot chaos without duration", func() {
+			RequireDuration = true
+			chaos := &PodChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+				Spec: PodChaosSpec{
+					Action: PodKillAction,
+				},
+			}
+			Expect(chaos.ValidateCreate()).To(Succeed())
+		})
+
+		It("allows the chaos spawned by an existing workflow node without duration", func() {
+			scheme := runtime.NewScheme()
+			Expect(AddToScheme(scheme)).To(Succeed())
+			node := &WorkflowNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "time-skew-abcde",
+					UID:       "node-uid",
+				},
+				Spec: WorkflowNodeSpec{
+					Type:       TypeTimeChaos,
+					EmbedChaos: &EmbedChaos{TimeChaos: &TimeChaosSpec{TimeOffset: "100ms"}},
+				},
+			}
+			RegisterOwnerReader(fake.NewFakeClientWithScheme(scheme, node))
+			defer RegisterOwnerReader(nil)
+
+			RequireDuration = true
+			chaos := &TimeChaos{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "foo",
+					Labels:    map[string]string{LabelWorkflow: "workflow"},
+				},
+				Spec: TimeChaosSpec{
+					TimeOffset: "100ms",
+				},
+			}
+			// the label could be set by anyone
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			isController := true
+			chaos.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: GroupVersion.String(),
+				Kind:       KindWorkflowNode,
+				Name:       "time-skew-abcde",
+				UID:        "node-uid",
+				Controller: &isController,
+			}}
+			Expect(chaos.ValidateCreate()).To(Succeed())
+
+			// the controller reference could be forged as well
+			chaos.OwnerReferences[0].UID = "forged-uid"
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			chaos.OwnerReferences[0].UID = "node-uid"
+			chaos.OwnerReferences[0].Name = "missing-node"
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			// the node spawns another kind of chaos
+			chaos.OwnerReferences[0].Name = "time-skew-abcde"
+			Expect(chaos.ValidateCreate()).To(Succeed())
+			podChaos := &PodChaos{
+				ObjectMeta: chaos.ObjectMeta,
+				Spec: PodChaosSpec{
+					Action: PodFailureAction,
+				},
+			}
+			Expect(podChaos.ValidateCreate()).ToNot(Succeed())
+
+			chaos.OwnerReferences[0].Controller = nil
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+		})
+
+		It("allows the network chaos requested by the annotation of an existing pod without duration", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   metav1.NamespaceDefault,
+					Name:        "p0",
+					UID:         "pod-uid",
+					Annotations: map[string]string{NetemDelayAnnotationKey: "100ms"},
+				},
+			}
+			RegisterOwnerReader(fake.NewFakeClientWithScheme(scheme, pod))
+			defer RegisterOwnerReader(nil)
+
+			RequireDuration = true
+			isController := true
+			chaos := &NetworkChaos{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "p0-netem-delay",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "v1",
+						Kind:       "Pod",
+						Name:       "p0",
+						UID:        "pod-uid",
+						Controller: &isController,
+					}},
+				},
+				Spec: NetworkChaosSpec{
+					PodSelector: PodSelector{Mode: AllPodMode},
+					Action:      DelayAction,
+					TcParameter: TcParameter{Delay: &DelaySpec{Latency: "100ms"}},
+				},
+			}
+			chaos.Default()
+			Expect(chaos.ValidateCreate()).To(Succeed())
+
+			// the controller reference could be forged
+			chaos.OwnerReferences[0].UID = "forged-uid"
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			// the pod doesn't request the chaos
+			chaos.OwnerReferences[0].UID = "pod-uid"
+			pod.Annotations = nil
+			RegisterOwnerReader(fake.NewFakeClientWithScheme(scheme, pod))
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+		})
+	})
+
+	Context("MaxDuration", func() {
+		AfterEach(func() {
+			MaxDuration = 0
+		})
+
+		It("rejects the chaos whose duration exceeds MaxDuration", func() {
+			MaxDuration = 24 * time.Hour
+
+			duration := Duration("720h")
+			chaos := &TimeChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+				Spec: TimeChaosSpec{
+					TimeOffset: "100ms",
+					Duration:   &duration,
+				},
+			}
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			duration = "24h"
+			Expect(chaos.ValidateCreate()).To(Succeed())
+		})
+	})
+
+	Context("RecoverTimeout", func() {
+		It("rejects the chaos whose recoverTimeout is not a positive duration", func() {
+			duration := Duration("1h")
+			recoverTimeout := "foo"
+			chaos := &TimeChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+				Spec: TimeChaosSpec{
+					TimeOffset:     "100ms",
+					Duration:       &duration,
+					RecoverTimeout: &recoverTimeout,
+				},
+			}
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			recoverTimeout = "-1m"
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			recoverTimeout = "5m"
+			Expect(chaos.ValidateCreate()).To(Succeed())
+		})
+	})
+
+	Context("ContainerImage", func() {
+		It("rejects the chaos whose containerImage doesn't compile", func() {
+			chaos := &StressChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+				Spec: StressChaosSpec{
+					ContainerSelector: ContainerSelector{
+						ContainerImage: "mysql:[8",
+					},
+					StressngStressors: "--cpu 1",
+				},
+			}
+			Expect(chaos.ValidateCreate()).ToNot(Succeed())
+
+			chaos.Spec.ContainerImage = "^mysql:8\\."
+			Expect(chaos.ValidateCreate()).To(Succeed())
+		})
+	})
+
+	Context("Ordinals", func() {
+		It("validates the ordinal ranges of the StatefulSet", func() {
+			chaos := &PodChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+				Spec:       PodChaosSpec{Action: PodKillAction},
+			}
+			for _, ordinals := range []string{"0", "1-2", "1-", "0,2-3, 5-"} {
+				chaos.Spec.Selector.Workloads = []WorkloadReference{{Kind: "StatefulSet", Name: "db", Ordinals: ordinals}}
+				Expect(chaos.ValidateCreate()).To(Succeed(), ordinals)
+			}
+			for _, ordinals := range []string{"2-1", "-1", "a", "1-b", "+1", "1,,2", "1-2-3"} {
+				chaos.Spec.Selector.Workloads = []WorkloadReference{{Kind: "StatefulSet", Name: "db", Ordinals: ordinals}}
+				Expect(chaos.ValidateCreate()).ToNot(Succeed(), ordinals)
+			}
+
+			chaos.Spec.Selector.Workloads = []WorkloadReference{{Kind: "Deployment", Name: "web", Ordinals: "0"}}
+			err := chaos.ValidateCreate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.selector.workloads[0].ordinals"))
+		})
+
+		It("selects the ordinals in the ranges", func() {
+			workload := WorkloadReference{Kind: "StatefulSet", Name: "db", Ordinals: "0,2-3,5-"}
+			for ordinal, expected := range []bool{true, false, true, true, false, true, true} {
+				contained, err := workload.ContainsOrdinal(ordinal)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(contained).To(Equal(expected), "ordinal %d", ordinal)
+			}
+		})
+	})
+
+	Context("Duration", func() {
+		It("keeps the invalid duration at unmarshal and rejects it in the webhook", func() {
+			chaos := &TimeChaos{}
+			Expect(json.Unmarshal([]byte(`{"spec":{"timeOffset":"100ms","duration":"10m"}}`), chaos)).To(Succeed())
+			Expect(*chaos.Spec.Duration).To(Equal(Duration("10m")))
+			Expect(chaos.ValidateCreate()).To(Succeed())
+
+			// the chaos stored before the validation is still decoded, e.g. when it's listed
+			Expect(json.Unmarshal([]byte(`{"spec":{"timeOffset":"100ms","duration":"10 minutes"}}`), chaos)).To(Succeed())
+			Expect(*chaos.Spec.Duration).To(Equal(Duration("10 minutes")))
+			err := chaos.ValidateCreate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("parse duration field error"))
+		})
+
+		It("rejects the invalid duration with the field path for every kind of chaos", func() {
+			for kind, chaosKind := range AllKinds() {
+				chaos := chaosKind.Chaos.DeepCopyObject()
+				duration := Duration("10 minutes")
+				spec := reflect.ValueOf(chaos).Elem().FieldByName("Spec")
+				spec.FieldByName("Duration").Set(reflect.ValueOf(&duration))
+
+				validator, ok := chaos.(webhook.Validator)
+				Expect(ok).To(BeTrue(), kind)
+				err := validator.ValidateCreate()
+				Expect(err).To(HaveOccurred(), kind)
+				Expect(err.Error()).To(ContainSubstring("spec.duration: Invalid value"), kind)
+			}
+		})
+
+		It("keeps the duration a string on the wire", func() {
+			duration := Duration("1h30m")
+			data, err := json.Marshal(&PodChaosSpec{Action: PodFailureAction, Duration: &duration})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"duration":"1h30m"`))
+		})
+	})
+
+	Context("MinMatches", func() {
+		It("rejects the min matches which the mode can never meet", func() {
+			newNetworkChaos := func(selector PodSelector) *NetworkChaos {
+				return &NetworkChaos{
+					ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "min-matches"},
+					Spec: NetworkChaosSpec{
+						Action:      PartitionAction,
+						PodSelector: selector,
+					},
+				}
+			}
+			newPodChaos := func(selector PodSelector) *PodChaos {
+				return &PodChaos{
+					ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "min-matches"},
+					Spec: PodChaosSpec{
+						Action:            PodKillAction,
+						ContainerSelector: ContainerSelector{PodSelector: selector},
+					},
+				}
+			}
+
+			for _, selector := range []PodSelector{
+				{Mode: OnePodMode, MinMatches: 2},
+				{Mode: FixedPodMode, Value: "2", MinMatches: 3},
+			} {
+				for _, chaos := range []webhook.Validator{newNetworkChaos(selector), newPodChaos(selector)} {
+					err := chaos.ValidateCreate()
+					Expect(err).To(HaveOccurred(), string(selector.Mode))
+					Expect(err.Error()).To(ContainSubstring("spec.minMatches: Invalid value"), string(selector.Mode))
+				}
+			}
+
+			for _, selector := range []PodSelector{
+				{Mode: OnePodMode, MinMatches: 1},
+				{Mode: FixedPodMode, Value: "2", MinMatches: 2},
+				{Mode: AllPodMode, MinMatches: 3},
+				{Mode: FixedPercentPodMode, Value: "50", MinMatches: 3},
+			} {
+				for _, chaos := range []webhook.Validator{newNetworkChaos(selector), newPodChaos(selector)} {
+					Expect(chaos.ValidateCreate()).To(Succeed(), string(selector.Mode))
+				}
+			}
+		})
+	})
+
+	Context("PercentValue", func() {
+		It("accepts the fractional percentages in (0,100]", func() {
+			for _, mode := range []PodMode{FixedPercentPodMode, RandomMaxPercentPodMode} {
+				for _, value := range []string{"0.5", "0.01", "33.3", "100"} {
+					Expect(validatePodSelector(value, mode, field.NewPath("value"))).To(BeEmpty(), value)
+				}
+				for _, value := range []string{"0", "0.0", "-0.5", "100.5", "NaN", "half"} {
+					Expect(validatePodSelector(value, mode, field.NewPath("value"))).To(HaveLen(1), value)
+				}
+			}
+		})
 	})
 })
@@ -29,6 +29,20 @@ const (
 
 	// RandomAction represents get random IP when send DNS request.
 	RandomAction DNSChaosAction = "random"
+
+	// MapAction represents get the value of the matched record when send DNS request.
+	MapAction DNSChaosAction = "map"
+)
+
+// DNSRecordType represents the type of the DNS record served by the chaos DNS server.
+type DNSRecordType string
+
+const (
+	// ARecord maps the domain name to an IPv4 address.
+	ARecord DNSRecordType = "A"
+
+	// AAAARecord maps the domain name to an IPv6 address.
+	AAAARecord DNSRecordType = "AAAA"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -53,15 +67,30 @@ type DNSChaos struct {
 // DNSChaosSpec defines the desired state of DNSChaos
 type DNSChaosSpec struct {
 	// Action defines the specific DNS chaos action.
-	// Supported action: error, random
+	// Supported action: error, random, map
 	// Default action: error
-	// +kubebuilder:validation:Enum=error;random
+	// +kubebuilder:validation:Enum=error;random;map
 	Action DNSChaosAction `json:"action"`
 
 	ContainerSelector `json:",inline"`
 
 	// Duration represents the duration of the chaos action
-	Duration *string `json:"duration,omitempty"`
+	Duration *Duration `json:"duration,omitempty"`
+
+	// RecoverTimeout represents how long to wait for the chaos to be recovered after the duration ends,
+	// the chaos which is not recovered in time is escalated with a warning event and the RecoverTimedOut condition.
+	// +optional
+	RecoverTimeout *string `json:"recoverTimeout,omitempty"`
+
+	// ActiveWindows are the recurring windows in which the chaos is active, e.g. 9:00-17:00 on weekdays.
+	// The chaos is recovered outside the windows, and applied again inside them.
+	// +optional
+	ActiveWindows ActiveWindows `json:"activeWindows,omitempty"`
+
+	// ManualRecover keeps the chaos injected after the duration is exceeded, until it's paused or deleted.
+	// It's not supported by the one-shot actions.
+	// +optional
+	ManualRecover bool `json:"manualRecover,omitempty"`
 
 	// Choose which domain names to take effect, support the placeholder ? and wildcard *, or the Specified domain name.
 	// Note:
@@ -72,6 +101,31 @@ type DNSChaosSpec struct {
 	// 		will take effect on "google.com", "github.com" and "chaos-mesh.org"
 	// +optional
 	DomainNamePatterns []string `json:"patterns"`
+
+	// Records are served by the chaos DNS server for the map action, the first record whose
+	// pattern matches the domain name is returned. The pattern supports the same placeholder
+	// and wildcard as the patterns.
+	// +optional
+	Records []DNSRecord `json:"records,omitempty"`
+}
+
+// DNSRecord is a record served by the chaos DNS server
+type DNSRecord struct {
+	// Pattern is the domain name pattern which the record takes effect on, e.g. "github.*"
+	Pattern string `json:"pattern"`
+
+	// Type is the type of the record.
+	// Supported type: A, AAAA
+	// +kubebuilder:validation:Enum=A;AAAA
+	Type DNSRecordType `json:"type"`
+
+	// Value is the IP address returned for the domain name, it must be an IPv4 address for A
+	// and an IPv6 address for AAAA.
+	Value string `json:"value"`
+
+	// TTL is the time to live of the record in seconds.
+	// +optional
+	TTL uint32 `json:"ttl,omitempty"`
 }
 
 // DNSChaosStatus defines the observed state of DNSChaos
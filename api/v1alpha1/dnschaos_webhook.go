@@ -15,7 +15,8 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
+	"net"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -34,7 +35,7 @@ var _ webhook.Defaulter = &DNSChaos{}
 func (in *DNSChaos) Default() {
 	dnschaoslog.Info("default", "name", in.Name)
 
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	in.Spec.Default()
 }
 
@@ -54,7 +55,7 @@ func (in *DNSChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *DNSChaos) ValidateUpdate(old runtime.Object) error {
 	dnschaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*DNSChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*DNSChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -71,6 +72,8 @@ func (in *DNSChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *DNSChaos) Validate() error {
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
 	}
@@ -81,6 +84,55 @@ func (in *DNSChaos) Validate() error {
 func (in *DNSChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := validatePodSelector(in.PodSelector.Value, in.PodSelector.Mode, specField.Child("value"))
+	allErrs = append(allErrs, validateContainerSelector(&in.ContainerSelector, specField)...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, in.validateRecords(specField.Child("records"))...)
+	return allErrs
+}
+
+// validateRecords validates the records, which are only served for the map action
+func (in *DNSChaosSpec) validateRecords(recordsField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in.Action != MapAction {
+		if len(in.Records) != 0 {
+			allErrs = append(allErrs, field.Invalid(recordsField, in.Records,
+				fmt.Sprintf("records are only served for the %s action", MapAction)))
+		}
+		return allErrs
+	}
+
+	if len(in.Records) == 0 {
+		allErrs = append(allErrs, field.Required(recordsField,
+			fmt.Sprintf("records are required for the %s action", MapAction)))
+	}
+
+	for i, record := range in.Records {
+		recordField := recordsField.Index(i)
+
+		// the wildcard * must be at the end of the pattern, e.g. chaos-*.org is invalid
+		if len(record.Pattern) == 0 || strings.Contains(strings.TrimSuffix(record.Pattern, "*"), "*") {
+			allErrs = append(allErrs, field.Invalid(recordField.Child("pattern"), record.Pattern,
+				"the pattern should be a domain name with the wildcard * at the end"))
+		}
+
+		ip := net.ParseIP(record.Value)
+		switch record.Type {
+		case ARecord:
+			if ip == nil || ip.To4() == nil {
+				allErrs = append(allErrs, field.Invalid(recordField.Child("value"), record.Value,
+					"the value of A record should be an IPv4 address"))
+			}
+		case AAAARecord:
+			if ip == nil || ip.To4() != nil {
+				allErrs = append(allErrs, field.Invalid(recordField.Child("value"), record.Value,
+					"the value of AAAA record should be an IPv6 address"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(recordField.Child("type"), record.Type,
+				[]string{string(ARecord), string(AAAARecord)}))
+		}
+	}
+
 	return allErrs
 }
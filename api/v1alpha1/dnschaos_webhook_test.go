@@ -0,0 +1,114 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("dnschaos_webhook", func() {
+	Context("webhook.Validator of dnschaos", func() {
+		It("Validate records", func() {
+			type TestCase struct {
+				name    string
+				action  DNSChaosAction
+				records []DNSRecord
+				expect  string
+			}
+
+			tcs := []TestCase{
+				{
+					name:   "map action without records",
+					action: MapAction,
+					expect: "records are required",
+				},
+				{
+					name:   "records with error action",
+					action: ErrorAction,
+					records: []DNSRecord{
+						{Pattern: "github.com", Type: ARecord, Value: "10.0.0.1"},
+					},
+					expect: "records are only served for the map action",
+				},
+				{
+					name:   "valid records",
+					action: MapAction,
+					records: []DNSRecord{
+						{Pattern: "github.*", Type: ARecord, Value: "10.0.0.1", TTL: 60},
+						{Pattern: "chaos-mes?.org", Type: AAAARecord, Value: "fd00::1"},
+					},
+				},
+				{
+					name:   "wildcard in the middle of the pattern",
+					action: MapAction,
+					records: []DNSRecord{
+						{Pattern: "chaos-*.org", Type: ARecord, Value: "10.0.0.1"},
+					},
+					expect: "spec.records[0].pattern",
+				},
+				{
+					name:   "IPv6 address in A record",
+					action: MapAction,
+					records: []DNSRecord{
+						{Pattern: "github.com", Type: ARecord, Value: "fd00::1"},
+					},
+					expect: "the value of A record should be an IPv4 address",
+				},
+				{
+					name:   "IPv4 address in AAAA record",
+					action: MapAction,
+					records: []DNSRecord{
+						{Pattern: "github.com", Type: AAAARecord, Value: "10.0.0.1"},
+					},
+					expect: "the value of AAAA record should be an IPv6 address",
+				},
+				{
+					name:   "unsupported record type",
+					action: MapAction,
+					records: []DNSRecord{
+						{Pattern: "github.com", Type: "CNAME", Value: "example.com"},
+					},
+					expect: "Unsupported value",
+				},
+			}
+
+			for _, tc := range tcs {
+				chaos := DNSChaos{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: metav1.NamespaceDefault,
+						Name:      "foo",
+					},
+					Spec: DNSChaosSpec{
+						Action: tc.action,
+						ContainerSelector: ContainerSelector{
+							PodSelector: PodSelector{
+								Mode: OnePodMode,
+							},
+						},
+						Records: tc.records,
+					},
+				}
+				err := chaos.ValidateCreate()
+				if len(tc.expect) == 0 {
+					Expect(err).ToNot(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).To(HaveOccurred(), tc.name)
+					Expect(err.Error()).To(ContainSubstring(tc.expect), tc.name)
+				}
+			}
+		})
+	})
+})
@@ -15,7 +15,6 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -52,7 +51,7 @@ func (in *GCPChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *GCPChaos) ValidateUpdate(old runtime.Object) error {
 	gcpchaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*GCPChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*GCPChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -69,6 +68,8 @@ func (in *GCPChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *GCPChaos) Validate() error {
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
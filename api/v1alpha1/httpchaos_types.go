@@ -18,6 +18,11 @@ import (
 )
 
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="action",type=string,JSONPath=`.status.action`
+// +kubebuilder:printcolumn:name="phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="selected",type=integer,JSONPath=`.status.selected`
+// +kubebuilder:printcolumn:name="duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +chaos-mesh:base
 
 // HTTPChaos is the Schema for the HTTPchaos API
@@ -38,7 +43,8 @@ type HTTPChaosSpec struct {
 
 	PodHttpChaosActions `json:",inline"`
 
-	// Port represents the target port to be proxy of.
+	// Port represents the target port to be proxy of. It defaults to the only TCP port declared by the containers
+	// of the pod.
 	Port int32 `json:"port,omitempty"`
 
 	// Path is a rule to select target by uri path in http request.
@@ -65,7 +71,22 @@ type HTTPChaosSpec struct {
 
 	// Duration represents the duration of the chaos action.
 	// +optional
-	Duration *string `json:"duration,omitempty"`
+	Duration *Duration `json:"duration,omitempty"`
+
+	// RecoverTimeout represents how long to wait for the chaos to be recovered after the duration ends,
+	// the chaos which is not recovered in time is escalated with a warning event and the RecoverTimedOut condition.
+	// +optional
+	RecoverTimeout *string `json:"recoverTimeout,omitempty"`
+
+	// ActiveWindows are the recurring windows in which the chaos is active, e.g. 9:00-17:00 on weekdays.
+	// The chaos is recovered outside the windows, and applied again inside them.
+	// +optional
+	ActiveWindows ActiveWindows `json:"activeWindows,omitempty"`
+
+	// ManualRecover keeps the chaos injected after the duration is exceeded, until it's paused or deleted.
+	// It's not supported by the one-shot actions.
+	// +optional
+	ManualRecover bool `json:"manualRecover,omitempty"`
 }
 
 type HTTPChaosStatus struct {
@@ -15,7 +15,7 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -34,7 +34,7 @@ var _ webhook.Defaulter = &HTTPChaos{}
 func (in *HTTPChaos) Default() {
 	httpchaoslog.Info("default", "name", in.Name)
 
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	in.Spec.Default()
 }
 
@@ -55,7 +55,7 @@ func (in *HTTPChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *HTTPChaos) ValidateUpdate(old runtime.Object) error {
 	httpchaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*HTTPChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*HTTPChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -73,6 +73,8 @@ func (in *HTTPChaos) ValidateDelete() error {
 func (in *HTTPChaos) Validate() error {
 
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
 	}
@@ -84,7 +86,76 @@ func (in *HTTPChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 
 	allErrs := validatePodSelector(in.PodSelector.Value, in.PodSelector.Mode, specField.Child("value"))
+	allErrs = append(allErrs, validatePodSelectorSpec(&in.Selector, specField.Child("selector"))...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, in.validateTarget(specField)...)
+	allErrs = append(allErrs, in.validateActions(specField)...)
 	return allErrs
 
 }
+
+// validateTarget validates that the rules to select the target are supported by the target
+func (in *HTTPChaosSpec) validateTarget(specField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch in.Target {
+	case PodHttpRequest:
+		if in.Code != nil {
+			allErrs = append(allErrs, field.Invalid(specField.Child("code"), *in.Code, "the status code could only select the Response target"))
+		}
+		if len(in.ResponseHeaders) > 0 {
+			allErrs = append(allErrs, field.Invalid(specField.Child("response_headers"), in.ResponseHeaders, "the response headers could only select the Response target"))
+		}
+	case PodHttpResponse:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specField.Child("target"), in.Target, []string{string(PodHttpRequest), string(PodHttpResponse)}))
+	}
+	return allErrs
+}
+
+// validateActions validates that at least one action is specified, and the actions are supported by the target
+func (in *HTTPChaosSpec) validateActions(specField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if in.Abort == nil && in.Delay == nil && in.Replace == nil && in.Patch == nil {
+		allErrs = append(allErrs, field.Invalid(specField, "", "one of abort, delay, replace and patch should be specified"))
+	}
+
+	if in.Delay != nil {
+		delay, err := time.ParseDuration(*in.Delay)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(specField.Child("delay"), *in.Delay, fmt.Sprintf("parse delay field error:%s", err)))
+		} else if delay < 0 {
+			allErrs = append(allErrs, field.Invalid(specField.Child("delay"), *in.Delay, "delay should not be negative"))
+		}
+	}
+
+	requestOnly := func(path *field.Path, value interface{}) {
+		if in.Target != PodHttpRequest {
+			allErrs = append(allErrs, field.Invalid(path, value, "it could only be applied to the Request target"))
+		}
+	}
+	if in.Replace != nil {
+		replaceField := specField.Child("replace")
+		if in.Replace.Path != nil {
+			requestOnly(replaceField.Child("path"), *in.Replace.Path)
+		}
+		if in.Replace.Method != nil {
+			requestOnly(replaceField.Child("method"), *in.Replace.Method)
+		}
+		if len(in.Replace.Queries) > 0 {
+			requestOnly(replaceField.Child("queries"), in.Replace.Queries)
+		}
+		if in.Replace.Code != nil && in.Target != PodHttpResponse {
+			allErrs = append(allErrs, field.Invalid(replaceField.Child("code"), *in.Replace.Code, "it could only be applied to the Response target"))
+		}
+	}
+	if in.Patch != nil {
+		patchField := specField.Child("patch")
+		if len(in.Patch.Queries) > 0 {
+			requestOnly(patchField.Child("queries"), in.Patch.Queries)
+		}
+		if in.Patch.Body != nil && in.Patch.Body.Type != "JSON" {
+			allErrs = append(allErrs, field.NotSupported(patchField.Child("body").Child("type"), in.Patch.Body.Type, []string{"JSON"}))
+		}
+	}
+	return allErrs
+}
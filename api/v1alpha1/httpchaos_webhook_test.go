@@ -0,0 +1,137 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("httpchaos_webhook", func() {
+	Context("webhook.Validator of httpchaos", func() {
+		It("Validate", func() {
+			abort := true
+			delay := "1s"
+			invalidDelay := "1"
+			path := "/api"
+			code := int32(500)
+
+			type TestCase struct {
+				name   string
+				spec   HTTPChaosSpec
+				expect string
+			}
+			tcs := []TestCase{
+				{
+					name: "abort the requests",
+					spec: HTTPChaosSpec{
+						Target:              PodHttpRequest,
+						PodHttpChaosActions: PodHttpChaosActions{Abort: &abort},
+					},
+					expect: "",
+				},
+				{
+					name: "delay the requests",
+					spec: HTTPChaosSpec{
+						Target:              PodHttpRequest,
+						Path:                &path,
+						PodHttpChaosActions: PodHttpChaosActions{Delay: &delay},
+					},
+					expect: "",
+				},
+				{
+					name: "replace the body of the responses",
+					spec: HTTPChaosSpec{
+						Target: PodHttpResponse,
+						Code:   &code,
+						PodHttpChaosActions: PodHttpChaosActions{
+							Replace: &PodHttpChaosReplaceActions{Body: []byte("{}"), Code: &code},
+						},
+					},
+					expect: "",
+				},
+				{
+					name: "patch the body of the responses",
+					spec: HTTPChaosSpec{
+						Target: PodHttpResponse,
+						PodHttpChaosActions: PodHttpChaosActions{
+							Patch: &PodHttpChaosPatchActions{Body: &PodHttpChaosPatchBodyAction{Type: "JSON", Value: `{"foo":"bar"}`}},
+						},
+					},
+					expect: "",
+				},
+				{
+					name:   "no action",
+					spec:   HTTPChaosSpec{Target: PodHttpRequest},
+					expect: "error",
+				},
+				{
+					name: "invalid delay",
+					spec: HTTPChaosSpec{
+						Target:              PodHttpRequest,
+						PodHttpChaosActions: PodHttpChaosActions{Delay: &invalidDelay},
+					},
+					expect: "error",
+				},
+				{
+					name: "select the requests by status code",
+					spec: HTTPChaosSpec{
+						Target:              PodHttpRequest,
+						Code:                &code,
+						PodHttpChaosActions: PodHttpChaosActions{Abort: &abort},
+					},
+					expect: "error",
+				},
+				{
+					name: "replace the path of the responses",
+					spec: HTTPChaosSpec{
+						Target: PodHttpResponse,
+						PodHttpChaosActions: PodHttpChaosActions{
+							Replace: &PodHttpChaosReplaceActions{Path: &path},
+						},
+					},
+					expect: "error",
+				},
+				{
+					name: "patch the body with unknown type",
+					spec: HTTPChaosSpec{
+						Target: PodHttpResponse,
+						PodHttpChaosActions: PodHttpChaosActions{
+							Patch: &PodHttpChaosPatchActions{Body: &PodHttpChaosPatchBodyAction{Type: "XML", Value: "<foo/>"}},
+						},
+					},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				tc.spec.PodSelector = PodSelector{Mode: AllPodMode}
+				chaos := HTTPChaos{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: metav1.NamespaceDefault,
+						Name:      "foo",
+					},
+					Spec: tc.spec,
+				}
+				err := chaos.ValidateCreate()
+				if tc.expect == "error" {
+					Expect(err).To(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).NotTo(HaveOccurred(), tc.name)
+				}
+			}
+		})
+	})
+})
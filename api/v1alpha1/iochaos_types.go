@@ -21,6 +21,11 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="action",type=string,JSONPath=`.status.action`
+// +kubebuilder:printcolumn:name="phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="selected",type=integer,JSONPath=`.status.selected`
+// +kubebuilder:printcolumn:name="duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +chaos-mesh:base
 
 // IOChaos is the Schema for the iochaos API
@@ -86,7 +91,22 @@ type IOChaosSpec struct {
 	// such as "300ms", "-1.5h" or "2h45m".
 	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
 	// +optional
-	Duration *string `json:"duration,omitempty"`
+	Duration *Duration `json:"duration,omitempty"`
+
+	// RecoverTimeout represents how long to wait for the chaos to be recovered after the duration ends,
+	// the chaos which is not recovered in time is escalated with a warning event and the RecoverTimedOut condition.
+	// +optional
+	RecoverTimeout *string `json:"recoverTimeout,omitempty"`
+
+	// ActiveWindows are the recurring windows in which the chaos is active, e.g. 9:00-17:00 on weekdays.
+	// The chaos is recovered outside the windows, and applied again inside them.
+	// +optional
+	ActiveWindows ActiveWindows `json:"activeWindows,omitempty"`
+
+	// ManualRecover keeps the chaos injected after the duration is exceeded, until it's paused or deleted.
+	// It's not supported by the one-shot actions.
+	// +optional
+	ManualRecover bool `json:"manualRecover,omitempty"`
 }
 
 // IOChaosStatus defines the observed state of IOChaos
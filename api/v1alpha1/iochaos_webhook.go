@@ -15,7 +15,6 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -43,7 +42,7 @@ var _ webhook.Defaulter = &IOChaos{}
 func (in *IOChaos) Default() {
 	iochaoslog.Info("default", "name", in.Name)
 
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	in.Spec.Default()
 }
 
@@ -64,7 +63,7 @@ func (in *IOChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *IOChaos) ValidateUpdate(old runtime.Object) error {
 	iochaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*IOChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*IOChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -81,6 +80,8 @@ func (in *IOChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *IOChaos) Validate() error {
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
@@ -93,6 +94,7 @@ func (in *IOChaosSpec) Validate() field.ErrorList {
 	allErrs := in.validateDelay(specField.Child("delay"))
 	allErrs = append(allErrs, validateDuration(in, specField)...)
 	allErrs = append(allErrs, validatePodSelector(in.PodSelector.Value, in.PodSelector.Mode, specField.Child("value"))...)
+	allErrs = append(allErrs, validateContainerSelector(&in.ContainerSelector, specField)...)
 	allErrs = append(allErrs, in.validateErrno(specField.Child("errno"))...)
 	allErrs = append(allErrs, in.validatePercent(specField.Child("percent"))...)
 
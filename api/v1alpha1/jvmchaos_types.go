@@ -14,6 +14,8 @@
 package v1alpha1
 
 import (
+	"strconv"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,7 +25,22 @@ type JVMChaosSpec struct {
 
 	// Duration represents the duration of the chaos action
 	// +optional
-	Duration *string `json:"duration,omitempty"`
+	Duration *Duration `json:"duration,omitempty"`
+
+	// RecoverTimeout represents how long to wait for the chaos to be recovered after the duration ends,
+	// the chaos which is not recovered in time is escalated with a warning event and the RecoverTimedOut condition.
+	// +optional
+	RecoverTimeout *string `json:"recoverTimeout,omitempty"`
+
+	// ActiveWindows are the recurring windows in which the chaos is active, e.g. 9:00-17:00 on weekdays.
+	// The chaos is recovered outside the windows, and applied again inside them.
+	// +optional
+	ActiveWindows ActiveWindows `json:"activeWindows,omitempty"`
+
+	// ManualRecover keeps the chaos injected after the duration is exceeded, until it's paused or deleted.
+	// It's not supported by the one-shot actions.
+	// +optional
+	ManualRecover bool `json:"manualRecover,omitempty"`
 
 	// Action defines the specific jvm chaos action.
 	// Supported action: delay;return;script;cfl;oom;ccf;tce;cpf;tde;tpf
@@ -34,6 +51,27 @@ type JVMChaosSpec struct {
 	// +optional
 	JVMParameter `json:",inline"`
 
+	// Class is the name of the class whose method throws the exception, e.g. com.foo.Service.
+	// Class, Method, ThrowException and Probability are the structured parameters of the tce action on the jvm target,
+	// they are translated into the flags and matchers of the action.
+	// +optional
+	Class string `json:"class,omitempty"`
+
+	// Method is the name of the method which throws the exception, e.g. doThing.
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// ThrowException is the name of the exception thrown by the method, e.g. java.io.IOException.
+	// +optional
+	ThrowException string `json:"throwException,omitempty"`
+
+	// Probability is the percentage of the invocations of the method which throw the exception,
+	// every invocation throws the exception if it's not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Probability *int32 `json:"probability,omitempty"`
+
 	// Target defines the specific jvm chaos target.
 	// Supported target: servlet;psql;jvm;jedis;http;dubbo;rocketmq;tars;mysql;druid;redisson;rabbitmq;mongodb
 	// +kubebuilder:validation:Enum=servlet;psql;jvm;jedis;http;dubbo;rocketmq;tars;mysql;druid;redisson;rabbitmq;mongodb
@@ -132,12 +170,51 @@ type JVMParameter struct {
 	Matchers map[string]string `json:"matchers,omitempty"`
 }
 
+// ThrowsException returns true if the structured parameters of throwing the exception are specified
+func (in *JVMChaosSpec) ThrowsException() bool {
+	return in.Class != "" || in.Method != "" || in.ThrowException != "" || in.Probability != nil
+}
+
+// ResolvedParameter returns the flags and matchers of the action, including the ones translated from
+// the structured parameters, which take precedence over the ones with the same names.
+func (in *JVMChaosSpec) ResolvedParameter() JVMParameter {
+	parameter := *in.JVMParameter.DeepCopy()
+	if !in.ThrowsException() {
+		return parameter
+	}
+
+	if parameter.Flags == nil {
+		parameter.Flags = make(map[string]string)
+	}
+	if parameter.Matchers == nil {
+		parameter.Matchers = make(map[string]string)
+	}
+	if in.Class != "" {
+		parameter.Matchers["classname"] = in.Class
+	}
+	if in.Method != "" {
+		parameter.Matchers["methodname"] = in.Method
+	}
+	if in.ThrowException != "" {
+		parameter.Flags["exception"] = in.ThrowException
+	}
+	if in.Probability != nil {
+		parameter.Matchers["effect-percent"] = strconv.Itoa(int(*in.Probability))
+	}
+	return parameter
+}
+
 // JVMChaosStatus defines the observed state of JVMChaos
 type JVMChaosStatus struct {
 	ChaosStatus `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="action",type=string,JSONPath=`.status.action`
+// +kubebuilder:printcolumn:name="phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="selected",type=integer,JSONPath=`.status.selected`
+// +kubebuilder:printcolumn:name="duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +chaos-mesh:base
 
 // JVMChaos is the Schema for the jvmchaos API
@@ -15,7 +15,6 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
 	"strconv"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -35,12 +34,20 @@ var _ webhook.Defaulter = &JVMChaos{}
 func (in *JVMChaos) Default() {
 	jvmchaoslog.Info("default", "name", in.Name)
 
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	in.Spec.Default()
 }
 
 func (in *JVMChaosSpec) Default() {
-
+	// the structured parameters are the parameters of throwing the exception from the method
+	if in.ThrowsException() {
+		if in.Target == "" {
+			in.Target = JVM
+		}
+		if in.Action == "" {
+			in.Action = JVMExceptionAction
+		}
+	}
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-chaos-mesh-org-v1alpha1-jvmchaos,mutating=false,failurePolicy=fail,groups=chaos-mesh.org,resources=jvmchaos,versions=v1alpha1,name=vjvmchaos.kb.io
@@ -57,7 +64,7 @@ func (in *JVMChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *JVMChaos) ValidateUpdate(old runtime.Object) error {
 	jvmchaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*JVMChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*JVMChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -74,6 +81,8 @@ func (in *JVMChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *JVMChaos) Validate() error {
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
 	}
@@ -84,6 +93,8 @@ func (in *JVMChaos) Validate() error {
 func (in *JVMChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := in.validateJvmChaos(specField)
+	allErrs = append(allErrs, in.validateThrowException(specField)...)
+	allErrs = append(allErrs, validateContainerSelector(&in.ContainerSelector, specField)...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
 	return allErrs
 }
@@ -94,14 +105,15 @@ func (in *JVMChaosSpec) validateJvmChaos(spec *field.Path) field.ErrorList {
 	actionField := spec.Child("action")
 	flagsField := spec.Child("flags")
 	matcherField := spec.Child("matcher")
+	parameter := in.ResolvedParameter()
 	if actions, ok := JvmSpec[in.Target]; ok {
 		if actionPR, actionOK := actions[in.Action]; actionOK {
 			if actionPR.Flags != nil {
-				allErrs = append(allErrs, in.validateParameterRules(in.Flags, actionPR.Flags, flagsField, targetField, actionField)...)
+				allErrs = append(allErrs, in.validateParameterRules(parameter.Flags, actionPR.Flags, flagsField, targetField, actionField)...)
 			}
 
 			if actionPR.Matchers != nil {
-				allErrs = append(allErrs, in.validateParameterRules(in.Matchers, actionPR.Matchers, matcherField, targetField, actionField)...)
+				allErrs = append(allErrs, in.validateParameterRules(parameter.Matchers, actionPR.Matchers, matcherField, targetField, actionField)...)
 			}
 
 		} else {
@@ -122,6 +134,32 @@ func (in *JVMChaosSpec) validateJvmChaos(spec *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// validateThrowException validates the structured parameters of throwing the exception from the method
+func (in *JVMChaosSpec) validateThrowException(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !in.ThrowsException() {
+		return allErrs
+	}
+
+	if in.Target != JVM || in.Action != JVMExceptionAction {
+		allErrs = append(allErrs, field.Invalid(spec.Child("action"), in.Action,
+			fmt.Sprintf("class, method, throwException and probability are only supported by the action %s on the target %s", JVMExceptionAction, JVM)))
+	}
+	if in.Class == "" {
+		allErrs = append(allErrs, field.Required(spec.Child("class"), "the class which throws the exception is required"))
+	}
+	if in.Method == "" {
+		allErrs = append(allErrs, field.Required(spec.Child("method"), "the method which throws the exception is required"))
+	}
+	if in.ThrowException == "" {
+		allErrs = append(allErrs, field.Required(spec.Child("throwException"), "the exception to throw is required"))
+	}
+	if in.Probability != nil && (*in.Probability < 0 || *in.Probability > 100) {
+		allErrs = append(allErrs, field.Invalid(spec.Child("probability"), *in.Probability, "probability should be between 0 and 100"))
+	}
+	return allErrs
+}
+
 func toString(actions []JVMChaosAction) []string {
 	ret := make([]string, 0)
 	for _, act := range actions {
@@ -0,0 +1,136 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("jvmchaos_webhook", func() {
+	Context("Defaulter", func() {
+		It("set the action of throwing the exception", func() {
+			jvmchaos := &JVMChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault},
+				Spec: JVMChaosSpec{
+					Class:          "com.foo.Service",
+					Method:         "doThing",
+					ThrowException: "java.io.IOException",
+				},
+			}
+			jvmchaos.Default()
+			Expect(jvmchaos.Spec.Target).To(Equal(JVM))
+			Expect(jvmchaos.Spec.Action).To(Equal(JVMExceptionAction))
+		})
+	})
+	Context("webhook.Validator of jvmchaos", func() {
+		It("Validate", func() {
+			probability := int32(30)
+			invalidProbability := int32(101)
+
+			type TestCase struct {
+				name   string
+				spec   JVMChaosSpec
+				expect string
+			}
+			tcs := []TestCase{
+				{
+					name: "throw the exception",
+					spec: JVMChaosSpec{
+						Action:         JVMExceptionAction,
+						Target:         JVM,
+						Class:          "com.foo.Service",
+						Method:         "doThing",
+						ThrowException: "java.io.IOException",
+						Probability:    &probability,
+					},
+					expect: "",
+				},
+				{
+					name: "throw the exception with the flags and matchers",
+					spec: JVMChaosSpec{
+						Action: JVMExceptionAction,
+						Target: JVM,
+						JVMParameter: JVMParameter{
+							Flags:    map[string]string{"exception": "java.io.IOException"},
+							Matchers: map[string]string{"classname": "com.foo.Service", "methodname": "doThing"},
+						},
+					},
+					expect: "",
+				},
+				{
+					name: "without the class",
+					spec: JVMChaosSpec{
+						Action:         JVMExceptionAction,
+						Target:         JVM,
+						Method:         "doThing",
+						ThrowException: "java.io.IOException",
+					},
+					expect: "error",
+				},
+				{
+					name: "without the method",
+					spec: JVMChaosSpec{
+						Action:         JVMExceptionAction,
+						Target:         JVM,
+						Class:          "com.foo.Service",
+						ThrowException: "java.io.IOException",
+					},
+					expect: "error",
+				},
+				{
+					name: "invalid probability",
+					spec: JVMChaosSpec{
+						Action:         JVMExceptionAction,
+						Target:         JVM,
+						Class:          "com.foo.Service",
+						Method:         "doThing",
+						ThrowException: "java.io.IOException",
+						Probability:    &invalidProbability,
+					},
+					expect: "error",
+				},
+				{
+					name: "unsupported action",
+					spec: JVMChaosSpec{
+						Action: JVMDelayAction,
+						Target: JVM,
+						JVMParameter: JVMParameter{
+							Flags: map[string]string{"time": "1000"},
+						},
+						Class:          "com.foo.Service",
+						Method:         "doThing",
+						ThrowException: "java.io.IOException",
+					},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				tc.spec.Mode = OnePodMode
+				jvmchaos := &JVMChaos{
+					ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault},
+					Spec:       tc.spec,
+				}
+				err := jvmchaos.ValidateCreate()
+				if tc.expect == "error" {
+					Expect(err).To(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).ToNot(HaveOccurred(), tc.name)
+				}
+			}
+		})
+	})
+})
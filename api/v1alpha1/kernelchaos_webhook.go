@@ -15,7 +15,6 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -34,7 +33,7 @@ var _ webhook.Defaulter = &KernelChaos{}
 func (in *KernelChaos) Default() {
 	kernelchaoslog.Info("default", "name", in.Name)
 
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	in.Spec.Default()
 }
 
@@ -55,7 +54,7 @@ func (in *KernelChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *KernelChaos) ValidateUpdate(old runtime.Object) error {
 	kernelchaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*KernelChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*KernelChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -72,6 +71,8 @@ func (in *KernelChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *KernelChaos) Validate() error {
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
 	}
@@ -82,6 +83,7 @@ func (in *KernelChaos) Validate() error {
 func (in *KernelChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := validatePodSelector(in.PodSelector.Value, in.PodSelector.Mode, specField.Child("value"))
+	allErrs = append(allErrs, validatePodSelectorSpec(&in.Selector, specField.Child("selector"))...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
 
 	return allErrs
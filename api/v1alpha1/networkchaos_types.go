@@ -21,6 +21,11 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="action",type=string,JSONPath=`.status.action`
+// +kubebuilder:printcolumn:name="phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="selected",type=integer,JSONPath=`.status.selected`
+// +kubebuilder:printcolumn:name="duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +chaos-mesh:base
 
 // NetworkChaos is the Schema for the networkchaos API
@@ -61,6 +66,14 @@ const (
 
 	// BandwidthAction represents the chaos action of network bandwidth of pods.
 	BandwidthAction NetworkChaosAction = "bandwidth"
+
+	// ShapedNetemAction limits the bandwidth like the bandwidth action, and emulates the network
+	// like the netem action on the shaped link. The netem qdisc is attached under the tbf qdisc.
+	ShapedNetemAction NetworkChaosAction = "shaped-netem"
+
+	// WeightedAction picks one of the weighted actions randomly every time the chaos is applied,
+	// the chance of an action being picked is proportional to its weight.
+	WeightedAction NetworkChaosAction = "weighted"
 )
 
 // Direction represents traffic direction from source to target,
@@ -84,13 +97,33 @@ type NetworkChaosSpec struct {
 	PodSelector `json:",inline"`
 
 	// Action defines the specific network chaos action.
-	// Supported action: partition, netem, delay, loss, duplicate, corrupt
+	// Supported action: partition, netem, delay, loss, duplicate, corrupt, bandwidth, shaped-netem, weighted
 	// Default action: delay
-	// +kubebuilder:validation:Enum=netem;delay;loss;duplicate;corrupt;partition;bandwidth
+	// +kubebuilder:validation:Enum=netem;delay;loss;duplicate;corrupt;partition;bandwidth;shaped-netem;weighted
 	Action NetworkChaosAction `json:"action"`
 
+	// WeightedActions are the candidates of the weighted action, one of them is picked every time the chaos
+	// is applied, and the chaos is applied with the delay, loss, duplicate or corrupt spec of the picked action.
+	// +optional
+	WeightedActions []WeightedNetworkAction `json:"weightedActions,omitempty"`
+
 	// Duration represents the duration of the chaos action
-	Duration *string `json:"duration,omitempty"`
+	Duration *Duration `json:"duration,omitempty"`
+
+	// RecoverTimeout represents how long to wait for the chaos to be recovered after the duration ends,
+	// the chaos which is not recovered in time is escalated with a warning event and the RecoverTimedOut condition.
+	// +optional
+	RecoverTimeout *string `json:"recoverTimeout,omitempty"`
+
+	// ActiveWindows are the recurring windows in which the chaos is active, e.g. 9:00-17:00 on weekdays.
+	// The chaos is recovered outside the windows, and applied again inside them.
+	// +optional
+	ActiveWindows ActiveWindows `json:"activeWindows,omitempty"`
+
+	// ManualRecover keeps the chaos injected after the duration is exceeded, until it's paused or deleted.
+	// It's not supported by the one-shot actions.
+	// +optional
+	ManualRecover bool `json:"manualRecover,omitempty"`
 
 	// TcParameter represents the traffic control definition
 	TcParameter `json:",inline"`
@@ -107,6 +140,47 @@ type NetworkChaosSpec struct {
 	// ExternalTargets represents network targets outside k8s
 	// +optional
 	ExternalTargets []string `json:"externalTargets,omitempty"`
+
+	// Protocol limits the traffic control to the packets of the protocol, all the packets are affected if it's empty.
+	// It doesn't apply on network partition action.
+	// +optional
+	// +kubebuilder:validation:Enum=tcp;udp;""
+	Protocol string `json:"protocol,omitempty"`
+
+	// Port limits the traffic control to the packets sent to the port, which requires the protocol.
+	// It doesn't apply on network partition action.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+}
+
+// WeightedNetworkAction is a candidate of the weighted action
+type WeightedNetworkAction struct {
+	// Action is the action to be picked, it's one of delay, loss, duplicate and corrupt
+	// +kubebuilder:validation:Enum=delay;loss;duplicate;corrupt
+	Action NetworkChaosAction `json:"action"`
+
+	// Weight is the relative chance of the action being picked, the action is never picked if it's zero
+	// +kubebuilder:validation:Minimum=0
+	Weight int32 `json:"weight"`
+}
+
+// ParameterOf returns the traffic control parameter which contains only the emulation of the action,
+// it returns false if the action is not one of delay, loss, duplicate and corrupt or its emulation is not set.
+func (in *TcParameter) ParameterOf(action NetworkChaosAction) (TcParameter, bool) {
+	var parameter TcParameter
+	switch action {
+	case DelayAction:
+		parameter.Delay = in.Delay
+	case LossAction:
+		parameter.Loss = in.Loss
+	case DuplicateAction:
+		parameter.Duplicate = in.Duplicate
+	case CorruptAction:
+		parameter.Corrupt = in.Corrupt
+	}
+	return parameter, parameter.Delay != nil || parameter.Loss != nil || parameter.Duplicate != nil || parameter.Corrupt != nil
 }
 
 // NetworkChaosStatus defines the observed state of NetworkChaos
@@ -115,6 +189,10 @@ type NetworkChaosStatus struct {
 	// Instances always specifies podnetworkchaos generation or empty
 	// +optional
 	Instances map[string]int64 `json:"instances,omitempty"`
+	// Cycles counts how many times each record is applied with the weighted action,
+	// the action of every cycle is picked with the seed derived from the uid of the chaos and the cycle
+	// +optional
+	Cycles map[string]int64 `json:"cycles,omitempty"`
 }
 
 // DelaySpec defines detail of a delay action
@@ -126,6 +204,10 @@ type DelaySpec struct {
 	Jitter string `json:"jitter,omitempty"`
 	// +optional
 	Reorder *ReorderSpec `json:"reorder,omitempty"`
+	// Probability is the percentage of the packets to be delayed, e.g. "50" delays half of them.
+	// All the packets are delayed if it's empty.
+	// +optional
+	Probability string `json:"probability,omitempty"`
 }
 
 // LossSpec defines detail of a loss action
@@ -147,11 +229,16 @@ type CorruptSpec struct {
 	Corrupt string `json:"corrupt"`
 	// +optional
 	Correlation string `json:"correlation,omitempty"`
+	// Probability is the percentage of the packets to be impaired by the corrupt action, and the
+	// other packets are never corrupted. All the packets are impaired if it's empty.
+	// +optional
+	Probability string `json:"probability,omitempty"`
 }
 
 // BandwidthSpec defines detail of bandwidth limit.
 type BandwidthSpec struct {
 	// Rate is the speed knob. Allows bps, kbps, mbps, gbps, tbps unit. bps means bytes per second.
+	// Bit-based units bit, kbit, mbit, gbit, tbit are also allowed, bit means bits per second.
 	Rate string `json:"rate"`
 	// Limit is the number of bytes that can be queued waiting for tokens to become available.
 	// +kubebuilder:validation:Minimum=1
@@ -16,7 +16,7 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
-	"reflect"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -33,6 +33,10 @@ const (
 
 	// DefaultCorrelation defines default value for correlation
 	DefaultCorrelation = "0"
+
+	// MaxNetemDelay is the max latency or jitter which netem could represent, as it's sent in microseconds
+	// through an uint32 field
+	MaxNetemDelay = time.Duration(math.MaxUint32) * time.Microsecond
 )
 
 // log is for logging in this package.
@@ -46,10 +50,10 @@ var _ webhook.Defaulter = &NetworkChaos{}
 func (in *NetworkChaos) Default() {
 	networkchaoslog.Info("default", "name", in.Name)
 
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	// the target's namespace selector
 	if in.Spec.Target != nil {
-		in.Spec.Target.Selector.DefaultNamespace(in.GetNamespace())
+		in.Spec.Target.Selector.DefaultNamespace(in)
 	}
 
 	in.Spec.Default()
@@ -122,7 +126,7 @@ func (in *NetworkChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *NetworkChaos) ValidateUpdate(old runtime.Object) error {
 	networkchaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*NetworkChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*NetworkChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -140,6 +144,8 @@ func (in *NetworkChaos) ValidateDelete() error {
 func (in *NetworkChaos) Validate() error {
 
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
@@ -151,8 +157,11 @@ func (in *NetworkChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	var allErrs field.ErrorList
 
+	allErrs = append(allErrs, validatePodSelectorSpec(&in.Selector, specField.Child("selector"))...)
+	allErrs = append(allErrs, validateMinMatches(&in.PodSelector, specField)...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
 	allErrs = append(allErrs, in.validateTargets(specField.Child("target"))...)
+	allErrs = append(allErrs, in.validatePortFilter(specField)...)
 	if in.Delay != nil {
 		allErrs = append(allErrs, in.Delay.validateDelay(specField.Child("delay"))...)
 	}
@@ -165,11 +174,52 @@ func (in *NetworkChaosSpec) Validate() field.ErrorList {
 	if in.Corrupt != nil {
 		allErrs = append(allErrs, in.Corrupt.validateCorrupt(specField.Child("corrupt"))...)
 	}
+	allErrs = append(allErrs, in.validateNetem(specField)...)
+	allErrs = append(allErrs, in.validateWeightedActions(specField)...)
 	if in.Bandwidth != nil {
 		allErrs = append(allErrs, in.Bandwidth.validateBandwidth(specField.Child("bandwidth"))...)
 	}
 	if in.Target != nil {
 		allErrs = append(allErrs, in.validateTargetPodSelector(specField.Child("target"))...)
+		allErrs = append(allErrs, validatePodSelectorSpec(&in.Target.Selector, specField.Child("target", "selector"))...)
+		allErrs = append(allErrs, validateMinMatches(in.Target, specField.Child("target"))...)
+	}
+
+	return allErrs
+}
+
+// validatePortFilter validates the protocol and the port which limit the traffic control
+func (in *NetworkChaosSpec) validatePortFilter(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in.Action == PartitionAction {
+		if len(in.Protocol) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(spec.Child("protocol"), in.Protocol,
+					"protocol cannot be used with partition action"))
+		}
+		if in.Port != 0 {
+			allErrs = append(allErrs,
+				field.Invalid(spec.Child("port"), in.Port,
+					"port cannot be used with partition action"))
+		}
+		return allErrs
+	}
+
+	if len(in.Protocol) > 0 && in.Protocol != "tcp" && in.Protocol != "udp" {
+		allErrs = append(allErrs,
+			field.NotSupported(spec.Child("protocol"), in.Protocol, []string{"tcp", "udp"}))
+	}
+	if in.Port != 0 {
+		if in.Port < 0 || in.Port > 65535 {
+			allErrs = append(allErrs,
+				field.Invalid(spec.Child("port"), in.Port,
+					"port should be in [1, 65535]"))
+		}
+		if len(in.Protocol) == 0 {
+			allErrs = append(allErrs,
+				field.Required(spec.Child("protocol"), "protocol is required to filter the packets by port"))
+		}
 	}
 
 	return allErrs
@@ -178,130 +228,293 @@ func (in *NetworkChaosSpec) Validate() field.ErrorList {
 // validateDelay validates the delay
 func (in *DelaySpec) validateDelay(delay *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	_, err := time.ParseDuration(in.Latency)
+	latency, err := time.ParseDuration(in.Latency)
 	if err != nil {
 		allErrs = append(allErrs,
 			field.Invalid(delay.Child("latency"), in.Latency,
 				fmt.Sprintf("parse latency field error:%s", err)))
+	} else if latency < 0 || latency > MaxNetemDelay {
+		allErrs = append(allErrs,
+			field.Invalid(delay.Child("latency"), in.Latency,
+				fmt.Sprintf("latency should be in [0, %s]", MaxNetemDelay)))
 	}
-	_, err = time.ParseDuration(in.Jitter)
+	jitter, err := time.ParseDuration(in.Jitter)
 	if err != nil {
 		allErrs = append(allErrs,
 			field.Invalid(delay.Child("jitter"), in.Jitter,
 				fmt.Sprintf("parse jitter field error:%s", err)))
-	}
-
-	_, err = strconv.ParseFloat(in.Correlation, 32)
-	if err != nil {
+	} else if jitter < 0 || jitter > MaxNetemDelay {
 		allErrs = append(allErrs,
-			field.Invalid(delay.Child("correlation"), in.Correlation,
-				fmt.Sprintf("parse correlation field error:%s", err)))
+			field.Invalid(delay.Child("jitter"), in.Jitter,
+				fmt.Sprintf("jitter should be in [0, %s]", MaxNetemDelay)))
 	}
 
+	allErrs = append(allErrs, validatePercentage(delay.Child("correlation"), in.Correlation, "correlation")...)
+
 	if in.Reorder != nil {
 		allErrs = append(allErrs, in.Reorder.validateReorder(delay.Child("reorder"))...)
 	}
+	if len(in.Probability) > 0 {
+		allErrs = append(allErrs, validateProbability(delay.Child("probability"), in.Probability)...)
+	}
 	return allErrs
 }
 
 // validateReorder validates the reorder
 func (in *ReorderSpec) validateReorder(reorder *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	_, err := strconv.ParseFloat(in.Reorder, 32)
+	percentage, err := strconv.ParseFloat(in.Reorder, 32)
 	if err != nil {
 		allErrs = append(allErrs,
 			field.Invalid(reorder.Child("reorder"), in.Reorder,
 				fmt.Sprintf("parse reorder field error:%s", err)))
+	} else if percentage < 0 || percentage > 100 {
+		allErrs = append(allErrs,
+			field.Invalid(reorder.Child("reorder"), in.Reorder,
+				"reorder percentage should be in [0, 100]"))
 	}
 
-	_, err = strconv.ParseFloat(in.Correlation, 32)
-	if err != nil {
+	if in.Gap < 0 {
 		allErrs = append(allErrs,
-			field.Invalid(reorder.Child("correlation"), in.Correlation,
-				fmt.Sprintf("parse correlation field error:%s", err)))
+			field.Invalid(reorder.Child("gap"), in.Gap,
+				"gap should be greater than or equal to 0"))
 	}
+
+	allErrs = append(allErrs, validatePercentage(reorder.Child("correlation"), in.Correlation, "correlation")...)
 	return allErrs
 }
 
 // validateLoss validates the loss
 func (in *LossSpec) validateLoss(loss *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validatePercentage(loss.Child("loss"), in.Loss, "loss")...)
+	allErrs = append(allErrs, validatePercentage(loss.Child("correlation"), in.Correlation, "correlation")...)
+	return allErrs
+}
 
-	_, err := strconv.ParseFloat(in.Loss, 32)
-	if err != nil {
-		allErrs = append(allErrs,
-			field.Invalid(loss.Child("loss"), in.Loss,
-				fmt.Sprintf("parse loss field error:%s", err)))
+// validateDuplicate validates the duplicate
+func (in *DuplicateSpec) validateDuplicate(duplicate *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validatePercentage(duplicate.Child("duplicate"), in.Duplicate, "duplicate")...)
+	allErrs = append(allErrs, validatePercentage(duplicate.Child("correlation"), in.Correlation, "correlation")...)
+	return allErrs
+}
+
+// validateCorrupt validates the corrupt
+func (in *CorruptSpec) validateCorrupt(corrupt *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validatePercentage(corrupt.Child("corrupt"), in.Corrupt, "corrupt")...)
+	allErrs = append(allErrs, validatePercentage(corrupt.Child("correlation"), in.Correlation, "correlation")...)
+	if len(in.Probability) > 0 {
+		allErrs = append(allErrs, validateProbability(corrupt.Child("probability"), in.Probability)...)
 	}
+	return allErrs
+}
 
-	_, err = strconv.ParseFloat(in.Correlation, 32)
+// validatePercentage validates the percentage, which should be a number in [0, 100]
+func validatePercentage(path *field.Path, value string, name string) field.ErrorList {
+	percentage, err := strconv.ParseFloat(value, 32)
 	if err != nil {
-		allErrs = append(allErrs,
-			field.Invalid(loss.Child("correlation"), in.Correlation,
-				fmt.Sprintf("parse correlation field error:%s", err)))
+		return field.ErrorList{
+			field.Invalid(path, value, fmt.Sprintf("parse %s field error:%s", name, err)),
+		}
+	}
+	if percentage < 0 || percentage > 100 {
+		return field.ErrorList{
+			field.Invalid(path, value, fmt.Sprintf("%s should be in [0, 100]", name)),
+		}
 	}
+	return nil
+}
 
-	return allErrs
+// validateProbability validates the probability, which should be a number in [0, 100]
+func validateProbability(path *field.Path, value string) field.ErrorList {
+	probability, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return field.ErrorList{
+			field.Invalid(path, value, fmt.Sprintf("parse probability field error:%s", err)),
+		}
+	}
+	if probability < 0 || probability > 100 {
+		return field.ErrorList{
+			field.Invalid(path, value, "probability should be in [0, 100]"),
+		}
+	}
+	return nil
 }
 
-// validateDuplicate validates the duplicate
-func (in *DuplicateSpec) validateDuplicate(duplicate *field.Path) field.ErrorList {
+// validateNetem validates the combination of delay, loss, duplicate and corrupt,
+// which are merged into one netem qdisc.
+func (in *NetworkChaosSpec) validateNetem(spec *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	_, err := strconv.ParseFloat(in.Duplicate, 32)
-	if err != nil {
+
+	if (in.Action == NetemAction || in.Action == ShapedNetemAction) &&
+		in.Delay == nil && in.Loss == nil && in.Duplicate == nil && in.Corrupt == nil {
 		allErrs = append(allErrs,
-			field.Invalid(duplicate.Child("duplicate"), in.Duplicate,
-				fmt.Sprintf("parse duplicate field error:%s", err)))
+			field.Required(spec, fmt.Sprintf("at least one of delay, loss, duplicate and corrupt is required by the %s action", in.Action)))
+	}
+	if in.Action == ShapedNetemAction {
+		if in.Bandwidth == nil {
+			allErrs = append(allErrs,
+				field.Required(spec.Child("bandwidth"), "bandwidth is required by the shaped-netem action"))
+		} else {
+			allErrs = append(allErrs, in.Bandwidth.validateShaping(spec.Child("bandwidth"))...)
+		}
 	}
 
-	_, err = strconv.ParseFloat(in.Correlation, 32)
-	if err != nil {
-		allErrs = append(allErrs,
-			field.Invalid(duplicate.Child("correlation"), in.Correlation,
-				fmt.Sprintf("parse correlation field error:%s", err)))
+	// the weighted action applies only one of the emulations at a time, so they are never merged
+	merged := in.Action != WeightedAction
+
+	// all the packets are dropped, so the other emulations are pointless
+	if merged && in.Loss != nil && (in.Delay != nil || in.Duplicate != nil || in.Corrupt != nil) {
+		if loss, err := strconv.ParseFloat(in.Loss.Loss, 32); err == nil && loss >= 100 {
+			allErrs = append(allErrs,
+				field.Invalid(spec.Child("loss", "loss"), in.Loss.Loss,
+					"100% loss could not be combined with delay, duplicate or corrupt"))
+		}
 	}
+
+	// the probability selects the packets flowed into the netem qdisc, so it applies to all the emulations in it
+	var probabilityField *field.Path
+	probability := ""
+	if in.Delay != nil && len(in.Delay.Probability) > 0 {
+		probabilityField, probability = spec.Child("delay", "probability"), in.Delay.Probability
+	} else if in.Corrupt != nil && len(in.Corrupt.Probability) > 0 {
+		probabilityField, probability = spec.Child("corrupt", "probability"), in.Corrupt.Probability
+	}
+	if merged && probabilityField != nil {
+		if in.Delay != nil && in.Corrupt != nil && in.Delay.Probability != in.Corrupt.Probability {
+			allErrs = append(allErrs,
+				field.Invalid(probabilityField, probability,
+					"the probabilities of delay and corrupt should be the same"))
+		}
+		if in.Loss != nil || in.Duplicate != nil {
+			allErrs = append(allErrs,
+				field.Invalid(probabilityField, probability,
+					"probability could not be combined with loss or duplicate"))
+		}
+		if in.Action == ShapedNetemAction {
+			allErrs = append(allErrs,
+				field.Invalid(probabilityField, probability,
+					"probability is not supported by the shaped-netem action"))
+		}
+	}
+
+	// netem could only reorder the packets which are delayed
+	if in.Delay != nil && in.Delay.Reorder != nil {
+		if latency, err := time.ParseDuration(in.Delay.Latency); err == nil && latency == 0 {
+			allErrs = append(allErrs,
+				field.Invalid(spec.Child("delay", "reorder"), in.Delay.Reorder.Reorder,
+					"reorder requires a positive latency"))
+		}
+	}
+
 	return allErrs
 }
 
-// validateCorrupt validates the corrupt
-func (in *CorruptSpec) validateCorrupt(corrupt *field.Path) field.ErrorList {
+// validateWeightedActions validates the candidates of the weighted action
+func (in *NetworkChaosSpec) validateWeightedActions(spec *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	_, err := strconv.ParseFloat(in.Corrupt, 32)
-	if err != nil {
-		allErrs = append(allErrs,
-			field.Invalid(corrupt.Child("corrupt"), in.Corrupt,
-				fmt.Sprintf("parse corrupt field error:%s", err)))
+	actionsField := spec.Child("weightedActions")
+
+	if in.Action != WeightedAction {
+		if len(in.WeightedActions) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(actionsField, in.WeightedActions,
+					fmt.Sprintf("weightedActions cannot be used with %s action", in.Action)))
+		}
+		return allErrs
 	}
 
-	_, err = strconv.ParseFloat(in.Correlation, 32)
-	if err != nil {
+	if len(in.WeightedActions) == 0 {
+		return append(allErrs, field.Required(actionsField, "weightedActions is required by the weighted action"))
+	}
+
+	var sum int64
+	for i, action := range in.WeightedActions {
+		actionField := actionsField.Index(i)
+		switch action.Action {
+		case DelayAction, LossAction, DuplicateAction, CorruptAction:
+			if _, ok := in.TcParameter.ParameterOf(action.Action); !ok {
+				allErrs = append(allErrs,
+					field.Required(spec.Child(string(action.Action)),
+						fmt.Sprintf("%s is required by the weighted action which may pick it", action.Action)))
+			}
+		default:
+			allErrs = append(allErrs,
+				field.NotSupported(actionField.Child("action"), action.Action,
+					[]string{string(DelayAction), string(LossAction), string(DuplicateAction), string(CorruptAction)}))
+		}
+
+		if action.Weight < 0 {
+			allErrs = append(allErrs,
+				field.Invalid(actionField.Child("weight"), action.Weight, "weight should not be negative"))
+			continue
+		}
+		sum += int64(action.Weight)
+	}
+	if sum <= 0 {
 		allErrs = append(allErrs,
-			field.Invalid(corrupt.Child("correlation"), in.Correlation,
-				fmt.Sprintf("parse correlation field error:%s", err)))
+			field.Invalid(actionsField, in.WeightedActions, "the sum of the weights should be positive"))
 	}
+
 	return allErrs
 }
 
 // validateBandwidth validates the bandwidth
 func (in *BandwidthSpec) validateBandwidth(bandwidth *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	_, err := ConvertUnitToBytes(in.Rate)
+	rate, err := ConvertUnitToBytes(in.Rate)
 
 	if err != nil {
 		allErrs = append(allErrs,
 			field.Invalid(bandwidth.Child("rate"), in.Rate,
 				fmt.Sprintf("parse rate field error:%s", err)))
+	} else if rate == 0 {
+		// e.g. 7bit is less than one byte per second
+		allErrs = append(allErrs,
+			field.Invalid(bandwidth.Child("rate"), in.Rate,
+				"rate should be at least 1 byte per second"))
+	}
+	return allErrs
+}
+
+// validateShaping validates that the bandwidth is complete to build the tbf qdisc, under which the netem qdisc
+// is attached. The rate is validated by validateBandwidth.
+func (in *BandwidthSpec) validateShaping(bandwidth *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(in.Rate) == 0 {
+		allErrs = append(allErrs, field.Required(bandwidth.Child("rate"), "rate is required to shape the link"))
+	}
+	if in.Limit == 0 {
+		allErrs = append(allErrs, field.Required(bandwidth.Child("limit"), "limit is required to shape the link"))
+	}
+	if in.Buffer == 0 {
+		allErrs = append(allErrs, field.Required(bandwidth.Child("buffer"), "buffer is required to shape the link"))
 	}
 	return allErrs
 }
 
+// rateUnits are the supported units of rate, the units with more characters are checked first
+// as they share the same suffix. The byte-based units are `bps` suffixed, and the bit-based units
+// are `bit` suffixed.
+var rateUnits = []struct {
+	suffix string
+	power  int
+	isBit  bool
+}{
+	{"tbps", 4, false}, {"gbps", 3, false}, {"mbps", 2, false}, {"kbps", 1, false}, {"bps", 0, false},
+	{"tbit", 4, true}, {"gbit", 3, true}, {"mbit", 2, true}, {"kbit", 1, true}, {"bit", 0, true},
+}
+
+// ConvertUnitToBytes converts the rate with unit to bytes per second
 func ConvertUnitToBytes(nu string) (uint64, error) {
 	// normalize input
 	s := strings.ToLower(strings.TrimSpace(nu))
 
-	for i, u := range []string{"tbps", "gbps", "mbps", "kbps", "bps"} {
-		if strings.HasSuffix(s, u) {
-			ts := strings.TrimSuffix(s, u)
+	for _, u := range rateUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			ts := strings.TrimSuffix(s, u.suffix)
 			s := strings.TrimSpace(ts)
 
 			n, err := strconv.ParseUint(s, 10, 64)
@@ -311,15 +524,19 @@ func ConvertUnitToBytes(nu string) (uint64, error) {
 			}
 
 			// convert unit to bytes
-			for j := 4 - i; j > 0; j-- {
+			for j := u.power; j > 0; j-- {
 				n = n * 1024
 			}
+			if u.isBit {
+				n = n / 8
+			}
 
 			return n, nil
 		}
 	}
 
-	return 0, errors.New("invalid unit")
+	return 0, errors.New("invalid rate unit, supported units are bps, kbps, mbps, gbps, tbps (bytes per second) " +
+		"and bit, kbit, mbit, gbit, tbit (bits per second)")
 }
 
 // validateTarget validates the target
@@ -17,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var _ = Describe("networkchaos_webhook", func() {
@@ -270,6 +271,345 @@ var _ = Describe("networkchaos_webhook", func() {
 			}
 		})
 	})
+	Context("validateDelay", func() {
+		It("should reject an over-large latency", func() {
+			delay := DelaySpec{
+				Latency:     "72m",
+				Jitter:      DefaultJitter,
+				Correlation: DefaultCorrelation,
+			}
+			errs := delay.validateDelay(field.NewPath("delay"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("delay.latency"))
+		})
+
+		It("should reject an over-large jitter", func() {
+			delay := DelaySpec{
+				Latency:     "10ms",
+				Jitter:      "72m",
+				Correlation: DefaultCorrelation,
+			}
+			errs := delay.validateDelay(field.NewPath("delay"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("delay.jitter"))
+		})
+
+		It("should accept the max latency", func() {
+			delay := DelaySpec{
+				Latency:     MaxNetemDelay.String(),
+				Jitter:      DefaultJitter,
+				Correlation: DefaultCorrelation,
+			}
+			Expect(delay.validateDelay(field.NewPath("delay"))).To(BeEmpty())
+		})
+
+		It("should validate the probability in [0, 100]", func() {
+			delay := DelaySpec{
+				Latency:     "10ms",
+				Jitter:      DefaultJitter,
+				Correlation: DefaultCorrelation,
+			}
+			for _, probability := range []string{"0", "50", "100"} {
+				delay.Probability = probability
+				Expect(delay.validateDelay(field.NewPath("delay"))).To(BeEmpty())
+			}
+
+			for _, probability := range []string{"-0.1", "100.1", "101", "num"} {
+				delay.Probability = probability
+				errs := delay.validateDelay(field.NewPath("delay"))
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Field).To(Equal("delay.probability"))
+			}
+		})
+	})
+	Context("validateNetem", func() {
+		It("should share the probability between the delay and the corrupt", func() {
+			spec := NetworkChaosSpec{
+				Action: NetemAction,
+				TcParameter: TcParameter{
+					Delay:   &DelaySpec{Latency: "10ms", Jitter: DefaultJitter, Correlation: DefaultCorrelation, Probability: "50"},
+					Corrupt: &CorruptSpec{Corrupt: "10", Correlation: DefaultCorrelation, Probability: "50"},
+				},
+			}
+			Expect(spec.validateNetem(field.NewPath("spec"))).To(BeEmpty())
+
+			spec.Corrupt.Probability = "20"
+			errs := spec.validateNetem(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.delay.probability"))
+
+			// the loss would be applied to a part of the packets as well
+			spec.Corrupt = nil
+			spec.Loss = &LossSpec{Loss: "10", Correlation: DefaultCorrelation}
+			errs = spec.validateNetem(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Detail).To(Equal("probability could not be combined with loss or duplicate"))
+		})
+
+		It("should require a netem spec for the netem action", func() {
+			spec := NetworkChaosSpec{Action: NetemAction}
+			errs := spec.validateNetem(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+		})
+
+		It("should require both the bandwidth and a netem spec for the shaped-netem action", func() {
+			spec := NetworkChaosSpec{
+				Action: ShapedNetemAction,
+				TcParameter: TcParameter{
+					Delay: &DelaySpec{Latency: "10ms", Jitter: DefaultJitter, Correlation: DefaultCorrelation},
+				},
+			}
+			errs := spec.validateNetem(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.bandwidth"))
+
+			spec.Bandwidth = &BandwidthSpec{Rate: "1mbps", Limit: 100, Buffer: 100}
+			Expect(spec.validateNetem(field.NewPath("spec"))).To(BeEmpty())
+
+			spec.Delay = nil
+			errs = spec.validateNetem(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+		})
+
+		It("should require the rate, limit and buffer to shape the delayed link", func() {
+			spec := NetworkChaosSpec{
+				Action: ShapedNetemAction,
+				TcParameter: TcParameter{
+					Delay:     &DelaySpec{Latency: "10ms", Jitter: DefaultJitter, Correlation: DefaultCorrelation},
+					Bandwidth: &BandwidthSpec{},
+				},
+			}
+			errs := spec.validateNetem(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(3))
+			var fields []string
+			for _, err := range errs {
+				Expect(err.Type).To(Equal(field.ErrorTypeRequired))
+				fields = append(fields, err.Field)
+			}
+			Expect(fields).To(ConsistOf("spec.bandwidth.rate", "spec.bandwidth.limit", "spec.bandwidth.buffer"))
+
+			spec.Bandwidth = &BandwidthSpec{Rate: "1mbps", Limit: 20971520, Buffer: 10000}
+			Expect(spec.validateNetem(field.NewPath("spec"))).To(BeEmpty())
+		})
+
+		It("should reject 100% loss combined with the other emulations", func() {
+			spec := NetworkChaosSpec{
+				Action: NetemAction,
+				TcParameter: TcParameter{
+					Loss:      &LossSpec{Loss: "100", Correlation: DefaultCorrelation},
+					Duplicate: &DuplicateSpec{Duplicate: "10", Correlation: DefaultCorrelation},
+				},
+			}
+			errs := spec.validateNetem(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.loss.loss"))
+		})
+
+		It("should reject reordering the packets without delay", func() {
+			spec := NetworkChaosSpec{
+				Action: DelayAction,
+				TcParameter: TcParameter{
+					Delay: &DelaySpec{
+						Latency:     "0ms",
+						Jitter:      DefaultJitter,
+						Correlation: DefaultCorrelation,
+						Reorder:     &ReorderSpec{Reorder: "50", Correlation: DefaultCorrelation},
+					},
+				},
+			}
+			errs := spec.validateNetem(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.delay.reorder"))
+		})
+
+		It("should accept all the emulations combined", func() {
+			chaos := NetworkChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "netem"},
+				Spec: NetworkChaosSpec{
+					Action: NetemAction,
+					TcParameter: TcParameter{
+						Delay:     &DelaySpec{Latency: "10ms", Reorder: &ReorderSpec{Reorder: "5", Gap: 3}},
+						Loss:      &LossSpec{Loss: "20"},
+						Duplicate: &DuplicateSpec{Duplicate: "30"},
+						Corrupt:   &CorruptSpec{Corrupt: "40"},
+					},
+				},
+			}
+			chaos.Default()
+			Expect(chaos.Spec.Delay.Correlation).To(Equal(DefaultCorrelation))
+			Expect(chaos.Spec.Delay.Reorder.Correlation).To(Equal(DefaultCorrelation))
+			Expect(chaos.Spec.Loss.Correlation).To(Equal(DefaultCorrelation))
+			Expect(chaos.Spec.Duplicate.Correlation).To(Equal(DefaultCorrelation))
+			Expect(chaos.Spec.Corrupt.Correlation).To(Equal(DefaultCorrelation))
+			Expect(chaos.Spec.Validate()).To(BeEmpty())
+		})
+
+		It("should reject an out-of-range percentage", func() {
+			loss := LossSpec{Loss: "101", Correlation: "-1"}
+			errs := loss.validateLoss(field.NewPath("loss"))
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Field).To(Equal("loss.loss"))
+			Expect(errs[1].Field).To(Equal("loss.correlation"))
+		})
+
+		It("should accept the bounds of the percentage", func() {
+			for _, percentage := range []string{"0", "100"} {
+				loss := LossSpec{Loss: percentage, Correlation: percentage}
+				Expect(loss.validateLoss(field.NewPath("loss"))).To(BeEmpty())
+
+				corrupt := CorruptSpec{Corrupt: percentage, Correlation: percentage, Probability: percentage}
+				Expect(corrupt.validateCorrupt(field.NewPath("corrupt"))).To(BeEmpty())
+			}
+
+			for _, percentage := range []string{"-0.1", "100.1"} {
+				duplicate := DuplicateSpec{Duplicate: percentage, Correlation: DefaultCorrelation}
+				errs := duplicate.validateDuplicate(field.NewPath("duplicate"))
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Field).To(Equal("duplicate.duplicate"))
+			}
+		})
+	})
+	Context("validatePortFilter", func() {
+		It("should reject the port filter with partition action", func() {
+			spec := NetworkChaosSpec{Action: PartitionAction, Protocol: "tcp", Port: 80}
+			errs := spec.validatePortFilter(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Field).To(Equal("spec.protocol"))
+			Expect(errs[1].Field).To(Equal("spec.port"))
+		})
+
+		It("should require the protocol to filter by port", func() {
+			spec := NetworkChaosSpec{Action: DelayAction, Port: 80}
+			errs := spec.validatePortFilter(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+		})
+
+		It("should reject an unknown protocol and an out-of-range port", func() {
+			spec := NetworkChaosSpec{Action: DelayAction, Protocol: "icmp", Port: 65536}
+			errs := spec.validatePortFilter(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+			Expect(errs[1].Field).To(Equal("spec.port"))
+		})
+
+		It("should accept the port filter with netem actions", func() {
+			spec := NetworkChaosSpec{Action: DelayAction, Protocol: "udp", Port: 53}
+			Expect(spec.validatePortFilter(field.NewPath("spec"))).To(BeEmpty())
+			spec = NetworkChaosSpec{Action: BandwidthAction, Protocol: "tcp"}
+			Expect(spec.validatePortFilter(field.NewPath("spec"))).To(BeEmpty())
+		})
+	})
+	Context("validateWeightedActions", func() {
+		It("should accept the weighted actions whose specs are set", func() {
+			spec := NetworkChaosSpec{
+				Action: WeightedAction,
+				WeightedActions: []WeightedNetworkAction{
+					{Action: DelayAction, Weight: 3},
+					{Action: LossAction, Weight: 1},
+					{Action: CorruptAction, Weight: 0},
+				},
+				TcParameter: TcParameter{
+					Delay:   &DelaySpec{Latency: "10ms", Jitter: DefaultJitter, Correlation: DefaultCorrelation, Probability: "50"},
+					Loss:    &LossSpec{Loss: "100", Correlation: DefaultCorrelation},
+					Corrupt: &CorruptSpec{Corrupt: "10", Correlation: DefaultCorrelation},
+				},
+			}
+			Expect(spec.validateWeightedActions(field.NewPath("spec"))).To(BeEmpty())
+			// only one of them is applied at a time, so they could not conflict with each other
+			Expect(spec.validateNetem(field.NewPath("spec"))).To(BeEmpty())
+		})
+
+		It("should require the weighted actions and their specs", func() {
+			spec := NetworkChaosSpec{Action: WeightedAction}
+			errs := spec.validateWeightedActions(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.weightedActions"))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+
+			spec.WeightedActions = []WeightedNetworkAction{{Action: DuplicateAction, Weight: 1}}
+			errs = spec.validateWeightedActions(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.duplicate"))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+		})
+
+		It("should reject the weights which don't sum positive", func() {
+			spec := NetworkChaosSpec{
+				Action: WeightedAction,
+				WeightedActions: []WeightedNetworkAction{
+					{Action: DelayAction, Weight: 0},
+					{Action: LossAction, Weight: -1},
+				},
+				TcParameter: TcParameter{
+					Delay: &DelaySpec{Latency: "10ms"},
+					Loss:  &LossSpec{Loss: "10"},
+				},
+			}
+			errs := spec.validateWeightedActions(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Field).To(Equal("spec.weightedActions[1].weight"))
+			Expect(errs[1].Field).To(Equal("spec.weightedActions"))
+			Expect(errs[1].Detail).To(Equal("the sum of the weights should be positive"))
+		})
+
+		It("should reject the actions which couldn't be weighted", func() {
+			spec := NetworkChaosSpec{
+				Action:          WeightedAction,
+				WeightedActions: []WeightedNetworkAction{{Action: PartitionAction, Weight: 1}},
+			}
+			errs := spec.validateWeightedActions(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.weightedActions[0].action"))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+
+			spec = NetworkChaosSpec{
+				Action:          DelayAction,
+				WeightedActions: []WeightedNetworkAction{{Action: DelayAction, Weight: 1}},
+				TcParameter:     TcParameter{Delay: &DelaySpec{Latency: "10ms"}},
+			}
+			errs = spec.validateWeightedActions(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.weightedActions"))
+		})
+	})
+	Context("validateReorder", func() {
+		It("should reject a negative gap", func() {
+			reorder := ReorderSpec{
+				Reorder:     "50",
+				Correlation: "0",
+				Gap:         -1,
+			}
+			errs := reorder.validateReorder(field.NewPath("reorder"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("reorder.gap"))
+		})
+
+		It("should reject an out-of-range reorder percentage", func() {
+			for _, percentage := range []string{"-1", "101"} {
+				reorder := ReorderSpec{
+					Reorder:     percentage,
+					Correlation: "0",
+					Gap:         5,
+				}
+				errs := reorder.validateReorder(field.NewPath("reorder"))
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Field).To(Equal("reorder.reorder"))
+			}
+		})
+
+		It("should accept a valid reorder", func() {
+			reorder := ReorderSpec{
+				Reorder:     "100",
+				Correlation: "0",
+				Gap:         0,
+			}
+			Expect(reorder.validateReorder(field.NewPath("reorder"))).To(BeEmpty())
+		})
+	})
+
 	Context("convertUnitToBytes", func() {
 		It("should convert number with unit successfully", func() {
 			n, err := ConvertUnitToBytes("  10   mbPs  ")
@@ -277,9 +617,40 @@ var _ = Describe("networkchaos_webhook", func() {
 			Expect(n).To(Equal(uint64(10 * 1024 * 1024)))
 		})
 
+		It("should convert number with bit-based unit successfully", func() {
+			n, err := ConvertUnitToBytes(" 10 mbit ")
+			Expect(err).Should(Succeed())
+			Expect(n).To(Equal(uint64(10 * 1024 * 1024 / 8)))
+
+			n, err = ConvertUnitToBytes("8bit")
+			Expect(err).Should(Succeed())
+			Expect(n).To(Equal(uint64(1)))
+		})
+
+		It("should distinguish mbit from mbps", func() {
+			bits, err := ConvertUnitToBytes("80mbit")
+			Expect(err).Should(Succeed())
+			bytes, err := ConvertUnitToBytes("10mbps")
+			Expect(err).Should(Succeed())
+			Expect(bits).To(Equal(bytes))
+		})
+
+		It("should reject the rate less than one byte per second", func() {
+			for _, rate := range []string{"0bps", "1bit", "7bit"} {
+				bandwidth := BandwidthSpec{Rate: rate}
+				errs := bandwidth.validateBandwidth(field.NewPath("bandwidth"))
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(ContainSubstring("at least 1 byte per second"))
+			}
+
+			bandwidth := BandwidthSpec{Rate: "8bit"}
+			Expect(bandwidth.validateBandwidth(field.NewPath("bandwidth"))).To(BeEmpty())
+		})
+
 		It("should return error with invalid unit", func() {
 			n, err := ConvertUnitToBytes(" 10 cpbs")
 			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bits per second"))
 			Expect(n).To(Equal(uint64(0)))
 		})
 	})
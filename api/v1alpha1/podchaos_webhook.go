@@ -15,7 +15,6 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -34,7 +33,7 @@ var _ webhook.Defaulter = &PodChaos{}
 func (in *PodChaos) Default() {
 	podchaoslog.Info("default", "name", in.Name)
 
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	in.Spec.Default()
 }
 
@@ -55,7 +54,7 @@ func (in *PodChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *PodChaos) ValidateUpdate(old runtime.Object) error {
 	podchaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*PodChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*PodChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -72,6 +71,8 @@ func (in *PodChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *PodChaos) Validate() error {
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
@@ -82,7 +83,10 @@ func (in *PodChaos) Validate() error {
 func (in *PodChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := in.validateContainerNames(specField.Child("containerNames"))
+	allErrs = append(allErrs, validateContainerSelector(&in.ContainerSelector, specField)...)
+	allErrs = append(allErrs, validateMinMatches(&in.PodSelector, specField)...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, in.validateFailureImage(specField.Child("failureImage"))...)
 
 	return allErrs
 }
@@ -98,3 +102,18 @@ func (in *PodChaosSpec) validateContainerNames(containerField *field.Path) field
 	}
 	return allErrs
 }
+
+// validateFailureImage validates the FailureImage
+func (in *PodChaosSpec) validateFailureImage(failureImageField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if in.FailureImage == nil {
+		return allErrs
+	}
+	if in.Action != PodFailureAction {
+		err := fmt.Errorf("the failure image is only supported on %s action", PodFailureAction)
+		allErrs = append(allErrs, field.Invalid(failureImageField, *in.FailureImage, err.Error()))
+	} else if *in.FailureImage == "" {
+		allErrs = append(allErrs, field.Invalid(failureImageField, *in.FailureImage, "the failure image should not be empty"))
+	}
+	return allErrs
+}
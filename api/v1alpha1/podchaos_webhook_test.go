@@ -31,6 +31,9 @@ var _ = Describe("podchaos_webhook", func() {
 	})
 	Context("webhook.Validator of podchaos", func() {
 		It("Validate", func() {
+			duration := Duration("1m")
+			failureImage := "busybox:not-exist"
+			emptyFailureImage := ""
 
 			type TestCase struct {
 				name    string
@@ -84,6 +87,59 @@ var _ = Describe("podchaos_webhook", func() {
 					},
 					expect: "error",
 				},
+				{
+					name: "validate the FailureImage",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo8",
+						},
+						Spec: PodChaosSpec{
+							Action:       PodFailureAction,
+							Duration:     &duration,
+							FailureImage: &failureImage,
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the empty FailureImage",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo9",
+						},
+						Spec: PodChaosSpec{
+							Action:       PodFailureAction,
+							Duration:     &duration,
+							FailureImage: &emptyFailureImage,
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the FailureImage on PodKillAction",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo10",
+						},
+						Spec: PodChaosSpec{
+							Action:       PodKillAction,
+							FailureImage: &failureImage,
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
 			}
 
 			for _, tc := range tcs {
@@ -102,6 +102,9 @@ const (
 
 	// Bandwidth represents bandwidth shape traffic control
 	Bandwidth TcType = "bandwidth"
+
+	// ShapedNetem represents netem traffic control under bandwidth shape traffic control
+	ShapedNetem TcType = "shaped-netem"
 )
 
 // RawTrafficControl represents the traffic control chaos on specific pod
@@ -115,6 +118,14 @@ type RawTrafficControl struct {
 	// +optional
 	IPSet string `json:"ipset,omitempty"`
 
+	// The protocol of the packets to be controlled
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// The destination port of the packets to be controlled
+	// +optional
+	EgressPort string `json:"egressPort,omitempty"`
+
 	// The name and namespace of the source network chaos
 	Source string `json:"source"`
 }
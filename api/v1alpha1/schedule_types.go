@@ -52,6 +52,10 @@ func (c ConcurrencyPolicy) IsAllow() bool {
 type ScheduleSpec struct {
 	Schedule string `json:"schedule"`
 
+	// Schedules are the additional cron expressions, the chaos is spawned at the times of any of Schedule and Schedules
+	// +optional
+	Schedules []string `json:"schedules,omitempty"`
+
 	// +optional
 	// +nullable
 	// +kubebuilder:validation:Minimum=0
@@ -66,6 +70,11 @@ type ScheduleSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	HistoryLimit int `json:"historyLimit,omitempty"`
 
+	// ArchiveHistory archives the experiments removed by the history limit in the dashboard,
+	// rather than dropping them with their events.
+	// +optional
+	ArchiveHistory bool `json:"archiveHistory,omitempty"`
+
 	// TODO: use a custom type, as `TemplateType` contains other possible values
 	Type ScheduleTemplateType `json:"type"`
 
@@ -84,6 +93,11 @@ type ScheduleStatus struct {
 
 type ScheduleTemplateType string
 
+// CronExpressions returns all the cron expressions of the schedule
+func (in *ScheduleSpec) CronExpressions() []string {
+	return append([]string{in.Schedule}, in.Schedules...)
+}
+
 func (in *Schedule) IsPaused() bool {
 	if in.Annotations == nil || in.Annotations[PauseAnnotationKey] != "true" {
 		return false
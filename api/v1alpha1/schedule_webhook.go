@@ -15,6 +15,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/robfig/cron/v3"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -69,21 +70,42 @@ func (in *Schedule) Validate() error {
 func (in *ScheduleSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, in.validateSchedule(specField.Child("schedule"))...)
+	allErrs = append(allErrs, in.validateSchedule(specField)...)
 	allErrs = append(allErrs, in.validateChaos(specField)...)
 	return allErrs
 }
 
+// parseCron parses the cron expression in the same way as the scheduler. The expressions which could be parsed
+// but never fire, e.g. "0 0 30 2 *", are rejected too, as they would never spawn anything.
+func parseCron(cronExpr string) error {
+	schedule, err := cron.ParseStandard(cronExpr)
+	if err != nil {
+		return err
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("%q never fires", cronExpr)
+	}
+	return nil
+}
+
 // validateSchedule validates the cron
-func (in *ScheduleSpec) validateSchedule(schedule *field.Path) field.ErrorList {
+func (in *ScheduleSpec) validateSchedule(spec *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	_, err := cron.ParseStandard(in.Schedule)
-	if err != nil {
-		allErrs = append(allErrs, field.Invalid(schedule,
+	if err := parseCron(in.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(spec.Child("schedule"),
 			in.Schedule,
 			fmt.Sprintf("parse schedule field error:%s", err)))
 	}
 
+	schedules := spec.Child("schedules")
+	for i, cronExpr := range in.Schedules {
+		if err := parseCron(cronExpr); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedules.Index(i),
+				cronExpr,
+				fmt.Sprintf("parse schedule field error:%s", err)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -17,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var _ = Describe("schedule_webhook", func() {
@@ -86,6 +87,25 @@ var _ = Describe("schedule_webhook", func() {
 					},
 					expect: "",
 				},
+				{
+					name: "validation for additional schedules",
+					schedule: Schedule{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo4",
+						},
+						Spec: ScheduleSpec{
+							ScheduleItem: ScheduleItem{Workflow: &WorkflowSpec{}},
+							Type:         ScheduleTypeWorkflow,
+							Schedule:     "0 9 * * 1-5",
+							Schedules:    []string{"0 22 * * 0,6", "not a cron"},
+						},
+					},
+					execute: func(schedule *Schedule) error {
+						return schedule.ValidateCreate()
+					},
+					expect: "error",
+				},
 			}
 
 			for _, tc := range tcs {
@@ -98,4 +118,31 @@ var _ = Describe("schedule_webhook", func() {
 			}
 		})
 	})
+
+	Context("validateSchedule", func() {
+		It("should accept the cron expressions the scheduler could run", func() {
+			for _, cronExpr := range []string{"*/5 * * * *", "0 9 * * 1-5", "@hourly", "@every 1m", "CRON_TZ=Asia/Shanghai 0 9 * * *"} {
+				spec := ScheduleSpec{Schedule: cronExpr}
+				Expect(spec.validateSchedule(field.NewPath("spec"))).To(BeEmpty(), cronExpr)
+			}
+		})
+
+		It("should reject the malformed cron expressions", func() {
+			for _, cronExpr := range []string{"", "not a cron", "* * * *", "61 * * * *", "0 0 * * 8", "@every 5"} {
+				spec := ScheduleSpec{Schedule: cronExpr}
+				errs := spec.validateSchedule(field.NewPath("spec"))
+				Expect(errs).To(HaveLen(1), cronExpr)
+				Expect(errs[0].Field).To(Equal("spec.schedule"))
+				Expect(errs[0].Detail).To(HavePrefix("parse schedule field error:"))
+			}
+		})
+
+		It("should reject the cron expressions which never fire", func() {
+			spec := ScheduleSpec{Schedule: "0 9 * * *", Schedules: []string{"0 0 30 2 *"}}
+			errs := spec.validateSchedule(field.NewPath("spec"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.schedules[0]"))
+			Expect(errs[0].Detail).To(ContainSubstring("never fires"))
+		})
+	})
 })
@@ -13,7 +13,13 @@
 
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // LabelSelectorRequirements is list of LabelSelectorRequirement
 type LabelSelectorRequirements []metav1.LabelSelectorRequirement
@@ -53,6 +59,19 @@ type PodSelectorSpec struct {
 	// +optional
 	Pods map[string][]string `json:"pods,omitempty"`
 
+	// Services is a map of string keys and a set values that used to select the backends of services.
+	// The key defines the namespace which services belong,
+	// and the each values is a set of service names.
+	// The pods are resolved from the current endpoints of the services on each selection.
+	// +optional
+	Services map[string][]string `json:"services,omitempty"`
+
+	// Workloads is a set of workloads which own the pods to be selected, in the namespaces of the pods.
+	// The pods owned by a Deployment are resolved through its ReplicaSets.
+	// The pods must also match the namespace, node, label, field, annotation and phase selectors.
+	// +optional
+	Workloads []WorkloadReference `json:"workloads,omitempty"`
+
 	// Map of string keys and values that can be used to select nodes.
 	// Selector which must match a node's labels,
 	// and objects must belong to these selected nodes.
@@ -79,15 +98,102 @@ type PodSelectorSpec struct {
 	// +optional
 	AnnotationSelectors map[string]string `json:"annotationSelectors,omitempty"`
 
+	// a slice of annotation selector expressions that can be used to select objects.
+	// A list of selectors based on set-based annotation expressions, e.g. the operator `DoesNotExist`
+	// excludes the pods annotated with the key, and `NotIn` excludes the pods annotated with the values.
+	// +optional
+	AnnotationExpressionSelectors LabelSelectorRequirements `json:"annotationExpressionSelectors,omitempty"`
+
 	// PodPhaseSelectors is a set of condition of a pod at the current time.
 	// supported value: Pending / Running / Succeeded / Failed / Unknown
 	// +optional
 	PodPhaseSelectors []string `json:"podPhaseSelectors,omitempty"`
 }
 
-func (in *PodSelectorSpec) DefaultNamespace(namespace string) {
-	if len(in.Namespaces) == 0 {
-		in.Namespaces = []string{namespace}
+// WorkloadReference refers to a workload which owns pods
+type WorkloadReference struct {
+	// Kind is the kind of the workload.
+	// Supported kind: Deployment / StatefulSet / DaemonSet / ReplicaSet
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet;DaemonSet;ReplicaSet
+	Kind string `json:"kind"`
+
+	// Name is the name of the workload
+	Name string `json:"name"`
+
+	// Ordinals is a set of the ordinal ranges which the pods of the StatefulSet are selected by, separated by commas,
+	// e.g. `0` selects the first pod, `1-2` selects the second and the third pods,
+	// and `1-` selects all the pods except the first one.
+	// It's only supported by the StatefulSet, and all the pods are selected if it's empty.
+	// +optional
+	Ordinals string `json:"ordinals,omitempty"`
+}
+
+// ContainsOrdinal returns whether the pod with the ordinal is selected by the ordinals of the workload
+func (in *WorkloadReference) ContainsOrdinal(ordinal int) (bool, error) {
+	if len(in.Ordinals) == 0 {
+		return true, nil
+	}
+
+	ranges, err := parseOrdinals(in.Ordinals)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range ranges {
+		if ordinal >= r.start && (r.end < 0 || ordinal <= r.end) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ordinalRange is a closed range of the ordinals, which is unbounded if the end is negative
+type ordinalRange struct {
+	start int
+	end   int
+}
+
+// parseOrdinals parses the ordinal ranges separated by commas, e.g. `0,2-3,5-`
+func parseOrdinals(ordinals string) ([]ordinalRange, error) {
+	var ranges []ordinalRange
+	for _, part := range strings.Split(ordinals, ",") {
+		part = strings.TrimSpace(part)
+		bounds := strings.SplitN(part, "-", 2)
+
+		start, err := parseOrdinal(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid ordinal range %q: %v", part, err)
+		}
+		r := ordinalRange{start: start, end: start}
+		if len(bounds) == 2 {
+			r.end = -1
+			if len(bounds[1]) > 0 {
+				r.end, err = parseOrdinal(bounds[1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid ordinal range %q: %v", part, err)
+				}
+				if r.end < r.start {
+					return nil, fmt.Errorf("invalid ordinal range %q: the end is less than the start", part)
+				}
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// parseOrdinal parses the non-negative ordinal, the signs are not allowed
+func parseOrdinal(ordinal string) (int, error) {
+	if len(ordinal) == 0 || strings.TrimLeft(ordinal, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not an ordinal", ordinal)
+	}
+	return strconv.Atoi(ordinal)
+}
+
+// DefaultNamespace scopes the selector which omits the namespaces to the namespace of the chaos,
+// unless the chaos opts in to select the pods in the whole cluster.
+func (in *PodSelectorSpec) DefaultNamespace(chaos metav1.Object) {
+	if len(in.Namespaces) == 0 && chaos.GetAnnotations()[ClusterWideSelectorAnnotationKey] != "true" {
+		in.Namespaces = []string{chaos.GetNamespace()}
 	}
 }
 
@@ -106,6 +212,18 @@ type PodSelector struct {
 	// IF `RandomMaxPercentPodMod`,  provide a number from 0-100 to specify the max percent of pods to do chaos action
 	// +optional
 	Value string `json:"value,omitempty"`
+
+	// MinMatches is the minimum number of pods which should be chosen by the selector and the mode.
+	// If fewer pods are chosen, the chaos fails rather than being injected into too few pods.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinMatches int `json:"minMatches,omitempty"`
+
+	// Seed is the seed of choosing the pods randomly in the one / fixed / fixed-percent / random-max-percent modes.
+	// The same seed chooses the same pods among the same matched pods, so that an experiment could be replayed.
+	// If it's not set, the seed is derived from the UID of the chaos, and it's kept in the status.
+	// +optional
+	Seed *int64 `json:"seed,omitempty"`
 }
 
 type ContainerSelector struct {
@@ -115,13 +233,17 @@ type ContainerSelector struct {
 	// If not set, all containers will be injected
 	// +optional
 	ContainerNames []string `json:"containerNames,omitempty"`
+
+	// ContainerImage selects the containers whose image matches the regular expression, regardless of their names,
+	// e.g. `^nginx:1\.19` or `mysql`. If ContainerNames is also set, the containers must match both.
+	// +optional
+	ContainerImage string `json:"containerImage,omitempty"`
 }
 
 // ClusterScoped returns true if the selector selects Pods in the cluster
 func (in PodSelectorSpec) ClusterScoped() bool {
-	// in fact, this will never happened, will add namespace if it is empty, so len(s.Namespaces) can not be 0,
-	// but still add judgentment here for safe
-	// https://github.com/chaos-mesh/chaos-mesh/blob/478d00d01bb0f9fb08a1085428a7da8c8f9df4e8/api/v1alpha1/common_webhook.go#L22
+	// the namespaces are defaulted by the webhook, so len(s.Namespaces) can only be 0 if the chaos
+	// opts in to select the pods in the whole cluster, see DefaultNamespace
 	if len(in.Namespaces) == 0 && len(in.Pods) == 0 {
 		return true
 	}
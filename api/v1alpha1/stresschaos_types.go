@@ -23,6 +23,11 @@ import (
 // Stress chaos is a chaos to generate plenty of stresses over a collection of pods.
 
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="action",type=string,JSONPath=`.status.action`
+// +kubebuilder:printcolumn:name="phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="selected",type=integer,JSONPath=`.status.selected`
+// +kubebuilder:printcolumn:name="duration",type=string,JSONPath=`.spec.duration`
+// +kubebuilder:printcolumn:name="age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +chaos-mesh:base
 
 // StressChaos is the Schema for the stresschaos API
@@ -52,14 +57,34 @@ type StressChaosSpec struct {
 	// feature and more powerful. You can define stressors in `stress-ng` (see also `man stress-ng`) dialect,
 	// however not all of the supported stressors are well tested. It maybe retired in later releases. You
 	// should always use `Stressors` to define the stressors and use this only when you want more stressors
-	// unsupported by `Stressors`. When both `StressngStressors` and `Stressors` are defined, `StressngStressors`
-	// wins.
+	// unsupported by `Stressors`. `StressngStressors` and `Stressors` are mutually exclusive.
 	// +optional
 	StressngStressors string `json:"stressngStressors,omitempty"`
 
+	// ProcessName is a regular expression to match the command name or the command line of the process
+	// in the container. If it's set, the stressors are applied to the first matched process in the process
+	// tree of the container rather than its main process.
+	// +optional
+	ProcessName string `json:"processName,omitempty"`
+
 	// Duration represents the duration of the chaos action
 	// +optional
-	Duration *string `json:"duration,omitempty"`
+	Duration *Duration `json:"duration,omitempty"`
+
+	// RecoverTimeout represents how long to wait for the chaos to be recovered after the duration ends,
+	// the chaos which is not recovered in time is escalated with a warning event and the RecoverTimedOut condition.
+	// +optional
+	RecoverTimeout *string `json:"recoverTimeout,omitempty"`
+
+	// ActiveWindows are the recurring windows in which the chaos is active, e.g. 9:00-17:00 on weekdays.
+	// The chaos is recovered outside the windows, and applied again inside them.
+	// +optional
+	ActiveWindows ActiveWindows `json:"activeWindows,omitempty"`
+
+	// ManualRecover keeps the chaos injected after the duration is exceeded, until it's paused or deleted.
+	// It's not supported by the one-shot actions.
+	// +optional
+	ManualRecover bool `json:"manualRecover,omitempty"`
 }
 
 // StressChaosStatus defines the observed state of StressChaos
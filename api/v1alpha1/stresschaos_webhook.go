@@ -16,7 +16,7 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
-	"reflect"
+	"regexp"
 	"strconv"
 
 	"github.com/docker/go-units"
@@ -45,7 +45,7 @@ var _ webhook.Defaulter = &StressChaos{}
 // Default implements webhook.Defaulter so a webhook will be registered for the type
 func (in *StressChaos) Default() {
 	stressChaosLog.Info("default", "name", in.Name)
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	in.Spec.Default()
 }
 
@@ -66,7 +66,7 @@ func (in *StressChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *StressChaos) ValidateUpdate(old runtime.Object) error {
 	stressChaosLog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*StressChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*StressChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -83,6 +83,8 @@ func (in *StressChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *StressChaos) Validate() error {
 	errs := in.Spec.Validate()
+	errs = append(errs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	errs = append(errs, validateManualRecover(in, field.NewPath("spec"))...)
 	if len(errs) > 0 {
 		return fmt.Errorf(errs.ToAggregate().Error())
 	}
@@ -96,9 +98,19 @@ func (in *StressChaosSpec) Validate() field.ErrorList {
 	var allErrs field.ErrorList
 	if len(in.StressngStressors) == 0 && in.Stressors == nil {
 		allErrs = append(errs, field.Invalid(specField, in, "missing stressors"))
+	} else if len(in.StressngStressors) != 0 && in.Stressors != nil {
+		allErrs = append(errs, field.Forbidden(specField.Child("stressngStressors"),
+			"stressngStressors and stressors are mutually exclusive"))
 	} else if in.Stressors != nil {
 		allErrs = append(errs, in.Stressors.Validate(specField)...)
 	}
+	if len(in.ProcessName) != 0 {
+		if _, err := regexp.Compile(in.ProcessName); err != nil {
+			allErrs = append(allErrs, field.Invalid(specField.Child("processName"), in.ProcessName,
+				fmt.Sprintf("parse process name error: %s", err)))
+		}
+	}
+	allErrs = append(allErrs, validateContainerSelector(&in.ContainerSelector, specField)...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
 	return allErrs
 }
@@ -61,6 +61,40 @@ var _ = Describe("stresschaos_webhook", func() {
 					},
 					expect: "",
 				},
+				{
+					name: "validate the process name",
+					chaos: StressChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo1",
+						},
+						Spec: StressChaosSpec{
+							Stressors:   stressors,
+							ProcessName: "^java$",
+						},
+					},
+					execute: func(chaos *StressChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the invalid process name",
+					chaos: StressChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo1",
+						},
+						Spec: StressChaosSpec{
+							Stressors:   stressors,
+							ProcessName: "java(",
+						},
+					},
+					execute: func(chaos *StressChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
 				{
 					name: "simple ValidateUpdate",
 					chaos: StressChaos{
@@ -106,6 +140,39 @@ var _ = Describe("stresschaos_webhook", func() {
 					},
 					expect: "error",
 				},
+				{
+					name: "both stressors and stressngStressors",
+					chaos: StressChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo6",
+						},
+						Spec: StressChaosSpec{
+							Stressors:         stressors,
+							StressngStressors: "--cpu 1",
+						},
+					},
+					execute: func(chaos *StressChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "only stressngStressors",
+					chaos: StressChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo7",
+						},
+						Spec: StressChaosSpec{
+							StressngStressors: "--cpu 1",
+						},
+					},
+					execute: func(chaos *StressChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
 			}
 
 			for _, tc := range tcs {
@@ -124,6 +191,7 @@ var _ = Describe("stresschaos_webhook", func() {
 				stressor Validateable
 				errs     int
 			}
+			overload := 101
 			tcs := []TestCase{
 				{
 					name:     "missing workers",
@@ -144,6 +212,22 @@ var _ = Describe("stresschaos_webhook", func() {
 					},
 					errs: 0,
 				},
+				{
+					name: "overloaded CPUStressor",
+					stressor: &CPUStressor{
+						Stressor: Stressor{Workers: 1},
+						Load:     &overload,
+					},
+					errs: 1,
+				},
+				{
+					name: "MemoryStressor with an unparsable size",
+					stressor: &MemoryStressor{
+						Stressor: Stressor{Workers: 1},
+						Size:     "1 gigabyte",
+					},
+					errs: 1,
+				},
 			}
 			parent := field.NewPath("parent")
 			for _, tc := range tcs {
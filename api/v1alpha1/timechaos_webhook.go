@@ -15,7 +15,6 @@ package v1alpha1
 
 import (
 	"fmt"
-	"reflect"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,6 +23,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// clockIds are the ids of the clocks which could be skewed by TimeChaos, refer to `uapi/linux/time.h`
+var clockIds = []string{
+	"CLOCK_REALTIME",
+	"CLOCK_MONOTONIC",
+	"CLOCK_PROCESS_CPUTIME_ID",
+	"CLOCK_THREAD_CPUTIME_ID",
+	"CLOCK_MONOTONIC_RAW",
+	"CLOCK_REALTIME_COARSE",
+	"CLOCK_MONOTONIC_COARSE",
+	"CLOCK_BOOTTIME",
+	"CLOCK_REALTIME_ALARM",
+	"CLOCK_BOOTTIME_ALARM",
+}
+
 // log is for logging in this package.
 var timechaoslog = logf.Log.WithName("timechaos-resource")
 
@@ -35,7 +48,7 @@ var _ webhook.Defaulter = &TimeChaos{}
 func (in *TimeChaos) Default() {
 	timechaoslog.Info("default", "name", in.Name)
 
-	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+	in.Spec.Selector.DefaultNamespace(in)
 	in.Spec.Default()
 }
 
@@ -56,7 +69,7 @@ func (in *TimeChaos) ValidateCreate() error {
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *TimeChaos) ValidateUpdate(old runtime.Object) error {
 	timechaoslog.Info("validate update", "name", in.Name)
-	if !reflect.DeepEqual(in.Spec, old.(*TimeChaos).Spec) {
+	if !equalExceptDuration(in.Spec, old.(*TimeChaos).Spec) {
 		return ErrCanNotUpdateChaos
 	}
 	return in.Validate()
@@ -73,6 +86,8 @@ func (in *TimeChaos) ValidateDelete() error {
 // Validate validates chaos object
 func (in *TimeChaos) Validate() error {
 	allErrs := in.Spec.Validate()
+	allErrs = append(allErrs, validateDurationRequired(in, &in.Spec, in.IsOneShot(), field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateManualRecover(in, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
@@ -83,6 +98,8 @@ func (in *TimeChaos) Validate() error {
 func (in *TimeChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := in.validateTimeOffset(specField.Child("timeOffset"))
+	allErrs = append(allErrs, in.validateClockIds(specField.Child("clockIds"))...)
+	allErrs = append(allErrs, validateContainerSelector(&in.ContainerSelector, specField)...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
 
 	return allErrs
@@ -101,3 +118,23 @@ func (in *TimeChaosSpec) validateTimeOffset(timeOffset *field.Path) field.ErrorL
 
 	return allErrs
 }
+
+// validateClockIds validates the clockIds, the unknown clock ids are rejected here rather than failing the injection
+func (in *TimeChaosSpec) validateClockIds(path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, id := range in.ClockIds {
+		known := false
+		for _, clockId := range clockIds {
+			if id == clockId {
+				known = true
+				break
+			}
+		}
+		if !known {
+			allErrs = append(allErrs, field.NotSupported(path.Index(i), id, clockIds))
+		}
+	}
+
+	return allErrs
+}
@@ -98,6 +98,40 @@ var _ = Describe("timechaos_webhook", func() {
 					},
 					expect: "error",
 				},
+				{
+					name: "skew the monotonic clock only",
+					chaos: TimeChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo7",
+						},
+						Spec: TimeChaosSpec{
+							TimeOffset: "1s",
+							ClockIds:   []string{"CLOCK_MONOTONIC"},
+						},
+					},
+					execute: func(chaos *TimeChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the clockIds",
+					chaos: TimeChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo8",
+						},
+						Spec: TimeChaosSpec{
+							TimeOffset: "1s",
+							ClockIds:   []string{"CLOCK_REALTIME", "CLOCK_MONOTONIK"},
+						},
+					},
+					execute: func(chaos *TimeChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
 			}
 
 			for _, tc := range tcs {
@@ -60,9 +60,17 @@ func (in *Workflow) GetObjectMeta() *metav1.ObjectMeta {
 
 const KindWorkflow = "Workflow"
 
+// ResumeFromFailedAnnotationKey is the annotation to resume the workflow from its failed nodes. When it's set to
+// "true", the failed nodes are created again, and the nodes which succeeded are kept. It's removed once handled.
+const ResumeFromFailedAnnotationKey = "workflow.chaos-mesh.org/resume-from-failed"
+
 type WorkflowSpec struct {
 	Entry     string     `json:"entry"`
 	Templates []Template `json:"templates"`
+	// Deadline is the duration the whole workflow could run since it started, e.g. "1h". When it's exceeded, all
+	// the running nodes are finished, and the workflow is accomplished as failed.
+	// +optional
+	Deadline *string `json:"deadline,omitempty"`
 }
 
 type WorkflowStatus struct {
@@ -121,12 +129,24 @@ type Template struct {
 	Type TemplateType `json:"templateType"`
 	// +optional
 	Deadline *string `json:"deadline,omitempty"`
+	// Cron describes when to wake up the suspend node, the node holds until the next occurrence of the cron expression.
+	// Only used when Type is TypeSuspend.
+	// +optional
+	Cron *string `json:"cron,omitempty"`
 	// Task describes the behavior of the custom task. Only used when Type is TypeTask.
 	// +optional
 	Task *Task `json:"task,omitempty"`
 	// Children describes the children steps of serial or parallel node. Only used when Type is TypeSerial or TypeParallel.
 	// +optional
 	Children []string `json:"children,omitempty"`
+	// ForEach describes the children of parallel node which are generated from the pods selected at runtime, it
+	// takes the place of Children. Only used when Type is TypeParallel.
+	// +optional
+	ForEach *ForEachPod `json:"forEach,omitempty"`
+	// FailFast makes the parallel node fail as soon as any of its children fails, the other children are aborted
+	// then. Otherwise the parallel node fails after all the children are finished. Only used when Type is TypeParallel.
+	// +optional
+	FailFast bool `json:"failFast,omitempty"`
 	// ConditionalBranches describes the conditional branches of custom tasks. Only used when Type is TypeTask.
 	// +optional
 	ConditionalBranches []ConditionalBranch `json:"conditionalBranches,omitempty"`
@@ -138,11 +158,26 @@ type Template struct {
 	Schedule *ChaosOnlyScheduleSpec `json:"schedule,omitempty"`
 }
 
+// ForEachPod describes a parallel node which spawns one child for each pod matched by the selector, the chaos of
+// the child only takes effect on its own pod.
+type ForEachPod struct {
+	// Selector selects the pods to spawn the children for. It's evaluated again each time the parallel node is
+	// reconciled, the children of the pods which are no longer selected are removed, and the new pods get theirs.
+	// The node fails if no pod is selected within 5 minutes after it's created.
+	Selector PodSelectorSpec `json:"selector"`
+	// Template is the name of the chaos template to spawn for each pod.
+	Template string `json:"template"`
+}
+
 // ChaosOnlyScheduleSpec is very similar with ScheduleSpec, but it could not schedule Workflow
 // because we could not resolve nested CRD now
 type ChaosOnlyScheduleSpec struct {
 	Schedule string `json:"schedule"`
 
+	// Schedules are the additional cron expressions, the chaos is spawned at the times of any of Schedule and Schedules
+	// +optional
+	Schedules []string `json:"schedules,omitempty"`
+
 	// +optional
 	// +nullable
 	// +kubebuilder:validation:Minimum=0
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -35,6 +36,7 @@ func (in *Workflow) ValidateCreate() error {
 	specPath := field.NewPath("spec")
 	allErrs = append(allErrs, entryMustExists(specPath.Child("entry"), in.Spec.Entry, in.Spec.Templates)...)
 	allErrs = append(allErrs, validateTemplates(specPath.Child("templates"), in.Spec.Templates)...)
+	allErrs = append(allErrs, validateWorkflowDeadline(specPath.Child("deadline"), in.Spec.Deadline)...)
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
 	}
@@ -68,6 +70,25 @@ func entryMustExists(path *field.Path, entry string, templates []Template) field
 	return result
 }
 
+// validateWorkflowDeadline validates that the deadline of workflow is a positive duration
+func validateWorkflowDeadline(path *field.Path, deadline *string) field.ErrorList {
+	if deadline == nil {
+		return nil
+	}
+	duration, err := time.ParseDuration(*deadline)
+	if err != nil {
+		return field.ErrorList{
+			field.Invalid(path, *deadline, fmt.Sprintf("parse deadline field error:%s", err)),
+		}
+	}
+	if duration <= 0 {
+		return field.ErrorList{
+			field.Invalid(path, *deadline, "deadline of workflow should be greater than 0"),
+		}
+	}
+	return nil
+}
+
 func validateTemplates(path *field.Path, templates []Template) field.ErrorList {
 	var result field.ErrorList
 	if len(templates) == 0 {
@@ -104,15 +125,19 @@ func validateTemplate(path *field.Path, template Template, allTemplates []Templa
 
 	switch templateType := template.Type; {
 	case templateType == TypeSuspend:
-		if template.Deadline == nil || len(*template.Deadline) == 0 {
-			result = append(result, field.Invalid(path.Child("deadline"), template.Deadline, "deadline in template with type Suspend could not be empty"))
-		}
+		result = append(result, validateSuspendWakeup(path, template)...)
 		result = append(result, shouldBeNoTask(path, template)...)
 		result = append(result, shouldBeNoChildren(path, template)...)
+		result = append(result, shouldBeNoForEach(path, template)...)
 		result = append(result, shouldBeNoConditionalBranches(path, template)...)
 		result = append(result, shouldBeNoEmbedChaos(path, template)...)
 		result = append(result, shouldBeNoSchedule(path, template)...)
 	case templateType == TypeSerial, templateType == TypeParallel:
+		if templateType == TypeParallel && template.ForEach != nil {
+			result = append(result, validateForEach(path, template, allTemplates)...)
+		} else {
+			result = append(result, shouldBeNoForEach(path, template)...)
+		}
 		for i, item := range template.Children {
 			result = append(result, templateMustExists(item, path.Child("children").Index(i), allTemplates)...)
 		}
@@ -120,22 +145,29 @@ func validateTemplate(path *field.Path, template Template, allTemplates []Templa
 		result = append(result, shouldBeNoConditionalBranches(path, template)...)
 		result = append(result, shouldBeNoEmbedChaos(path, template)...)
 		result = append(result, shouldBeNoSchedule(path, template)...)
+		result = append(result, shouldBeNoCron(path, template)...)
 	case templateType == TypeSchedule:
 		result = append(result, shouldBeNoTask(path, template)...)
 		result = append(result, shouldBeNoChildren(path, template)...)
+		result = append(result, shouldBeNoForEach(path, template)...)
 		result = append(result, shouldBeNoConditionalBranches(path, template)...)
 		result = append(result, shouldBeNoEmbedChaos(path, template)...)
+		result = append(result, shouldBeNoCron(path, template)...)
 	case templateType == TypeTask:
 		result = append(result, shouldBeNoChildren(path, template)...)
+		result = append(result, shouldBeNoForEach(path, template)...)
 		result = append(result, shouldBeNoEmbedChaos(path, template)...)
 		result = append(result, shouldBeNoSchedule(path, template)...)
+		result = append(result, shouldBeNoCron(path, template)...)
 	case IsChaosTemplateType(templateType):
 		result = append(result, shouldNotSetupDurationInTheChaos(path, template)...)
 
 		result = append(result, shouldBeNoTask(path, template)...)
 		result = append(result, shouldBeNoChildren(path, template)...)
+		result = append(result, shouldBeNoForEach(path, template)...)
 		result = append(result, shouldBeNoConditionalBranches(path, template)...)
 		result = append(result, shouldBeNoSchedule(path, template)...)
+		result = append(result, shouldBeNoCron(path, template)...)
 
 		result = append(result, template.EmbedChaos.Validate(string(templateType))...)
 	default:
@@ -216,6 +248,31 @@ func shouldNotSetupDurationInTheChaos(path *field.Path, template Template) field
 	return result
 }
 
+// validateSuspendWakeup validates that the suspend template wakes up either after the deadline or at the cron time
+func validateSuspendWakeup(path *field.Path, template Template) field.ErrorList {
+	hasDeadline := template.Deadline != nil && len(*template.Deadline) != 0
+	hasCron := template.Cron != nil && len(*template.Cron) != 0
+
+	if !hasDeadline && !hasCron {
+		return field.ErrorList{
+			field.Invalid(path.Child("deadline"), template.Deadline, "deadline or cron in template with type Suspend could not be empty"),
+		}
+	}
+	if hasDeadline && hasCron {
+		return field.ErrorList{
+			field.Invalid(path.Child("cron"), *template.Cron, "deadline and cron in template with type Suspend could not be set at the same time"),
+		}
+	}
+	if hasCron {
+		if err := parseCron(*template.Cron); err != nil {
+			return field.ErrorList{
+				field.Invalid(path.Child("cron"), *template.Cron, fmt.Sprintf("parse cron field error:%s", err)),
+			}
+		}
+	}
+	return nil
+}
+
 func shouldBeNoTask(path *field.Path, template Template) field.ErrorList {
 	if template.Task != nil {
 		return field.ErrorList{
@@ -234,6 +291,38 @@ func shouldBeNoChildren(path *field.Path, template Template) field.ErrorList {
 	return nil
 }
 
+func shouldBeNoForEach(path *field.Path, template Template) field.ErrorList {
+	if template.ForEach != nil {
+		return field.ErrorList{
+			field.Invalid(path, template.ForEach, "this template should not contain ForEach"),
+		}
+	}
+	return nil
+}
+
+// validateForEach validates the parallel template which spawns one child for each selected pod, the children are
+// generated from the chaos template instead of being listed in Children.
+func validateForEach(path *field.Path, template Template, allTemplates []Template) field.ErrorList {
+	result := shouldBeNoChildren(path, template)
+
+	templatePath := path.Child("forEach").Child("template")
+	result = append(result, templateMustExists(template.ForEach.Template, templatePath, allTemplates)...)
+	for _, item := range allTemplates {
+		if item.Name != template.ForEach.Template {
+			continue
+		}
+		if !IsChaosTemplateType(item.Type) {
+			result = append(result, field.Invalid(templatePath, template.ForEach.Template,
+				fmt.Sprintf("the template spawned for each pod should be a chaos, but its type is %s", item.Type)))
+		} else if item.Type == TypeAWSChaos || item.Type == TypeGCPChaos {
+			// the chaos on the cloud resources couldn't be narrowed to a pod
+			result = append(result, field.Invalid(templatePath, template.ForEach.Template,
+				fmt.Sprintf("the template spawned for each pod should select pods, but its type is %s", item.Type)))
+		}
+	}
+	return result
+}
+
 func shouldBeNoConditionalBranches(path *field.Path, template Template) field.ErrorList {
 	if len(template.ConditionalBranches) > 0 {
 		return field.ErrorList{
@@ -261,3 +350,12 @@ func shouldBeNoSchedule(path *field.Path, template Template) field.ErrorList {
 	}
 	return nil
 }
+
+func shouldBeNoCron(path *field.Path, template Template) field.ErrorList {
+	if template.Cron != nil {
+		return field.ErrorList{
+			field.Invalid(path, template.Cron, "this template should not contain Cron"),
+		}
+	}
+	return nil
+}
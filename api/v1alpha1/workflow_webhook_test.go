@@ -16,8 +16,11 @@ package v1alpha1
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -72,6 +75,34 @@ func Test_entryMustExists(t *testing.T) {
 	}
 }
 
+func TestWorkflow_ValidateCreateWithNonexistentEntry(t *testing.T) {
+	deadline := "1m"
+	workflow := Workflow{
+		Spec: WorkflowSpec{
+			Entry: "entyr",
+			Templates: []Template{
+				{
+					Name:     "entry",
+					Type:     TypeSuspend,
+					Deadline: &deadline,
+				},
+			},
+		},
+	}
+	err := workflow.ValidateCreate()
+	if err == nil {
+		t.Fatal("ValidateCreate() should reject the workflow whose entry doesn't exist")
+	}
+	if !strings.Contains(err.Error(), "spec.entry") || !strings.Contains(err.Error(), "can not find a template with name entyr") {
+		t.Errorf("ValidateCreate() error = %v, want the error of the nonexistent entry", err)
+	}
+
+	workflow.Spec.Entry = "entry"
+	if err := workflow.ValidateCreate(); err != nil {
+		t.Errorf("ValidateCreate() error = %v, want nil", err)
+	}
+}
+
 func Test_validateTemplates(t *testing.T) {
 	templatesPath := field.NewPath("spec", "templates")
 	var nilTemplates []Template
@@ -206,6 +237,100 @@ func Test_shouldBeNoChildren(t *testing.T) {
 	}
 }
 
+func Test_validateForEach(t *testing.T) {
+	templatePath := field.NewPath("spec", "templates").Index(0)
+	forEachTemplatePath := templatePath.Child("forEach").Child("template")
+	allTemplates := []Template{
+		{
+			Name: "pod-chaos",
+			Type: TypePodChaos,
+		}, {
+			Name: "suspend",
+			Type: TypeSuspend,
+		}, {
+			Name: "aws-chaos",
+			Type: TypeAWSChaos,
+		},
+	}
+	type args struct {
+		path     *field.Path
+		template Template
+	}
+	tests := []struct {
+		name string
+		args args
+		want field.ErrorList
+	}{
+		{
+			name: "spawns chaos for each pod",
+			args: args{
+				path: templatePath,
+				template: Template{
+					Type:    TypeParallel,
+					ForEach: &ForEachPod{Template: "pod-chaos"},
+				},
+			},
+			want: nil,
+		}, {
+			name: "contains unexpected children",
+			args: args{
+				path: templatePath,
+				template: Template{
+					Type:     TypeParallel,
+					Children: []string{"pod-chaos"},
+					ForEach:  &ForEachPod{Template: "pod-chaos"},
+				},
+			},
+			want: field.ErrorList{
+				field.Invalid(templatePath, []string{"pod-chaos"}, "this template should not contain Children"),
+			},
+		}, {
+			name: "template does not exist",
+			args: args{
+				path: templatePath,
+				template: Template{
+					Type:    TypeParallel,
+					ForEach: &ForEachPod{Template: "network-chaos"},
+				},
+			},
+			want: field.ErrorList{
+				field.Invalid(forEachTemplatePath, "network-chaos", "can not find a template with name network-chaos"),
+			},
+		}, {
+			name: "template is not chaos",
+			args: args{
+				path: templatePath,
+				template: Template{
+					Type:    TypeParallel,
+					ForEach: &ForEachPod{Template: "suspend"},
+				},
+			},
+			want: field.ErrorList{
+				field.Invalid(forEachTemplatePath, "suspend", "the template spawned for each pod should be a chaos, but its type is Suspend"),
+			},
+		}, {
+			name: "template does not select pods",
+			args: args{
+				path: templatePath,
+				template: Template{
+					Type:    TypeParallel,
+					ForEach: &ForEachPod{Template: "aws-chaos"},
+				},
+			},
+			want: field.ErrorList{
+				field.Invalid(forEachTemplatePath, "aws-chaos", "the template spawned for each pod should select pods, but its type is AWSChaos"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateForEach(tt.args.path, tt.args.template, allTemplates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateForEach() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_shouldBeNoConditionalBranches(t *testing.T) {
 	templatePath := field.NewPath("spec", "templates").Index(0)
 	mockConditionalBranches := []ConditionalBranch{
@@ -405,3 +530,122 @@ func Test_namesCouldNotBeDuplicated(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateSuspendWakeup(t *testing.T) {
+	templatePath := field.NewPath("spec", "templates").Index(0)
+	deadline := "1h"
+	wakeAtNine := "0 9 * * *"
+	invalidCron := "0 25 * * *"
+	_, parseError := cron.ParseStandard(invalidCron)
+	tests := []struct {
+		name     string
+		template Template
+		want     field.ErrorList
+	}{
+		{
+			name:     "wakes up after the deadline",
+			template: Template{Type: TypeSuspend, Deadline: &deadline},
+			want:     nil,
+		}, {
+			name:     "wakes up at the cron time",
+			template: Template{Type: TypeSuspend, Cron: &wakeAtNine},
+			want:     nil,
+		}, {
+			name:     "neither deadline nor cron",
+			template: Template{Type: TypeSuspend},
+			want: field.ErrorList{
+				field.Invalid(templatePath.Child("deadline"), (*string)(nil), "deadline or cron in template with type Suspend could not be empty"),
+			},
+		}, {
+			name:     "both deadline and cron",
+			template: Template{Type: TypeSuspend, Deadline: &deadline, Cron: &wakeAtNine},
+			want: field.ErrorList{
+				field.Invalid(templatePath.Child("cron"), wakeAtNine, "deadline and cron in template with type Suspend could not be set at the same time"),
+			},
+		}, {
+			name:     "invalid cron",
+			template: Template{Type: TypeSuspend, Cron: &invalidCron},
+			want: field.ErrorList{
+				field.Invalid(templatePath.Child("cron"), invalidCron, fmt.Sprintf("parse cron field error:%s", parseError)),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateSuspendWakeup(templatePath, tt.template); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateSuspendWakeup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateWorkflowDeadline(t *testing.T) {
+	deadlinePath := field.NewPath("spec", "deadline")
+	oneHour := "1h"
+	zero := "0s"
+	invalid := "one hour"
+	_, parseError := time.ParseDuration(invalid)
+	tests := []struct {
+		name     string
+		deadline *string
+		want     field.ErrorList
+	}{
+		{
+			name:     "no deadline",
+			deadline: nil,
+			want:     nil,
+		}, {
+			name:     "valid deadline",
+			deadline: &oneHour,
+			want:     nil,
+		}, {
+			name:     "zero deadline",
+			deadline: &zero,
+			want: field.ErrorList{
+				field.Invalid(deadlinePath, zero, "deadline of workflow should be greater than 0"),
+			},
+		}, {
+			name:     "invalid deadline",
+			deadline: &invalid,
+			want: field.ErrorList{
+				field.Invalid(deadlinePath, invalid, fmt.Sprintf("parse deadline field error:%s", parseError)),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateWorkflowDeadline(deadlinePath, tt.deadline); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateWorkflowDeadline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_shouldBeNoCron(t *testing.T) {
+	templatePath := field.NewPath("spec", "templates").Index(0)
+	wakeAtNine := "0 9 * * *"
+	tests := []struct {
+		name     string
+		template Template
+		want     field.ErrorList
+	}{
+		{
+			name:     "contains unexpected cron",
+			template: Template{Cron: &wakeAtNine},
+			want: field.ErrorList{
+				field.Invalid(templatePath, &wakeAtNine, "this template should not contain Cron"),
+			},
+		}, {
+			name:     "does not contain cron",
+			template: Template{},
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldBeNoCron(templatePath, tt.template); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("shouldBeNoCron() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ const (
 	LabelWorkflow     = "chaos-mesh.org/workflow"
 )
 
+const KindWorkflowNode = "WorkflowNode"
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=wfn
 // +kubebuilder:subresource:status
@@ -51,6 +53,15 @@ type WorkflowNodeSpec struct {
 	// +optional
 	Children []string `json:"children,omitempty"`
 	// +optional
+	ForEach *ForEachPod `json:"forEach,omitempty"`
+	// TargetPod is the pod which the chaos of this node is narrowed to, in the form of "namespace/name".
+	// It's set on the children spawned by the parallel node with ForEach.
+	// +optional
+	TargetPod *string `json:"targetPod,omitempty"`
+	// FailFast makes the parallel node fail as soon as any of its children fails, the other children are aborted then.
+	// +optional
+	FailFast bool `json:"failFast,omitempty"`
+	// +optional
 	ConditionalBranches []ConditionalBranch `json:"conditionalBranches,omitempty"`
 	// +optional
 	*EmbedChaos `json:",inline,omitempty"`
@@ -76,6 +87,11 @@ type WorkflowNodeStatus struct {
 	// +optional
 	FinishedChildren []corev1.LocalObjectReference `json:"finishedChildren,omitempty"`
 
+	// FailedChildren are the children which failed, or accomplished with any failed child of their own. The
+	// finished children which are not failed are succeeded.
+	// +optional
+	FailedChildren []corev1.LocalObjectReference `json:"failedChildren,omitempty"`
+
 	// Represents the latest available observations of a workflow node's current state.
 	// +optional
 	// +patchMergeKey=type
@@ -109,6 +125,7 @@ const (
 	ConditionAccomplished   WorkflowNodeConditionType = "Accomplished"
 	ConditionDeadlineExceed WorkflowNodeConditionType = "DeadlineExceed"
 	ConditionChaosInjected  WorkflowNodeConditionType = "ChaosInjected"
+	ConditionAborted        WorkflowNodeConditionType = "Aborted"
 )
 
 type WorkflowNodeCondition struct {
@@ -133,7 +150,13 @@ const (
 	EntryCreated                string = "EntryCreated"
 	InvalidEntry                string = "InvalidEntry"
 	WorkflowAccomplished        string = "WorkflowAccomplished"
+	WorkflowDeadlineExceed      string = "WorkflowDeadlineExceed"
+	WorkflowFailed              string = "WorkflowFailed"
+	WorkflowResumed             string = "WorkflowResumed"
 	NodeAccomplished            string = "NodeAccomplished"
+	ChildNodeFailed             string = "ChildNodeFailed"
+	NoPodSelected               string = "NoPodSelected"
+	ParentNodeFailedFast        string = "ParentNodeFailedFast"
 	NodesCreated                string = "NodesCreated"
 	NodeDeadlineExceed          string = "NodeDeadlineExceed"
 	NodeDeadlineNotExceed       string = "NodeDeadlineNotExceed"
@@ -46,13 +46,30 @@ func (in *AWSChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *AWSChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *AWSChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *AWSChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -70,7 +87,10 @@ func (in *AWSChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -123,7 +143,7 @@ func (in *AWSChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -133,6 +153,46 @@ func (in *AWSChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *AWSChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *AWSChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *AWSChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *AWSChaos) IsOneShot() bool {
 	
 	if in.Spec.Action==Ec2Restart {
@@ -168,13 +228,30 @@ func (in *DNSChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *DNSChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *DNSChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *DNSChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -192,7 +269,10 @@ func (in *DNSChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -245,7 +325,7 @@ func (in *DNSChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -255,6 +335,46 @@ func (in *DNSChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *DNSChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *DNSChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *DNSChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *DNSChaos) IsOneShot() bool {
 	
 	return false
@@ -286,13 +406,30 @@ func (in *GCPChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *GCPChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *GCPChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *GCPChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -310,7 +447,10 @@ func (in *GCPChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -363,7 +503,7 @@ func (in *GCPChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -373,6 +513,46 @@ func (in *GCPChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *GCPChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *GCPChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *GCPChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *GCPChaos) IsOneShot() bool {
 	
 	if in.Spec.Action==NodeReset {
@@ -408,13 +588,30 @@ func (in *HTTPChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *HTTPChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *HTTPChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *HTTPChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -432,7 +629,10 @@ func (in *HTTPChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -485,7 +685,7 @@ func (in *HTTPChaos) DurationExceeded(now time.Time) (bool, time.Duration, error
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -495,6 +695,46 @@ func (in *HTTPChaos) DurationExceeded(now time.Time) (bool, time.Duration, error
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *HTTPChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *HTTPChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *HTTPChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *HTTPChaos) IsOneShot() bool {
 	
 	return false
@@ -526,13 +766,30 @@ func (in *IOChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *IOChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *IOChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *IOChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -550,7 +807,10 @@ func (in *IOChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -603,7 +863,7 @@ func (in *IOChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -613,6 +873,46 @@ func (in *IOChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *IOChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *IOChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *IOChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *IOChaos) IsOneShot() bool {
 	
 	return false
@@ -644,13 +944,30 @@ func (in *JVMChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *JVMChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *JVMChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *JVMChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -668,7 +985,10 @@ func (in *JVMChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -721,7 +1041,7 @@ func (in *JVMChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -731,6 +1051,46 @@ func (in *JVMChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *JVMChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *JVMChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *JVMChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *JVMChaos) IsOneShot() bool {
 	
 	return false
@@ -762,13 +1122,30 @@ func (in *KernelChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *KernelChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *KernelChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *KernelChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -786,7 +1163,10 @@ func (in *KernelChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -839,7 +1219,7 @@ func (in *KernelChaos) DurationExceeded(now time.Time) (bool, time.Duration, err
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -849,6 +1229,46 @@ func (in *KernelChaos) DurationExceeded(now time.Time) (bool, time.Duration, err
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *KernelChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *KernelChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *KernelChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *KernelChaos) IsOneShot() bool {
 	
 	return false
@@ -880,13 +1300,30 @@ func (in *NetworkChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *NetworkChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *NetworkChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *NetworkChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -904,7 +1341,10 @@ func (in *NetworkChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -957,7 +1397,7 @@ func (in *NetworkChaos) DurationExceeded(now time.Time) (bool, time.Duration, er
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -967,6 +1407,46 @@ func (in *NetworkChaos) DurationExceeded(now time.Time) (bool, time.Duration, er
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *NetworkChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *NetworkChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *NetworkChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *NetworkChaos) IsOneShot() bool {
 	
 	return false
@@ -998,13 +1478,30 @@ func (in *PodChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *PodChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *PodChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *PodChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -1022,7 +1519,10 @@ func (in *PodChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -1075,7 +1575,7 @@ func (in *PodChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -1085,6 +1585,46 @@ func (in *PodChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *PodChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *PodChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *PodChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *PodChaos) IsOneShot() bool {
 	
 	if in.Spec.Action==PodKillAction || in.Spec.Action==ContainerKillAction {
@@ -1120,13 +1660,30 @@ func (in *StressChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *StressChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *StressChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *StressChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -1144,7 +1701,10 @@ func (in *StressChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -1197,7 +1757,7 @@ func (in *StressChaos) DurationExceeded(now time.Time) (bool, time.Duration, err
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -1207,6 +1767,46 @@ func (in *StressChaos) DurationExceeded(now time.Time) (bool, time.Duration, err
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *StressChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *StressChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *StressChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *StressChaos) IsOneShot() bool {
 	
 	return false
@@ -1238,13 +1838,30 @@ func (in *TimeChaosSpec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *TimeChaosSpec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *TimeChaosSpec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *TimeChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -1262,7 +1879,10 @@ func (in *TimeChaos) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -1315,7 +1935,7 @@ func (in *TimeChaos) DurationExceeded(now time.Time) (bool, time.Duration, error
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -1325,6 +1945,46 @@ func (in *TimeChaos) DurationExceeded(now time.Time) (bool, time.Duration, error
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *TimeChaos) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *TimeChaos) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *TimeChaos) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *TimeChaos) IsOneShot() bool {
 	
 	return false
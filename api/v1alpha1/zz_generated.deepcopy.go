@@ -86,9 +86,21 @@ func (in *AWSChaosSpec) DeepCopyInto(out *AWSChaosSpec) {
 	*out = *in
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SecretName != nil {
 		in, out := &in.SecretName, &out.SecretName
 		*out = new(string)
@@ -178,6 +190,47 @@ func (in *ActionParameterRules) DeepCopy() *ActionParameterRules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveWindow) DeepCopyInto(out *ActiveWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveWindow.
+func (in *ActiveWindow) DeepCopy() *ActiveWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ActiveWindows) DeepCopyInto(out *ActiveWindows) {
+	{
+		in := &in
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveWindows.
+func (in ActiveWindows) DeepCopy() ActiveWindows {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveWindows)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AttrOverrideSpec) DeepCopyInto(out *AttrOverrideSpec) {
 	*out = *in
@@ -322,6 +375,11 @@ func (in *ChaosCondition) DeepCopy() *ChaosCondition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChaosOnlyScheduleSpec) DeepCopyInto(out *ChaosOnlyScheduleSpec) {
 	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.StartingDeadlineSeconds != nil {
 		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
 		*out = new(int64)
@@ -517,14 +575,31 @@ func (in *DNSChaosSpec) DeepCopyInto(out *DNSChaosSpec) {
 	in.ContainerSelector.DeepCopyInto(&out.ContainerSelector)
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DomainNamePatterns != nil {
 		in, out := &in.DomainNamePatterns, &out.DomainNamePatterns
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]DNSRecord, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChaosSpec.
@@ -553,6 +628,21 @@ func (in *DNSChaosStatus) DeepCopy() *DNSChaosStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecord) DeepCopyInto(out *DNSRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecord.
+func (in *DNSRecord) DeepCopy() *DNSRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DelaySpec) DeepCopyInto(out *DelaySpec) {
 	*out = *in
@@ -668,10 +758,17 @@ func (in *ExperimentStatus) DeepCopyInto(out *ExperimentStatus) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(Record)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
+	if in.Selections != nil {
+		in, out := &in.Selections, &out.Selections
+		*out = make([]Selection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExperimentStatus.
@@ -729,6 +826,22 @@ func (in *Filter) DeepCopy() *Filter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForEachPod) DeepCopyInto(out *ForEachPod) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEachPod.
+func (in *ForEachPod) DeepCopy() *ForEachPod {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEachPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Frame) DeepCopyInto(out *Frame) {
 	*out = *in
@@ -808,9 +921,21 @@ func (in *GCPChaosSpec) DeepCopyInto(out *GCPChaosSpec) {
 	*out = *in
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SecretName != nil {
 		in, out := &in.SecretName, &out.SecretName
 		*out = new(string)
@@ -969,9 +1094,21 @@ func (in *HTTPChaosSpec) DeepCopyInto(out *HTTPChaosSpec) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPChaosSpec.
@@ -1118,9 +1255,21 @@ func (in *IOChaosSpec) DeepCopyInto(out *IOChaosSpec) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOChaosSpec.
@@ -1236,10 +1385,27 @@ func (in *JVMChaosSpec) DeepCopyInto(out *JVMChaosSpec) {
 	in.ContainerSelector.DeepCopyInto(&out.ContainerSelector)
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.JVMParameter.DeepCopyInto(&out.JVMParameter)
+	if in.Probability != nil {
+		in, out := &in.Probability, &out.Probability
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JVMChaosSpec.
@@ -1363,9 +1529,21 @@ func (in *KernelChaosSpec) DeepCopyInto(out *KernelChaosSpec) {
 	in.FailKernRequest.DeepCopyInto(&out.FailKernRequest)
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelChaosSpec.
@@ -1529,11 +1707,28 @@ func (in *NetworkChaosList) DeepCopyObject() runtime.Object {
 func (in *NetworkChaosSpec) DeepCopyInto(out *NetworkChaosSpec) {
 	*out = *in
 	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.WeightedActions != nil {
+		in, out := &in.WeightedActions, &out.WeightedActions
+		*out = make([]WeightedNetworkAction, len(*in))
+		copy(*out, *in)
+	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.TcParameter.DeepCopyInto(&out.TcParameter)
 	if in.Target != nil {
 		in, out := &in.Target, &out.Target
@@ -1568,6 +1763,13 @@ func (in *NetworkChaosStatus) DeepCopyInto(out *NetworkChaosStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Cycles != nil {
+		in, out := &in.Cycles, &out.Cycles
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkChaosStatus.
@@ -1660,6 +1862,23 @@ func (in *PodChaosSpec) DeepCopyInto(out *PodChaosSpec) {
 	in.ContainerSelector.DeepCopyInto(&out.ContainerSelector)
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureImage != nil {
+		in, out := &in.FailureImage, &out.FailureImage
 		*out = new(string)
 		**out = **in
 	}
@@ -2225,6 +2444,11 @@ func (in *PodNetworkChaosStatus) DeepCopy() *PodNetworkChaosStatus {
 func (in *PodSelector) DeepCopyInto(out *PodSelector) {
 	*out = *in
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSelector.
@@ -2265,6 +2489,26 @@ func (in *PodSelectorSpec) DeepCopyInto(out *PodSelectorSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]WorkloadReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.NodeSelectors != nil {
 		in, out := &in.NodeSelectors, &out.NodeSelectors
 		*out = make(map[string]string, len(*in))
@@ -2300,6 +2544,13 @@ func (in *PodSelectorSpec) DeepCopyInto(out *PodSelectorSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.AnnotationExpressionSelectors != nil {
+		in, out := &in.AnnotationExpressionSelectors, &out.AnnotationExpressionSelectors
+		*out = make(LabelSelectorRequirements, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PodPhaseSelectors != nil {
 		in, out := &in.PodPhaseSelectors, &out.PodPhaseSelectors
 		*out = make([]string, len(*in))
@@ -2393,6 +2644,10 @@ func (in *RawTrafficControl) DeepCopy() *RawTrafficControl {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Record) DeepCopyInto(out *Record) {
 	*out = *in
+	if in.InjectedAt != nil {
+		in, out := &in.InjectedAt, &out.InjectedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Record.
@@ -2503,6 +2758,11 @@ func (in *ScheduleList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
 	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.StartingDeadlineSeconds != nil {
 		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
 		*out = new(int64)
@@ -2542,6 +2802,26 @@ func (in *ScheduleStatus) DeepCopy() *ScheduleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Selection) DeepCopyInto(out *Selection) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Selection.
+func (in *Selection) DeepCopy() *Selection {
+	if in == nil {
+		return nil
+	}
+	out := new(Selection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StressChaos) DeepCopyInto(out *StressChaos) {
 	*out = *in
@@ -2612,9 +2892,21 @@ func (in *StressChaosSpec) DeepCopyInto(out *StressChaosSpec) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StressChaosSpec.
@@ -2784,6 +3076,11 @@ func (in *Template) DeepCopyInto(out *Template) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Cron != nil {
+		in, out := &in.Cron, &out.Cron
+		*out = new(string)
+		**out = **in
+	}
 	if in.Task != nil {
 		in, out := &in.Task, &out.Task
 		*out = new(Task)
@@ -2794,6 +3091,11 @@ func (in *Template) DeepCopyInto(out *Template) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ForEach != nil {
+		in, out := &in.ForEach, &out.ForEach
+		*out = new(ForEachPod)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ConditionalBranches != nil {
 		in, out := &in.ConditionalBranches, &out.ConditionalBranches
 		*out = make([]ConditionalBranch, len(*in))
@@ -2891,9 +3193,21 @@ func (in *TimeChaosSpec) DeepCopyInto(out *TimeChaosSpec) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RecoverTimeout != nil {
+		in, out := &in.RecoverTimeout, &out.RecoverTimeout
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveWindows != nil {
+		in, out := &in.ActiveWindows, &out.ActiveWindows
+		*out = make(ActiveWindows, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeChaosSpec.
@@ -2937,6 +3251,21 @@ func (in *Timespec) DeepCopy() *Timespec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedNetworkAction) DeepCopyInto(out *WeightedNetworkAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedNetworkAction.
+func (in *WeightedNetworkAction) DeepCopy() *WeightedNetworkAction {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedNetworkAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Workflow) DeepCopyInto(out *Workflow) {
 	*out = *in
@@ -3110,6 +3439,16 @@ func (in *WorkflowNodeSpec) DeepCopyInto(out *WorkflowNodeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ForEach != nil {
+		in, out := &in.ForEach, &out.ForEach
+		*out = new(ForEachPod)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetPod != nil {
+		in, out := &in.TargetPod, &out.TargetPod
+		*out = new(string)
+		**out = **in
+	}
 	if in.ConditionalBranches != nil {
 		in, out := &in.ConditionalBranches, &out.ConditionalBranches
 		*out = make([]ConditionalBranch, len(*in))
@@ -3160,6 +3499,11 @@ func (in *WorkflowNodeStatus) DeepCopyInto(out *WorkflowNodeStatus) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.FailedChildren != nil {
+		in, out := &in.FailedChildren, &out.FailedChildren
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]WorkflowNodeCondition, len(*in))
@@ -3187,6 +3531,11 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
@@ -3233,3 +3582,18 @@ func (in *WorkflowStatus) DeepCopy() *WorkflowStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadReference) DeepCopyInto(out *WorkloadReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReference.
+func (in *WorkloadReference) DeepCopy() *WorkloadReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Admission serves the AdmissionReview in both admission.k8s.io/v1 and admission.k8s.io/v1beta1,
+// and responds in the version of the request. The embedded webhook only understands v1beta1, which
+// is removed in newer Kubernetes, so the v1 reviews are converted before being handled.
+type Admission struct {
+	*admission.Webhook
+}
+
+// NewAdmission returns an Admission serving the handler
+func NewAdmission(handler admission.Handler) *Admission {
+	return &Admission{
+		Webhook: &admission.Webhook{Handler: handler},
+	}
+}
+
+// ServeHTTP negotiates the version of AdmissionReview with the apiVersion of the request
+func (a *Admission) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Body == nil {
+		a.Webhook.ServeHTTP(w, r)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error(err, "unable to read the body from the incoming request")
+		writeReview(w, http.StatusBadRequest, admission.Errored(http.StatusBadRequest, err).AdmissionResponse, admissionv1beta1.SchemeGroupVersion.String())
+		return
+	}
+
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(body, &typeMeta); err != nil || typeMeta.APIVersion != admissionv1.SchemeGroupVersion.String() {
+		// the v1beta1 review and the malformed request are left to the embedded webhook
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		a.Webhook.ServeHTTP(w, r)
+		return
+	}
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+		err := fmt.Errorf("contentType=%s, expected application/json", contentType)
+		writeReview(w, http.StatusBadRequest, admission.Errored(http.StatusBadRequest, err).AdmissionResponse, typeMeta.APIVersion)
+		return
+	}
+
+	review := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		if err == nil {
+			err = fmt.Errorf("request of the AdmissionReview is empty")
+		}
+		log.Error(err, "unable to decode the request")
+		writeReview(w, http.StatusBadRequest, admission.Errored(http.StatusBadRequest, err).AdmissionResponse, typeMeta.APIVersion)
+		return
+	}
+
+	// the embedded webhook completes the response with the uid of the request
+	resp := a.Handle(r.Context(), admission.Request{AdmissionRequest: convertRequest(review.Request)})
+	writeReview(w, http.StatusOK, resp.AdmissionResponse, typeMeta.APIVersion)
+}
+
+// writeReview writes the response as an AdmissionReview in the apiVersion
+func writeReview(w http.ResponseWriter, code int, resp admissionv1beta1.AdmissionResponse, apiVersion string) {
+	var review interface{}
+	if apiVersion == admissionv1.SchemeGroupVersion.String() {
+		review = admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: apiVersion,
+				Kind:       "AdmissionReview",
+			},
+			Response: convertResponse(&resp),
+		}
+	} else {
+		review = admissionv1beta1.AdmissionReview{
+			Response: &resp,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Error(err, "unable to encode the response")
+	}
+}
+
+// convertRequest converts the admission.k8s.io/v1 request to v1beta1, they share the same fields
+func convertRequest(in *admissionv1.AdmissionRequest) admissionv1beta1.AdmissionRequest {
+	return admissionv1beta1.AdmissionRequest{
+		UID:                in.UID,
+		Kind:               in.Kind,
+		Resource:           in.Resource,
+		SubResource:        in.SubResource,
+		RequestKind:        in.RequestKind,
+		RequestResource:    in.RequestResource,
+		RequestSubResource: in.RequestSubResource,
+		Name:               in.Name,
+		Namespace:          in.Namespace,
+		Operation:          admissionv1beta1.Operation(in.Operation),
+		UserInfo:           in.UserInfo,
+		Object:             in.Object,
+		OldObject:          in.OldObject,
+		DryRun:             in.DryRun,
+		Options:            in.Options,
+	}
+}
+
+// convertResponse converts the admission.k8s.io/v1beta1 response to v1, they share the same fields
+func convertResponse(in *admissionv1beta1.AdmissionResponse) *admissionv1.AdmissionResponse {
+	out := &admissionv1.AdmissionResponse{
+		UID:              in.UID,
+		Allowed:          in.Allowed,
+		Result:           in.Result,
+		Patch:            in.Patch,
+		AuditAnnotations: in.AuditAnnotations,
+	}
+	if in.PatchType != nil {
+		patchType := admissionv1.PatchType(*in.PatchType)
+		out.PatchType = &patchType
+	}
+	return out
+}
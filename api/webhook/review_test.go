@@ -0,0 +1,115 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestAdmissionReviewVersions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	a := NewAdmission(admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		g.Expect(req.Namespace).To(Equal("app"))
+		g.Expect(req.Operation).To(Equal(admissionv1beta1.Create))
+		g.Expect(req.Object.Raw).To(MatchJSON(`{"kind":"Pod"}`))
+		patchType := admissionv1beta1.PatchTypeJSONPatch
+		return admission.Response{AdmissionResponse: admissionv1beta1.AdmissionResponse{
+			Allowed:   true,
+			Patch:     []byte(`[{"op":"add","path":"/metadata/annotations","value":{"injected":"true"}}]`),
+			PatchType: &patchType,
+		}}
+	}))
+	g.Expect(a.InjectLogger(zap.New(zap.UseDevMode(true)))).To(Succeed())
+
+	serve := func(review interface{}) []byte {
+		body, err := json.Marshal(review)
+		g.Expect(err).ToNot(HaveOccurred())
+		req := httptest.NewRequest(http.MethodPost, "/inject-v1-pod", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		a.ServeHTTP(rr, req)
+		g.Expect(rr.Code).To(Equal(http.StatusOK))
+		return rr.Body.Bytes()
+	}
+
+	// the v1 review is responded in v1
+	body := serve(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "uid-v1",
+			Namespace: "app",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(`{"kind":"Pod"}`)},
+		},
+	})
+	v1Review := admissionv1.AdmissionReview{}
+	g.Expect(json.Unmarshal(body, &v1Review)).To(Succeed())
+	g.Expect(v1Review.APIVersion).To(Equal("admission.k8s.io/v1"))
+	g.Expect(v1Review.Kind).To(Equal("AdmissionReview"))
+	g.Expect(v1Review.Response.UID).To(BeEquivalentTo("uid-v1"))
+	g.Expect(v1Review.Response.Allowed).To(BeTrue())
+	g.Expect(*v1Review.Response.PatchType).To(Equal(admissionv1.PatchTypeJSONPatch))
+	g.Expect(v1Review.Response.Patch).To(MatchJSON(`[{"op":"add","path":"/metadata/annotations","value":{"injected":"true"}}]`))
+
+	// the v1beta1 review is still responded in v1beta1
+	body = serve(admissionv1beta1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1beta1", Kind: "AdmissionReview"},
+		Request: &admissionv1beta1.AdmissionRequest{
+			UID:       "uid-v1beta1",
+			Namespace: "app",
+			Operation: admissionv1beta1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(`{"kind":"Pod"}`)},
+		},
+	})
+	v1beta1Review := admissionv1beta1.AdmissionReview{}
+	g.Expect(json.Unmarshal(body, &v1beta1Review)).To(Succeed())
+	g.Expect(v1beta1Review.APIVersion).ToNot(Equal("admission.k8s.io/v1"))
+	g.Expect(v1beta1Review.Response.UID).To(BeEquivalentTo("uid-v1beta1"))
+	g.Expect(*v1beta1Review.Response.PatchType).To(Equal(admissionv1beta1.PatchTypeJSONPatch))
+	g.Expect(v1beta1Review.Response.Patch).To(MatchJSON(`[{"op":"add","path":"/metadata/annotations","value":{"injected":"true"}}]`))
+}
+
+func TestMalformedAdmissionReview(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	a := NewAdmission(admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		return admission.Allowed("")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/inject-v1-pod", bytes.NewBufferString(`{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	a.ServeHTTP(rr, req)
+	g.Expect(rr.Code).To(Equal(http.StatusBadRequest))
+
+	review := admissionv1.AdmissionReview{}
+	g.Expect(json.Unmarshal(rr.Body.Bytes(), &review)).To(Succeed())
+	g.Expect(review.APIVersion).To(Equal("admission.k8s.io/v1"))
+	g.Expect(review.Response.Allowed).To(BeFalse())
+	g.Expect(review.Response.Result.Code).To(BeEquivalentTo(http.StatusBadRequest))
+}
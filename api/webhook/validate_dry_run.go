@@ -0,0 +1,131 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+)
+
+// DryRunAnnotationKey is the annotation to select the pods of a chaos without creating it
+const DryRunAnnotationKey = "chaos-mesh.org/dry-run"
+
+var dryRunLog = ctrl.Log.WithName("validate-dry-run")
+
+// +kubebuilder:webhook:path=/validate-dry-run,mutating=false,failurePolicy=ignore,groups=chaos-mesh.org,resources=*,verbs=create,versions=v1alpha1,name=vdryrun.kb.io
+
+// DryRunValidator reports the pods affected by the chaos with the dry-run annotation.
+// The admission webhooks of this Kubernetes version can't respond warnings, so the
+// chaos is always denied with the affected pods in the message, and it's never persisted.
+// The other chaos is allowed, and the failure of this webhook is ignored, so that it never
+// blocks the creation of the chaos without the annotation.
+type DryRunValidator struct {
+	client client.Client
+	reader client.Reader
+
+	decoder *admission.Decoder
+
+	clusterScoped         bool
+	targetNamespace       string
+	enableFilterNamespace bool
+	roundingMode          pod.RoundingMode
+}
+
+// NewDryRunValidator returns a new DryRunValidator
+func NewDryRunValidator(c client.Client, r client.Reader,
+	clusterScoped bool, targetNamespace string, enableFilterNamespace bool, roundingMode pod.RoundingMode) *DryRunValidator {
+	return &DryRunValidator{
+		client:                c,
+		reader:                r,
+		clusterScoped:         clusterScoped,
+		targetNamespace:       targetNamespace,
+		enableFilterNamespace: enableFilterNamespace,
+		roundingMode:          roundingMode,
+	}
+}
+
+// Handle selects the pods of the chaos with the dry-run annotation, and denies it with the result
+func (v *DryRunValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1beta1.Create {
+		return admission.Allowed("")
+	}
+
+	kind, ok := v1alpha1.AllKinds()[req.Kind.Kind]
+	if !ok {
+		return admission.Allowed("")
+	}
+	chaos := kind.Chaos.DeepCopyObject().(common.InnerObjectWithSelector)
+	if err := v.decoder.Decode(req, chaos); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	meta := chaos.GetObjectMeta()
+	if meta.GetAnnotations()[DryRunAnnotationKey] != "true" {
+		return admission.Allowed("")
+	}
+	if meta.Namespace == "" {
+		meta.Namespace = req.Namespace
+	}
+
+	affectedPods := make(map[types.NamespacedName]struct{})
+	affectedNamespaces := make(map[string]struct{})
+	for name, spec := range chaos.GetSelectorSpecs() {
+		var selector v1alpha1.PodSelector
+		if s, ok := spec.(*v1alpha1.ContainerSelector); ok {
+			selector = s.PodSelector
+		} else if p, ok := spec.(*v1alpha1.PodSelector); ok {
+			selector = *p
+		} else {
+			continue
+		}
+		selector.Selector.DefaultNamespace(meta)
+
+		pods, err := pod.SelectAndFilterPods(ctx, v.client, v.reader, &selector, v.clusterScoped, v.targetNamespace, v.enableFilterNamespace, v.roundingMode)
+		if err != nil {
+			return admission.Denied(fmt.Sprintf("dry run: fail to select pods by selector %s: %s", name, err))
+		}
+		for _, target := range pods {
+			affectedPods[types.NamespacedName{Namespace: target.Namespace, Name: target.Name}] = struct{}{}
+			affectedNamespaces[target.Namespace] = struct{}{}
+		}
+	}
+
+	namespaces := make([]string, 0, len(affectedNamespaces))
+	for namespace := range affectedNamespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	dryRunLog.Info("chaos is validated in dry run", "kind", req.Kind.Kind, "name", meta.Name, "pods", len(affectedPods), "namespaces", namespaces)
+	return admission.Denied(fmt.Sprintf("dry run: %d pods in namespaces [%s] would be affected, remove the annotation %s to create the chaos",
+		len(affectedPods), strings.Join(namespaces, ", "), DryRunAnnotationKey))
+}
+
+// InjectDecoder injects the decoder.
+func (v *DryRunValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestDryRunValidator(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+	var objs []runtime.Object
+	for _, arg := range []PodArg{
+		{Name: "p0", Labels: map[string]string{"app": "foo"}},
+		{Name: "p1", Labels: map[string]string{"app": "foo"}},
+		{Name: "p2", Namespace: "app", Labels: map[string]string{"app": "foo"}},
+		{Name: "p3", Labels: map[string]string{"app": "bar"}},
+	} {
+		pod := NewPod(arg)
+		objs = append(objs, &pod)
+	}
+	c := fake.NewFakeClientWithScheme(scheme, objs...)
+
+	v := NewDryRunValidator(c, c, true, "", false, pod.RoundingModeRound)
+	decoder, err := admission.NewDecoder(scheme)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(v.InjectDecoder(decoder)).To(Succeed())
+
+	handle := func(operation admissionv1beta1.Operation, annotations map[string]string, selector v1alpha1.PodSelector) admission.Response {
+		chaos := &v1alpha1.PodChaos{
+			TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.KindPodChaos},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod-kill",
+				Annotations: annotations,
+			},
+			Spec: v1alpha1.PodChaosSpec{
+				Action:            v1alpha1.PodKillAction,
+				ContainerSelector: v1alpha1.ContainerSelector{PodSelector: selector},
+			},
+		}
+		raw, err := json.Marshal(chaos)
+		g.Expect(err).ToNot(HaveOccurred())
+		return v.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Group: v1alpha1.GroupVersion.Group, Version: v1alpha1.GroupVersion.Version, Kind: v1alpha1.KindPodChaos},
+			Namespace: metav1.NamespaceDefault,
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: raw},
+		}})
+	}
+	dryRun := map[string]string{DryRunAnnotationKey: "true"}
+	all := v1alpha1.PodSelector{
+		Selector: v1alpha1.PodSelectorSpec{
+			Namespaces:     []string{metav1.NamespaceDefault, "app"},
+			LabelSelectors: map[string]string{"app": "foo"},
+		},
+		Mode: v1alpha1.AllPodMode,
+	}
+
+	// the chaos without the annotation is created as usual
+	resp := handle(admissionv1beta1.Create, nil, all)
+	g.Expect(resp.Allowed).To(BeTrue())
+
+	resp = handle(admissionv1beta1.Update, dryRun, all)
+	g.Expect(resp.Allowed).To(BeTrue())
+
+	// the chaos in dry run is never persisted
+	resp = handle(admissionv1beta1.Create, dryRun, all)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Reason).To(BeEquivalentTo("dry run: 3 pods in namespaces [app, default] would be affected, remove the annotation chaos-mesh.org/dry-run to create the chaos"))
+
+	// the pods are filtered by the mode
+	percent := all
+	percent.Mode = v1alpha1.FixedPercentPodMode
+	percent.Value = "50"
+	resp = handle(admissionv1beta1.Create, dryRun, percent)
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(string(resp.Result.Reason)).To(HavePrefix("dry run: 2 pods in namespaces"))
+
+	// the pods are selected in the namespace of the chaos by default
+	resp = handle(admissionv1beta1.Create, dryRun, v1alpha1.PodSelector{Mode: v1alpha1.AllPodMode})
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(string(resp.Result.Reason)).To(HavePrefix("dry run: 3 pods in namespaces [default]"))
+
+	// the selection failure is reported as well
+	resp = handle(admissionv1beta1.Create, dryRun, v1alpha1.PodSelector{
+		Selector: v1alpha1.PodSelectorSpec{LabelSelectors: map[string]string{"app": "baz"}},
+		Mode:     v1alpha1.AllPodMode,
+	})
+	g.Expect(resp.Allowed).To(BeFalse())
+	g.Expect(resp.Result.Reason).To(BeEquivalentTo("dry run: fail to select pods by selector .: no pod is selected"))
+}
@@ -54,13 +54,30 @@ func (in *{{.Type}}Spec) GetDuration() (*time.Duration, error) {
 	if in.Duration == nil {
 		return nil, nil
 	}
-	duration, err := time.ParseDuration(*in.Duration)
+	duration, err := in.Duration.Parse()
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
 }
 
+// GetRecoverTimeout would return the recover timeout for chaos
+func (in *{{.Type}}Spec) GetRecoverTimeout() (*time.Duration, error) {
+	if in.RecoverTimeout == nil {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(*in.RecoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &timeout, nil
+}
+
+// GetActiveWindows would return the active windows for chaos
+func (in *{{.Type}}Spec) GetActiveWindows() ActiveWindows {
+	return in.ActiveWindows
+}
+
 // GetChaos would return the a record for chaos
 func (in *{{.Type}}) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -78,7 +95,10 @@ func (in *{{.Type}}) GetChaos() *ChaosInstance {
 		instance.Action = action.String()
 	}
 	if in.Spec.Duration != nil {
-		instance.Duration = *in.Spec.Duration
+		instance.Duration = string(*in.Spec.Duration)
+	}
+	if in.Spec.RecoverTimeout != nil {
+		instance.RecoverTimeout = *in.Spec.RecoverTimeout
 	}
 	if in.DeletionTimestamp != nil {
 		instance.EndTime = in.DeletionTimestamp.Time
@@ -131,7 +151,7 @@ func (in *{{.Type}}) DurationExceeded(now time.Time) (bool, time.Duration, error
 
 	if duration != nil {
 		stopTime := in.GetCreationTimestamp().Add(*duration)
-		if stopTime.Before(now) {
+		if !now.Before(stopTime) {
 			return true, 0, nil
 		}
 
@@ -141,6 +161,46 @@ func (in *{{.Type}}) DurationExceeded(now time.Time) (bool, time.Duration, error
 	return false, 0, nil
 }
 
+// RecoverTimeoutExceeded returns whether the chaos should have been recovered according to
+// the duration and the recover timeout, and how long it is until then.
+func (in *{{.Type}}) RecoverTimeoutExceeded(now time.Time) (bool, time.Duration, error) {
+	// The chaos recovered manually is not expected to be recovered after the duration
+	if in.Spec.ManualRecover {
+		return false, 0, nil
+	}
+
+	duration, err := in.Spec.GetDuration()
+	if err != nil {
+		return false, 0, err
+	}
+	timeout, err := in.Spec.GetRecoverTimeout()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if duration != nil && timeout != nil {
+		deadline := in.GetCreationTimestamp().Add(*duration + *timeout)
+		if deadline.Before(now) {
+			return true, 0, nil
+		}
+
+		return false, deadline.Sub(now), nil
+	}
+
+	return false, 0, nil
+}
+
+// OutOfActiveWindows returns whether the chaos is out of its active windows, and how long it is until that changes.
+func (in *{{.Type}}) OutOfActiveWindows(now time.Time) (bool, time.Duration, error) {
+	active, untilChange, err := in.Spec.ActiveWindows.Active(now)
+	return !active, untilChange, err
+}
+
+// IsManualRecover returns whether the chaos is kept injected after the duration until it's paused or deleted
+func (in *{{.Type}}) IsManualRecover() bool {
+	return in.Spec.ManualRecover
+}
+
 func (in *{{.Type}}) IsOneShot() bool {
 	{{if .OneShotExp}}
 	if {{.OneShotExp}} {
@@ -33,6 +33,9 @@ func init() {
 	faker.AddProvider("ioMethods", func(v reflect.Value) (interface{}, error) {
 		return []IoMethod{LookUp}, nil
 	})
+	faker.AddProvider("weekdays", func(v reflect.Value) (interface{}, error) {
+		return []Weekday{"Mon"}, nil
+	})
 }
 `
 
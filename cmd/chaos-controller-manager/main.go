@@ -19,6 +19,8 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"time"
+	// the time zones of the active windows of chaos are loaded without the tzdata of the image
+	_ "time/tzdata"
 
 	"github.com/go-logr/logr"
 	"go.uber.org/fx"
@@ -29,7 +31,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	controllermetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	apiWebhook "github.com/chaos-mesh/chaos-mesh/api/webhook"
@@ -40,6 +41,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/types"
 	grpcUtils "github.com/chaos-mesh/chaos-mesh/pkg/grpc"
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
 	"github.com/chaos-mesh/chaos-mesh/pkg/version"
 	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
 	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config/watcher"
@@ -73,6 +75,10 @@ func main() {
 			selector.Module,
 			types.ChaosObjects,
 		),
+		fx.Provide(
+			config.NewConfigWatcherConf,
+			newChaosCollector,
+		),
 		fx.Invoke(Run),
 	)
 
@@ -85,15 +91,27 @@ type RunParams struct {
 	Mgr     ctrl.Manager
 	Logger  logr.Logger
 	AuthCli *authorizationv1.AuthorizationV1Client
+	// InjectConfig is shared by the inject webhook and the controller which injects the running pods
+	InjectConfig *config.Config
+	Metrics      *metrics.ChaosCollector
 
 	Controllers []types.Controller `group:"controller"`
 	Objs        []types.Object     `group:"objs"`
 }
 
+// newChaosCollector initializes the metrics collector shared by the webhooks and the controllers
+func newChaosCollector(mgr ctrl.Manager) *metrics.ChaosCollector {
+	return metrics.NewChaosCollector(mgr.GetCache(), controllermetrics.Registry)
+}
+
 func Run(params RunParams) error {
 	mgr := params.Mgr
 	authCli := params.AuthCli
 
+	v1alpha1.RequireDuration = ccfg.ControllerCfg.RequireDuration
+	v1alpha1.MaxDuration = ccfg.ControllerCfg.MaxDuration
+	v1alpha1.RegisterOwnerReader(mgr.GetAPIReader())
+
 	var err error
 	for _, obj := range params.Objs {
 		err = ctrl.NewWebhookManagedBy(mgr).
@@ -120,13 +138,12 @@ func Run(params RunParams) error {
 		return err
 	}
 
-	// Init metrics collector
-	metricsCollector := metrics.NewChaosCollector(mgr.GetCache(), controllermetrics.Registry)
+	metricsCollector := params.Metrics
 
 	setupLog.Info("Setting up webhook server")
 	hookServer := mgr.GetWebhookServer()
 	hookServer.CertDir = ccfg.ControllerCfg.CertsDir
-	conf := config.NewConfigWatcherConf()
+	conf := params.InjectConfig
 
 	stopCh := ctrl.SetupSignalHandler()
 
@@ -150,18 +167,20 @@ func Run(params RunParams) error {
 	}
 
 	go watchConfig(configWatcher, conf, stopCh)
-	hookServer.Register("/inject-v1-pod", &webhook.Admission{
-		Handler: &apiWebhook.PodInjector{
-			Config:        conf,
-			ControllerCfg: ccfg.ControllerCfg,
-			Metrics:       metricsCollector,
-		}},
-	)
-	hookServer.Register("/validate-auth", &webhook.Admission{
-		Handler: apiWebhook.NewAuthValidator(ccfg.ControllerCfg.SecurityMode, authCli,
+	hookServer.Register("/inject-v1-pod", apiWebhook.NewAdmission(&apiWebhook.PodInjector{
+		Config:        conf,
+		ControllerCfg: ccfg.ControllerCfg,
+		Metrics:       metricsCollector,
+	}))
+	hookServer.Register("/validate-auth", apiWebhook.NewAdmission(
+		apiWebhook.NewAuthValidator(ccfg.ControllerCfg.SecurityMode, authCli,
 			ccfg.ControllerCfg.ClusterScoped, ccfg.ControllerCfg.TargetNamespace, ccfg.ControllerCfg.EnableFilterNamespace),
-	},
-	)
+	))
+	hookServer.Register("/validate-dry-run", apiWebhook.NewAdmission(
+		apiWebhook.NewDryRunValidator(mgr.GetClient(), mgr.GetAPIReader(),
+			ccfg.ControllerCfg.ClusterScoped, ccfg.ControllerCfg.TargetNamespace, ccfg.ControllerCfg.EnableFilterNamespace,
+			pod.RoundingMode(ccfg.ControllerCfg.PercentRoundingMode)),
+	))
 
 	setupLog.Info("Starting manager")
 	if err := mgr.Start(stopCh); err != nil {
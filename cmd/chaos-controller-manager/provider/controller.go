@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -102,6 +103,19 @@ func NewAuthCli(cfg *rest.Config) (*authorizationv1.AuthorizationV1Client, error
 	return authorizationv1.NewForConfig(cfg)
 }
 
+// NewCoreCli returns the client of the core group, which supports the subresources of pods
+// that are not supported by client.Client, e.g. the ephemeral containers
+func NewCoreCli(cfg *rest.Config) (corev1.CoreV1Interface, error) {
+	if config.ControllerCfg.QPS > 0 {
+		cfg.QPS = config.ControllerCfg.QPS
+	}
+	if config.ControllerCfg.Burst > 0 {
+		cfg.Burst = config.ControllerCfg.Burst
+	}
+
+	return corev1.NewForConfig(cfg)
+}
+
 func NewClient(mgr ctrl.Manager, scheme *runtime.Scheme) (client.Client, error) {
 	// TODO: make this size configurable
 	cache, err := lru.New(100)
@@ -198,6 +212,7 @@ var Module = fx.Provide(
 	NewManager,
 	NewLogger,
 	NewAuthCli,
+	NewCoreCli,
 	NewScheme,
 	NewConfig,
 	NewNoCacheReader,
@@ -28,20 +28,22 @@ import (
 
 var (
 	log  = ctrl.Log.WithName("chaos-daemon")
-	conf = &chaosdaemon.Config{Host: "0.0.0.0"}
+	conf = &chaosdaemon.Config{}
 
 	printVersion bool
 )
 
 func init() {
 	flag.BoolVar(&printVersion, "version", false, "print version information and exit")
+	flag.StringVar(&conf.Host, "host", "0.0.0.0", "the address which grpc and http server listen on")
 	flag.IntVar(&conf.GRPCPort, "grpc-port", 31767, "the port which grpc server listens on")
-	flag.IntVar(&conf.HTTPPort, "http-port", 31766, "the port which http server listens on")
+	flag.IntVar(&conf.HTTPPort, "http-port", 31766, "the port which http server listens on, serving the metrics on /metrics")
 	flag.StringVar(&conf.Runtime, "runtime", "docker", "current container runtime")
 	flag.StringVar(&conf.CaCert, "ca", "", "ca certificate of grpc server")
 	flag.StringVar(&conf.Cert, "cert", "", "certificate of grpc server")
 	flag.StringVar(&conf.Key, "key", "", "key of grpc server")
 	flag.BoolVar(&conf.Profiling, "pprof", false, "enable pprof")
+	flag.BoolVar(&conf.DNSBindMount, "dns-bind-mount", false, "bind mount the config file of the chaos dns server over /etc/resolv.conf rather than modifying it in place")
 
 	flag.Parse()
 }
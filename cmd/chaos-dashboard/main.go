@@ -35,6 +35,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/pkg/store/dbstore"
 	"github.com/chaos-mesh/chaos-mesh/pkg/ttlcontroller"
 	"github.com/chaos-mesh/chaos-mesh/pkg/version"
+	injectconfig "github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
 )
 
 var (
@@ -90,6 +91,8 @@ func main() {
 			dbstore.NewDBStore,
 			collector.NewServer,
 			ttlcontroller.NewController,
+			// the same config as the inject webhook runs with, used to preview the injection
+			injectconfig.NewConfigWatcherConf,
 		),
 		store.Module,
 		apiserver.Module,
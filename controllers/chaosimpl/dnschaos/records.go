@@ -0,0 +1,43 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	dnspb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdns/pb"
+)
+
+// newSetDNSChaosRequest returns the request which sets the rules of the chaos on the chaos dns server
+func newSetDNSChaosRequest(name string, pods []*dnspb.Pod, spec *v1alpha1.DNSChaosSpec) *dnspb.SetDNSChaosRequest {
+	request := &dnspb.SetDNSChaosRequest{
+		Name:     name,
+		Action:   string(spec.Action),
+		Pods:     pods,
+		Patterns: spec.DomainNamePatterns,
+	}
+	if spec.Action == v1alpha1.MapAction {
+		// only the domain names matched by the records are served by the chaos dns server
+		request.Patterns = make([]string, 0, len(spec.Records))
+		for _, record := range spec.Records {
+			request.Patterns = append(request.Patterns, record.Pattern)
+			request.Records = append(request.Records, &dnspb.Record{
+				Pattern: record.Pattern,
+				Type:    string(record.Type),
+				Value:   record.Value,
+				Ttl:     record.TTL,
+			})
+		}
+	}
+	return request
+}
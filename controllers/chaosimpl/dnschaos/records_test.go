@@ -0,0 +1,56 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	dnspb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdns/pb"
+)
+
+func TestNewSetDNSChaosRequest(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pods := []*dnspb.Pod{{Namespace: "default", Name: "p0"}}
+	request := newSetDNSChaosRequest("dns", pods, &v1alpha1.DNSChaosSpec{
+		Action:             v1alpha1.MapAction,
+		DomainNamePatterns: []string{"ignored.*"},
+		Records: []v1alpha1.DNSRecord{
+			{Pattern: "github.*", Type: v1alpha1.ARecord, Value: "10.0.0.1", TTL: 60},
+			{Pattern: "chaos-mes?.org", Type: v1alpha1.AAAARecord, Value: "fd00::1"},
+		},
+	})
+
+	// the records are sent in the request, and only their patterns take effect
+	data, err := proto.Marshal(request)
+	g.Expect(err).ToNot(HaveOccurred())
+	decoded := &dnspb.SetDNSChaosRequest{}
+	g.Expect(proto.Unmarshal(data, decoded)).To(Succeed())
+	g.Expect(decoded.Patterns).To(Equal([]string{"github.*", "chaos-mes?.org"}))
+	g.Expect(decoded.Records).To(HaveLen(2))
+	g.Expect(proto.Equal(decoded.Records[0], &dnspb.Record{Pattern: "github.*", Type: "A", Value: "10.0.0.1", Ttl: 60})).To(BeTrue())
+	g.Expect(proto.Equal(decoded.Records[1], &dnspb.Record{Pattern: "chaos-mes?.org", Type: "AAAA", Value: "fd00::1"})).To(BeTrue())
+
+	// the other actions take effect on the patterns without records
+	request = newSetDNSChaosRequest("dns", pods, &v1alpha1.DNSChaosSpec{
+		Action:             v1alpha1.ErrorAction,
+		DomainNamePatterns: []string{"github.*"},
+	})
+	g.Expect(request.Patterns).To(Equal([]string{"github.*"}))
+	g.Expect(request.Records).To(BeEmpty())
+}
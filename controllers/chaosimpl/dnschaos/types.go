@@ -19,11 +19,11 @@ import (
 	"net"
 	"time"
 
-	dnspb "github.com/chaos-mesh/k8s_dns_chaos/pb"
 	"github.com/go-logr/logr"
 	"go.uber.org/fx"
 	"google.golang.org/grpc"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
@@ -31,6 +31,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
 	"github.com/chaos-mesh/chaos-mesh/controllers/config"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	dnspb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdns/pb"
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
 )
 
@@ -57,7 +58,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	}
 
 	dnschaos := obj.(*v1alpha1.DNSChaos)
-	err = impl.setDNSServerRules(service.Spec.ClusterIP, config.ControllerCfg.DNSServicePort, dnschaos.Name, decodedContainer.Pod, dnschaos.Spec.Action, dnschaos.Spec.DomainNamePatterns)
+	err = impl.setDNSServerRules(service.Spec.ClusterIP, config.ControllerCfg.DNSServicePort, dnschaos.Name, decodedContainer.Pod, &dnschaos.Spec)
 	if err != nil {
 		impl.Log.Error(err, "fail to set DNS server rules")
 		return v1alpha1.NotInjected, err
@@ -68,6 +69,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		DnsServer:   service.Spec.ClusterIP,
 		Enable:      true,
 		EnterNS:     true,
+		Name:        types.NamespacedName{Namespace: dnschaos.Namespace, Name: dnschaos.Name}.String(),
 	})
 	if err != nil {
 		impl.Log.Error(err, "set dns server")
@@ -77,7 +79,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	return v1alpha1.Injected, nil
 }
 
-func (impl *Impl) setDNSServerRules(dnsServerIP string, port int, name string, pod *v1.Pod, action v1alpha1.DNSChaosAction, patterns []string) error {
+func (impl *Impl) setDNSServerRules(dnsServerIP string, port int, name string, pod *v1.Pod, spec *v1alpha1.DNSChaosSpec) error {
 	impl.Log.Info("setDNSServerRules", "name", name)
 
 	pbPods := make([]*dnspb.Pod, 1)
@@ -93,12 +95,7 @@ func (impl *Impl) setDNSServerRules(dnsServerIP string, port int, name string, p
 	defer conn.Close()
 
 	c := dnspb.NewDNSClient(conn)
-	request := &dnspb.SetDNSChaosRequest{
-		Name:     name,
-		Action:   string(action),
-		Pods:     pbPods,
-		Patterns: patterns,
-	}
+	request := newSetDNSChaosRequest(name, pbPods, spec)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -145,8 +142,10 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 
 	_, err = decodedContainer.PbClient.SetDNSServer(ctx, &pb.SetDNSServerRequest{
 		ContainerId: decodedContainer.ContainerId,
+		DnsServer:   service.Spec.ClusterIP,
 		Enable:      false,
 		EnterNS:     true,
+		Name:        types.NamespacedName{Namespace: dnschaos.Namespace, Name: dnschaos.Name}.String(),
 	})
 	if err != nil {
 		impl.Log.Error(err, "recover pod for DNS chaos")
@@ -16,6 +16,7 @@ package httpchaos
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -29,6 +30,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/httpchaos/podhttpchaosmanager"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/iochaos/podiochaosmanager"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/utils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 )
@@ -43,6 +45,8 @@ type Impl struct {
 	Log logr.Logger
 
 	builder *podhttpchaosmanager.Builder
+
+	status utils.StatusLock
 }
 
 func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
@@ -50,9 +54,6 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 
 	impl.Log.Info("httpchaos Apply", "namespace", obj.GetObjectMeta().Namespace, "name", obj.GetObjectMeta().Name)
 	httpchaos := obj.(*v1alpha1.HTTPChaos)
-	if httpchaos.Status.Instances == nil {
-		httpchaos.Status.Instances = make(map[string]int64)
-	}
 
 	record := records[index]
 	phase := record.Phase
@@ -68,7 +69,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			return waitForApplySync, errors.New(podhttpchaos.Status.FailedMessage)
 		}
 
-		if podhttpchaos.Status.ObservedGeneration >= httpchaos.Status.Instances[record.Id] {
+		if podhttpchaos.Status.ObservedGeneration >= impl.status.Get(&httpchaos.Status.Instances, record.Id) {
 			return v1alpha1.Injected, nil
 		}
 
@@ -88,13 +89,18 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		Name:      pod.Name,
 	})
 
+	port, err := proxyPort(&pod, httpchaos.Spec.Port)
+	if err != nil {
+		return v1alpha1.NotInjected, err
+	}
+
 	m.T.Append(v1alpha1.PodHttpChaosRule{
 		Source: m.Source,
-		Port:   httpchaos.Spec.Port,
+		Port:   port,
 		PodHttpChaosBaseRule: v1alpha1.PodHttpChaosBaseRule{
 			Target: httpchaos.Spec.Target,
 			Selector: v1alpha1.PodHttpChaosSelector{
-				Port:            &httpchaos.Spec.Port,
+				Port:            &port,
 				Path:            httpchaos.Spec.Path,
 				Method:          httpchaos.Spec.Method,
 				Code:            httpchaos.Spec.Code,
@@ -110,7 +116,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	}
 
 	// modify the custom status
-	httpchaos.Status.Instances[record.Id] = generationNumber
+	impl.status.Set(&httpchaos.Status.Instances, record.Id, generationNumber)
 	return waitForApplySync, nil
 }
 
@@ -118,9 +124,6 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	// The only possible phase to get in here is "Injected" or "Injected/Wait"
 
 	httpchaos := obj.(*v1alpha1.HTTPChaos)
-	if httpchaos.Status.Instances == nil {
-		httpchaos.Status.Instances = make(map[string]int64)
-	}
 
 	record := records[index]
 	phase := record.Phase
@@ -146,7 +149,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 			return waitForRecoverSync, errors.New(podhttpchaos.Status.FailedMessage)
 		}
 
-		if podhttpchaos.Status.ObservedGeneration >= httpchaos.Status.Instances[record.Id] {
+		if podhttpchaos.Status.ObservedGeneration >= impl.status.Get(&httpchaos.Status.Instances, record.Id) {
 			return v1alpha1.NotInjected, nil
 		}
 
@@ -185,10 +188,38 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	}
 
 	// Now modify the custom status and phase
-	httpchaos.Status.Instances[record.Id] = generationNumber
+	impl.status.Set(&httpchaos.Status.Instances, record.Id, generationNumber)
 	return waitForRecoverSync, nil
 }
 
+// proxyPort returns the port to be proxied in the pod. The explicit port is used if it's set, otherwise
+// the only TCP port declared by the containers of the pod is proxied. The pod declaring several ports
+// requires an explicit port, as only one port is proxied.
+func proxyPort(pod *v1.Pod, port int32) (int32, error) {
+	if port != 0 {
+		return port, nil
+	}
+
+	var ports []int32
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Protocol != "" && containerPort.Protocol != v1.ProtocolTCP {
+				continue
+			}
+			ports = append(ports, containerPort.ContainerPort)
+		}
+	}
+
+	if len(ports) == 0 {
+		return 0, fmt.Errorf("pod %s/%s declares no container port, please set the port of httpchaos explicitly", pod.Namespace, pod.Name)
+	}
+	if len(ports) > 1 {
+		return 0, fmt.Errorf("pod %s/%s declares several container ports %v, please set the port of httpchaos explicitly", pod.Namespace, pod.Name, ports)
+	}
+
+	return ports[0], nil
+}
+
 func NewImpl(c client.Client, b *podhttpchaosmanager.Builder, log logr.Logger) *common.ChaosImplPair {
 	return &common.ChaosImplPair{
 		Name:   "httpchaos",
@@ -200,6 +231,8 @@ func NewImpl(c client.Client, b *podhttpchaosmanager.Builder, log logr.Logger) *
 		},
 		ObjectList: &v1alpha1.HTTPChaosList{},
 		Controlls:  []runtime.Object{&v1alpha1.PodHttpChaos{}},
+
+		ConcurrencySafe: true,
 	}
 }
 
@@ -0,0 +1,65 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpchaos
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestProxyPort(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := NewPod(PodArg{Name: "p0"})
+
+	// a portless pod without an explicit port
+	_, err := proxyPort(&pod, 0)
+	g.Expect(err).To(HaveOccurred())
+
+	port, err := proxyPort(&pod, 8080)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(port).To(Equal(int32(8080)))
+
+	pod.Spec.Containers = []v1.Container{
+		{
+			Name: "c0",
+			Ports: []v1.ContainerPort{
+				{ContainerPort: 80},
+				{ContainerPort: 53, Protocol: v1.ProtocolUDP},
+			},
+		},
+	}
+	// the only TCP port is proxied
+	port, err = proxyPort(&pod, 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(port).To(Equal(int32(80)))
+
+	// the port to be proxied is ambiguous
+	pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+		Name: "c1",
+		Ports: []v1.ContainerPort{
+			{ContainerPort: 443, Protocol: v1.ProtocolTCP},
+		},
+	})
+	_, err = proxyPort(&pod, 0)
+	g.Expect(err).To(HaveOccurred())
+
+	port, err = proxyPort(&pod, 443)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(port).To(Equal(int32(443)))
+}
@@ -101,7 +101,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	impl.Log.Info("Try to recover pod", "namespace", pod.Namespace, "name", pod.Name)
 
 	suid := genSUID(&pod, jvmchaos)
-	jsonBytes, err := jvm.ToSandboxAction(suid, jvmchaos)
+	jsonBytes, err := jvm.ToSandboxRecoverAction(suid, jvmchaos)
 	if err != nil {
 		return v1alpha1.Injected, err
 	}
@@ -28,7 +28,7 @@ import (
 type Impl struct {
 	fx.In
 
-	TrafficControl *trafficcontrol.Impl `action:"bandwidth,netem,delay,loss,duplicate,corrupt"`
+	TrafficControl *trafficcontrol.Impl `action:"bandwidth,netem,delay,loss,duplicate,corrupt,shaped-netem,weighted"`
 	Partition      *partition.Impl      `action:"partition"`
 }
 
@@ -40,6 +40,8 @@ func NewImpl(impl Impl) *common.ChaosImplPair {
 		Impl:       &delegate,
 		ObjectList: &v1alpha1.NetworkChaosList{},
 		Controlls:  []runtime.Object{&v1alpha1.PodNetworkChaos{}},
+
+		ConcurrencySafe: true,
 	}
 }
 
@@ -26,6 +26,7 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/podnetworkchaosmanager"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/utils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/iptable"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
@@ -43,6 +44,8 @@ type Impl struct {
 
 	builder *podnetworkchaosmanager.Builder
 
+	status utils.StatusLock
+
 	Log logr.Logger
 }
 
@@ -52,16 +55,13 @@ const (
 )
 
 func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
-	impl.Log.Info("partition Apply", "chaos", obj)
+	impl.Log.Info("partition Apply", "namespace", obj.GetObjectMeta().Namespace, "name", obj.GetObjectMeta().Name)
 	networkchaos, ok := obj.(*v1alpha1.NetworkChaos)
 	if !ok {
 		err := errors.New("chaos is not NetworkChaos")
 		impl.Log.Error(err, "chaos is not NetworkChaos", "chaos", obj)
 		return v1alpha1.NotInjected, err
 	}
-	if networkchaos.Status.Instances == nil {
-		networkchaos.Status.Instances = make(map[string]int64)
-	}
 
 	record := records[index]
 	phase := record.Phase
@@ -87,7 +87,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			return waitForApplySync, errors.New(podnetworkchaos.Status.FailedMessage)
 		}
 
-		if podnetworkchaos.Status.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
+		if podnetworkchaos.Status.ObservedGeneration >= impl.status.Get(&networkchaos.Status.Instances, record.Id) {
 			return v1alpha1.Injected, nil
 		}
 
@@ -170,7 +170,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			}
 
 			// modify the custom status
-			networkchaos.Status.Instances[record.Id] = generationNumber
+			impl.status.Set(&networkchaos.Status.Instances, record.Id, generationNumber)
 			return waitForApplySync, nil
 		}
 
@@ -217,7 +217,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			}
 
 			// modify the custom status
-			networkchaos.Status.Instances[record.Id] = generationNumber
+			impl.status.Set(&networkchaos.Status.Instances, record.Id, generationNumber)
 			return waitForApplySync, nil
 		}
 
@@ -235,9 +235,6 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 		impl.Log.Error(err, "chaos is not NetworkChaos", "chaos", obj)
 		return v1alpha1.Injected, err
 	}
-	if networkchaos.Status.Instances == nil {
-		networkchaos.Status.Instances = make(map[string]int64)
-	}
 
 	record := records[index]
 	phase := record.Phase
@@ -257,7 +254,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 			return waitForRecoverSync, errors.New(podnetworkchaos.Status.FailedMessage)
 		}
 
-		if podnetworkchaos.Status.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
+		if podnetworkchaos.Status.ObservedGeneration >= impl.status.Get(&networkchaos.Status.Instances, record.Id) {
 			return v1alpha1.NotInjected, nil
 		}
 
@@ -294,7 +291,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	}
 
 	// Now modify the custom status and phase
-	networkchaos.Status.Instances[record.Id] = generationNumber
+	impl.status.Set(&networkchaos.Status.Instances, record.Id, generationNumber)
 	return waitForRecoverSync, nil
 }
 
@@ -17,6 +17,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -27,6 +30,7 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/podnetworkchaosmanager"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/utils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
@@ -51,6 +55,8 @@ type Impl struct {
 
 	builder *podnetworkchaosmanager.Builder
 
+	status utils.StatusLock
+
 	Log logr.Logger
 }
 
@@ -59,12 +65,6 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 
 	impl.Log.Info("traffic control Apply", "namespace", obj.GetObjectMeta().Namespace, "name", obj.GetObjectMeta().Name)
 	networkchaos := obj.(*v1alpha1.NetworkChaos)
-	if networkchaos.Status.Instances == nil {
-		networkchaos.Status.Instances = make(map[string]int64)
-	}
-	if networkchaos.Status.Instances == nil {
-		networkchaos.Status.Instances = make(map[string]int64)
-	}
 
 	record := records[index]
 	phase := record.Phase
@@ -90,7 +90,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			return waitForApplySync, errors.New(podnetworkchaos.Status.FailedMessage)
 		}
 
-		if podnetworkchaos.Status.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
+		if podnetworkchaos.Status.ObservedGeneration >= impl.status.Get(&networkchaos.Status.Instances, record.Id) {
 			return v1alpha1.Injected, nil
 		}
 
@@ -111,15 +111,13 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	})
 
 	if record.SelectorKey == "." {
-		if networkchaos.Spec.Direction == v1alpha1.To || networkchaos.Spec.Direction == v1alpha1.Both {
-			var targets []*v1alpha1.Record
-			for _, record := range records {
-				if record.SelectorKey == ".Target" {
-					targets = append(targets, record)
-				}
-			}
+		if (networkchaos.Spec.Direction == v1alpha1.To || networkchaos.Spec.Direction == v1alpha1.Both) &&
+			!selectedBy(records, record.Id, ".Target") {
+			targets := peerRecords(records, ".Target", ".")
 
-			err := impl.ApplyTc(ctx, m, targets, networkchaos, targetIPSetPostFix)
+			// the cycle is counted only after it's committed, so that the retries of a cycle pick the same action
+			cycle := impl.status.Get(&networkchaos.Status.Cycles, record.Id) + 1
+			err := impl.ApplyTc(ctx, m, targets, networkchaos, targetIPSetPostFix, cycle)
 			if err != nil {
 				return v1alpha1.NotInjected, err
 			}
@@ -130,21 +128,22 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			}
 
 			// modify the custom status
-			networkchaos.Status.Instances[record.Id] = generationNumber
+			impl.status.Set(&networkchaos.Status.Instances, record.Id, generationNumber)
+			if networkchaos.Spec.Action == v1alpha1.WeightedAction {
+				impl.status.Set(&networkchaos.Status.Cycles, record.Id, cycle)
+			}
 			return waitForApplySync, nil
 		}
 
 		return v1alpha1.Injected, nil
 	} else if record.SelectorKey == ".Target" {
-		if networkchaos.Spec.Direction == v1alpha1.From || networkchaos.Spec.Direction == v1alpha1.Both {
-			var targets []*v1alpha1.Record
-			for _, record := range records {
-				if record.SelectorKey == "." {
-					targets = append(targets, record)
-				}
-			}
+		if (networkchaos.Spec.Direction == v1alpha1.From || networkchaos.Spec.Direction == v1alpha1.Both) &&
+			!selectedBy(records, record.Id, ".") {
+			targets := peerRecords(records, ".", ".Target")
 
-			err := impl.ApplyTc(ctx, m, targets, networkchaos, sourceIPSetPostFix)
+			// the cycle is counted only after it's committed, so that the retries of a cycle pick the same action
+			cycle := impl.status.Get(&networkchaos.Status.Cycles, record.Id) + 1
+			err := impl.ApplyTc(ctx, m, targets, networkchaos, sourceIPSetPostFix, cycle)
 			if err != nil {
 				return v1alpha1.NotInjected, err
 			}
@@ -155,7 +154,10 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			}
 
 			// modify the custom status
-			networkchaos.Status.Instances[record.Id] = generationNumber
+			impl.status.Set(&networkchaos.Status.Instances, record.Id, generationNumber)
+			if networkchaos.Spec.Action == v1alpha1.WeightedAction {
+				impl.status.Set(&networkchaos.Status.Cycles, record.Id, cycle)
+			}
 			return waitForApplySync, nil
 		}
 
@@ -170,12 +172,6 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	// The only possible phase to get in here is "Injected" or "Injected/Wait"
 
 	networkchaos := obj.(*v1alpha1.NetworkChaos)
-	if networkchaos.Status.Instances == nil {
-		networkchaos.Status.Instances = make(map[string]int64)
-	}
-	if networkchaos.Status.Instances == nil {
-		networkchaos.Status.Instances = make(map[string]int64)
-	}
 
 	record := records[index]
 	phase := record.Phase
@@ -195,7 +191,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 			return waitForRecoverSync, errors.New(podnetworkchaos.Status.FailedMessage)
 		}
 
-		if podnetworkchaos.Status.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
+		if podnetworkchaos.Status.ObservedGeneration >= impl.status.Get(&networkchaos.Status.Instances, record.Id) {
 			return v1alpha1.NotInjected, nil
 		}
 
@@ -233,20 +229,61 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	}
 
 	// Now modify the custom status and phase
-	networkchaos.Status.Instances[record.Id] = generationNumber
+	impl.status.Set(&networkchaos.Status.Instances, record.Id, generationNumber)
 	return waitForRecoverSync, nil
 }
 
-func (impl *Impl) ApplyTc(ctx context.Context, m *podnetworkchaosmanager.PodNetworkManager, targets []*v1alpha1.Record, networkchaos *v1alpha1.NetworkChaos, ipSetPostFix string) error {
+// selectedBy returns whether the pod is selected by the selector key
+func selectedBy(records []*v1alpha1.Record, id string, selectorKey string) bool {
+	for _, record := range records {
+		if record.SelectorKey == selectorKey && record.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// peerRecords returns the records selected by the peer selector key, except the ones which are also selected by
+// the own selector key. The pods in both groups are neither the sources nor the targets of the traffic control,
+// so that only the traffic between the two groups is affected, and the traffic within each group is untouched.
+func peerRecords(records []*v1alpha1.Record, peerKey string, ownKey string) []*v1alpha1.Record {
+	var peers []*v1alpha1.Record
+	for _, record := range records {
+		if record.SelectorKey == peerKey && !selectedBy(records, record.Id, ownKey) {
+			peers = append(peers, record)
+		}
+	}
+	return peers
+}
+
+func (impl *Impl) ApplyTc(ctx context.Context, m *podnetworkchaosmanager.PodNetworkManager, targets []*v1alpha1.Record, networkchaos *v1alpha1.NetworkChaos, ipSetPostFix string, cycle int64) error {
 	spec := networkchaos.Spec
+	action, tcParameter := spec.Action, spec.TcParameter
+	if spec.Action == v1alpha1.WeightedAction {
+		picked, err := pickWeightedAction(spec.WeightedActions, fmt.Sprintf("%s/%d", networkchaos.UID, cycle))
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		tcParameter, ok = spec.TcParameter.ParameterOf(picked)
+		if !ok {
+			return fmt.Errorf("%s is required by the weighted action which picks it", picked)
+		}
+		action = picked
+		impl.Log.Info("pick the weighted action", "sources", m.Source, "cycle", cycle, "action", action)
+	}
+
 	tcType := v1alpha1.Bandwidth
-	switch spec.Action {
+	switch action {
 	case v1alpha1.NetemAction, v1alpha1.DelayAction, v1alpha1.DuplicateAction, v1alpha1.CorruptAction, v1alpha1.LossAction:
 		tcType = v1alpha1.Netem
 	case v1alpha1.BandwidthAction:
 		tcType = v1alpha1.Bandwidth
+	case v1alpha1.ShapedNetemAction:
+		tcType = v1alpha1.ShapedNetem
 	default:
-		return fmt.Errorf("unknown action %s", spec.Action)
+		return fmt.Errorf("unknown action %s", action)
 	}
 
 	externalCidrs, err := netutils.ResolveCidrs(networkchaos.Spec.ExternalTargets)
@@ -255,10 +292,18 @@ func (impl *Impl) ApplyTc(ctx context.Context, m *podnetworkchaosmanager.PodNetw
 	}
 
 	if len(targets)+len(externalCidrs) == 0 {
+		if spec.Target != nil {
+			// there is no traffic between the two groups to be controlled
+			impl.Log.Info("no peer to apply traffic control", "sources", m.Source)
+			return nil
+		}
+
 		impl.Log.Info("apply traffic control", "sources", m.Source)
 		m.T.Append(v1alpha1.RawTrafficControl{
 			Type:        tcType,
-			TcParameter: spec.TcParameter,
+			TcParameter: tcParameter,
+			Protocol:    spec.Protocol,
+			EgressPort:  egressPort(spec),
 			Source:      m.Source,
 		})
 		return nil
@@ -280,7 +325,9 @@ func (impl *Impl) ApplyTc(ctx context.Context, m *podnetworkchaosmanager.PodNetw
 	m.T.Append(dstIpset)
 	m.T.Append(v1alpha1.RawTrafficControl{
 		Type:        tcType,
-		TcParameter: spec.TcParameter,
+		TcParameter: tcParameter,
+		Protocol:    spec.Protocol,
+		EgressPort:  egressPort(spec),
 		Source:      m.Source,
 		IPSet:       dstIpset.Name,
 	})
@@ -288,6 +335,44 @@ func (impl *Impl) ApplyTc(ctx context.Context, m *podnetworkchaosmanager.PodNetw
 	return nil
 }
 
+// pickWeightedAction picks one of the weighted actions, the chance of an action being picked is proportional
+// to its weight. The pick is determined by the seed, so that it's reproducible.
+func pickWeightedAction(actions []v1alpha1.WeightedNetworkAction, seed string) (v1alpha1.NetworkChaosAction, error) {
+	var sum int64
+	for _, action := range actions {
+		if action.Weight > 0 {
+			sum += int64(action.Weight)
+		}
+	}
+	if sum <= 0 {
+		return "", errors.New("the sum of the weights should be positive")
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(seed))
+	n := rand.New(rand.NewSource(int64(hash.Sum64()))).Int63n(sum)
+	for _, action := range actions {
+		if action.Weight <= 0 {
+			continue
+		}
+		if n < int64(action.Weight) {
+			return action.Action, nil
+		}
+		n -= int64(action.Weight)
+	}
+
+	// unreachable, n is always less than the sum of the weights
+	return "", errors.New("failed to pick the weighted action")
+}
+
+// egressPort returns the destination port of the packets to be controlled, empty means all the ports
+func egressPort(spec v1alpha1.NetworkChaosSpec) string {
+	if spec.Port == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(spec.Port))
+}
+
 func NewImpl(c client.Client, b *podnetworkchaosmanager.Builder, log logr.Logger) *Impl {
 	return &Impl{
 		Client:  c,
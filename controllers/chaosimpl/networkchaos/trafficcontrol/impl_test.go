@@ -0,0 +1,212 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficcontrol
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/podnetworkchaosmanager"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestApplyDelayBetweenGroups(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// a0 and a1 are in datacenter A, b0 is in datacenter B, and ab is selected by both groups
+	ips := map[string]string{
+		"a0": "10.0.0.1",
+		"a1": "10.0.0.2",
+		"ab": "10.0.0.3",
+		"b0": "10.0.1.1",
+	}
+	objs := []runtime.Object{}
+	for name, ip := range ips {
+		pod := NewPod(PodArg{Name: name})
+		pod.Status.PodIP = ip
+		objs = append(objs, &pod)
+	}
+	newRecords := func() []*v1alpha1.Record {
+		return []*v1alpha1.Record{
+			{Id: "default/a0", SelectorKey: ".", Phase: v1alpha1.NotInjected},
+			{Id: "default/a1", SelectorKey: ".", Phase: v1alpha1.NotInjected},
+			{Id: "default/ab", SelectorKey: ".", Phase: v1alpha1.NotInjected},
+			{Id: "default/b0", SelectorKey: ".Target", Phase: v1alpha1.NotInjected},
+			{Id: "default/ab", SelectorKey: ".Target", Phase: v1alpha1.NotInjected},
+		}
+	}
+
+	applyAll := func(direction v1alpha1.Direction) map[string]*v1alpha1.PodNetworkChaos {
+		c := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+		log := zap.New(zap.UseDevMode(true))
+		impl := NewImpl(c, podnetworkchaosmanager.NewBuilder(podnetworkchaosmanager.Params{
+			Logger: log,
+			Client: c,
+			Reader: c,
+			Scheme: provider.NewScheme(),
+		}), log)
+
+		chaos := &v1alpha1.NetworkChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "slow-link"},
+			Spec: v1alpha1.NetworkChaosSpec{
+				Action:    v1alpha1.DelayAction,
+				Direction: direction,
+				Target:    &v1alpha1.PodSelector{Mode: v1alpha1.AllPodMode},
+				TcParameter: v1alpha1.TcParameter{
+					Delay: &v1alpha1.DelaySpec{Latency: "100ms"},
+				},
+			},
+		}
+		records := newRecords()
+		for index := range records {
+			_, err := impl.Apply(context.TODO(), index, records, chaos)
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+
+		podNetworkChaos := make(map[string]*v1alpha1.PodNetworkChaos)
+		for name := range ips {
+			pnc := &v1alpha1.PodNetworkChaos{}
+			err := c.Get(context.TODO(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: name}, pnc)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			podNetworkChaos[name] = pnc
+		}
+		return podNetworkChaos
+	}
+
+	// only the traffic from A to B is delayed
+	pncs := applyAll(v1alpha1.To)
+	g.Expect(pncs).To(HaveLen(2))
+	g.Expect(pncs).To(HaveKey("a0"))
+	g.Expect(pncs).To(HaveKey("a1"))
+	for _, pnc := range pncs {
+		g.Expect(pnc.Spec.IPSets).To(HaveLen(1))
+		g.Expect(pnc.Spec.IPSets[0].Cidrs).To(ConsistOf("10.0.1.1/32"))
+		g.Expect(pnc.Spec.TrafficControls).To(HaveLen(1))
+		g.Expect(pnc.Spec.TrafficControls[0].IPSet).To(Equal(pnc.Spec.IPSets[0].Name))
+		g.Expect(pnc.Spec.TrafficControls[0].Delay.Latency).To(Equal("100ms"))
+	}
+
+	// the traffic from B to A is delayed as well, while the traffic within each group is still untouched
+	pncs = applyAll(v1alpha1.Both)
+	g.Expect(pncs).To(HaveLen(3))
+	g.Expect(pncs["a0"].Spec.IPSets[0].Cidrs).To(ConsistOf("10.0.1.1/32"))
+	g.Expect(pncs["b0"].Spec.IPSets).To(HaveLen(1))
+	g.Expect(pncs["b0"].Spec.IPSets[0].Cidrs).To(ConsistOf("10.0.0.1/32", "10.0.0.2/32"))
+	g.Expect(pncs["b0"].Spec.TrafficControls[0].IPSet).To(Equal(pncs["b0"].Spec.IPSets[0].Name))
+}
+
+func TestPickWeightedAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	actions := []v1alpha1.WeightedNetworkAction{
+		{Action: v1alpha1.DelayAction, Weight: 5},
+		{Action: v1alpha1.LossAction, Weight: 3},
+		{Action: v1alpha1.DuplicateAction, Weight: 0},
+		{Action: v1alpha1.CorruptAction, Weight: 2},
+	}
+
+	// the distribution of the picked actions approximates the weights over many cycles
+	const cycles = 10000
+	picked := make(map[v1alpha1.NetworkChaosAction]int)
+	for cycle := 1; cycle <= cycles; cycle++ {
+		action, err := pickWeightedAction(actions, fmt.Sprintf("uid/%d", cycle))
+		g.Expect(err).ToNot(HaveOccurred())
+		picked[action]++
+	}
+	g.Expect(picked).ToNot(HaveKey(v1alpha1.DuplicateAction))
+	g.Expect(float64(picked[v1alpha1.DelayAction]) / cycles).To(BeNumerically("~", 0.5, 0.02))
+	g.Expect(float64(picked[v1alpha1.LossAction]) / cycles).To(BeNumerically("~", 0.3, 0.02))
+	g.Expect(float64(picked[v1alpha1.CorruptAction]) / cycles).To(BeNumerically("~", 0.2, 0.02))
+
+	// the pick is reproducible with the same seed
+	for cycle := 1; cycle <= 10; cycle++ {
+		first, err := pickWeightedAction(actions, fmt.Sprintf("uid/%d", cycle))
+		g.Expect(err).ToNot(HaveOccurred())
+		second, err := pickWeightedAction(actions, fmt.Sprintf("uid/%d", cycle))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(second).To(Equal(first))
+	}
+
+	_, err := pickWeightedAction([]v1alpha1.WeightedNetworkAction{{Action: v1alpha1.DelayAction}}, "uid/1")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestApplyWeightedAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := NewPod(PodArg{Name: "p0"})
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), &pod)
+	log := zap.New(zap.UseDevMode(true))
+	impl := NewImpl(c, podnetworkchaosmanager.NewBuilder(podnetworkchaosmanager.Params{
+		Logger: log,
+		Client: c,
+		Reader: c,
+		Scheme: provider.NewScheme(),
+	}), log)
+
+	chaos := &v1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "fuzz", UID: "fuzz-uid"},
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.WeightedAction,
+			WeightedActions: []v1alpha1.WeightedNetworkAction{
+				{Action: v1alpha1.DelayAction, Weight: 1},
+				{Action: v1alpha1.LossAction, Weight: 1},
+			},
+			Direction: v1alpha1.To,
+			TcParameter: v1alpha1.TcParameter{
+				Delay: &v1alpha1.DelaySpec{Latency: "100ms"},
+				Loss:  &v1alpha1.LossSpec{Loss: "10"},
+			},
+		},
+	}
+	records := []*v1alpha1.Record{{Id: "default/p0", SelectorKey: ".", Phase: v1alpha1.NotInjected}}
+
+	for cycle := int64(1); cycle <= 3; cycle++ {
+		phase, err := impl.Apply(context.TODO(), 0, records, chaos)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(phase).To(Equal(waitForApplySync))
+		g.Expect(chaos.Status.Cycles).To(HaveKeyWithValue("default/p0", cycle))
+
+		expected, err := pickWeightedAction(chaos.Spec.WeightedActions, fmt.Sprintf("fuzz-uid/%d", cycle))
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// only the emulation of the picked action is applied
+		pnc := &v1alpha1.PodNetworkChaos{}
+		g.Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "p0"}, pnc)).To(Succeed())
+		g.Expect(pnc.Spec.TrafficControls).To(HaveLen(1))
+		tc := pnc.Spec.TrafficControls[0]
+		g.Expect(tc.Type).To(Equal(v1alpha1.Netem))
+		if expected == v1alpha1.DelayAction {
+			g.Expect(tc.Delay).ToNot(BeNil())
+			g.Expect(tc.Loss).To(BeNil())
+		} else {
+			g.Expect(tc.Delay).To(BeNil())
+			g.Expect(tc.Loss).ToNot(BeNil())
+		}
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
@@ -50,7 +51,9 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		ContainerId: containerId,
 	}); err != nil {
 		impl.Log.Error(err, "kill container error", "containerID", containerId)
-		return v1alpha1.NotInjected, err
+		// only the containers which are failed to be killed stay not injected, and
+		// they are killed again on the next reconcile
+		return v1alpha1.NotInjected, errors.Wrapf(err, "kill container %s", containerId)
 	}
 
 	return v1alpha1.Injected, nil
@@ -0,0 +1,127 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerkill
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/utils"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	. "github.com/chaos-mesh/chaos-mesh/controllers/test"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestKillContainersPartially(t *testing.T) {
+	defer mock.With("MockChaosDaemonClient", &MockChaosDaemonClient{})()
+	if mock.On("MockChaosDaemonClient") == nil {
+		t.Skip("failpoints are not enabled, run it with `make test`")
+	}
+	g := NewGomegaWithT(t)
+
+	objs := []runtime.Object{}
+	records := []*v1alpha1.Record{}
+	for _, name := range []string{"p0", "p1"} {
+		pod := NewPod(PodArg{
+			Name: name,
+			ContainerStatus: v1.ContainerStatus{
+				Name:        "c0",
+				ContainerID: "docker://" + name,
+			},
+		})
+		objs = append(objs, &pod)
+		records = append(records, &v1alpha1.Record{
+			Id:    "default/" + name + "/c0",
+			Phase: v1alpha1.NotInjected,
+		})
+	}
+	objs = append(objs, &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "container-kill",
+		},
+		Spec: v1alpha1.PodChaosSpec{
+			Action: v1alpha1.ContainerKillAction,
+		},
+		Status: v1alpha1.PodChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records:      records,
+				},
+			},
+		},
+	})
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+
+	log := zap.New(zap.UseDevMode(true))
+	debugRecorder := recorder.NewDebugRecorder()
+	r := &common.Reconciler{
+		Impl:     NewImpl(c, log, utils.NewContainerRecordDecoder(c, &chaosdaemon.ChaosDaemonClientBuilder{Reader: c})),
+		Object:   &v1alpha1.PodChaos{},
+		Client:   c,
+		Reader:   c,
+		Recorder: debugRecorder,
+		Log:      log,
+	}
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "container-kill"}
+	reconcile := func() []*v1alpha1.Record {
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		chaos := &v1alpha1.PodChaos{}
+		g.Expect(c.Get(context.Background(), key, chaos)).To(Succeed())
+		return chaos.Status.Experiment.Records
+	}
+
+	// the kill of the container in p1 fails, while the one in p0 is killed
+	killErr := errors.New("container not found")
+	resetErrors := mock.With("MockContainerKillErrors", map[string]error{
+		"docker://p1": killErr,
+	})
+	records = reconcile()
+	g.Expect(records[0].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(records[0].Message).To(BeEmpty())
+	g.Expect(records[1].Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(records[1].Message).To(Equal("kill container docker://p1: container not found"))
+	g.Expect(debugRecorder.Events[key]).To(ContainElements(
+		recorder.Applied{Id: "default/p0/c0"},
+		recorder.RecordFailed{Id: "default/p1/c0", Activity: "apply chaos", Err: records[1].Message},
+	))
+	g.Expect(resetErrors()).To(Succeed())
+
+	// only the container which is failed to be killed is killed again
+	debugRecorder.Events[key] = nil
+	records = reconcile()
+	g.Expect(records[0].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(records[1].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(records[1].Message).To(BeEmpty())
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.Applied{Id: "default/p1/c0"}))
+	g.Expect(debugRecorder.Events[key]).ToNot(ContainElement(recorder.Applied{Id: "default/p0/c0"}))
+}
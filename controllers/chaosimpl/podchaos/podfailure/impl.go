@@ -44,6 +44,11 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		// TODO: handle this error
 		return v1alpha1.NotInjected, err
 	}
+	failureImage := config.ControllerCfg.PodFailurePauseImage
+	if podchaos.Spec.FailureImage != nil {
+		failureImage = *podchaos.Spec.FailureImage
+	}
+
 	pod := origin.DeepCopy()
 	for index := range pod.Spec.Containers {
 		originImage := pod.Spec.Containers[index].Image
@@ -59,7 +64,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			continue
 		}
 		pod.Annotations[key] = originImage
-		pod.Spec.Containers[index].Image = config.ControllerCfg.PodFailurePauseImage
+		pod.Spec.Containers[index].Image = failureImage
 	}
 
 	for index := range pod.Spec.InitContainers {
@@ -76,7 +81,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			continue
 		}
 		pod.Annotations[key] = originImage
-		pod.Spec.InitContainers[index].Image = config.ControllerCfg.PodFailurePauseImage
+		pod.Spec.InitContainers[index].Image = failureImage
 	}
 
 	err = impl.Patch(ctx, pod, client.MergeFrom(&origin))
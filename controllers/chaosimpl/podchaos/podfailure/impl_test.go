@@ -0,0 +1,80 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podfailure
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/config"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestReplaceImagesWithFailureImage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := NewPod(PodArg{Name: "p0"})
+	pod.Spec.Containers = []v1.Container{{Name: "c0", Image: "nginx:1.19"}}
+	pod.Spec.InitContainers = []v1.Container{{Name: "i0", Image: "busybox:1.32"}}
+	c := fake.NewFakeClient(&pod)
+
+	failureImage := "busybox:not-exist"
+	chaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "pod-failure"},
+		Spec: v1alpha1.PodChaosSpec{
+			Action:       v1alpha1.PodFailureAction,
+			FailureImage: &failureImage,
+		},
+	}
+	records := []*v1alpha1.Record{{Id: "default/p0", Phase: v1alpha1.NotInjected}}
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "p0"}
+	getPod := func() v1.Pod {
+		var pod v1.Pod
+		g.Expect(c.Get(context.Background(), key, &pod)).To(Succeed())
+		return pod
+	}
+
+	phase, err := NewImpl(c).Apply(context.Background(), 0, records, chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(phase).To(Equal(v1alpha1.Injected))
+	injected := getPod()
+	g.Expect(injected.Spec.Containers[0].Image).To(Equal(failureImage))
+	g.Expect(injected.Spec.InitContainers[0].Image).To(Equal(failureImage))
+
+	// the original images are stashed in the pod, so applying it again after restarting the controller keeps them
+	_, err = NewImpl(c).Apply(context.Background(), 0, records, chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(getPod().Annotations).To(Equal(injected.Annotations))
+
+	phase, err = NewImpl(c).Recover(context.Background(), 0, records, chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(phase).To(Equal(v1alpha1.NotInjected))
+	recovered := getPod()
+	g.Expect(recovered.Spec.Containers[0].Image).To(Equal("nginx:1.19"))
+	g.Expect(recovered.Spec.InitContainers[0].Image).To(Equal("busybox:1.32"))
+	g.Expect(recovered.Annotations).To(BeEmpty())
+
+	// the pause image configured in the controller is used by default
+	chaos.Spec.FailureImage = nil
+	_, err = NewImpl(c).Apply(context.Background(), 0, records, chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(getPod().Spec.Containers[0].Image).To(Equal(config.ControllerCfg.PodFailurePauseImage))
+}
@@ -15,10 +15,13 @@ package stresschaos
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/go-logr/logr"
 	"go.uber.org/fx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -67,10 +70,11 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		}
 	}
 	res, err := pbClient.ExecStressors(ctx, &pb.ExecStressRequest{
-		Scope:     pb.ExecStressRequest_CONTAINER,
-		Target:    containerId,
-		Stressors: stressors,
-		EnterNS:   true,
+		Scope:       pb.ExecStressRequest_CONTAINER,
+		Target:      containerId,
+		Stressors:   stressors,
+		EnterNS:     true,
+		ProcessName: stresschaos.Spec.ProcessName,
 	})
 	if err != nil {
 		return v1alpha1.NotInjected, err
@@ -121,6 +125,47 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	return v1alpha1.NotInjected, nil
 }
 
+// Check reports the record as not injected with the reason, if the stress-ng has exited
+// unsuccessfully after being applied, so that it will be applied again.
+func (impl *Impl) Check(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	stresschaos := obj.(*v1alpha1.StressChaos)
+	instance, ok := stresschaos.Status.Instances[records[index].Id]
+	if !ok {
+		return v1alpha1.Injected, nil
+	}
+
+	decodedContainer, err := impl.decoder.DecodeContainerRecord(ctx, records[index])
+	pbClient := decodedContainer.PbClient
+	if pbClient != nil {
+		defer pbClient.Close()
+	}
+	if err != nil {
+		if utils.IsFailToGet(err) {
+			// the disappeared container will be recovered
+			return v1alpha1.Injected, nil
+		}
+		return v1alpha1.Injected, err
+	}
+
+	res, err := pbClient.GetStressorsStatus(ctx, &pb.StressorsStatusRequest{
+		Instance:  instance.UID,
+		StartTime: instance.StartTime.UnixNano() / int64(time.Millisecond),
+	})
+	if status.Code(err) == codes.Unimplemented {
+		// the chaos daemon is too old to report the status
+		return v1alpha1.Injected, nil
+	}
+	if err != nil {
+		return v1alpha1.Injected, err
+	}
+	if !res.Exited || len(res.Message) == 0 {
+		return v1alpha1.Injected, nil
+	}
+
+	delete(stresschaos.Status.Instances, records[index].Id)
+	return v1alpha1.NotInjected, errors.New(res.Message)
+}
+
 func NewImpl(c client.Client, log logr.Logger, decoder *utils.ContianerRecordDecoder) *common.ChaosImplPair {
 	return &common.ChaosImplPair{
 		Name:   "stresschaos",
@@ -0,0 +1,143 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stresschaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/utils"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	. "github.com/chaos-mesh/chaos-mesh/controllers/test"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func newReconciler(c client.Client) *common.Reconciler {
+	log := zap.New(zap.UseDevMode(true))
+	decoder := utils.NewContainerRecordDecoder(c, &chaosdaemon.ChaosDaemonClientBuilder{Reader: c})
+
+	return &common.Reconciler{
+		Impl:     NewImpl(c, log, decoder).Impl,
+		Object:   &v1alpha1.StressChaos{},
+		Client:   c,
+		Reader:   c,
+		Recorder: recorder.NewDebugRecorder(),
+		Log:      log,
+	}
+}
+
+func reconcileRecord(g *GomegaWithT, c client.Client) (v1alpha1.Record, *v1alpha1.StressChaos) {
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "stress"}
+
+	_, err := newReconciler(c).Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	chaos := &v1alpha1.StressChaos{}
+	g.Expect(c.Get(context.Background(), key, chaos)).To(Succeed())
+	g.Expect(chaos.Status.Experiment.Records).To(HaveLen(1))
+	return *chaos.Status.Experiment.Records[0], chaos
+}
+
+func TestReconcileFailedStressors(t *testing.T) {
+	defer mock.With("MockChaosDaemonClient", &MockChaosDaemonClient{})()
+	if mock.On("MockChaosDaemonClient") == nil {
+		t.Skip("failpoints are not enabled, run it with `make test`")
+	}
+	g := NewGomegaWithT(t)
+
+	pod := NewPod(PodArg{
+		Name: "p0",
+		ContainerStatus: v1.ContainerStatus{
+			Name:        "c0",
+			ContainerID: "docker://c0",
+		},
+	})
+	chaos := &v1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "stress",
+		},
+		Spec: v1alpha1.StressChaosSpec{
+			StressngStressors: "--cpu 1",
+		},
+		Status: v1alpha1.StressChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{
+							Id:    "default/p0/c0",
+							Phase: v1alpha1.NotInjected,
+						},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), &pod, chaos)
+
+	// the stress-ng which exits on startup fails the record with its stderr
+	execErr := errors.New("stress-ng exit status 2: unrecognized option '--foo'")
+	resetExec := mock.With("MockExecStressorsError", execErr)
+	record, _ := reconcileRecord(g, c)
+	g.Expect(record.Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(record.Message).To(Equal(execErr.Error()))
+	g.Expect(resetExec()).To(Succeed())
+
+	defer mock.With("MockExecStressorsResponse", &pb.ExecStressResponse{
+		Instance:  "1000",
+		StartTime: 1000,
+	})()
+	record, chaos = reconcileRecord(g, c)
+	g.Expect(record.Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(record.Message).To(BeEmpty())
+	g.Expect(chaos.Status.Instances).To(HaveKey("default/p0/c0"))
+
+	// the running stressors keep the record injected
+	record, _ = reconcileRecord(g, c)
+	g.Expect(record.Phase).To(Equal(v1alpha1.Injected))
+
+	// the stress-ng which exits later fails the record, and the instance is forgotten
+	exitMessage := "stress-ng exit status 1: stress-ng: error: [1] cannot allocate memory"
+	resetStatus := mock.With("MockGetStressorsStatusResponse", &pb.StressorsStatusResponse{
+		Exited:  true,
+		Message: exitMessage,
+	})
+	record, chaos = reconcileRecord(g, c)
+	g.Expect(record.Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(record.Message).To(Equal(exitMessage))
+	g.Expect(chaos.Status.Instances).ToNot(HaveKey("default/p0/c0"))
+	g.Expect(resetStatus()).To(Succeed())
+
+	// and it is applied again
+	record, _ = reconcileRecord(g, c)
+	g.Expect(record.Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(record.Message).To(BeEmpty())
+}
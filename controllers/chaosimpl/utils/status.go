@@ -0,0 +1,41 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "sync"
+
+// StatusLock guards the maps in the custom status of a chaos, e.g. the generations of the instances committed for
+// the records, which may be read and written concurrently as the records are applied and recovered concurrently
+type StatusLock struct {
+	lock sync.Mutex
+}
+
+// Get returns the value kept for the record in the map
+func (l *StatusLock) Get(m *map[string]int64, id string) int64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return (*m)[id]
+}
+
+// Set keeps the value for the record in the map, the map is created if it's nil
+func (l *StatusLock) Set(m *map[string]int64, id string, value int64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if *m == nil {
+		*m = make(map[string]int64)
+	}
+	(*m)[id] = value
+}
@@ -15,18 +15,30 @@ package common
 
 import (
 	"context"
+	"errors"
+	"hash/fnv"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/metrics"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/container"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
 )
 
 type InnerObjectWithCustomStatus interface {
@@ -46,6 +58,28 @@ type ChaosImpl interface {
 	Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error)
 }
 
+// ChaosImplChecker is implemented by the ChaosImpl whose injection could fail after being
+// applied, e.g. the background process exits. The injected records are checked periodically
+// while the chaos is running, and the failed ones are applied again.
+type ChaosImplChecker interface {
+	Check(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error)
+}
+
+// checkInterval is the interval to check the injected records with ChaosImplChecker
+const checkInterval = 10 * time.Second
+
+// minMatchesInterval is the interval to select the pods again if fewer pods than the minimum matches are selected
+const minMatchesInterval = 30 * time.Second
+
+// DaemonHealthChecker checks whether the chaos daemon on the node of the pod is reachable
+type DaemonHealthChecker interface {
+	CheckHealth(ctx context.Context, pod *corev1.Pod) error
+}
+
+// quarantineInterval is the interval to retry the records quarantined as their chaos daemons are unreachable,
+// the chaos daemon may take a while to be back, so they are not retried as soon as the other failed records
+const quarantineInterval = 30 * time.Second
+
 // Reconciler for common chaos
 type Reconciler struct {
 	Impl ChaosImpl
@@ -61,17 +95,87 @@ type Reconciler struct {
 
 	Selector *selector.Selector
 
+	// Metrics observes the selections, it's optional
+	Metrics *metrics.ChaosCollector
+
+	// HealthChecker checks the chaos daemons serving the failed records, it's optional
+	HealthChecker DaemonHealthChecker
+
+	// MaxConcurrency is how many records are applied and recovered concurrently in a reconcile, the records are
+	// processed one by one if it's not greater than one
+	MaxConcurrency int
+
 	Log logr.Logger
 }
 
+// scopeSelector returns a copy of the pod selector whose omitted namespaces are defaulted to the namespace of
+// the chaos, in case the chaos hasn't been defaulted by the webhook.
+func scopeSelector(chaos metav1.Object, sel interface{}) interface{} {
+	switch s := sel.(type) {
+	case *v1alpha1.PodSelector:
+		if s == nil {
+			return sel
+		}
+		scoped := *s
+		scoped.Selector.DefaultNamespace(chaos)
+		return &scoped
+	case *v1alpha1.ContainerSelector:
+		if s == nil {
+			return sel
+		}
+		scoped := *s
+		scoped.Selector.DefaultNamespace(chaos)
+		return &scoped
+	}
+	return sel
+}
+
+// seedSelector sets the seed of choosing the pods randomly to the scoped selector, which is the seed in the spec if
+// it's set, otherwise it's derived from the UID of the chaos and the selector key, so that the selection could be
+// reproduced. It returns false if the selector doesn't select pods.
+func seedSelector(chaos metav1.Object, key string, sel interface{}) (int64, bool) {
+	var podSelector *v1alpha1.PodSelector
+	switch s := sel.(type) {
+	case *v1alpha1.PodSelector:
+		podSelector = s
+	case *v1alpha1.ContainerSelector:
+		if s != nil {
+			podSelector = &s.PodSelector
+		}
+	}
+	if podSelector == nil {
+		return 0, false
+	}
+
+	if podSelector.Seed == nil {
+		hash := fnv.New64a()
+		hash.Write([]byte(string(chaos.GetUID()) + "/" + key))
+		seed := int64(hash.Sum64())
+		podSelector.Seed = &seed
+	}
+	return *podSelector.Seed, true
+}
+
 type Operation string
 
 const (
 	Apply   Operation = "apply"
 	Recover Operation = "recover"
 	Nothing Operation = ""
+	Check   Operation = "check"
 )
 
+// task is the operation on a record, whose result is set to the record after it's done
+type task struct {
+	index         int
+	operation     Operation
+	originalPhase v1alpha1.Phase
+
+	phase    v1alpha1.Phase
+	err      error
+	duration time.Duration
+}
+
 // Reconcile the common chaos
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	obj := r.Object.DeepCopyObject().(InnerObjectWithSelector)
@@ -90,11 +194,38 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	desiredPhase := obj.GetStatus().Experiment.DesiredPhase
 	records := obj.GetStatus().Experiment.Records
+	selections := obj.GetStatus().Experiment.Selections
 	selectors := obj.GetSelectorSpecs()
 
 	if records == nil {
+		selections = nil
 		for name, sel := range selectors {
-			targets, err := r.Selector.Select(context.TODO(), sel)
+			startTime := time.Now()
+			scoped := scopeSelector(obj.GetObjectMeta(), sel)
+			seed, seeded := seedSelector(obj.GetObjectMeta(), name, scoped)
+			targets, err := r.Selector.Select(context.TODO(), scoped)
+			r.observeSelection(obj, len(targets), time.Since(startTime))
+			var notFound *container.ContainerNotFoundError
+			if errors.As(err, &notFound) {
+				// the pods without the named containers are skipped, and the others are still injected
+				for _, pod := range notFound.Pods {
+					r.Recorder.Event(obj, recorder.ContainerNotFound{
+						Pod:            pod,
+						ContainerNames: notFound.ContainerNames,
+					})
+				}
+				err = nil
+			}
+			var minMatchesNotMet *pod.MinMatchesNotMetError
+			if errors.As(err, &minMatchesNotMet) {
+				r.Log.Info("too few pods are selected", "matched", minMatchesNotMet.Matched, "required", minMatchesNotMet.Required)
+				r.Recorder.Event(obj, recorder.MinMatchesNotMet{
+					Matched:  minMatchesNotMet.Matched,
+					Required: minMatchesNotMet.Required,
+				})
+				// the pods are not watched, so the selection is retried later in case more pods are created
+				return ctrl.Result{RequeueAfter: minMatchesInterval}, nil
+			}
 			if err != nil {
 				r.Log.Error(err, "fail to select")
 				r.Recorder.Event(obj, recorder.Failed{
@@ -104,15 +235,27 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 				return ctrl.Result{}, nil
 			}
 
+			selection := v1alpha1.Selection{
+				SelectorKey: name,
+				Seed:        seed,
+			}
 			for _, target := range targets {
 				records = append(records, &v1alpha1.Record{
 					Id:          target.Id(),
 					SelectorKey: name,
 					Phase:       v1alpha1.NotInjected,
 				})
+				selection.Targets = append(selection.Targets, target.Id())
 				shouldUpdate = true
 			}
+			if seeded {
+				selections = append(selections, selection)
+			}
 		}
+		// the selectors are iterated in a random order
+		sort.Slice(selections, func(i, j int) bool {
+			return selections[i].SelectorKey < selections[j].SelectorKey
+		})
 		// TODO: dynamic upgrade the records when some of these pods/containers stopped
 	}
 
@@ -126,8 +269,125 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 
 	needRetry := false
-	for index, record := range records {
-		var err error
+	needCheck := false
+	quarantined := false
+	reverseRecover := desiredPhase == v1alpha1.StoppedPhase &&
+		obj.GetObjectMeta().GetAnnotations()[v1alpha1.RecoverOrderAnnotationKey] == "reverse"
+
+	// handle updates the record with the result of its task. It returns false if the records after it shouldn't
+	// be processed in this reconcile.
+	handle := func(t *task) bool {
+		record := records[t.index]
+		err := t.err
+		record.Phase = t.phase
+		if record.Phase != t.originalPhase {
+			shouldUpdate = true
+		}
+		if updateRecordMessage(record, err) {
+			shouldUpdate = true
+		}
+
+		switch t.operation {
+		case Apply:
+			if err == nil && record.Phase == v1alpha1.Injected && desiredPhase == v1alpha1.RunningPhase {
+				r.observeInjection(obj, t.duration)
+			}
+			if err != nil {
+				// the chaos is requeued through the rate limiter of the queue, so the retries are backed off
+				// exponentially and don't block the other chaos
+				r.Log.Error(err, "fail to apply chaos")
+				r.observeFailure(obj, Apply, err)
+				r.Recorder.Event(obj, recorder.RecordFailed{
+					Id:       record.Id,
+					Activity: "apply chaos",
+					Err:      err.Error(),
+				})
+				// the malformed request reported by chaos daemon will fail again on retry.
+				// The recovery is always retried, because the injected chaos must be cleaned up.
+				if !errcode.Retryable(err) {
+					r.Log.Info("skip retrying to apply chaos", "id", record.Id)
+					return true
+				}
+				if r.daemonUnreachable(context.TODO(), record, err) {
+					if r.quarantine(obj, record) {
+						shouldUpdate = true
+					}
+					quarantined = true
+					return true
+				}
+				needRetry = true
+				return true
+			}
+			if r.release(obj, record) {
+				shouldUpdate = true
+			}
+
+			if record.Phase == v1alpha1.Injected {
+				record.ApplySequence = nextApplySequence(records)
+				if record.InjectedAt == nil {
+					injectedAt := metav1.Now()
+					record.InjectedAt = &injectedAt
+				}
+				r.Recorder.Event(obj, recorder.Applied{
+					Id: record.Id,
+				})
+			}
+		case Recover:
+			if err != nil {
+				r.Log.Error(err, "fail to recover chaos")
+				r.observeFailure(obj, Recover, err)
+				r.observeRecovery(obj, false)
+				r.Recorder.Event(obj, recorder.RecordFailed{
+					Id:       record.Id,
+					Activity: "recover chaos",
+					Err:      err.Error(),
+				})
+				if r.daemonUnreachable(context.TODO(), record, err) {
+					if r.quarantine(obj, record) {
+						shouldUpdate = true
+					}
+					quarantined = true
+				} else {
+					needRetry = true
+				}
+				// the records injected earlier wait for this one to be recovered
+				return !reverseRecover
+			}
+			if r.release(obj, record) {
+				shouldUpdate = true
+			}
+
+			if record.Phase == v1alpha1.NotInjected {
+				record.ApplySequence = 0
+				r.observeRecovery(obj, true)
+				r.Recorder.Event(obj, recorder.Recovered{
+					Id: record.Id,
+				})
+			}
+		case Check:
+			if err != nil {
+				r.Log.Error(err, "chaos is not injected any more")
+				r.Recorder.Event(obj, recorder.RecordFailed{
+					Id:       record.Id,
+					Activity: "check chaos",
+					Err:      err.Error(),
+				})
+				needRetry = true
+				return true
+			}
+
+			needCheck = true
+		}
+		return true
+	}
+
+	expired := obj.IsManualRecover() && durationExceeded(obj)
+
+	// the records are recovered in the reverse order one by one, as each of them waits for the ones after it
+	concurrent := r.MaxConcurrency > 1 && !reverseRecover
+	var tasks []*task
+	for _, index := range processingOrder(records, reverseRecover) {
+		record := records[index]
 		r.Log.Info("iterating record", "record", record, "desiredPhase", desiredPhase)
 
 		// The whole running logic is a cycle:
@@ -146,6 +406,13 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			} else {
 				operation = Recover
 			}
+
+			// the chaos with manualRecover keeps running after its duration only for the records injected or
+			// being injected then
+			if operation == Apply && expired && originalPhase == v1alpha1.NotInjected {
+				r.Log.Info("skip applying the record after the duration is exceeded", "record", record)
+				continue
+			}
 		}
 		if desiredPhase == v1alpha1.StoppedPhase && originalPhase != v1alpha1.NotInjected {
 			// The originalPhase has three possible situations: Not Injedcted/*, Injected, or Injected/*
@@ -158,52 +425,46 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			}
 		}
 
-		if operation == Apply {
-			r.Log.Info("apply chaos", "id", records[index].Id)
-			record.Phase, err = r.Impl.Apply(context.TODO(), index, records, obj)
-			if record.Phase != originalPhase {
-				shouldUpdate = true
-			}
-			if err != nil {
-				// TODO: add backoff and retry mechanism
-				// but the retry shouldn't block other resource process
-				r.Log.Error(err, "fail to apply chaos")
-				r.Recorder.Event(obj, recorder.Failed{
-					Activity: "apply chaos",
-					Err:      err.Error(),
-				})
-				needRetry = true
+		// the quarantined record is retried only if its chaos daemon is back
+		if record.DaemonUnreachable && operation != Nothing && r.HealthChecker != nil {
+			if r.daemonUnreachable(context.TODO(), record, nil) {
+				quarantined = true
+				if operation == Recover && reverseRecover {
+					break
+				}
 				continue
 			}
-
-			if record.Phase == v1alpha1.Injected {
-				r.Recorder.Event(obj, recorder.Applied{
-					Id: records[index].Id,
-				})
-			}
-		} else if operation == Recover {
-			r.Log.Info("recover chaos", "id", records[index].Id)
-			record.Phase, err = r.Impl.Recover(context.TODO(), index, records, obj)
-			if record.Phase != originalPhase {
+			if r.release(obj, record) {
 				shouldUpdate = true
 			}
-			if err != nil {
-				// TODO: add backoff and retry mechanism
-				// but the retry shouldn't block other resource process
-				r.Log.Error(err, "fail to recover chaos")
-				r.Recorder.Event(obj, recorder.Failed{
-					Activity: "recover chaos",
-					Err:      err.Error(),
-				})
-				needRetry = true
-				continue
-			}
+		}
 
-			if record.Phase == v1alpha1.NotInjected {
-				r.Recorder.Event(obj, recorder.Recovered{
-					Id: records[index].Id,
-				})
-			}
+		if _, ok := r.Impl.(ChaosImplChecker); ok && operation == Nothing && desiredPhase == v1alpha1.RunningPhase {
+			operation = Check
+		}
+		if operation == Nothing {
+			continue
+		}
+
+		t := &task{
+			index:         index,
+			operation:     operation,
+			originalPhase: originalPhase,
+		}
+		if concurrent {
+			tasks = append(tasks, t)
+			continue
+		}
+		r.runTask(context.TODO(), t, records, obj)
+		if !handle(t) {
+			break
+		}
+	}
+	if concurrent {
+		// the records are updated after all the tasks are done, so the failed ones don't stop the others
+		r.runTasks(context.TODO(), tasks, records, obj)
+		for _, t := range tasks {
+			handle(t)
 		}
 	}
 
@@ -212,9 +473,15 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	if objWithStatus, ok := obj.(InnerObjectWithCustomStatus); ok {
 		customStatus = reflect.Indirect(reflect.ValueOf(objWithStatus.GetCustomStatus()))
 	}
+	// the summary is refreshed along with the records, so that the printer columns of `kubectl get` are current
+	phase := phaseOf(desiredPhase, records, obj.IsPaused(), expired)
+	action := actionOf(obj)
+	if status := obj.GetStatus(); status.Phase != phase || status.Action != action || status.Selected != len(records) {
+		shouldUpdate = true
+	}
 	if shouldUpdate {
 		updateError := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			r.Log.Info("updating records", "records", records)
+			r.Log.Info("updating records", "records", records, "phase", phase)
 			obj := r.Object.DeepCopyObject().(InnerObjectWithSelector)
 
 			if err := r.Client.Get(context.TODO(), req.NamespacedName, obj); err != nil {
@@ -223,6 +490,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			}
 
 			obj.GetStatus().Experiment.Records = records
+			obj.GetStatus().Experiment.Selections = selections
+			obj.GetStatus().Phase = phase
+			obj.GetStatus().Action = action
+			obj.GetStatus().Selected = len(records)
 			if objWithStatus, ok := obj.(InnerObjectWithCustomStatus); ok {
 				ptrToCustomStatus := objWithStatus.GetCustomStatus()
 				// TODO: auto generate SetCustomStatus rather than reflect
@@ -243,5 +514,250 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			Field: "records",
 		})
 	}
+	if !needRetry && quarantined {
+		return ctrl.Result{RequeueAfter: quarantineInterval}, nil
+	}
+	if !needRetry && needCheck {
+		return ctrl.Result{RequeueAfter: checkInterval}, nil
+	}
 	return ctrl.Result{Requeue: needRetry}, nil
 }
+
+// runTask runs the operation of the task with the Impl, and keeps the result in the task
+func (r *Reconciler) runTask(ctx context.Context, t *task, records []*v1alpha1.Record, obj InnerObjectWithSelector) {
+	record := records[t.index]
+	startTime := time.Now()
+	switch t.operation {
+	case Apply:
+		r.Log.Info("apply chaos", "id", record.Id)
+		t.phase, t.err = r.Impl.Apply(ctx, t.index, records, obj)
+	case Recover:
+		r.Log.Info("recover chaos", "id", record.Id)
+		t.phase, t.err = r.Impl.Recover(ctx, t.index, records, obj)
+	case Check:
+		r.Log.Info("check chaos", "id", record.Id)
+		t.phase, t.err = r.Impl.(ChaosImplChecker).Check(ctx, t.index, records, obj)
+	}
+	t.duration = time.Since(startTime)
+}
+
+// runTasks runs the tasks with at most MaxConcurrency workers. The tasks on the records with the same id, e.g. the
+// pod selected by several selectors, are run one by one in their own order, as they operate on the same target.
+func (r *Reconciler) runTasks(ctx context.Context, tasks []*task, records []*v1alpha1.Record, obj InnerObjectWithSelector) {
+	var ids []string
+	groups := make(map[string][]*task)
+	for _, t := range tasks {
+		id := records[t.index].Id
+		if _, ok := groups[id]; !ok {
+			ids = append(ids, id)
+		}
+		groups[id] = append(groups[id], t)
+	}
+
+	workers := make(chan struct{}, r.MaxConcurrency)
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		group := groups[id]
+		workers <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			for _, t := range group {
+				r.runTask(ctx, t, records, obj)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// daemonUnreachable returns true if the chaos daemon serving the record is unreachable. It's checked by the health
+// checker if there is one, otherwise it's told by the error of the last request to the chaos daemon.
+func (r *Reconciler) daemonUnreachable(ctx context.Context, record *v1alpha1.Record, err error) bool {
+	if _, ok := errcode.ReasonOf(err); ok {
+		// the failure is reported by the chaos daemon itself
+		return false
+	}
+	if r.HealthChecker == nil || strings.Count(record.Id, "/") == 0 || strings.HasPrefix(record.Id, "{") {
+		return errcode.Unreachable(err)
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.Reader.Get(ctx, controller.ParseNamespacedName(record.Id), pod); err != nil {
+		// the pod which is gone is not served by any chaos daemon
+		return false
+	}
+	return errcode.Unreachable(r.HealthChecker.CheckHealth(ctx, pod))
+}
+
+// quarantine marks the record as its chaos daemon is unreachable. It returns true if the record is changed.
+func (r *Reconciler) quarantine(obj InnerObjectWithSelector, record *v1alpha1.Record) bool {
+	if record.DaemonUnreachable {
+		return false
+	}
+	r.Log.Info("quarantine the record as its chaos daemon is unreachable", "id", record.Id)
+	record.DaemonUnreachable = true
+	r.Recorder.Event(obj, recorder.DaemonUnreachable{
+		Id: record.Id,
+	})
+	return true
+}
+
+// release clears the quarantine of the record as its chaos daemon is back. It returns true if the record is changed.
+func (r *Reconciler) release(obj InnerObjectWithSelector, record *v1alpha1.Record) bool {
+	if !record.DaemonUnreachable {
+		return false
+	}
+	r.Log.Info("release the record as its chaos daemon is reachable", "id", record.Id)
+	record.DaemonUnreachable = false
+	r.Recorder.Event(obj, recorder.DaemonReachable{
+		Id: record.Id,
+	})
+	return true
+}
+
+// observeSelection records the number of the selected targets and the time taken by the selection
+func (r *Reconciler) observeSelection(obj InnerObjectWithSelector, targets int, duration time.Duration) {
+	if r.Metrics == nil {
+		return
+	}
+
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	r.Metrics.SelectedTargets.WithLabelValues(kind).Observe(float64(targets))
+	r.Metrics.SelectionDuration.WithLabelValues(kind).Observe(duration.Seconds())
+}
+
+// processingOrder returns the indexes of the records in the order of being processed. The records are recovered
+// in the reverse order of being injected if it's required, otherwise they are processed in their own order.
+func processingOrder(records []*v1alpha1.Record, reverse bool) []int {
+	order := make([]int, len(records))
+	for i := range order {
+		order[i] = i
+	}
+	if reverse {
+		sort.SliceStable(order, func(i, j int) bool {
+			return records[order[i]].ApplySequence > records[order[j]].ApplySequence
+		})
+	}
+	return order
+}
+
+// nextApplySequence returns the sequence of the record being injected, which is after all the injected records
+func nextApplySequence(records []*v1alpha1.Record) int64 {
+	var sequence int64
+	for _, record := range records {
+		if record.ApplySequence > sequence {
+			sequence = record.ApplySequence
+		}
+	}
+	return sequence + 1
+}
+
+// updateRecordMessage keeps the reason of the last failure in the record, so
+// that users could find out why the chaos cannot be applied or recovered from
+// the status. It returns true if the message is changed.
+func updateRecordMessage(record *v1alpha1.Record, err error) bool {
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	if record.Message == message {
+		return false
+	}
+
+	record.Message = message
+	return true
+}
+
+// observeInjection records the time taken by applying the chaos until the target is injected
+func (r *Reconciler) observeInjection(obj InnerObjectWithSelector, duration time.Duration) {
+	if r.Metrics == nil {
+		return
+	}
+
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	r.Metrics.InjectionDuration.WithLabelValues(kind, actionOf(obj)).Observe(duration.Seconds())
+}
+
+// observeFailure counts the failed operation by the reason reported by the chaos daemon
+func (r *Reconciler) observeFailure(obj InnerObjectWithSelector, operation Operation, err error) {
+	if r.Metrics == nil {
+		return
+	}
+
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	reason, ok := errcode.ReasonOf(err)
+	if !ok {
+		reason = "Unknown"
+	}
+	r.Metrics.ChaosImplFailures.WithLabelValues(kind, string(operation), string(reason)).Inc()
+}
+
+// observeRecovery counts the recovery of a record by whether it succeeds, the recovery waiting for the result
+// (e.g. "Injected/Wait") is counted when it's finished
+func (r *Reconciler) observeRecovery(obj InnerObjectWithSelector, succeeded bool) {
+	if r.Metrics == nil {
+		return
+	}
+
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	result := "failure"
+	if succeeded {
+		result = "success"
+	}
+	r.Metrics.Recoveries.WithLabelValues(kind, result).Inc()
+}
+
+// phaseOf summarizes the records into the phase of chaos, which tells whether the records have reached the
+// desired phase. The records not injected are never applied after the duration of the chaos with manualRecover
+// is expired, so they have reached their final phase.
+func phaseOf(desiredPhase v1alpha1.DesiredPhase, records []*v1alpha1.Record, paused bool, expired bool) v1alpha1.ChaosPhase {
+	if desiredPhase == v1alpha1.RunningPhase {
+		injected := 0
+		for _, record := range records {
+			switch {
+			case record.Phase == v1alpha1.Injected:
+				injected++
+			case expired && record.Phase == v1alpha1.NotInjected:
+				// it's left not injected until the chaos is stopped
+			default:
+				return v1alpha1.ChaosPhaseInjecting
+			}
+		}
+		if injected == 0 && len(records) > 0 {
+			return v1alpha1.ChaosPhaseRecovered
+		}
+		return v1alpha1.ChaosPhaseRunning
+	}
+
+	for _, record := range records {
+		if record.Phase != v1alpha1.NotInjected {
+			return v1alpha1.ChaosPhaseRecovering
+		}
+	}
+	if paused {
+		return v1alpha1.ChaosPhasePaused
+	}
+	return v1alpha1.ChaosPhaseRecovered
+}
+
+// durationExceeded returns whether the condition controller has observed that the duration of chaos is exceeded
+func durationExceeded(obj InnerObjectWithSelector) bool {
+	for _, c := range obj.GetStatus().Conditions {
+		if c.Type == v1alpha1.ConditionDurationExceeded {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// actionOf returns the action in the spec of chaos, or an empty string for the chaos without actions
+func actionOf(obj InnerObjectWithSelector) string {
+	action := reflect.Indirect(reflect.ValueOf(obj)).FieldByName("Spec").FieldByName("Action")
+	if !action.IsValid() {
+		return ""
+	}
+	return action.String()
+}
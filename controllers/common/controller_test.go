@@ -0,0 +1,866 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/metrics"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/container"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestUpdateRecordMessage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	record := &v1alpha1.Record{
+		Id:    "default/p0",
+		Phase: v1alpha1.NotInjected,
+	}
+
+	// a stress-ng which exits immediately fails the record with its stderr
+	err := errors.New("stress-ng exit status 2: unrecognized option '--foo'")
+	g.Expect(updateRecordMessage(record, err)).To(BeTrue())
+	g.Expect(record.Message).To(Equal(err.Error()))
+
+	g.Expect(updateRecordMessage(record, err)).To(BeFalse())
+
+	// the message is cleared once the record succeeds
+	g.Expect(updateRecordMessage(record, nil)).To(BeTrue())
+	g.Expect(record.Message).To(BeEmpty())
+}
+
+// concurrentImpl tracks how many records are being applied at the same time
+type concurrentImpl struct {
+	lock        *sync.Mutex
+	inflight    *int
+	maxInflight *int
+	// failOn fails to apply the record with the id
+	failOn string
+}
+
+func (i concurrentImpl) track(delta int) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	*i.inflight += delta
+	if *i.inflight > *i.maxInflight {
+		*i.maxInflight = *i.inflight
+	}
+}
+
+func (i concurrentImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	i.track(1)
+	defer i.track(-1)
+
+	time.Sleep(50 * time.Millisecond)
+	if records[index].Id == i.failOn {
+		return v1alpha1.NotInjected, errors.New("device or resource busy")
+	}
+	return v1alpha1.Injected, nil
+}
+
+func (i concurrentImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.NotInjected, nil
+}
+
+func TestApplyConcurrently(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "network-delay"}
+	records := []*v1alpha1.Record{}
+	for i := 0; i < 6; i++ {
+		records = append(records, &v1alpha1.Record{Id: fmt.Sprintf("default/p%d", i), Phase: v1alpha1.NotInjected})
+	}
+	reconcile := func(maxConcurrency int, failOn string) (int, *v1alpha1.NetworkChaos) {
+		chaos := &v1alpha1.NetworkChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec:       v1alpha1.NetworkChaosSpec{Action: v1alpha1.DelayAction},
+			Status: v1alpha1.NetworkChaosStatus{
+				ChaosStatus: v1alpha1.ChaosStatus{
+					Experiment: v1alpha1.ExperimentStatus{
+						DesiredPhase: v1alpha1.RunningPhase,
+						Records:      records,
+					},
+				},
+			},
+		}
+		c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+		inflight, maxInflight := 0, 0
+		impl := concurrentImpl{
+			lock:        &sync.Mutex{},
+			inflight:    &inflight,
+			maxInflight: &maxInflight,
+			failOn:      failOn,
+		}
+		r := &Reconciler{
+			Impl:           impl,
+			Object:         &v1alpha1.NetworkChaos{},
+			Client:         c,
+			Reader:         c,
+			Recorder:       recorder.NewDebugRecorder(),
+			Log:            zap.New(zap.UseDevMode(true)),
+			MaxConcurrency: maxConcurrency,
+		}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		chaos = &v1alpha1.NetworkChaos{}
+		g.Expect(c.Get(context.TODO(), key, chaos)).To(Succeed())
+		return maxInflight, chaos
+	}
+
+	// the records are applied one by one by default
+	maxInflight, _ := reconcile(0, "")
+	g.Expect(maxInflight).To(Equal(1))
+
+	// at most the max concurrency of records are applied at the same time
+	maxInflight, _ = reconcile(3, "")
+	g.Expect(maxInflight).To(Equal(3))
+
+	// the failed record doesn't stop the others from being updated
+	_, chaos := reconcile(3, "default/p3")
+	var sequences []int64
+	for _, record := range chaos.Status.Experiment.Records {
+		if record.Id == "default/p3" {
+			g.Expect(record.Phase).To(Equal(v1alpha1.NotInjected))
+			g.Expect(record.Message).To(Equal("device or resource busy"))
+			continue
+		}
+		g.Expect(record.Phase).To(Equal(v1alpha1.Injected))
+		sequences = append(sequences, record.ApplySequence)
+	}
+	g.Expect(sequences).To(Equal([]int64{1, 2, 3, 4, 5}))
+}
+
+func TestScopeSelector(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	chaos := &metav1.ObjectMeta{Namespace: "team-a", Name: "stress"}
+	sel := &v1alpha1.ContainerSelector{
+		PodSelector: v1alpha1.PodSelector{
+			Selector: v1alpha1.PodSelectorSpec{
+				LabelSelectors: map[string]string{"app": "foo"},
+			},
+			Mode: v1alpha1.AllPodMode,
+		},
+	}
+
+	// the omitted namespaces are scoped to the namespace of the chaos, without changing the spec
+	scoped := scopeSelector(chaos, sel).(*v1alpha1.ContainerSelector)
+	g.Expect(scoped.Selector.Namespaces).To(Equal([]string{"team-a"}))
+	g.Expect(scoped.Selector.LabelSelectors).To(Equal(sel.Selector.LabelSelectors))
+	g.Expect(sel.Selector.Namespaces).To(BeEmpty())
+
+	podSelector := &v1alpha1.PodSelector{Selector: v1alpha1.PodSelectorSpec{Namespaces: []string{"team-b"}}}
+	g.Expect(scopeSelector(chaos, podSelector).(*v1alpha1.PodSelector).Selector.Namespaces).To(Equal([]string{"team-b"}))
+
+	// unless the chaos opts in to select the whole cluster
+	chaos.Annotations = map[string]string{v1alpha1.ClusterWideSelectorAnnotationKey: "true"}
+	g.Expect(scopeSelector(chaos, sel).(*v1alpha1.ContainerSelector).Selector.Namespaces).To(BeEmpty())
+
+	// the omitted target of NetworkChaos is left as nil
+	g.Expect(scopeSelector(chaos, (*v1alpha1.PodSelector)(nil))).To(BeNil())
+
+	// the other selectors are left untouched
+	awsSelector := &v1alpha1.AWSSelector{}
+	g.Expect(scopeSelector(chaos, awsSelector)).To(BeIdenticalTo(awsSelector))
+}
+
+// failingImpl fails to apply the chaos with the error
+type failingImpl struct {
+	err error
+}
+
+func (i failingImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.NotInjected, i.err
+}
+
+func (i failingImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.NotInjected, nil
+}
+
+// injectedImpl applies the chaos successfully
+type injectedImpl struct{}
+
+func (i injectedImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.Injected, nil
+}
+
+func (i injectedImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.NotInjected, nil
+}
+
+func TestRetryByErrorReason(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "failing"}
+	reconcile := func(err error) ctrl.Result {
+		chaos := &v1alpha1.TimeChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec:       v1alpha1.TimeChaosSpec{TimeOffset: "100ms"},
+			Status: v1alpha1.TimeChaosStatus{
+				ChaosStatus: v1alpha1.ChaosStatus{
+					Experiment: v1alpha1.ExperimentStatus{
+						DesiredPhase: v1alpha1.RunningPhase,
+						Records: []*v1alpha1.Record{
+							{Id: "default/p0/c0", Phase: v1alpha1.NotInjected},
+						},
+					},
+				},
+			},
+		}
+		c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+		r := &Reconciler{
+			Impl:     failingImpl{err: err},
+			Object:   &v1alpha1.TimeChaos{},
+			Client:   c,
+			Reader:   c,
+			Recorder: recorder.NewDebugRecorder(),
+			Log:      zap.New(zap.UseDevMode(true)),
+		}
+
+		result, reconcileErr := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(reconcileErr).ToNot(HaveOccurred())
+		g.Expect(c.Get(context.TODO(), key, chaos)).To(Succeed())
+		g.Expect(chaos.Status.Experiment.Records[0].Message).To(Equal(err.Error()))
+		return result
+	}
+
+	// the container may be restarting, so it's retried
+	result := reconcile(errcode.Errorf(errcode.ContainerNotFound, "container c0 not found"))
+	g.Expect(result.Requeue).To(BeTrue())
+
+	// the rules may fail to be applied transiently, e.g. the device is busy, so they're retried
+	result = reconcile(errcode.Errorf(errcode.RuleApplyFailed, "RTNETLINK answers: Device or resource busy"))
+	g.Expect(result.Requeue).To(BeTrue())
+
+	// the malformed request will fail again
+	result = reconcile(errcode.Errorf(errcode.InvalidRequest, "unknown action corrupt"))
+	g.Expect(result.Requeue).To(BeFalse())
+
+	// the errors without a reason are retried as before
+	result = reconcile(errors.New("connection refused"))
+	g.Expect(result.Requeue).To(BeTrue())
+}
+
+func TestObserveSelection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "time"}
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: v1alpha1.TimeChaosSpec{
+			ContainerSelector: v1alpha1.ContainerSelector{
+				PodSelector: v1alpha1.PodSelector{
+					Selector: v1alpha1.PodSelectorSpec{
+						Namespaces:     []string{metav1.NamespaceDefault},
+						LabelSelectors: map[string]string{"app": "foo"},
+					},
+					Mode: v1alpha1.AllPodMode,
+				},
+			},
+			TimeOffset: "100ms",
+		},
+	}
+	objs := []runtime.Object{chaos}
+	for _, name := range []string{"p0", "p1"} {
+		pod := NewPod(PodArg{Name: name, Labels: map[string]string{"app": "foo"}})
+		pod.Spec.Containers = []corev1.Container{{Name: "c0"}}
+		objs = append(objs, &pod)
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+
+	collector := metrics.NewChaosCollector(nil, prometheus.NewRegistry())
+	r := &Reconciler{
+		Impl:     failingImpl{err: errors.New("not ready")},
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   c,
+		Reader:   c,
+		Recorder: recorder.NewDebugRecorder(),
+		Selector: selector.New(selector.SelectorParams{
+			ContainerSelector: container.New(container.Params{Client: c, Reader: c}),
+		}),
+		Metrics: collector,
+		Log:     zap.New(zap.UseDevMode(true)),
+	}
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the experiments of the collector are collected from the cache, so only the histograms are gathered
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector.SelectedTargets, collector.SelectionDuration)
+	families, err := registry.Gather()
+	g.Expect(err).ToNot(HaveOccurred())
+	histograms := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			g.Expect(metric.GetLabel()[0].GetValue()).To(Equal("TimeChaos"))
+			g.Expect(metric.GetHistogram().GetSampleCount()).To(BeEquivalentTo(1))
+			histograms[family.GetName()] = metric.GetHistogram().GetSampleSum()
+		}
+	}
+	g.Expect(histograms).To(HaveKeyWithValue("chaos_mesh_selected_targets", BeEquivalentTo(2)))
+	g.Expect(histograms).To(HaveKey("chaos_mesh_selection_duration_seconds"))
+}
+
+func TestSkipPodsWithoutContainers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "stress"}
+	chaos := &v1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: v1alpha1.StressChaosSpec{
+			ContainerSelector: v1alpha1.ContainerSelector{
+				PodSelector: v1alpha1.PodSelector{
+					Selector: v1alpha1.PodSelectorSpec{
+						Namespaces:     []string{metav1.NamespaceDefault},
+						LabelSelectors: map[string]string{"app": "foo"},
+					},
+					Mode: v1alpha1.AllPodMode,
+				},
+				ContainerNames: []string{"c1"},
+			},
+			StressngStressors: "--cpu 1",
+		},
+	}
+	// only p0 runs the named container
+	p0 := NewPod(PodArg{Name: "p0", Labels: map[string]string{"app": "foo"}})
+	p0.Spec.Containers = []corev1.Container{{Name: "c0"}, {Name: "c1"}}
+	p1 := NewPod(PodArg{Name: "p1", Labels: map[string]string{"app": "foo"}})
+	p1.Spec.Containers = []corev1.Container{{Name: "c0"}}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos, &p0, &p1)
+
+	debugRecorder := recorder.NewDebugRecorder()
+	r := &Reconciler{
+		Impl:     failingImpl{err: errors.New("not ready")},
+		Object:   &v1alpha1.StressChaos{},
+		Client:   c,
+		Reader:   c,
+		Recorder: debugRecorder,
+		Selector: selector.New(selector.SelectorParams{
+			ContainerSelector: container.New(container.Params{Client: c, Reader: c}),
+		}),
+		Log: zap.New(zap.UseDevMode(true)),
+	}
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// p1 is skipped with an event, rather than failing the whole experiment
+	g.Expect(c.Get(context.TODO(), key, chaos)).To(Succeed())
+	g.Expect(chaos.Status.Experiment.Records).To(HaveLen(1))
+	g.Expect(chaos.Status.Experiment.Records[0].Id).To(Equal("default/p0/c1"))
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.ContainerNotFound{
+		Pod:            "default/p1",
+		ContainerNames: []string{"c1"},
+	}))
+}
+
+func TestRecordMinMatchesNotMet(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "time"}
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: v1alpha1.TimeChaosSpec{
+			ContainerSelector: v1alpha1.ContainerSelector{
+				PodSelector: v1alpha1.PodSelector{
+					Selector: v1alpha1.PodSelectorSpec{
+						Namespaces:     []string{metav1.NamespaceDefault},
+						LabelSelectors: map[string]string{"app": "foo"},
+					},
+					Mode:       v1alpha1.AllPodMode,
+					MinMatches: 3,
+				},
+			},
+			TimeOffset: "100ms",
+		},
+	}
+	objs := []runtime.Object{chaos}
+	for _, name := range []string{"p0", "p1"} {
+		pod := NewPod(PodArg{Name: name, Labels: map[string]string{"app": "foo"}})
+		pod.Spec.Containers = []corev1.Container{{Name: "c0"}}
+		objs = append(objs, &pod)
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+
+	debugRecorder := recorder.NewDebugRecorder()
+	r := &Reconciler{
+		Impl:     injectedImpl{},
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   c,
+		Reader:   c,
+		Recorder: debugRecorder,
+		Selector: selector.New(selector.SelectorParams{
+			ContainerSelector: container.New(container.Params{Client: c, Reader: c}),
+		}),
+		Log: zap.New(zap.UseDevMode(true)),
+	}
+	result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	// the pods are selected again later, as more pods may be created
+	g.Expect(result.RequeueAfter).To(Equal(minMatchesInterval))
+
+	// nothing is injected, and the event tells how many pods are matched and required
+	g.Expect(c.Get(context.TODO(), key, chaos)).To(Succeed())
+	g.Expect(chaos.Status.Experiment.Records).To(BeEmpty())
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.MinMatchesNotMet{
+		Matched:  2,
+		Required: 3,
+	}))
+}
+
+func TestObserveInjection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "pod-failure"}
+	collector := metrics.NewChaosCollector(nil, prometheus.NewRegistry())
+	reconcile := func(impl ChaosImpl) {
+		chaos := &v1alpha1.PodChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec:       v1alpha1.PodChaosSpec{Action: v1alpha1.PodFailureAction},
+			Status: v1alpha1.PodChaosStatus{
+				ChaosStatus: v1alpha1.ChaosStatus{
+					Experiment: v1alpha1.ExperimentStatus{
+						DesiredPhase: v1alpha1.RunningPhase,
+						Records: []*v1alpha1.Record{
+							{Id: "default/p0", Phase: v1alpha1.NotInjected},
+						},
+					},
+				},
+			},
+		}
+		c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+		r := &Reconciler{
+			Impl:     impl,
+			Object:   &v1alpha1.PodChaos{},
+			Client:   c,
+			Reader:   c,
+			Recorder: recorder.NewDebugRecorder(),
+			Metrics:  collector,
+			Log:      zap.New(zap.UseDevMode(true)),
+		}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	reconcile(injectedImpl{})
+	reconcile(failingImpl{err: errcode.Errorf(errcode.RuleApplyFailed, "RTNETLINK answers: Invalid argument")})
+	reconcile(failingImpl{err: errors.New("connection refused")})
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector.InjectionDuration, collector.ChaosImplFailures)
+	families, err := registry.Gather()
+	g.Expect(err).ToNot(HaveOccurred())
+	samples := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := family.GetName()
+			for _, label := range metric.GetLabel() {
+				labels += "," + label.GetName() + "=" + label.GetValue()
+			}
+			if histogram := metric.GetHistogram(); histogram != nil {
+				samples[labels] = float64(histogram.GetSampleCount())
+			} else {
+				samples[labels] = metric.GetCounter().GetValue()
+			}
+		}
+	}
+	g.Expect(samples).To(Equal(map[string]float64{
+		"chaos_mesh_injection_duration_seconds,action=pod-failure,kind=PodChaos":                    1,
+		"chaos_mesh_chaos_impl_failures_total,kind=PodChaos,operation=apply,reason=RuleApplyFailed": 1,
+		"chaos_mesh_chaos_impl_failures_total,kind=PodChaos,operation=apply,reason=Unknown":         1,
+	}))
+}
+
+// orderedImpl tracks the order of the records being applied and recovered
+type orderedImpl struct {
+	applied   *[]string
+	recovered *[]string
+	// failOn fails to recover the record with the id
+	failOn string
+}
+
+func (i orderedImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	*i.applied = append(*i.applied, records[index].Id)
+	return v1alpha1.Injected, nil
+}
+
+func (i orderedImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	*i.recovered = append(*i.recovered, records[index].Id)
+	if records[index].Id == i.failOn {
+		return v1alpha1.Injected, errors.New("device or resource busy")
+	}
+	return v1alpha1.NotInjected, nil
+}
+
+func TestRecoverInReverseOrder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "pod-failure"}
+	chaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec:       v1alpha1.PodChaosSpec{Action: v1alpha1.PodFailureAction},
+		Status: v1alpha1.PodChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0", Phase: v1alpha1.Injected, ApplySequence: 1},
+						{Id: "default/p1", Phase: v1alpha1.NotInjected},
+						{Id: "default/p2", Phase: v1alpha1.NotInjected},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	var applied, recovered []string
+	reconcile := func(impl orderedImpl) {
+		r := &Reconciler{
+			Impl:     impl,
+			Object:   &v1alpha1.PodChaos{},
+			Client:   c,
+			Reader:   c,
+			Recorder: recorder.NewDebugRecorder(),
+			Log:      zap.New(zap.UseDevMode(true)),
+		}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		chaos = &v1alpha1.PodChaos{}
+		g.Expect(c.Get(context.TODO(), key, chaos)).To(Succeed())
+	}
+	stop := func(annotations map[string]string, records []*v1alpha1.Record) {
+		chaos.Annotations = annotations
+		chaos.Status.Experiment.DesiredPhase = v1alpha1.StoppedPhase
+		chaos.Status.Experiment.Records = records
+		g.Expect(c.Update(context.TODO(), chaos)).To(Succeed())
+	}
+	sequences := func() []int64 {
+		var sequences []int64
+		for _, record := range chaos.Status.Experiment.Records {
+			sequences = append(sequences, record.ApplySequence)
+		}
+		return sequences
+	}
+
+	// the records are numbered in the order of being injected
+	reconcile(orderedImpl{applied: &applied, recovered: &recovered})
+	g.Expect(applied).To(Equal([]string{"default/p1", "default/p2"}))
+	g.Expect(sequences()).To(Equal([]int64{1, 2, 3}))
+
+	// the records are recovered in their own order by default
+	stop(nil, []*v1alpha1.Record{
+		{Id: "default/p0", Phase: v1alpha1.Injected, ApplySequence: 2},
+		{Id: "default/p1", Phase: v1alpha1.Injected, ApplySequence: 3},
+		{Id: "default/p2", Phase: v1alpha1.Injected, ApplySequence: 1},
+	})
+	reconcile(orderedImpl{applied: &applied, recovered: &recovered})
+	g.Expect(recovered).To(Equal([]string{"default/p0", "default/p1", "default/p2"}))
+	g.Expect(sequences()).To(Equal([]int64{0, 0, 0}))
+
+	// the last injected record is recovered first with the option
+	recovered = nil
+	reverse := map[string]string{v1alpha1.RecoverOrderAnnotationKey: "reverse"}
+	stop(reverse, []*v1alpha1.Record{
+		{Id: "default/p0", Phase: v1alpha1.Injected, ApplySequence: 2},
+		{Id: "default/p1", Phase: v1alpha1.Injected, ApplySequence: 3},
+		{Id: "default/p2", Phase: v1alpha1.Injected, ApplySequence: 1},
+	})
+	reconcile(orderedImpl{applied: &applied, recovered: &recovered})
+	g.Expect(recovered).To(Equal([]string{"default/p1", "default/p0", "default/p2"}))
+
+	// the records injected earlier wait for the failed one to be recovered
+	recovered = nil
+	stop(reverse, []*v1alpha1.Record{
+		{Id: "default/p0", Phase: v1alpha1.Injected, ApplySequence: 2},
+		{Id: "default/p1", Phase: v1alpha1.Injected, ApplySequence: 3},
+		{Id: "default/p2", Phase: v1alpha1.Injected, ApplySequence: 1},
+	})
+	reconcile(orderedImpl{applied: &applied, recovered: &recovered, failOn: "default/p0"})
+	g.Expect(recovered).To(Equal([]string{"default/p1", "default/p0"}))
+	g.Expect(chaos.Status.Experiment.Records[2].Phase).To(Equal(v1alpha1.Injected))
+}
+
+func TestRefreshPhase(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "pod-failure"}
+	chaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec:       v1alpha1.PodChaosSpec{Action: v1alpha1.PodFailureAction},
+		Status: v1alpha1.PodChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0", Phase: v1alpha1.NotInjected},
+						{Id: "default/p1", Phase: v1alpha1.NotInjected},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	r := &Reconciler{
+		Impl:     injectedImpl{},
+		Object:   &v1alpha1.PodChaos{},
+		Client:   c,
+		Reader:   c,
+		Recorder: recorder.NewDebugRecorder(),
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	reconcile := func() *v1alpha1.PodChaos {
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		chaos := &v1alpha1.PodChaos{}
+		g.Expect(c.Get(context.TODO(), key, chaos)).To(Succeed())
+		return chaos
+	}
+
+	chaos = reconcile()
+	g.Expect(chaos.Status.Phase).To(Equal(v1alpha1.ChaosPhaseRunning))
+	g.Expect(chaos.Status.Action).To(Equal(string(v1alpha1.PodFailureAction)))
+	g.Expect(chaos.Status.Selected).To(Equal(2))
+
+	chaos.Status.Experiment.DesiredPhase = v1alpha1.StoppedPhase
+	g.Expect(c.Update(context.TODO(), chaos)).To(Succeed())
+	chaos = reconcile()
+	g.Expect(chaos.Status.Phase).To(Equal(v1alpha1.ChaosPhaseRecovered))
+
+	// the phase is refreshed even if no record is changed
+	chaos.Annotations = map[string]string{v1alpha1.PauseAnnotationKey: "true"}
+	g.Expect(c.Update(context.TODO(), chaos)).To(Succeed())
+	chaos = reconcile()
+	g.Expect(chaos.Status.Phase).To(Equal(v1alpha1.ChaosPhasePaused))
+}
+
+func TestPhaseOf(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	records := []*v1alpha1.Record{
+		{Id: "default/p0", Phase: v1alpha1.Injected},
+		{Id: "default/p1", Phase: v1alpha1.NotInjected},
+	}
+	g.Expect(phaseOf(v1alpha1.RunningPhase, records, false, false)).To(Equal(v1alpha1.ChaosPhaseInjecting))
+	g.Expect(phaseOf(v1alpha1.StoppedPhase, records, false, false)).To(Equal(v1alpha1.ChaosPhaseRecovering))
+	g.Expect(phaseOf(v1alpha1.StoppedPhase, records, true, false)).To(Equal(v1alpha1.ChaosPhaseRecovering))
+
+	// the records not injected after the duration of the chaos with manualRecover are never applied
+	g.Expect(phaseOf(v1alpha1.RunningPhase, records, false, true)).To(Equal(v1alpha1.ChaosPhaseRunning))
+	g.Expect(phaseOf(v1alpha1.RunningPhase, records[1:], false, true)).To(Equal(v1alpha1.ChaosPhaseRecovered))
+	g.Expect(phaseOf(v1alpha1.RunningPhase, []*v1alpha1.Record{
+		{Id: "default/p0", Phase: v1alpha1.Injected},
+		{Id: "default/p1", Phase: v1alpha1.NotInjected + "/Wait"},
+	}, false, true)).To(Equal(v1alpha1.ChaosPhaseInjecting))
+}
+
+func TestKeepInjectedAt(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "time-offset"}
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec:       v1alpha1.TimeChaosSpec{TimeOffset: "100ms"},
+		Status: v1alpha1.TimeChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0/c0", Phase: v1alpha1.NotInjected},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	r := &Reconciler{
+		Impl:     injectedImpl{},
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   c,
+		Reader:   c,
+		Recorder: recorder.NewDebugRecorder(),
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	reconcile := func() *v1alpha1.Record {
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		chaos = &v1alpha1.TimeChaos{}
+		g.Expect(c.Get(context.TODO(), key, chaos)).To(Succeed())
+		return chaos.Status.Experiment.Records[0]
+	}
+
+	record := reconcile()
+	g.Expect(record.Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(record.InjectedAt).ToNot(BeNil())
+	injectedAt := record.InjectedAt.DeepCopy()
+
+	// the time of the first injection is kept after the record is recovered and injected again
+	for _, desiredPhase := range []v1alpha1.DesiredPhase{v1alpha1.StoppedPhase, v1alpha1.RunningPhase} {
+		chaos.Status.Experiment.DesiredPhase = desiredPhase
+		g.Expect(c.Update(context.TODO(), chaos)).To(Succeed())
+		record = reconcile()
+		g.Expect(record.InjectedAt.Equal(injectedAt)).To(BeTrue())
+	}
+	g.Expect(record.Phase).To(Equal(v1alpha1.Injected))
+}
+
+// recoverFailingImpl fails to recover the chaos with the error
+type recoverFailingImpl struct {
+	err error
+}
+
+func (i recoverFailingImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.Injected, nil
+}
+
+func (i recoverFailingImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.Injected, i.err
+}
+
+func TestObserveRecovery(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "pod-failure"}
+	collector := metrics.NewChaosCollector(nil, prometheus.NewRegistry())
+	reconcile := func(impl ChaosImpl) {
+		chaos := &v1alpha1.PodChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec:       v1alpha1.PodChaosSpec{Action: v1alpha1.PodFailureAction},
+			Status: v1alpha1.PodChaosStatus{
+				ChaosStatus: v1alpha1.ChaosStatus{
+					Experiment: v1alpha1.ExperimentStatus{
+						DesiredPhase: v1alpha1.StoppedPhase,
+						Records: []*v1alpha1.Record{
+							{Id: "default/p0", Phase: v1alpha1.Injected},
+						},
+					},
+				},
+			},
+		}
+		c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+		r := &Reconciler{
+			Impl:     impl,
+			Object:   &v1alpha1.PodChaos{},
+			Client:   c,
+			Reader:   c,
+			Recorder: recorder.NewDebugRecorder(),
+			Metrics:  collector,
+			Log:      zap.New(zap.UseDevMode(true)),
+		}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	recoveries := func(result string) float64 {
+		metric := &dto.Metric{}
+		g.Expect(collector.Recoveries.WithLabelValues("PodChaos", result).Write(metric)).To(Succeed())
+		return metric.GetCounter().GetValue()
+	}
+
+	reconcile(recoverFailingImpl{err: errors.New("connection refused")})
+	g.Expect(recoveries("failure")).To(BeEquivalentTo(1))
+	g.Expect(recoveries("success")).To(BeZero())
+
+	reconcile(injectedImpl{})
+	g.Expect(recoveries("failure")).To(BeEquivalentTo(1))
+	g.Expect(recoveries("success")).To(BeEquivalentTo(1))
+}
+
+func TestKeepSelection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects := []runtime.Object{}
+	for i := 0; i < 6; i++ {
+		pod := NewPod(PodArg{Name: fmt.Sprintf("p%d", i), Labels: map[string]string{"app": "foo"}})
+		objects = append(objects, &pod)
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), objects...)
+	reconcile := func(name string, seed *int64) *v1alpha1.PodChaos {
+		key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: name}
+		chaos := &v1alpha1.PodChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name, UID: types.UID(name)},
+			Spec: v1alpha1.PodChaosSpec{
+				Action: v1alpha1.PodFailureAction,
+				ContainerSelector: v1alpha1.ContainerSelector{
+					PodSelector: v1alpha1.PodSelector{
+						Selector: v1alpha1.PodSelectorSpec{
+							Namespaces:     []string{metav1.NamespaceDefault},
+							LabelSelectors: map[string]string{"app": "foo"},
+						},
+						Mode:  v1alpha1.FixedPodMode,
+						Value: "2",
+						Seed:  seed,
+					},
+				},
+			},
+		}
+		g.Expect(c.Create(context.TODO(), chaos)).To(Succeed())
+
+		r := &Reconciler{
+			Impl:     failingImpl{err: errors.New("not ready")},
+			Object:   &v1alpha1.PodChaos{},
+			Client:   c,
+			Reader:   c,
+			Recorder: recorder.NewDebugRecorder(),
+			Selector: selector.New(selector.SelectorParams{
+				PodSelector: pod.New(pod.Params{Client: c, Reader: c}),
+			}),
+			Log: zap.New(zap.UseDevMode(true)),
+		}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		chaos = &v1alpha1.PodChaos{}
+		g.Expect(c.Get(context.TODO(), key, chaos)).To(Succeed())
+		return chaos
+	}
+
+	// the seed derived from the UID is kept along with the chosen pods
+	chaos := reconcile("pod-failure", nil)
+	selections := chaos.Status.Experiment.Selections
+	g.Expect(selections).To(HaveLen(1))
+	g.Expect(selections[0].SelectorKey).To(Equal("."))
+	g.Expect(selections[0].Targets).To(HaveLen(2))
+	for i, record := range chaos.Status.Experiment.Records {
+		g.Expect(record.Id).To(Equal(selections[0].Targets[i]))
+	}
+
+	// the selection is replayed with the seed in the spec
+	seed := selections[0].Seed
+	replayed := reconcile("pod-failure-replayed", &seed)
+	g.Expect(replayed.Status.Experiment.Selections).To(Equal(selections))
+	g.Expect(*replayed.Spec.Seed).To(Equal(seed))
+}
@@ -27,8 +27,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	ccfg "github.com/chaos-mesh/chaos-mesh/controllers/config"
+	"github.com/chaos-mesh/chaos-mesh/controllers/metrics"
 	"github.com/chaos-mesh/chaos-mesh/controllers/types"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector"
@@ -41,6 +44,9 @@ type ChaosImplPair struct {
 
 	ObjectList runtime.Object
 	Controlls  []runtime.Object
+
+	// ConcurrencySafe marks the Impl whose Apply and Recover could be called concurrently for different records
+	ConcurrencySafe bool
 }
 
 type Params struct {
@@ -51,8 +57,11 @@ type Params struct {
 	Logger          logr.Logger
 	Selector        *selector.Selector
 	RecorderBuilder *recorder.RecorderBuilder
-	Impls           []*ChaosImplPair `group:"impl"`
-	Reader          client.Reader    `name:"no-cache"`
+	Metrics         *metrics.ChaosCollector `optional:"true"`
+	Impls           []*ChaosImplPair        `group:"impl"`
+	Reader          client.Reader           `name:"no-cache"`
+	// DaemonClientBuilder checks the health of the chaos daemons, it's optional
+	DaemonClientBuilder *chaosdaemon.ChaosDaemonClientBuilder `optional:"true"`
 }
 
 func NewController(params Params) (types.Controller, error) {
@@ -113,15 +122,25 @@ func NewController(params Params) (types.Controller, error) {
 			}
 		}
 
-		err := builder.Complete(&Reconciler{
+		reconciler := &Reconciler{
 			Impl:     pair.Impl,
 			Object:   pair.Object,
 			Client:   client,
 			Reader:   reader,
 			Recorder: recorderBuilder.Build("records"),
 			Selector: selector,
+			Metrics:  params.Metrics,
 			Log:      logger.WithName("records"),
-		})
+
+			MaxConcurrency: 1,
+		}
+		if pair.ConcurrencySafe {
+			reconciler.MaxConcurrency = ccfg.ControllerCfg.MaxInjectConcurrency
+		}
+		if params.DaemonClientBuilder != nil {
+			reconciler.HealthChecker = params.DaemonClientBuilder
+		}
+		err := builder.Complete(reconciler)
 		if err != nil {
 			return "", err
 		}
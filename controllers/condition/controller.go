@@ -15,19 +15,23 @@ package condition
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 )
 
 // Reconciler for common chaos
@@ -38,7 +42,7 @@ type Reconciler struct {
 	// Client is used to operate on the Kubernetes cluster
 	client.Client
 
-	Recorder record.EventRecorder
+	Recorder recorder.ChaosRecorder
 
 	Log logr.Logger
 }
@@ -60,6 +64,37 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	// The recovery after the duration ends is bounded by the recover timeout. It's checked with its own
+	// requeue, so that it's escalated in time no matter how the records are retried.
+	recoverTimedOut := false
+	requeueAfter := time.Duration(0)
+	for _, record := range obj.GetStatus().Experiment.Records {
+		if record.Phase == v1alpha1.NotInjected {
+			continue
+		}
+
+		exceeded, untilTimeout, err := obj.RecoverTimeoutExceeded(time.Now())
+		if err != nil {
+			r.Log.Error(err, "failed to parse recover timeout")
+		}
+		recoverTimedOut = exceeded
+		requeueAfter = untilTimeout
+		break
+	}
+	durationExceeded, untilStop, err := obj.DurationExceeded(time.Now())
+	if err != nil {
+		r.Log.Error(err, "failed to parse duration")
+	}
+	if untilStop > 0 && (requeueAfter == 0 || untilStop < requeueAfter) {
+		requeueAfter = untilStop
+	}
+	wasRecoverTimedOut := false
+	for _, c := range obj.GetStatus().Conditions {
+		if c.Type == v1alpha1.ConditionRecoverTimedOut && c.Status == corev1.ConditionTrue {
+			wasRecoverTimedOut = true
+		}
+	}
+
 	updateError := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		conditionMap := make(map[v1alpha1.ChaosConditionType]StatusAndReason)
 		for _, c := range obj.GetStatus().Conditions {
@@ -70,9 +105,12 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		}
 
 		newConditionMap := make(map[v1alpha1.ChaosConditionType]StatusAndReason)
-		if obj.GetStatus().Experiment.Records != nil {
+		if records := obj.GetStatus().Experiment.Records; records != nil {
+			// The records are selected even if the chaos is paused, so the summary of the selected
+			// targets could be reviewed before the chaos is unpaused.
 			newConditionMap[v1alpha1.ConditionSelected] = StatusAndReason{
 				Status: corev1.ConditionTrue,
+				Reason: selectedReason(records),
 			}
 		} else {
 			newConditionMap[v1alpha1.ConditionSelected] = StatusAndReason{
@@ -82,6 +120,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 		allInjected := corev1.ConditionTrue
 		allRecovered := corev1.ConditionTrue
+		daemonUnreachable := corev1.ConditionFalse
 		for _, record := range obj.GetStatus().Experiment.Records {
 			if record.Phase != v1alpha1.NotInjected {
 				allRecovered = corev1.ConditionFalse
@@ -90,6 +129,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			if record.Phase != v1alpha1.Injected {
 				allInjected = corev1.ConditionFalse
 			}
+
+			if record.DaemonUnreachable {
+				daemonUnreachable = corev1.ConditionTrue
+			}
 		}
 		newConditionMap[v1alpha1.ConditionAllInjected] = StatusAndReason{
 			Status: allInjected,
@@ -97,6 +140,9 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		newConditionMap[v1alpha1.ConditionAllRecovered] = StatusAndReason{
 			Status: allRecovered,
 		}
+		newConditionMap[v1alpha1.ConditionDaemonUnreachable] = StatusAndReason{
+			Status: daemonUnreachable,
+		}
 
 		if obj.IsPaused() {
 			newConditionMap[v1alpha1.ConditionPaused] = StatusAndReason{
@@ -108,8 +154,28 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			}
 		}
 
+		if recoverTimedOut {
+			newConditionMap[v1alpha1.ConditionRecoverTimedOut] = StatusAndReason{
+				Status: corev1.ConditionTrue,
+			}
+		} else {
+			newConditionMap[v1alpha1.ConditionRecoverTimedOut] = StatusAndReason{
+				Status: corev1.ConditionFalse,
+			}
+		}
+
+		if durationExceeded {
+			newConditionMap[v1alpha1.ConditionDurationExceeded] = StatusAndReason{
+				Status: corev1.ConditionTrue,
+			}
+		} else {
+			newConditionMap[v1alpha1.ConditionDurationExceeded] = StatusAndReason{
+				Status: corev1.ConditionFalse,
+			}
+		}
+
 		if !reflect.DeepEqual(newConditionMap, conditionMap) {
-			conditions := make([]v1alpha1.ChaosCondition, 0, 5)
+			conditions := make([]v1alpha1.ChaosCondition, 0, 7)
 			for k, v := range newConditionMap {
 				conditions = append(conditions, v1alpha1.ChaosCondition{
 					Type:   k,
@@ -135,8 +201,39 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	if updateError != nil {
 		r.Log.Error(updateError, "fail to update")
-		r.Recorder.Eventf(obj, "Normal", "Failed", "Failed to update conditions: %s", updateError.Error())
+		r.Recorder.Event(obj, recorder.Failed{
+			Activity: "update conditions",
+			Err:      updateError.Error(),
+		})
 		return ctrl.Result{}, nil
 	}
-	return ctrl.Result{}, nil
+
+	if recoverTimedOut && !wasRecoverTimedOut {
+		timeout := obj.GetChaos().RecoverTimeout
+		r.Log.Info("chaos is not recovered before the recover timeout", "timeout", timeout)
+		r.Recorder.Event(obj, recorder.RecoverTimedOut{
+			Timeout: timeout,
+		})
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// selectedReason summarizes the number of the selected targets of each selector, e.g. "2 targets selected by ."
+func selectedReason(records []*v1alpha1.Record) string {
+	counts := make(map[string]int)
+	for _, record := range records {
+		counts[record.SelectorKey]++
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	summary := make([]string, 0, len(keys))
+	for _, key := range keys {
+		summary = append(summary, fmt.Sprintf("%d targets selected by %s", counts[key], key))
+	}
+	return strings.Join(summary, ", ")
 }
@@ -0,0 +1,390 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package condition
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/container"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+// hangingImpl never completes the recovery
+type hangingImpl struct{}
+
+func (hangingImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.Injected, nil
+}
+
+func (hangingImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.Injected, errors.New("the recovery is still in progress")
+}
+
+func recoverTimedOut(chaos *v1alpha1.TimeChaos) corev1.ConditionStatus {
+	for _, c := range chaos.Status.Conditions {
+		if c.Type == v1alpha1.ConditionRecoverTimedOut {
+			return c.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+func TestRecoverTimeout(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "hanging",
+	}
+	duration := v1alpha1.Duration("5m")
+	recoverTimeout := "10m"
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         key.Namespace,
+			Name:              key.Name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+		},
+		Spec: v1alpha1.TimeChaosSpec{
+			TimeOffset:     "100ms",
+			Duration:       &duration,
+			RecoverTimeout: &recoverTimeout,
+		},
+		Status: v1alpha1.TimeChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.StoppedPhase,
+					Records: []*v1alpha1.Record{
+						{
+							Id:    "default/p0/c0",
+							Phase: v1alpha1.Injected,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	debugRecorder := recorder.NewDebugRecorder()
+	log := zap.New(zap.UseDevMode(true))
+	records := &common.Reconciler{
+		Impl:     hangingImpl{},
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Reader:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      log,
+	}
+	r := &Reconciler{
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      log,
+	}
+	reconcile := func() ctrl.Result {
+		result, err := records.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.Requeue).To(BeTrue())
+
+		result, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+		g.Expect(chaos.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.Injected))
+		return result
+	}
+
+	// the recovery is retried while the recover timeout is not reached
+	result := reconcile()
+	g.Expect(recoverTimedOut(chaos)).To(Equal(corev1.ConditionFalse))
+	g.Expect(result.RequeueAfter).To(BeNumerically("~", 5*time.Minute, time.Minute))
+	g.Expect(debugRecorder.Events[key]).ToNot(ContainElement(BeAssignableToTypeOf(recorder.RecoverTimedOut{})))
+
+	// the recover timeout is reached
+	shorterTimeout := "3m"
+	chaos.Spec.RecoverTimeout = &shorterTimeout
+	g.Expect(fakeClient.Update(context.TODO(), chaos)).To(Succeed())
+
+	result = reconcile()
+	g.Expect(recoverTimedOut(chaos)).To(Equal(corev1.ConditionTrue))
+	g.Expect(result.RequeueAfter).To(BeZero())
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.RecoverTimedOut{Timeout: "3m"}))
+
+	// the escalation is reported once, while the recovery is still retried
+	reconcile()
+	timedOut := 0
+	for _, ev := range debugRecorder.Events[key] {
+		if _, ok := ev.(recorder.RecoverTimedOut); ok {
+			timedOut++
+		}
+	}
+	g.Expect(timedOut).To(Equal(1))
+}
+
+// fakeDaemon is the chaos daemon which could be down
+type fakeDaemon struct {
+	down      bool
+	attempts  int
+	recovered int
+}
+
+func (d *fakeDaemon) CheckHealth(ctx context.Context, pod *corev1.Pod) error {
+	if d.down {
+		return status.Error(codes.Unavailable, "connection error: connect: connection refused")
+	}
+	return nil
+}
+
+// daemonImpl recovers the chaos through the fake chaos daemon
+type daemonImpl struct {
+	daemon *fakeDaemon
+}
+
+func (i daemonImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.Injected, nil
+}
+
+func (i daemonImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	i.daemon.attempts++
+	if i.daemon.down {
+		return v1alpha1.Injected, status.Error(codes.Unavailable, "connection error: connect: connection refused")
+	}
+	i.daemon.recovered++
+	return v1alpha1.NotInjected, nil
+}
+
+func daemonUnreachable(chaos *v1alpha1.TimeChaos) corev1.ConditionStatus {
+	for _, c := range chaos.Status.Conditions {
+		if c.Type == v1alpha1.ConditionDaemonUnreachable {
+			return c.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+func TestQuarantineUnreachableDaemon(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "quarantined",
+	}
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec:       v1alpha1.TimeChaosSpec{TimeOffset: "100ms"},
+		Status: v1alpha1.TimeChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.StoppedPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0/c0", Phase: v1alpha1.Injected},
+					},
+				},
+			},
+		},
+	}
+
+	pod := NewPod(PodArg{Name: "p0"})
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos, &pod)
+	debugRecorder := recorder.NewDebugRecorder()
+	log := zap.New(zap.UseDevMode(true))
+	daemon := &fakeDaemon{down: true}
+	records := &common.Reconciler{
+		Impl:          daemonImpl{daemon: daemon},
+		Object:        &v1alpha1.TimeChaos{},
+		Client:        fakeClient,
+		Reader:        fakeClient,
+		Recorder:      debugRecorder,
+		HealthChecker: daemon,
+		Log:           log,
+	}
+	r := &Reconciler{
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      log,
+	}
+	reconcile := func() ctrl.Result {
+		result, err := records.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		// the omitted fields are not reset by getting into the same object
+		chaos = &v1alpha1.TimeChaos{}
+		g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+		return result
+	}
+
+	// the chaos daemon dies before the chaos is recovered
+	result := reconcile()
+	g.Expect(chaos.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(chaos.Status.Experiment.Records[0].DaemonUnreachable).To(BeTrue())
+	g.Expect(daemonUnreachable(chaos)).To(Equal(corev1.ConditionTrue))
+	g.Expect(result.Requeue).To(BeFalse())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.DaemonUnreachable{Id: "default/p0/c0"}))
+
+	// the quarantined record isn't retried while the chaos daemon is still down
+	result = reconcile()
+	g.Expect(daemon.attempts).To(Equal(1))
+	g.Expect(chaos.Status.Experiment.Records[0].DaemonUnreachable).To(BeTrue())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+	// the chaos is recovered once the chaos daemon is back
+	daemon.down = false
+	result = reconcile()
+	g.Expect(daemon.recovered).To(Equal(1))
+	g.Expect(chaos.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(chaos.Status.Experiment.Records[0].DaemonUnreachable).To(BeFalse())
+	g.Expect(daemonUnreachable(chaos)).To(Equal(corev1.ConditionFalse))
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.DaemonReachable{Id: "default/p0/c0"}))
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.Recovered{Id: "default/p0/c0"}))
+}
+
+// countingImpl counts the applications of the chaos
+type countingImpl struct {
+	applied *int
+}
+
+func (i countingImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	*i.applied++
+	return v1alpha1.Injected, nil
+}
+
+func (i countingImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.NotInjected, nil
+}
+
+func condition(chaos *v1alpha1.TimeChaos, conditionType v1alpha1.ChaosConditionType) v1alpha1.ChaosCondition {
+	for _, c := range chaos.Status.Conditions {
+		if c.Type == conditionType {
+			return c
+		}
+	}
+	return v1alpha1.ChaosCondition{}
+}
+
+func TestPausedChaosPreviewsTargets(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "paused",
+	}
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   key.Namespace,
+			Name:        key.Name,
+			Annotations: map[string]string{v1alpha1.PauseAnnotationKey: "true"},
+		},
+		Spec: v1alpha1.TimeChaosSpec{
+			ContainerSelector: v1alpha1.ContainerSelector{
+				PodSelector: v1alpha1.PodSelector{
+					Selector: v1alpha1.PodSelectorSpec{
+						Namespaces:     []string{metav1.NamespaceDefault},
+						LabelSelectors: map[string]string{"app": "foo"},
+					},
+					Mode: v1alpha1.AllPodMode,
+				},
+			},
+			TimeOffset: "100ms",
+		},
+		Status: v1alpha1.TimeChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.StoppedPhase,
+				},
+			},
+		},
+	}
+	objs := []runtime.Object{chaos}
+	for _, name := range []string{"p0", "p1"} {
+		pod := NewPod(PodArg{Name: name, Labels: map[string]string{"app": "foo"}})
+		pod.Spec.Containers = []corev1.Container{{Name: "c0"}}
+		objs = append(objs, &pod)
+	}
+
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+	debugRecorder := recorder.NewDebugRecorder()
+	log := zap.New(zap.UseDevMode(true))
+	applied := 0
+	records := &common.Reconciler{
+		Impl:     countingImpl{applied: &applied},
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Reader:   fakeClient,
+		Recorder: debugRecorder,
+		Selector: selector.New(selector.SelectorParams{
+			ContainerSelector: container.New(container.Params{Client: fakeClient, Reader: fakeClient}),
+		}),
+		Log: log,
+	}
+	r := &Reconciler{
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      log,
+	}
+	reconcile := func() {
+		_, err := records.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+	}
+
+	// the targets of the paused chaos are selected, but never injected
+	reconcile()
+	reconcile()
+	g.Expect(applied).To(BeZero())
+	g.Expect(chaos.Status.Experiment.Records).To(ConsistOf(
+		&v1alpha1.Record{Id: "default/p0/c0", SelectorKey: ".", Phase: v1alpha1.NotInjected},
+		&v1alpha1.Record{Id: "default/p1/c0", SelectorKey: ".", Phase: v1alpha1.NotInjected},
+	))
+	g.Expect(condition(chaos, v1alpha1.ConditionSelected)).To(Equal(v1alpha1.ChaosCondition{
+		Type:   v1alpha1.ConditionSelected,
+		Status: corev1.ConditionTrue,
+		Reason: "2 targets selected by .",
+	}))
+	g.Expect(condition(chaos, v1alpha1.ConditionPaused).Status).To(Equal(corev1.ConditionTrue))
+
+	// the reviewed targets are injected after the chaos is unpaused
+	delete(chaos.Annotations, v1alpha1.PauseAnnotationKey)
+	chaos.Status.Experiment.DesiredPhase = v1alpha1.RunningPhase
+	g.Expect(fakeClient.Update(context.TODO(), chaos)).To(Succeed())
+
+	reconcile()
+	g.Expect(applied).To(Equal(2))
+	g.Expect(condition(chaos, v1alpha1.ConditionAllInjected).Status).To(Equal(corev1.ConditionTrue))
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/controllers/types"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 )
 
 type Objs struct {
@@ -30,7 +31,7 @@ type Objs struct {
 	Objs []types.Object `group:"objs"`
 }
 
-func NewController(mgr ctrl.Manager, client client.Client, logger logr.Logger, objs Objs) (types.Controller, error) {
+func NewController(mgr ctrl.Manager, client client.Client, logger logr.Logger, recorderBuilder *recorder.RecorderBuilder, objs Objs) (types.Controller, error) {
 	setupLog := logger.WithName("setup-condition")
 	for _, obj := range objs.Objs {
 		setupLog.Info("setting up controller", "resource-name", obj.Name)
@@ -41,7 +42,7 @@ func NewController(mgr ctrl.Manager, client client.Client, logger logr.Logger, o
 			Complete(&Reconciler{
 				Object:   obj.Object,
 				Client:   client,
-				Recorder: mgr.GetEventRecorderFor("condition"),
+				Recorder: recorderBuilder.Build("condition"),
 				Log:      logger.WithName("condition"),
 			})
 		if err != nil {
@@ -18,6 +18,7 @@ import (
 	"os"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -67,5 +68,17 @@ func validate(config *config.ChaosControllerConfig) error {
 		}
 	}
 
+	switch config.PercentRoundingMode {
+	case "", "round", "ceil", "floor":
+	default:
+		return fmt.Errorf("rounding mode %s is not supported, it should be one of round, ceil and floor", config.PercentRoundingMode)
+	}
+
+	for _, key := range append(config.PropagatedLabels, config.PropagatedAnnotations...) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid propagated label or annotation key %s: %s", key, strings.Join(errs, "; "))
+		}
+	}
+
 	return nil
 }
@@ -93,6 +93,28 @@ var _ = Describe("Namespace-scoped Chaos", func() {
 					},
 					expectValid: false,
 				},
+				{
+					name: "rounding mode should be supported",
+					config: config.ChaosControllerConfig{
+						WatcherConfig: &watcher.Config{
+							ClusterScoped: true,
+						},
+						ClusterScoped:       true,
+						PercentRoundingMode: "truncate",
+					},
+					expectValid: false,
+				},
+				{
+					name: "rounding mode could be ceil",
+					config: config.ChaosControllerConfig{
+						WatcherConfig: &watcher.Config{
+							ClusterScoped: true,
+						},
+						ClusterScoped:       true,
+						PercentRoundingMode: "ceil",
+					},
+					expectValid: true,
+				},
 			}
 
 			for _, testCase := range testCases {
@@ -20,6 +20,7 @@ import (
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +39,16 @@ type Reconciler struct {
 
 	Recorder recorder.ChaosRecorder
 	Log      logr.Logger
+
+	// Clock is used to get the current time, it's the real clock if it's nil
+	Clock clock.Clock
+
+	// MinRequeueInterval is the minimum interval to requeue the chaos, the requeue sooner than it is delayed.
+	// Zero means the chaos is requeued exactly when its duration is exceeded or its active window changes
+	MinRequeueInterval time.Duration
+
+	// MaxActiveDuration is the cap of how long the chaos could be active since it's injected, zero means unlimited
+	MaxActiveDuration time.Duration
 }
 
 // Reconcile the common chaos
@@ -91,14 +102,19 @@ func (ctx *reconcileContext) CalcDesiredPhase() (v1alpha1.DesiredPhase, []record
 		return v1alpha1.RunningPhase, events
 	}
 
-	// Consider the duration
+	// Consider the duration. The duration of a running chaos could be updated,
+	// and if it's shortened below the elapsed time, the chaos is stopped right now
+	// rather than waiting for the original requeue.
 	now := time.Now()
+	if ctx.Clock != nil {
+		now = ctx.Clock.Now()
+	}
 
 	durationExceeded, untilStop, err := ctx.obj.DurationExceeded(now)
 	if err != nil {
 		ctx.Log.Error(err, "failed to parse duration")
 	}
-	if durationExceeded {
+	if durationExceeded && !ctx.obj.IsManualRecover() {
 		if ctx.obj.GetStatus().Experiment.DesiredPhase != v1alpha1.StoppedPhase {
 			events = append(events, recorder.TimeUp{})
 		}
@@ -107,6 +123,22 @@ func (ctx *reconcileContext) CalcDesiredPhase() (v1alpha1.DesiredPhase, []record
 
 	ctx.requeueAfter = untilStop
 
+	// The max active duration is a safety net of the cluster, the chaos injected for too long is recovered
+	// no matter what its spec says. It stays recovered, as the injection time of the records is kept.
+	activeExceeded, untilExceeded := ctx.activeDurationExceeded(now)
+	if activeExceeded {
+		if ctx.obj.GetStatus().Experiment.DesiredPhase != v1alpha1.StoppedPhase {
+			ctx.Log.Info("chaos is active longer than the max active duration", "maxActiveDuration", ctx.MaxActiveDuration)
+			events = append(events, recorder.MaxActiveDurationExceeded{
+				MaxActiveDuration: ctx.MaxActiveDuration.String(),
+			})
+		}
+		return v1alpha1.StoppedPhase, events
+	}
+	if untilExceeded > 0 && (ctx.requeueAfter == 0 || untilExceeded < ctx.requeueAfter) {
+		ctx.requeueAfter = untilExceeded
+	}
+
 	// Then decide the pause logic
 	if ctx.obj.IsPaused() {
 		if ctx.obj.GetStatus().Experiment.DesiredPhase != v1alpha1.StoppedPhase {
@@ -115,12 +147,61 @@ func (ctx *reconcileContext) CalcDesiredPhase() (v1alpha1.DesiredPhase, []record
 		return v1alpha1.StoppedPhase, events
 	}
 
+	// The chaos recovered manually is neither applied again nor recovered after its duration, it stays
+	// in the current phase until it's paused or deleted.
+	if durationExceeded {
+		if ctx.obj.GetStatus().Experiment.DesiredPhase != v1alpha1.RunningPhase {
+			return v1alpha1.StoppedPhase, events
+		}
+		return v1alpha1.RunningPhase, events
+	}
+
+	// The chaos is recovered outside its active windows, and it's reconciled again
+	// when the next window begins or the current one ends.
+	outOfWindows, untilChange, err := ctx.obj.OutOfActiveWindows(now)
+	if err != nil {
+		ctx.Log.Error(err, "failed to parse active windows")
+	}
+	if untilChange > 0 && (ctx.requeueAfter == 0 || untilChange < ctx.requeueAfter) {
+		ctx.requeueAfter = untilChange
+	}
+	if outOfWindows {
+		if ctx.obj.GetStatus().Experiment.DesiredPhase != v1alpha1.StoppedPhase {
+			events = append(events, recorder.OutOfActiveWindows{})
+		}
+		return v1alpha1.StoppedPhase, events
+	}
+
 	if ctx.obj.GetStatus().Experiment.DesiredPhase != v1alpha1.RunningPhase {
 		events = append(events, recorder.Started{})
 	}
 	return v1alpha1.RunningPhase, events
 }
 
+// activeDurationExceeded returns whether the chaos has been active longer than the max active duration since
+// its earliest record is injected, and the time until it's exceeded
+func (ctx *reconcileContext) activeDurationExceeded(now time.Time) (bool, time.Duration) {
+	if ctx.MaxActiveDuration <= 0 {
+		return false, 0
+	}
+
+	var injectedAt *metav1.Time
+	for _, record := range ctx.obj.GetStatus().Experiment.Records {
+		if record.InjectedAt != nil && (injectedAt == nil || record.InjectedAt.Before(injectedAt)) {
+			injectedAt = record.InjectedAt
+		}
+	}
+	if injectedAt == nil {
+		return false, 0
+	}
+
+	untilExceeded := injectedAt.Add(ctx.MaxActiveDuration).Sub(now)
+	if untilExceeded <= 0 {
+		return true, 0
+	}
+	return false, untilExceeded
+}
+
 func (ctx *reconcileContext) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	desiredPhase, events := ctx.CalcDesiredPhase()
 
@@ -159,5 +240,9 @@ func (ctx *reconcileContext) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			Field: "desiredPhase",
 		})
 	}
-	return ctrl.Result{RequeueAfter: ctx.requeueAfter}, nil
+	requeueAfter := ctx.requeueAfter
+	if requeueAfter > 0 && requeueAfter < ctx.MinRequeueInterval {
+		requeueAfter = ctx.MinRequeueInterval
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
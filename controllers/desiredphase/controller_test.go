@@ -0,0 +1,395 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package desiredphase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	commonctrl "github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/controllers/condition"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func TestShortenDuration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "shorten",
+	}
+	duration := v1alpha1.Duration("1h")
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         key.Namespace,
+			Name:              key.Name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+		},
+		Spec: v1alpha1.TimeChaosSpec{
+			TimeOffset: "100ms",
+			Duration:   &duration,
+		},
+	}
+
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	debugRecorder := recorder.NewDebugRecorder()
+	r := &Reconciler{
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+
+	result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+	g.Expect(chaos.Status.Experiment.DesiredPhase).To(Equal(v1alpha1.RunningPhase))
+	g.Expect(result.RequeueAfter).To(BeNumerically("~", 50*time.Minute, time.Minute))
+
+	// the duration is reduced below the elapsed time
+	shorterDuration := v1alpha1.Duration("5m")
+	chaos.Spec.Duration = &shorterDuration
+	g.Expect(fakeClient.Update(context.TODO(), chaos)).To(Succeed())
+
+	result, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+	g.Expect(chaos.Status.Experiment.DesiredPhase).To(Equal(v1alpha1.StoppedPhase))
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.TimeUp{}))
+}
+
+func TestRequeueAtStopTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "requeue",
+	}
+	createdAt := time.Date(2021, time.June, 16, 12, 0, 0, 0, time.UTC)
+	duration := v1alpha1.Duration("10m")
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         key.Namespace,
+			Name:              key.Name,
+			CreationTimestamp: metav1.NewTime(createdAt),
+		},
+		Spec: v1alpha1.TimeChaosSpec{
+			TimeOffset: "100ms",
+			Duration:   &duration,
+		},
+	}
+
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	fakeClock := clock.NewFakeClock(createdAt.Add(7*time.Minute + 30*time.Second))
+	r := &Reconciler{
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Recorder: recorder.NewDebugRecorder(),
+		Log:      zap.New(zap.UseDevMode(true)),
+		Clock:    fakeClock,
+	}
+	reconcile := func() (v1alpha1.DesiredPhase, time.Duration) {
+		result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+		return chaos.Status.Experiment.DesiredPhase, result.RequeueAfter
+	}
+
+	// the chaos is requeued exactly when the remaining duration runs out
+	phase, requeueAfter := reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.RunningPhase))
+	g.Expect(requeueAfter).To(Equal(2*time.Minute + 30*time.Second))
+
+	// the requeue sooner than the minimum interval is delayed
+	r.MinRequeueInterval = time.Second
+	fakeClock.SetTime(createdAt.Add(10*time.Minute - 100*time.Millisecond))
+	phase, requeueAfter = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.RunningPhase))
+	g.Expect(requeueAfter).To(Equal(time.Second))
+
+	// and the chaos is stopped at the stop time
+	fakeClock.SetTime(createdAt.Add(10 * time.Minute))
+	phase, requeueAfter = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.StoppedPhase))
+	g.Expect(requeueAfter).To(BeZero())
+}
+
+func TestActiveWindows(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "office-hours",
+	}
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+		},
+		Spec: v1alpha1.TimeChaosSpec{
+			TimeOffset: "100ms",
+			ActiveWindows: v1alpha1.ActiveWindows{{
+				Start:    "09:00",
+				End:      "17:00",
+				Days:     []v1alpha1.Weekday{"Mon", "Tue", "Wed", "Thu", "Fri"},
+				TimeZone: "Asia/Shanghai",
+			}},
+		},
+	}
+
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	debugRecorder := recorder.NewDebugRecorder()
+	location, err := time.LoadLocation("Asia/Shanghai")
+	g.Expect(err).ToNot(HaveOccurred())
+	fakeClock := clock.NewFakeClock(time.Date(2021, time.June, 16, 12, 0, 0, 0, location))
+	r := &Reconciler{
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      zap.New(zap.UseDevMode(true)),
+		Clock:    fakeClock,
+	}
+	reconcile := func() (v1alpha1.DesiredPhase, time.Duration) {
+		result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+		return chaos.Status.Experiment.DesiredPhase, result.RequeueAfter
+	}
+
+	// it's Wednesday noon, so the chaos is applied until the window ends
+	phase, requeueAfter := reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.RunningPhase))
+	g.Expect(requeueAfter).To(Equal(5 * time.Hour))
+
+	// the chaos is recovered after work, until the window of Thursday begins
+	fakeClock.SetTime(time.Date(2021, time.June, 16, 18, 0, 0, 0, location))
+	phase, requeueAfter = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.StoppedPhase))
+	g.Expect(requeueAfter).To(Equal(15 * time.Hour))
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.OutOfActiveWindows{}))
+
+	// there is no window on weekends
+	fakeClock.SetTime(time.Date(2021, time.June, 19, 12, 0, 0, 0, location))
+	phase, requeueAfter = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.StoppedPhase))
+	g.Expect(requeueAfter).To(Equal(45 * time.Hour))
+
+	// and it's applied again on Monday
+	fakeClock.SetTime(time.Date(2021, time.June, 21, 9, 0, 0, 0, location))
+	phase, requeueAfter = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.RunningPhase))
+	g.Expect(requeueAfter).To(Equal(8 * time.Hour))
+}
+
+// injectedImpl injects and recovers the chaos successfully
+type injectedImpl struct{}
+
+func (i injectedImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.Injected, nil
+}
+
+func (i injectedImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	return v1alpha1.NotInjected, nil
+}
+
+func TestMaxActiveDuration(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "endless",
+	}
+	injectedAt := time.Date(2021, time.June, 16, 12, 0, 0, 0, time.UTC)
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         key.Namespace,
+			Name:              key.Name,
+			CreationTimestamp: metav1.NewTime(injectedAt),
+		},
+		Spec: v1alpha1.TimeChaosSpec{
+			TimeOffset: "100ms",
+		},
+		Status: v1alpha1.TimeChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0/c0", Phase: v1alpha1.Injected, InjectedAt: &metav1.Time{Time: injectedAt}},
+					},
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	debugRecorder := recorder.NewDebugRecorder()
+	fakeClock := clock.NewFakeClock(injectedAt.Add(23 * time.Hour))
+	r := &Reconciler{
+		Object:            &v1alpha1.TimeChaos{},
+		Client:            fakeClient,
+		Recorder:          debugRecorder,
+		Log:               zap.New(zap.UseDevMode(true)),
+		Clock:             fakeClock,
+		MaxActiveDuration: 24 * time.Hour,
+	}
+	reconcile := func() (v1alpha1.DesiredPhase, time.Duration) {
+		result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		chaos = &v1alpha1.TimeChaos{}
+		g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+		return chaos.Status.Experiment.DesiredPhase, result.RequeueAfter
+	}
+
+	// the chaos without a duration is requeued when it's exceeding the max active duration
+	phase, requeueAfter := reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.RunningPhase))
+	g.Expect(requeueAfter).To(Equal(time.Hour))
+
+	// and it's force recovered then
+	fakeClock.SetTime(injectedAt.Add(24 * time.Hour))
+	phase, _ = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.StoppedPhase))
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.MaxActiveDurationExceeded{MaxActiveDuration: "24h0m0s"}))
+
+	commonReconciler := &commonctrl.Reconciler{
+		Impl:     injectedImpl{},
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Reader:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	_, err := commonReconciler.Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	chaos = &v1alpha1.TimeChaos{}
+	g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+	g.Expect(chaos.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.NotInjected))
+
+	// the recovered chaos isn't injected again, as the injection time is kept
+	phase, _ = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.StoppedPhase))
+}
+
+func TestManualRecover(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      "terminal",
+	}
+	duration := v1alpha1.Duration("1h")
+	createdAt := time.Date(2021, time.June, 16, 12, 0, 0, 0, time.UTC)
+	chaos := &v1alpha1.TimeChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         key.Namespace,
+			Name:              key.Name,
+			CreationTimestamp: metav1.NewTime(createdAt),
+		},
+		Spec: v1alpha1.TimeChaosSpec{
+			TimeOffset:    "100ms",
+			Duration:      &duration,
+			ManualRecover: true,
+		},
+		Status: v1alpha1.TimeChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0/c0", Phase: v1alpha1.Injected, InjectedAt: &metav1.Time{Time: createdAt}},
+						{Id: "default/p1/c0", Phase: v1alpha1.NotInjected},
+					},
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos)
+	debugRecorder := recorder.NewDebugRecorder()
+	fakeClock := clock.NewFakeClock(createdAt.Add(2 * time.Hour))
+	r := &Reconciler{
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      zap.New(zap.UseDevMode(true)),
+		Clock:    fakeClock,
+	}
+	conditionReconciler := &condition.Reconciler{
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	commonReconciler := &commonctrl.Reconciler{
+		Impl:     injectedImpl{},
+		Object:   &v1alpha1.TimeChaos{},
+		Client:   fakeClient,
+		Reader:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	reconcile := func() (v1alpha1.DesiredPhase, time.Duration) {
+		result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = conditionReconciler.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = commonReconciler.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		chaos = &v1alpha1.TimeChaos{}
+		g.Expect(fakeClient.Get(context.TODO(), key, chaos)).To(Succeed())
+		return chaos.Status.Experiment.DesiredPhase, result.RequeueAfter
+	}
+
+	// the expired chaos stays injected, and it's not requeued by the duration
+	phase, requeueAfter := reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.RunningPhase))
+	g.Expect(requeueAfter).To(BeZero())
+	g.Expect(chaos.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(debugRecorder.Events[key]).ToNot(ContainElement(recorder.TimeUp{}))
+
+	// but the record not injected before the duration is exceeded isn't applied any more
+	g.Expect(chaos.Status.Conditions).To(ContainElement(v1alpha1.ChaosCondition{
+		Type:   v1alpha1.ConditionDurationExceeded,
+		Status: corev1.ConditionTrue,
+	}))
+	g.Expect(chaos.Status.Experiment.Records[1].Phase).To(Equal(v1alpha1.NotInjected))
+	// and the chaos isn't shown as injecting it forever
+	g.Expect(chaos.Status.Phase).To(Equal(v1alpha1.ChaosPhaseRunning))
+
+	// it's recovered when it's paused explicitly
+	chaos.Annotations = map[string]string{v1alpha1.PauseAnnotationKey: "true"}
+	g.Expect(fakeClient.Update(context.TODO(), chaos)).To(Succeed())
+	phase, _ = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.StoppedPhase))
+	g.Expect(chaos.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.NotInjected))
+
+	// and it isn't applied again after it's unpaused
+	chaos.Annotations = nil
+	g.Expect(fakeClient.Update(context.TODO(), chaos)).To(Succeed())
+	phase, _ = reconcile()
+	g.Expect(phase).To(Equal(v1alpha1.StoppedPhase))
+	g.Expect(chaos.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.NotInjected))
+}
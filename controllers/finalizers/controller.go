@@ -15,6 +15,7 @@ package finalizers
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -47,6 +48,10 @@ type Reconciler struct {
 	Recorder recorder.ChaosRecorder
 
 	Log logr.Logger
+
+	// Timeout is how long to wait for the records to be recovered after the chaos is deleted,
+	// zero means waiting forever
+	Timeout time.Duration
 }
 
 // Reconcile the common chaos
@@ -66,6 +71,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	finalizers := obj.GetObjectMeta().Finalizers
 	records := obj.GetStatus().Experiment.Records
 	shouldUpdate := false
+	requeueAfter := time.Duration(0)
 	if obj.IsDeleted() {
 		resumed := true
 		for _, record := range records {
@@ -78,6 +84,19 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			r.Recorder.Event(obj, recorder.FinalizerRemoved{})
 			finalizers = []string{}
 			shouldUpdate = true
+		} else if r.Timeout > 0 && len(finalizers) != 0 {
+			// give up the recovery to make the deletion bounded
+			deadline := obj.GetObjectMeta().DeletionTimestamp.Add(r.Timeout)
+			if now := time.Now(); now.Before(deadline) {
+				requeueAfter = deadline.Sub(now)
+			} else {
+				r.Log.Info("chaos is not recovered before timeout, remove the finalizer", "timeout", r.Timeout)
+				r.Recorder.Event(obj, recorder.FinalizerTimedOut{
+					Timeout: r.Timeout.String(),
+				})
+				finalizers = []string{}
+				shouldUpdate = true
+			}
 		}
 	} else {
 		if !ContainsFinalizer(obj.(metav1.Object), RecordFinalizer) {
@@ -113,7 +132,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			Field: "finalizer",
 		})
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // ContainsFinalizer checks an Object that the provided finalizer is present.
@@ -19,6 +19,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -26,6 +27,8 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 )
 
+const finalizerTimeout = 5 * time.Second
+
 // These tests use Ginkgo (BDD-style Go testing framework). Refer to
 // http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
 
@@ -45,7 +48,7 @@ var _ = Describe("Finalizer", func() {
 				Name:      "foo1",
 				Namespace: "default",
 			}
-			duration := "1000s"
+			duration := v1alpha1.Duration("1000s")
 			chaos := &v1alpha1.TimeChaos{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "foo1",
@@ -85,5 +88,73 @@ var _ = Describe("Finalizer", func() {
 				Expect(k8sClient.Delete(context.TODO(), chaos)).To(Succeed())
 			}
 		})
+
+		It("should remove record finalizer after timeout", func() {
+			key := types.NamespacedName{
+				Name:      "foo2",
+				Namespace: "default",
+			}
+			duration := v1alpha1.Duration("1000s")
+			chaos := &v1alpha1.TimeChaos{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo2",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.TimeChaosSpec{
+					TimeOffset: "100ms",
+					ClockIds:   []string{"CLOCK_REALTIME"},
+					Duration:   &duration,
+					ContainerSelector: v1alpha1.ContainerSelector{
+						PodSelector: v1alpha1.PodSelector{
+							Mode: v1alpha1.OnePodMode,
+						},
+					},
+				},
+			}
+
+			By("creating a chaos")
+			{
+				Expect(k8sClient.Create(context.TODO(), chaos)).To(Succeed())
+				err := wait.Poll(time.Second*1, time.Second*10, func() (ok bool, err error) {
+					err = k8sClient.Get(context.TODO(), key, chaos)
+					if err != nil {
+						return false, err
+					}
+					return ContainsFinalizer(chaos, RecordFinalizer), nil
+				})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			By("injecting a record which can never be recovered")
+			{
+				chaos.Status.Experiment.Records = []*v1alpha1.Record{
+					{
+						Id:          "default/not-exist",
+						SelectorKey: ".",
+						Phase:       v1alpha1.Injected,
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), chaos)).To(Succeed())
+			}
+
+			By("deleting the chaos")
+			{
+				Expect(k8sClient.Delete(context.TODO(), chaos)).To(Succeed())
+				Expect(k8sClient.Get(context.TODO(), key, chaos)).To(Succeed())
+				Expect(chaos.IsDeleted()).To(BeTrue())
+			}
+
+			By("removing the finalizer after timeout")
+			{
+				err := wait.Poll(time.Second*1, finalizerTimeout*3, func() (ok bool, err error) {
+					err = k8sClient.Get(context.TODO(), key, chaos)
+					if apierrors.IsNotFound(err) {
+						return true, nil
+					}
+					return false, err
+				})
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
 	})
 })
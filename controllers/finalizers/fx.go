@@ -19,6 +19,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	ccfg "github.com/chaos-mesh/chaos-mesh/controllers/config"
 	"github.com/chaos-mesh/chaos-mesh/controllers/types"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
@@ -40,6 +41,7 @@ func NewController(mgr ctrl.Manager, client client.Client, logger logr.Logger, r
 				Client:   client,
 				Recorder: recorderBuilder.Build("finalizer"),
 				Log:      logger.WithName("finalizers"),
+				Timeout:  ccfg.ControllerCfg.FinalizerTimeout,
 			})
 		if err != nil {
 			return "", err
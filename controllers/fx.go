@@ -21,9 +21,11 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/condition"
 	"github.com/chaos-mesh/chaos-mesh/controllers/desiredphase"
 	"github.com/chaos-mesh/chaos-mesh/controllers/finalizers"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podannotation"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podhttpchaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podiochaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podsidecar"
 	"github.com/chaos-mesh/chaos-mesh/controllers/schedule"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
@@ -60,6 +62,14 @@ var Module = fx.Options(
 			Group:  "controller",
 			Target: podiochaos.NewController,
 		},
+		fx.Annotated{
+			Group:  "controller",
+			Target: podannotation.NewController,
+		},
+		fx.Annotated{
+			Group:  "controller",
+			Target: podsidecar.NewController,
+		},
 
 		chaosdaemon.New,
 		recorder.NewRecorderBuilder,
@@ -39,6 +39,11 @@ type ChaosCollector struct {
 	ConfigNameDuplicate *prometheus.CounterVec
 	InjectRequired      *prometheus.CounterVec
 	Injections          *prometheus.CounterVec
+	SelectedTargets     *prometheus.HistogramVec
+	SelectionDuration   *prometheus.HistogramVec
+	InjectionDuration   *prometheus.HistogramVec
+	ChaosImplFailures   *prometheus.CounterVec
+	Recoveries          *prometheus.CounterVec
 }
 
 // NewChaosCollector initializes metrics and collector
@@ -81,6 +86,29 @@ func NewChaosCollector(store cache.Cache, registerer prometheus.Registerer) *Cha
 			Name: "chaos_mesh_injections_total",
 			Help: "Total number of sidecar injections performed on the webhook",
 		}, []string{"namespace", "config"}),
+		SelectedTargets: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "chaos_mesh_selected_targets",
+			Help:    "Number of targets selected by a selector of chaos",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"kind"}),
+		SelectionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "chaos_mesh_selection_duration_seconds",
+			Help:    "Time taken to select the targets by a selector of chaos",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"kind"}),
+		InjectionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "chaos_mesh_injection_duration_seconds",
+			Help:    "Time taken to inject the chaos into a target",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"kind", "action"}),
+		ChaosImplFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chaos_mesh_chaos_impl_failures_total",
+			Help: "Total number of failures when applying or recovering the chaos",
+		}, []string{"kind", "operation", "reason"}),
+		Recoveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chaos_mesh_recoveries_total",
+			Help: "Total number of the attempts to recover the chaos on the targets, by their results",
+		}, []string{"kind", "result"}),
 	}
 	registerer.MustRegister(c)
 	return c
@@ -97,6 +125,11 @@ func (c *ChaosCollector) Describe(ch chan<- *prometheus.Desc) {
 	c.TemplateLoadError.Describe(ch)
 	c.InjectRequired.Describe(ch)
 	c.Injections.Describe(ch)
+	c.SelectedTargets.Describe(ch)
+	c.SelectionDuration.Describe(ch)
+	c.InjectionDuration.Describe(ch)
+	c.ChaosImplFailures.Describe(ch)
+	c.Recoveries.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -110,6 +143,11 @@ func (c *ChaosCollector) Collect(ch chan<- prometheus.Metric) {
 	c.TemplateLoadError.Collect(ch)
 	c.InjectRequired.Collect(ch)
 	c.Injections.Collect(ch)
+	c.SelectedTargets.Collect(ch)
+	c.SelectionDuration.Collect(ch)
+	c.InjectionDuration.Collect(ch)
+	c.ChaosImplFailures.Collect(ch)
+	c.Recoveries.Collect(ch)
 	c.experimentStatus.Collect(ch)
 }
 
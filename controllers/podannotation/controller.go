@@ -0,0 +1,206 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podannotation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+const (
+	// ManagedByLabelKey is set on the NetworkChaos created for an annotated pod
+	ManagedByLabelKey = "chaos-mesh.org/managed-by-annotation"
+
+	chaosNameSuffix = "-netem-delay"
+)
+
+// Reconciler turns the netem-delay annotation of pods into ephemeral NetworkChaos
+type Reconciler struct {
+	client.Client
+
+	Recorder recorder.ChaosRecorder
+	Log      logr.Logger
+}
+
+// ParseNetemDelay validates the value of the netem-delay annotation
+func ParseNetemDelay(value string) (time.Duration, error) {
+	latency, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse latency %q error: %s", value, err)
+	}
+	if latency <= 0 {
+		return 0, fmt.Errorf("latency %q should be greater than 0", value)
+	}
+
+	return latency, nil
+}
+
+// ChaosName returns the name of the NetworkChaos managed for the pod
+func ChaosName(podName string) string {
+	return podName + chaosNameSuffix
+}
+
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.TODO()
+
+	chaosKey := types.NamespacedName{
+		Namespace: req.Namespace,
+		Name:      ChaosName(req.Name),
+	}
+	chaos := &v1alpha1.NetworkChaos{}
+	chaosExists := true
+	if err := r.Client.Get(ctx, chaosKey, chaos); err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to get networkchaos", "key", chaosKey)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		chaosExists = false
+	}
+	if chaosExists && chaos.Labels[ManagedByLabelKey] != req.Name {
+		// the NetworkChaos is created by someone else, never touch it
+		r.Log.Info("networkchaos is not managed by annotation, skip", "key", chaosKey)
+		return ctrl.Result{}, nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.Client.Get(ctx, req.NamespacedName, pod); err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to get pod")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		// the NetworkChaos will be collected through the owner reference
+		return ctrl.Result{}, nil
+	}
+
+	value, annotated := pod.Annotations[v1alpha1.NetemDelayAnnotationKey]
+	if !annotated {
+		if chaosExists {
+			return r.deleteChaos(ctx, pod, chaos)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if _, err := ParseNetemDelay(value); err != nil {
+		r.Recorder.Event(pod, recorder.Failed{
+			Activity: "parse " + v1alpha1.NetemDelayAnnotationKey + " annotation",
+			Err:      err.Error(),
+		})
+		if chaosExists {
+			return r.deleteChaos(ctx, pod, chaos)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if chaosExists {
+		if !chaos.IsDeleted() && chaos.Spec.Delay != nil && chaos.Spec.Delay.Latency == value {
+			return ctrl.Result{}, nil
+		}
+
+		// the spec of a chaos cannot be updated, so the old one is removed first
+		// and a new one is created after it has been recovered.
+		if !chaos.IsDeleted() {
+			if result, err := r.deleteChaos(ctx, pod, chaos); err != nil || result.Requeue {
+				return result, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+
+	chaos = newNetemDelayChaos(pod, value)
+	if err := r.Client.Create(ctx, chaos); err != nil {
+		r.Log.Error(err, "fail to create networkchaos", "key", chaosKey)
+		r.Recorder.Event(pod, recorder.Failed{
+			Activity: "create networkchaos",
+			Err:      err.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+	r.Recorder.Event(pod, recorder.NetemDelayChaosCreated{
+		Name: chaos.Name,
+	})
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) deleteChaos(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.NetworkChaos) (ctrl.Result, error) {
+	if chaos.IsDeleted() {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Client.Delete(ctx, chaos); err != nil && !apierrors.IsNotFound(err) {
+		r.Log.Error(err, "fail to delete networkchaos", "name", chaos.Name)
+		r.Recorder.Event(pod, recorder.Failed{
+			Activity: "delete networkchaos",
+			Err:      err.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func newNetemDelayChaos(pod *corev1.Pod, latency string) *v1alpha1.NetworkChaos {
+	chaos := &v1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ChaosName(pod.Name),
+			Namespace: pod.Namespace,
+			Labels: map[string]string{
+				ManagedByLabelKey: pod.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "v1",
+					Kind:               "Pod",
+					Name:               pod.Name,
+					UID:                pod.UID,
+					Controller:         pointer.BoolPtr(true),
+					BlockOwnerDeletion: pointer.BoolPtr(true),
+				},
+			},
+		},
+		Spec: v1alpha1.NetworkChaosSpec{
+			PodSelector: v1alpha1.PodSelector{
+				Selector: v1alpha1.PodSelectorSpec{
+					Namespaces: []string{pod.Namespace},
+					Pods: map[string][]string{
+						pod.Namespace: {pod.Name},
+					},
+				},
+				Mode: v1alpha1.AllPodMode,
+			},
+			Action: v1alpha1.DelayAction,
+			TcParameter: v1alpha1.TcParameter{
+				Delay: &v1alpha1.DelaySpec{
+					Latency: latency,
+				},
+			},
+		},
+	}
+	chaos.Default()
+
+	return chaos
+}
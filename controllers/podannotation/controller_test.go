@@ -0,0 +1,145 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podannotation
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestParseNetemDelay(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := ParseNetemDelay("100ms")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for _, value := range []string{"", "100", "abc", "0s", "-10ms"} {
+		_, err := ParseNetemDelay(value)
+		g.Expect(err).To(HaveOccurred(), "value: %q", value)
+	}
+}
+
+func TestReconcileAnnotatedPod(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := NewPod(PodArg{
+		Name: "p0",
+		Ans:  map[string]string{v1alpha1.NetemDelayAnnotationKey: "100ms"},
+	})
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), &pod)
+	debugRecorder := recorder.NewDebugRecorder()
+	r := &Reconciler{
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "p0",
+		},
+	}
+	chaosKey := types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      ChaosName("p0"),
+	}
+
+	// annotating a pod creates the chaos
+	{
+		_, err := r.Reconcile(req)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		chaos := &v1alpha1.NetworkChaos{}
+		g.Expect(fakeClient.Get(context.TODO(), chaosKey, chaos)).To(Succeed())
+		g.Expect(chaos.Spec.Action).To(Equal(v1alpha1.DelayAction))
+		g.Expect(chaos.Spec.Delay.Latency).To(Equal("100ms"))
+		g.Expect(chaos.Spec.Selector.Pods).To(Equal(map[string][]string{metav1.NamespaceDefault: {"p0"}}))
+		g.Expect(chaos.Labels[ManagedByLabelKey]).To(Equal("p0"))
+		g.Expect(chaos.OwnerReferences).To(HaveLen(1))
+		g.Expect(chaos.OwnerReferences[0].Name).To(Equal("p0"))
+		g.Expect(debugRecorder.Events[req.NamespacedName]).To(ContainElement(recorder.NetemDelayChaosCreated{
+			Name: chaosKey.Name,
+		}))
+
+		// the chaos lasts as long as the annotation, so it's allowed without duration when the duration is required
+		v1alpha1.RequireDuration = true
+		v1alpha1.RegisterOwnerReader(fakeClient)
+		defer func() {
+			v1alpha1.RequireDuration = false
+			v1alpha1.RegisterOwnerReader(nil)
+		}()
+		g.Expect(chaos.ValidateCreate()).To(Succeed())
+	}
+
+	// de-annotating the pod removes the chaos
+	{
+		g.Expect(fakeClient.Get(context.TODO(), req.NamespacedName, &pod)).To(Succeed())
+		pod.Annotations = nil
+		g.Expect(fakeClient.Update(context.TODO(), &pod)).To(Succeed())
+
+		_, err := r.Reconcile(req)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		err = fakeClient.Get(context.TODO(), chaosKey, &v1alpha1.NetworkChaos{})
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	}
+}
+
+func TestReconcileInvalidAnnotation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := NewPod(PodArg{
+		Name: "p0",
+		Ans:  map[string]string{v1alpha1.NetemDelayAnnotationKey: "fast"},
+	})
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), &pod)
+	debugRecorder := recorder.NewDebugRecorder()
+	r := &Reconciler{
+		Client:   fakeClient,
+		Recorder: debugRecorder,
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "p0",
+		},
+	}
+
+	_, err := r.Reconcile(req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: metav1.NamespaceDefault,
+		Name:      ChaosName("p0"),
+	}, &v1alpha1.NetworkChaos{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	events := debugRecorder.Events[req.NamespacedName]
+	g.Expect(events).To(HaveLen(1))
+	g.Expect(events[0]).To(BeAssignableToTypeOf(recorder.Failed{}))
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podannotation
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/types"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func NewController(mgr ctrl.Manager, client client.Client, logger logr.Logger, recorderBuilder *recorder.RecorderBuilder) (types.Controller, error) {
+	err := builder.Default(mgr).
+		For(&corev1.Pod{}).
+		Owns(&v1alpha1.NetworkChaos{}).
+		Named("podannotation").
+		WithEventFilter(predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool {
+				if _, ok := e.Object.(*corev1.Pod); ok {
+					_, annotated := e.Meta.GetAnnotations()[v1alpha1.NetemDelayAnnotationKey]
+					return annotated
+				}
+				return false
+			},
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				if _, ok := e.ObjectNew.(*corev1.Pod); ok {
+					return e.MetaOld.GetAnnotations()[v1alpha1.NetemDelayAnnotationKey] != e.MetaNew.GetAnnotations()[v1alpha1.NetemDelayAnnotationKey]
+				}
+				return false
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				// a deleted NetworkChaos may need to be recreated with the new latency
+				_, ok := e.Object.(*v1alpha1.NetworkChaos)
+				return ok
+			},
+		}).
+		Complete(&Reconciler{
+			Client:   client,
+			Recorder: recorderBuilder.Build("podannotation"),
+			Log:      logger.WithName("podannotation"),
+		})
+	if err != nil {
+		return "", err
+	}
+
+	return "podannotation", nil
+}
@@ -16,6 +16,7 @@ package podnetworkchaos
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -31,6 +32,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/iptable"
 	tcpkg "github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/tc"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 	pbutils "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/netem"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
@@ -39,6 +41,9 @@ import (
 
 const (
 	invalidNetemSpecMsg = "invalid spec for netem action, at least one is required from delay, loss, duplicate, corrupt"
+
+	// maxPeersInEvent bounds the peers listed in the event of the applied traffic control
+	maxPeersInEvent = 10
 )
 
 // Reconciler applys podnetworkchaos
@@ -200,35 +205,175 @@ func (r *Reconciler) SetIptables(ctx context.Context, pod *corev1.Pod, chaos *v1
 
 // SetTcs sets traffic control related chaos on pod
 func (r *Reconciler) SetTcs(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.PodNetworkChaos) error {
+	tcs, err := buildTcs(chaos)
+	if err != nil {
+		return err
+	}
+
+	r.Log.Info("setting tcs", "tcs", tcs)
+	if err := tcpkg.SetTcs(ctx, r.ChaosDaemonClientBuilder, pod, tcs); err != nil {
+		return err
+	}
+
+	r.recordTcs(ctx, pod, chaos, tcs)
+	return nil
+}
+
+// recordTcs records the applied tcs on the network chaos which they come from, so that the parameters rendered
+// for the chaos daemon could be correlated with the spec of the chaos
+func (r *Reconciler) recordTcs(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.PodNetworkChaos, tcs []*pb.Tc) {
+	cidrs := make(map[string][]string)
+	for _, ipset := range chaos.Spec.IPSets {
+		cidrs[ipset.Name] = ipset.Cidrs
+	}
+
+	for i, tc := range chaos.Spec.TrafficControls {
+		networkchaos := &v1alpha1.NetworkChaos{}
+		if err := r.Client.Get(ctx, controller.ParseNamespacedName(tc.Source), networkchaos); err != nil {
+			r.Log.Error(err, "unable to get the source of the traffic control", "source", tc.Source)
+			continue
+		}
+
+		r.Recorder.Event(networkchaos, recorder.TrafficControlApplied{
+			Pod:   pod.Namespace + "/" + pod.Name,
+			Tc:    describeTc(tcs[i]),
+			Peers: summarizePeers(tc.IPSet, cidrs[tc.IPSet]),
+		})
+	}
+}
+
+// describeTc renders the tc as the arguments of the qdiscs and the filter, e.g. "netem delay 100000 protocol tcp"
+func describeTc(tc *pb.Tc) string {
+	var desc string
+	switch tc.Type {
+	case pb.Tc_NETEM:
+		desc = "netem " + pbutils.NetemArgs(tc.Netem)
+	case pb.Tc_BANDWIDTH:
+		desc = "tbf " + pbutils.TbfArgs(tc.Tbf)
+	}
+	if tc.Child != nil {
+		desc += " with " + describeTc(tc.Child)
+	}
+
+	if len(tc.Protocol) > 0 {
+		desc += " protocol " + tc.Protocol
+	}
+	if len(tc.EgressPort) > 0 {
+		desc += " egress port " + tc.EgressPort
+	}
+	if len(tc.SourcePort) > 0 {
+		desc += " source port " + tc.SourcePort
+	}
+	if tc.Probability > 0 {
+		desc += fmt.Sprintf(" probability %f", tc.Probability)
+	}
+	return desc
+}
+
+// summarizePeers lists the cidrs of the ipset, the ones beyond maxPeersInEvent are counted but not listed, so that
+// the event is bounded for large pod sets
+func summarizePeers(ipset string, cidrs []string) string {
+	if ipset == "" {
+		return "all"
+	}
+	if len(cidrs) <= maxPeersInEvent {
+		return fmt.Sprintf("%s %v", ipset, cidrs)
+	}
+	return fmt.Sprintf("%s %v and %d more", ipset, cidrs[:maxPeersInEvent], len(cidrs)-maxPeersInEvent)
+}
+
+// buildTcs converts the traffic controls to the tcs of the chaos daemon
+func buildTcs(chaos *v1alpha1.PodNetworkChaos) ([]*pb.Tc, error) {
 	tcs := []*pb.Tc{}
 	for _, tc := range chaos.Spec.TrafficControls {
 		if tc.Type == v1alpha1.Bandwidth {
 			tbf, err := netem.FromBandwidth(tc.Bandwidth)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			tcs = append(tcs, &pb.Tc{
-				Type:  pb.Tc_BANDWIDTH,
-				Tbf:   tbf,
-				Ipset: tc.IPSet,
+				Type:       pb.Tc_BANDWIDTH,
+				Tbf:        tbf,
+				Ipset:      tc.IPSet,
+				Protocol:   tc.Protocol,
+				EgressPort: tc.EgressPort,
 			})
 		} else if tc.Type == v1alpha1.Netem {
 			netem, err := mergeNetem(tc.TcParameter)
 			if err != nil {
-				return err
+				return nil, err
+			}
+			probability, err := netemProbability(tc.TcParameter)
+			if err != nil {
+				return nil, err
+			}
+			// no packet is flowed into the netem qdisc, so it isn't set up at all
+			if probability == 0 {
+				continue
+			}
+			// all the packets are flowed into the netem qdisc, so they aren't classified randomly
+			if probability >= 100 {
+				probability = 0
 			}
 			tcs = append(tcs, &pb.Tc{
-				Type:  pb.Tc_NETEM,
-				Netem: netem,
-				Ipset: tc.IPSet,
+				Type:        pb.Tc_NETEM,
+				Netem:       netem,
+				Ipset:       tc.IPSet,
+				Protocol:    tc.Protocol,
+				EgressPort:  tc.EgressPort,
+				Probability: probability,
+			})
+		} else if tc.Type == v1alpha1.ShapedNetem {
+			// the netem qdisc is attached under the tbf qdisc, so that the shaped link is also impaired
+			if tc.Bandwidth == nil {
+				return nil, errors.New("bandwidth is required to shape the link")
+			}
+			tbf, err := netem.FromBandwidth(tc.Bandwidth)
+			if err != nil {
+				return nil, err
+			}
+			netem, err := mergeNetem(tc.TcParameter)
+			if err != nil {
+				return nil, err
+			}
+			tcs = append(tcs, &pb.Tc{
+				Type:       pb.Tc_BANDWIDTH,
+				Tbf:        tbf,
+				Ipset:      tc.IPSet,
+				Protocol:   tc.Protocol,
+				EgressPort: tc.EgressPort,
+				Child: &pb.Tc{
+					Type:  pb.Tc_NETEM,
+					Netem: netem,
+				},
 			})
 		} else {
-			return fmt.Errorf("unknown tc type")
+			return nil, fmt.Errorf("unknown tc type")
 		}
 	}
 
-	r.Log.Info("setting tcs", "tcs", tcs)
-	return tcpkg.SetTcs(ctx, r.ChaosDaemonClientBuilder, pod, tcs)
+	return tcs, nil
+}
+
+// netemProbability returns the percentage of the packets flowed into the netem qdisc, which is shared by
+// the delay and the corrupt. All the packets are flowed into it if the probability isn't specified.
+func netemProbability(spec v1alpha1.TcParameter) (float32, error) {
+	probability := ""
+	if spec.Delay != nil {
+		probability = spec.Delay.Probability
+	}
+	if spec.Corrupt != nil && len(spec.Corrupt.Probability) > 0 {
+		probability = spec.Corrupt.Probability
+	}
+	if len(probability) == 0 {
+		return 100, nil
+	}
+
+	percentage, err := strconv.ParseFloat(probability, 32)
+	if err != nil {
+		return 0, err
+	}
+	return float32(percentage), nil
 }
 
 // NetemSpec defines the interface to convert to a Netem protobuf
@@ -282,5 +427,11 @@ func mergeNetem(spec v1alpha1.TcParameter) (*pb.Netem, error) {
 	for _, em := range emSpecs {
 		merged = pbutils.MergeNetem(merged, em)
 	}
+	if merged.Loss >= 100 && (merged.Time > 0 || merged.Duplicate > 0 || merged.Corrupt > 0) {
+		return nil, errors.New("100% loss could not be combined with delay, duplicate or corrupt")
+	}
+	if merged.Reorder > 0 && merged.Time == 0 {
+		return nil, errors.New("reorder requires a positive latency")
+	}
 	return merged, nil
 }
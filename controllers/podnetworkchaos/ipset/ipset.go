@@ -51,7 +51,7 @@ func BuildIPSet(pods []v1.Pod, externalCidrs []string, networkchaos *v1alpha1.Ne
 
 // GenerateIPSetName generates name for ipset
 func GenerateIPSetName(networkchaos *v1alpha1.NetworkChaos, namePostFix string) string {
-	return netutils.CompressName(networkchaos.Name, 27, namePostFix)
+	return netutils.CompressName(netutils.UniqueName(networkchaos.Name, string(networkchaos.UID)), 27, namePostFix)
 }
 
 // FlushIPSets makes grpc calls to chaosdaemon to save ipset
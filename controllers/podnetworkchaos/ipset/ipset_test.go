@@ -51,4 +51,18 @@ func Test_generateIPSetName(t *testing.T) {
 
 		g.Expect(len(name)).Should(Equal(27))
 	})
+	t.Run("namespaced by uid", func(t *testing.T) {
+		// the chaos with the same name in different namespaces never share the ipsets on the same pod
+		first := &v1alpha1.NetworkChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "test", UID: "4c3a0e3e-1f6b-4bfb-9d3c-6f9e0d0b7a11"},
+		}
+		second := &v1alpha1.NetworkChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "test", UID: "9b1f7a52-0d4e-4e55-8a8c-2f1c3a6b5d22"},
+		}
+
+		name := GenerateIPSetName(first, postfix)
+		g.Expect(name).Should(HavePrefix("test_"))
+		g.Expect(len(name)).Should(Equal(27))
+		g.Expect(name).ShouldNot(Equal(GenerateIPSetName(second, postfix)))
+	})
 }
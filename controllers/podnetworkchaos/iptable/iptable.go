@@ -65,11 +65,12 @@ func SetIptablesChains(ctx context.Context, builder *chaosdaemon.ChaosDaemonClie
 
 // GenerateName generates chain name for network chaos
 func GenerateName(direction pb.Chain_Direction, networkchaos *v1alpha1.NetworkChaos) (chainName string) {
+	name := netutils.UniqueName(networkchaos.Name, string(networkchaos.UID))
 	switch direction {
 	case pb.Chain_INPUT:
-		chainName = "INPUT/" + netutils.CompressName(networkchaos.Name, 21, "")
+		chainName = "INPUT/" + netutils.CompressName(name, 21, "")
 	case pb.Chain_OUTPUT:
-		chainName = "OUTPUT/" + netutils.CompressName(networkchaos.Name, 20, "")
+		chainName = "OUTPUT/" + netutils.CompressName(name, 20, "")
 	}
 
 	return
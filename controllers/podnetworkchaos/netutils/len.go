@@ -48,3 +48,12 @@ func CompressName(originalName string, targetLength int, namePostFix string) (na
 
 	return
 }
+
+// UniqueName appends the uid to the name of the chaos, so that the names compressed from it are different between
+// the chaos with the same name, e.g. the ones in different namespaces or the ones recreated with the same name
+func UniqueName(name string, uid string) string {
+	if len(uid) == 0 {
+		return name
+	}
+	return name + "_" + uid
+}
@@ -15,6 +15,7 @@ package podnetworkchaos
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -152,4 +153,274 @@ func TestMergenetem(t *testing.T) {
 		}
 		g.Expect(m).Should(Equal(em))
 	})
+
+	t.Run("delay loss duplicate corrupt", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		spec := v1alpha1.TcParameter{
+			Delay: &v1alpha1.DelaySpec{
+				Latency:     "10ms",
+				Correlation: "10",
+				Jitter:      "1ms",
+				Reorder: &v1alpha1.ReorderSpec{
+					Reorder:     "5",
+					Correlation: "15",
+					Gap:         3,
+				},
+			},
+			Loss: &v1alpha1.LossSpec{
+				Loss:        "20",
+				Correlation: "25",
+			},
+			Duplicate: &v1alpha1.DuplicateSpec{
+				Duplicate:   "30",
+				Correlation: "35",
+			},
+			Corrupt: &v1alpha1.CorruptSpec{
+				Corrupt:     "40",
+				Correlation: "45",
+			},
+		}
+		m, err := mergeNetem(spec)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(m).Should(Equal(&pb.Netem{
+			Time:          10000,
+			Jitter:        1000,
+			DelayCorr:     10,
+			Reorder:       5,
+			ReorderCorr:   15,
+			Gap:           3,
+			Loss:          20,
+			LossCorr:      25,
+			Duplicate:     30,
+			DuplicateCorr: 35,
+			Corrupt:       40,
+			CorruptCorr:   45,
+		}))
+	})
+
+	t.Run("omitted correlations", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		spec := v1alpha1.TcParameter{
+			Delay: &v1alpha1.DelaySpec{
+				Latency: "10ms",
+				Jitter:  "1ms",
+				Reorder: &v1alpha1.ReorderSpec{
+					Reorder: "5",
+				},
+			},
+			Loss:      &v1alpha1.LossSpec{Loss: "20"},
+			Duplicate: &v1alpha1.DuplicateSpec{Duplicate: "30"},
+			Corrupt:   &v1alpha1.CorruptSpec{Corrupt: "40"},
+		}
+		m, err := mergeNetem(spec)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(m).Should(Equal(&pb.Netem{
+			Time:      10000,
+			Jitter:    1000,
+			Reorder:   5,
+			Loss:      20,
+			Duplicate: 30,
+			Corrupt:   40,
+		}))
+	})
+
+	t.Run("contradictory", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		_, err := mergeNetem(v1alpha1.TcParameter{
+			Loss:    &v1alpha1.LossSpec{Loss: "100"},
+			Corrupt: &v1alpha1.CorruptSpec{Corrupt: "40"},
+		})
+		g.Expect(err).Should(HaveOccurred())
+
+		_, err = mergeNetem(v1alpha1.TcParameter{
+			Delay: &v1alpha1.DelaySpec{
+				Latency: "0ms",
+				Jitter:  "0ms",
+				Reorder: &v1alpha1.ReorderSpec{Reorder: "5"},
+			},
+		})
+		g.Expect(err).Should(HaveOccurred())
+
+		_, err = mergeNetem(v1alpha1.TcParameter{
+			Loss: &v1alpha1.LossSpec{Loss: "100"},
+		})
+		g.Expect(err).ShouldNot(HaveOccurred())
+	})
+}
+
+func TestBuildShapedNetemTcs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			TrafficControls: []v1alpha1.RawTrafficControl{{
+				Type: v1alpha1.ShapedNetem,
+				TcParameter: v1alpha1.TcParameter{
+					Bandwidth: &v1alpha1.BandwidthSpec{Rate: "1mbps", Limit: 20971520, Buffer: 10000},
+					Delay:     &v1alpha1.DelaySpec{Latency: "90ms", Jitter: "0ms", Correlation: "0"},
+				},
+				IPSet:  "sh-tgt",
+				Source: "default/shaped",
+			}},
+		},
+	}
+
+	// the tbf qdisc is the parent of the netem qdisc
+	tcs, err := buildTcs(chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(1))
+	g.Expect(tcs[0].Type).To(Equal(pb.Tc_BANDWIDTH))
+	g.Expect(tcs[0].Ipset).To(Equal("sh-tgt"))
+	g.Expect(tcs[0].Tbf.Rate).To(BeEquivalentTo(1024 * 1024))
+	g.Expect(tcs[0].Tbf.Limit).To(BeEquivalentTo(20971520))
+	g.Expect(tcs[0].Tbf.Buffer).To(BeEquivalentTo(10000))
+	g.Expect(tcs[0].Child.Type).To(Equal(pb.Tc_NETEM))
+	g.Expect(tcs[0].Child.Netem.Time).To(BeEquivalentTo(90000))
+	g.Expect(tcs[0].Child.Child).To(BeNil())
+
+	chaos.Spec.TrafficControls[0].Bandwidth = nil
+	_, err = buildTcs(chaos)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestBuildNetemTcsWithProbability(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			TrafficControls: []v1alpha1.RawTrafficControl{{
+				Type: v1alpha1.Netem,
+				TcParameter: v1alpha1.TcParameter{
+					Delay:   &v1alpha1.DelaySpec{Latency: "100ms", Jitter: "0ms", Correlation: "0", Probability: "50"},
+					Corrupt: &v1alpha1.CorruptSpec{Corrupt: "10", Correlation: "0", Probability: "50"},
+				},
+				Source: "default/partial",
+			}},
+		},
+	}
+
+	// the probability is shared by the delay and the corrupt in the netem qdisc
+	tcs, err := buildTcs(chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(1))
+	g.Expect(tcs[0].Type).To(Equal(pb.Tc_NETEM))
+	g.Expect(tcs[0].Netem.Time).To(BeEquivalentTo(100000))
+	g.Expect(tcs[0].Netem.Corrupt).To(BeEquivalentTo(10))
+	g.Expect(tcs[0].Probability).To(BeEquivalentTo(50))
+
+	// all the packets are impaired without the probability
+	chaos.Spec.TrafficControls[0].Delay.Probability = ""
+	chaos.Spec.TrafficControls[0].Corrupt = nil
+	tcs, err = buildTcs(chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tcs[0].Probability).To(BeZero())
+
+	// and with the max probability
+	chaos.Spec.TrafficControls[0].Delay.Probability = "100"
+	tcs, err = buildTcs(chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(1))
+	g.Expect(tcs[0].Probability).To(BeZero())
+
+	// no packet is impaired with the zero probability
+	chaos.Spec.TrafficControls[0].Delay.Probability = "0"
+	tcs, err = buildTcs(chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tcs).To(BeEmpty())
+}
+
+func TestBuildTcsWithPortFilter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			TrafficControls: []v1alpha1.RawTrafficControl{{
+				Type: v1alpha1.Netem,
+				TcParameter: v1alpha1.TcParameter{
+					Delay: &v1alpha1.DelaySpec{Latency: "100ms", Jitter: "0ms", Correlation: "0"},
+				},
+				Protocol:   "tcp",
+				EgressPort: "3306",
+				Source:     "default/mysql",
+			}, {
+				Type: v1alpha1.Bandwidth,
+				TcParameter: v1alpha1.TcParameter{
+					Bandwidth: &v1alpha1.BandwidthSpec{Rate: "1mbps", Limit: 100, Buffer: 10000},
+				},
+				Source: "default/all",
+			}},
+		},
+	}
+
+	// only the packets of the matched flows are passed to the filtered qdisc
+	tcs, err := buildTcs(chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(2))
+	g.Expect(tcs[0].Protocol).To(Equal("tcp"))
+	g.Expect(tcs[0].EgressPort).To(Equal("3306"))
+	g.Expect(tcs[1].Protocol).To(BeEmpty())
+	g.Expect(tcs[1].EgressPort).To(BeEmpty())
+}
+
+func TestRecordTcs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	networkchaos := &v1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "delay"},
+	}
+	cidrs := []string{}
+	for i := 0; i < 12; i++ {
+		cidrs = append(cidrs, fmt.Sprintf("10.0.0.%d/32", i))
+	}
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			IPSets: []v1alpha1.RawIPSet{{Name: "de-tgt", Cidrs: cidrs}},
+			TrafficControls: []v1alpha1.RawTrafficControl{
+				{
+					Type: v1alpha1.Netem,
+					TcParameter: v1alpha1.TcParameter{
+						Delay: &v1alpha1.DelaySpec{Latency: "100ms", Jitter: "10ms", Correlation: "25"},
+					},
+					Protocol: "tcp",
+					IPSet:    "de-tgt",
+					Source:   "default/delay",
+				},
+				{
+					Type: v1alpha1.Bandwidth,
+					TcParameter: v1alpha1.TcParameter{
+						Bandwidth: &v1alpha1.BandwidthSpec{Rate: "1mbps", Limit: 20971520, Buffer: 10000},
+					},
+					Source: "default/delay",
+				},
+			},
+		},
+	}
+	tcs, err := buildTcs(chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	rec := recorder.NewDebugRecorder()
+	r := &Reconciler{
+		Client:   fake.NewFakeClientWithScheme(provider.NewScheme(), networkchaos),
+		Recorder: rec,
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	pod := NewPod(PodArg{Name: "p0", Namespace: metav1.NamespaceDefault})
+	r.recordTcs(context.TODO(), &pod, chaos, tcs)
+
+	// the events are recorded on the network chaos, the peers beyond the bound are only counted
+	g.Expect(rec.Events[types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "delay"}]).To(Equal([]recorder.ChaosEvent{
+		recorder.TrafficControlApplied{
+			Pod:   "default/p0",
+			Tc:    "netem delay 100000 10000 25.000000 protocol tcp",
+			Peers: "de-tgt [10.0.0.0/32 10.0.0.1/32 10.0.0.2/32 10.0.0.3/32 10.0.0.4/32 10.0.0.5/32 10.0.0.6/32 10.0.0.7/32 10.0.0.8/32 10.0.0.9/32] and 2 more",
+		},
+		recorder.TrafficControlApplied{
+			Pod:   "default/p0",
+			Tc:    "tbf rate 1048576 burst 10000 limit 20971520",
+			Peers: "all",
+		},
+	}))
 }
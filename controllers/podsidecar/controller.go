@@ -0,0 +1,143 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsidecar
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	podselector "github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/inject"
+)
+
+// Reconciler injects the sidecars into the running pods as ephemeral containers, so that the chaos
+// could be applied on the live workloads without recreating them. The pods are requested through the
+// ephemeral-request annotation, whose value is the name of the injection config.
+type Reconciler struct {
+	client.Client
+
+	// PodsGetter is used to update the ephemeral containers subresource, which is not supported by client.Client
+	PodsGetter corev1client.PodsGetter
+
+	Config *config.Config
+
+	Recorder recorder.ChaosRecorder
+	Log      logr.Logger
+}
+
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.TODO()
+
+	pod := &corev1.Pod{}
+	if err := r.Client.Get(ctx, req.NamespacedName, pod); err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to get pod")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	requiredConfig, ok := pod.Annotations[r.Config.EphemeralRequestAnnotationKey()]
+	if !ok || !pod.DeletionTimestamp.IsZero() || pod.Status.Phase != corev1.PodRunning {
+		return ctrl.Result{}, nil
+	}
+
+	statusKey := inject.StatusAnnotationKey(pod.Namespace, r.Client, r.Config)
+	if strings.ToLower(pod.Annotations[statusKey]) == inject.StatusInjected {
+		return ctrl.Result{}, nil
+	}
+
+	// the configs are loaded asynchronously, so the pod is retried until its config is found
+	injectionConfig, err := r.Config.GetRequestedConfig(pod.Namespace, strings.ToLower(requiredConfig))
+	if err != nil {
+		r.Log.Error(err, "unable to get injection config", "pod", req.NamespacedName)
+		r.Recorder.Event(pod, recorder.Failed{
+			Activity: "get injection config",
+			Err:      err.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if injectionConfig.Selector != nil {
+		meet, err := podselector.CheckPodMeetSelector(ctx, r.Client, *pod, *injectionConfig.Selector)
+		if err != nil {
+			r.Log.Error(err, "failed to check pod selector", "pod", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		if !meet {
+			r.Log.Info("skip injection, the pod does not meet the selection criteria", "pod", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// the pod isn't marked as injected when the config cannot be injected, as it isn't injected entirely
+	ephemeralContainers, err := inject.EphemeralContainers(pod, injectionConfig)
+	if err != nil {
+		r.Log.Error(err, "unable to inject the config into the running pod", "pod", req.NamespacedName)
+		r.Recorder.Event(pod, recorder.Failed{
+			Activity: "inject ephemeral containers",
+			Err:      err.Error(),
+		})
+		return ctrl.Result{}, nil
+	}
+	if len(ephemeralContainers) > 0 {
+		_, err := r.PodsGetter.Pods(pod.Namespace).UpdateEphemeralContainers(pod.Name, &corev1.EphemeralContainers{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       pod.Namespace,
+				Name:            pod.Name,
+				ResourceVersion: pod.ResourceVersion,
+			},
+			EphemeralContainers: append(pod.Spec.EphemeralContainers, ephemeralContainers...),
+		})
+		if err != nil {
+			r.Log.Error(err, "fail to update ephemeral containers", "pod", req.NamespacedName)
+			r.Recorder.Event(pod, recorder.Failed{
+				Activity: "inject ephemeral containers",
+				Err:      err.Error(),
+			})
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	// mark the pod as injected, so that neither the controller nor the webhook injects it again
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[statusKey] = inject.StatusInjected
+	pod.Annotations[r.Config.InjectedConfigAnnotationKey()] = injectionConfig.Name
+	if err := r.Client.Patch(ctx, pod, patch); err != nil {
+		r.Log.Error(err, "fail to mark pod as injected", "pod", req.NamespacedName)
+		r.Recorder.Event(pod, recorder.Failed{
+			Activity: "update pod annotations",
+			Err:      err.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	r.Recorder.Event(pod, recorder.SidecarInjected{
+		Config: injectionConfig.Name,
+	})
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsidecar
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/inject"
+)
+
+// newKubeClient returns a fake clientset which updates the ephemeral containers of the pods like the API server
+func newKubeClient(pods ...runtime.Object) *kubernetesfake.Clientset {
+	kubeCli := kubernetesfake.NewSimpleClientset(pods...)
+	kubeCli.PrependReactor("update", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "ephemeralcontainers" {
+			return false, nil, nil
+		}
+
+		ec := action.(k8stesting.UpdateAction).GetObject().(*corev1.EphemeralContainers)
+		obj, err := kubeCli.Tracker().Get(corev1.SchemeGroupVersion.WithResource("pods"), ec.Namespace, ec.Name)
+		if err != nil {
+			return true, nil, err
+		}
+		pod := obj.(*corev1.Pod)
+		pod.Spec.EphemeralContainers = ec.EphemeralContainers
+		return true, ec, kubeCli.Tracker().Update(corev1.SchemeGroupVersion.WithResource("pods"), pod, ec.Namespace)
+	})
+	return kubeCli
+}
+
+func TestInjectRunningPod(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cfg := config.NewConfigWatcherConf()
+	cfg.ReplaceInjectionConfigs(map[string][]*config.InjectionConfig{
+		metav1.NamespaceDefault: {
+			{
+				Name: "chaosfs-sidecar",
+				Containers: []corev1.Container{
+					{
+						Name:  "chaosfs",
+						Image: "pingcap/chaos-fs:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 65534}},
+					},
+				},
+				Environment: []corev1.EnvVar{{Name: "CHAOSFS_ADDR", Value: ":65534"}},
+			},
+			{
+				Name: "chaosfs-volume",
+				Containers: []corev1.Container{
+					{
+						Name:  "chaosfs",
+						Image: "pingcap/chaos-fs:latest",
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "datadir", MountPath: "/var/run/data"}},
+			},
+		},
+	})
+
+	pod := NewPod(PodArg{
+		Name: "p0",
+		Ans:  map[string]string{cfg.EphemeralRequestAnnotationKey(): "chaosfs-sidecar"},
+	})
+	plainPod := NewPod(PodArg{Name: "p1"})
+	volumePod := NewPod(PodArg{
+		Name: "p2",
+		Ans:  map[string]string{cfg.EphemeralRequestAnnotationKey(): "chaosfs-volume"},
+	})
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), &pod, &plainPod, &volumePod)
+	kubeCli := newKubeClient(pod.DeepCopy(), plainPod.DeepCopy(), volumePod.DeepCopy())
+	debugRecorder := recorder.NewDebugRecorder()
+	r := &Reconciler{
+		Client:     fakeClient,
+		PodsGetter: kubeCli.CoreV1(),
+		Config:     cfg,
+		Recorder:   debugRecorder,
+		Log:        zap.New(zap.UseDevMode(true)),
+	}
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "p0"}
+
+	// the annotated running pod gets the ephemeral chaos container
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	injected, err := kubeCli.CoreV1().Pods(key.Namespace).Get(key.Name, metav1.GetOptions{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(injected.Spec.EphemeralContainers).To(HaveLen(1))
+	container := injected.Spec.EphemeralContainers[0]
+	g.Expect(container.Name).To(Equal("chaosfs"))
+	g.Expect(container.Image).To(Equal("pingcap/chaos-fs:latest"))
+	g.Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "CHAOSFS_ADDR", Value: ":65534"}))
+	g.Expect(container.Ports).To(BeEmpty())
+
+	g.Expect(fakeClient.Get(context.TODO(), key, &pod)).To(Succeed())
+	g.Expect(pod.Annotations[cfg.StatusAnnotationKey()]).To(Equal(inject.StatusInjected))
+	g.Expect(pod.Annotations[cfg.InjectedConfigAnnotationKey()]).To(Equal("chaosfs-sidecar"))
+	g.Expect(debugRecorder.Events[key]).To(ContainElement(recorder.SidecarInjected{Config: "chaosfs-sidecar"}))
+
+	// the injected pod is not injected again
+	actions := len(kubeCli.Actions())
+	_, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kubeCli.Actions()).To(HaveLen(actions))
+
+	// the pod which is not annotated is untouched
+	plainKey := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "p1"}
+	_, err = r.Reconcile(ctrl.Request{NamespacedName: plainKey})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kubeCli.Actions()).To(HaveLen(actions))
+	g.Expect(fakeClient.Get(context.TODO(), plainKey, &plainPod)).To(Succeed())
+	g.Expect(plainPod.Annotations).ToNot(HaveKey(cfg.StatusAnnotationKey()))
+
+	// the config which mounts the volume absent from the running pod is not injected partially
+	volumeKey := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "p2"}
+	_, err = r.Reconcile(ctrl.Request{NamespacedName: volumeKey})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kubeCli.Actions()).To(HaveLen(actions))
+	g.Expect(fakeClient.Get(context.TODO(), volumeKey, &volumePod)).To(Succeed())
+	g.Expect(volumePod.Annotations).ToNot(HaveKey(cfg.StatusAnnotationKey()))
+	g.Expect(debugRecorder.Events[volumeKey]).To(HaveLen(1))
+	g.Expect(debugRecorder.Events[volumeKey][0]).To(BeAssignableToTypeOf(recorder.Failed{}))
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsidecar
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ccfg "github.com/chaos-mesh/chaos-mesh/controllers/config"
+	"github.com/chaos-mesh/chaos-mesh/controllers/types"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
+)
+
+func NewController(mgr ctrl.Manager, client client.Client, coreCli corev1client.CoreV1Interface, cfg *config.Config, logger logr.Logger, recorderBuilder *recorder.RecorderBuilder) (types.Controller, error) {
+	if !ccfg.ControllerCfg.EnableEphemeralInjection {
+		return "podsidecar", nil
+	}
+
+	requested := func(annotations map[string]string) bool {
+		_, ok := annotations[cfg.EphemeralRequestAnnotationKey()]
+		return ok
+	}
+	err := builder.Default(mgr).
+		For(&corev1.Pod{}).
+		Named("podsidecar").
+		WithEventFilter(predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool {
+				return requested(e.Meta.GetAnnotations())
+			},
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				// the pod is requested to be injected, or it starts running after being requested
+				return requested(e.MetaNew.GetAnnotations())
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return false
+			},
+		}).
+		Complete(&Reconciler{
+			Client:     client,
+			PodsGetter: coreCli,
+			Config:     cfg,
+			Recorder:   recorderBuilder.Build("podsidecar"),
+			Log:        logger.WithName("podsidecar"),
+		})
+	if err != nil {
+		return "", err
+	}
+
+	return "podsidecar", nil
+}
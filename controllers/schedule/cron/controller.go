@@ -31,6 +31,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/types"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/propagation"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 	"github.com/chaos-mesh/chaos-mesh/pkg/workflow/controllers"
 )
@@ -62,7 +63,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	now := time.Now()
 	shouldSpawn := false
-	r.Log.Info("calculate schedule time", "schedule", schedule.Spec.Schedule, "lastScheduleTime", schedule.Status.LastScheduleTime, "now", now)
+	r.Log.Info("calculate schedule time", "schedule", schedule.Spec.CronExpressions(), "lastScheduleTime", schedule.Status.LastScheduleTime, "now", now)
 	missedRun, nextRun, err := getRecentUnmetScheduleTime(schedule, now)
 	if err != nil {
 		r.Recorder.Event(schedule, recorder.Failed{
@@ -136,8 +137,8 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 		meta.SetOwnerReferences([]metav1.OwnerReference{
 			{
-				APIVersion:         schedule.APIVersion,
-				Kind:               schedule.Kind,
+				APIVersion:         v1alpha1.GroupVersion.String(),
+				Kind:               v1alpha1.KindSchedule,
 				Name:               schedule.Name,
 				UID:                schedule.UID,
 				Controller:         &t,
@@ -147,6 +148,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		meta.SetLabels(map[string]string{
 			"managed-by": schedule.Name,
 		})
+		propagation.Propagate(schedule, meta)
 		meta.SetNamespace(schedule.Namespace)
 		meta.SetName(names.SimpleNameGenerator.GenerateName(schedule.Name + "-"))
 
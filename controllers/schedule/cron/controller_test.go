@@ -0,0 +1,81 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/schedule/utils"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func TestSpawnedChaosIsOwnedBySchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	schedule := &v1alpha1.Schedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         metav1.NamespaceDefault,
+			Name:              "schedule",
+			UID:               "schedule-uid",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+		},
+		Spec: v1alpha1.ScheduleSpec{
+			Schedule:          "@every 1m",
+			ConcurrencyPolicy: v1alpha1.AllowConcurrent,
+			Type:              v1alpha1.ScheduleTypePodChaos,
+			ScheduleItem: v1alpha1.ScheduleItem{
+				EmbedChaos: v1alpha1.EmbedChaos{
+					PodChaos: &v1alpha1.PodChaosSpec{Action: v1alpha1.PodKillAction},
+				},
+			},
+		},
+	}
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), schedule)
+	logger := zap.New(zap.UseDevMode(true))
+	reconciler := &Reconciler{
+		Client:       kubeClient,
+		Log:          logger,
+		ActiveLister: utils.NewActiveLister(kubeClient, logger),
+		Recorder:     recorder.NewDebugRecorder(),
+	}
+
+	_, err := reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: schedule.Namespace, Name: schedule.Name}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the spawned chaos is collected by kubernetes when the schedule is deleted
+	podChaosList := v1alpha1.PodChaosList{}
+	g.Expect(kubeClient.List(ctx, &podChaosList)).To(Succeed())
+	g.Expect(podChaosList.Items).To(HaveLen(1))
+	isController := true
+	g.Expect(podChaosList.Items[0].OwnerReferences).To(Equal([]metav1.OwnerReference{{
+		APIVersion:         v1alpha1.GroupVersion.String(),
+		Kind:               v1alpha1.KindSchedule,
+		Name:               schedule.Name,
+		UID:                schedule.UID,
+		Controller:         &isController,
+		BlockOwnerDeletion: &isController,
+	}}))
+}
@@ -17,7 +17,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/robfig/cron"
+	"github.com/robfig/cron/v3"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 )
@@ -28,9 +28,13 @@ import (
 //
 // If there are too many (>100) unstarted times, just give up and return a nil.
 func getRecentUnmetScheduleTime(schedule *v1alpha1.Schedule, now time.Time) (*time.Time, *time.Time, error) {
-	sched, err := cron.ParseStandard(schedule.Spec.Schedule)
-	if err != nil {
-		return nil, nil, fmt.Errorf("unparseable schedule: %s : %s", schedule.Spec.Schedule, err)
+	var sched multiSchedule
+	for _, cronExpr := range schedule.Spec.CronExpressions() {
+		s, err := cron.ParseStandard(cronExpr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unparseable schedule: %s : %s", cronExpr, err)
+		}
+		sched = append(sched, s)
 	}
 
 	var earliestTime time.Time
@@ -68,3 +72,22 @@ func getRecentUnmetScheduleTime(schedule *v1alpha1.Schedule, now time.Time) (*ti
 
 	return missedRun, &nextRun, nil
 }
+
+// multiSchedule activates at the times of any of the schedules
+type multiSchedule []cron.Schedule
+
+// Next returns the nearest activation time of all the schedules
+func (s multiSchedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, sched := range s {
+		n := sched.Next(t)
+		// zero time means the schedule will never be activated
+		if n.IsZero() {
+			continue
+		}
+		if next.IsZero() || n.Before(next) {
+			next = n
+		}
+	}
+	return next
+}
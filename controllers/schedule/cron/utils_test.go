@@ -132,3 +132,66 @@ func TestGetRecentUnmetScheduleTime(t *testing.T) {
 		g.Expect(nextRun).To(expectedNextRun)
 	}
 }
+
+func TestGetRecentUnmetScheduleTimeWithMultipleSchedules(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// Friday
+	now, err := time.Parse(time.RFC3339, "2021-04-30T12:00:00Z")
+	g.Expect(err).To(BeNil())
+	schedule := v1alpha1.Schedule{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.Time{
+				Time: now.Add(-time.Minute),
+			},
+		},
+		Spec: v1alpha1.ScheduleSpec{
+			// weekday mornings and weekend nights
+			Schedule:  "0 9 * * 1-5",
+			Schedules: []string{"0 22 * * 0,6", "30 21 * * *"},
+		},
+	}
+
+	missedRun, nextRun, err := getRecentUnmetScheduleTime(&schedule, now)
+	g.Expect(err).To(BeNil())
+	g.Expect(missedRun).To(BeNil())
+	expectedNextRun, err := time.Parse(time.RFC3339, "2021-04-30T21:30:00Z")
+	g.Expect(err).To(BeNil())
+	g.Expect(nextRun.Equal(expectedNextRun)).To(BeTrue())
+
+	// the next run after the daily one is the weekend night, rather than Monday morning
+	now = expectedNextRun.Add(time.Hour)
+	schedule.Status.LastScheduleTime = metav1.Time{Time: expectedNextRun}
+	schedule.Spec.Schedules = []string{"0 22 * * 0,6"}
+	missedRun, nextRun, err = getRecentUnmetScheduleTime(&schedule, now)
+	g.Expect(err).To(BeNil())
+	g.Expect(missedRun).To(BeNil())
+	expectedNextRun, err = time.Parse(time.RFC3339, "2021-05-01T22:00:00Z")
+	g.Expect(err).To(BeNil())
+	g.Expect(nextRun.Equal(expectedNextRun)).To(BeTrue())
+}
+
+func TestGetRecentUnmetScheduleTimeWithTimeZone(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now, err := time.Parse(time.RFC3339, "2021-04-30T00:00:00Z")
+	g.Expect(err).To(BeNil())
+	schedule := v1alpha1.Schedule{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.Time{
+				Time: now.Add(-time.Minute),
+			},
+		},
+		Spec: v1alpha1.ScheduleSpec{
+			// the time zone is accepted by the webhook, so it's respected by the scheduler too
+			Schedule: "CRON_TZ=Asia/Shanghai 0 9 * * *",
+		},
+	}
+
+	missedRun, nextRun, err := getRecentUnmetScheduleTime(&schedule, now)
+	g.Expect(err).To(BeNil())
+	g.Expect(missedRun).To(BeNil())
+	expectedNextRun, err := time.Parse(time.RFC3339, "2021-04-30T01:00:00Z")
+	g.Expect(err).To(BeNil())
+	g.Expect(nextRun.Equal(expectedNextRun)).To(BeTrue())
+}
@@ -81,6 +81,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	exceededHistory := len(metaItems) - schedule.Spec.HistoryLimit
 	requeuAfter := time.Duration(0)
+	requeue := false
 	if exceededHistory > 0 {
 		for _, obj := range metaItems[0:exceededHistory] {
 			innerObj, ok := obj.(v1alpha1.InnerObject)
@@ -117,6 +118,18 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 					}
 				}
 			}
+			if _, ok := obj.(v1alpha1.InnerObject); ok && schedule.Spec.ArchiveHistory {
+				// the chaos is removed only after it's marked, otherwise its history would be lost
+				if err := r.markToArchive(ctx, obj); err != nil {
+					r.Recorder.Event(schedule, recorder.Failed{
+						Activity: fmt.Sprintf("mark %s/%s to archive", obj.GetObjectMeta().Namespace, obj.GetObjectMeta().Name),
+						Err:      err.Error(),
+					})
+					requeue = true
+					continue
+				}
+			}
+
 			err := r.Client.Delete(ctx, obj)
 			if err != nil && !k8sError.IsNotFound(err) {
 				r.Recorder.Event(schedule, recorder.Failed{
@@ -128,10 +141,25 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 
 	return ctrl.Result{
+		Requeue:      requeue,
 		RequeueAfter: requeuAfter,
 	}, nil
 }
 
+// markToArchive annotates the chaos managed by the schedule, so that it's archived by the dashboard when it's removed
+func (r *Reconciler) markToArchive(ctx context.Context, obj v1alpha1.MetaObject) error {
+	meta := obj.GetObjectMeta()
+	if meta.Annotations[v1alpha1.ArchiveAnnotationKey] == "true" {
+		return nil
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	meta.Annotations[v1alpha1.ArchiveAnnotationKey] = "true"
+	return r.Client.Update(ctx, obj)
+}
+
 type Objs struct {
 	fx.In
 
@@ -0,0 +1,100 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/schedule/utils"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+// deleteRecorder records the objects when they are deleted
+type deleteRecorder struct {
+	client.Client
+	deleted []runtime.Object
+}
+
+func (c *deleteRecorder) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	c.deleted = append(c.deleted, obj.DeepCopyObject())
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func TestArchiveHistory(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "schedule"}
+	schedule := &v1alpha1.Schedule{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: v1alpha1.ScheduleSpec{
+			Schedule:     "@every 1m",
+			Type:         v1alpha1.ScheduleTypePodChaos,
+			HistoryLimit: 1,
+		},
+	}
+	objs := []runtime.Object{schedule}
+	now := time.Now()
+	for i, name := range []string{"old", "new"} {
+		objs = append(objs, &v1alpha1.PodChaos{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         key.Namespace,
+				Name:              name,
+				Labels:            map[string]string{"managed-by": key.Name},
+				CreationTimestamp: metav1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+			},
+			Spec: v1alpha1.PodChaosSpec{Action: v1alpha1.PodKillAction},
+		})
+	}
+
+	reconcile := func(archiveHistory bool) []runtime.Object {
+		c := &deleteRecorder{Client: fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)}
+		s := &v1alpha1.Schedule{}
+		g.Expect(c.Get(context.TODO(), key, s)).To(Succeed())
+		s.Spec.ArchiveHistory = archiveHistory
+		g.Expect(c.Update(context.TODO(), s)).To(Succeed())
+
+		r := &Reconciler{
+			Client:       c,
+			Log:          zap.New(zap.UseDevMode(true)),
+			Recorder:     recorder.NewDebugRecorder(),
+			ActiveLister: utils.NewActiveLister(c, zap.New(zap.UseDevMode(true))),
+		}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(c.deleted).To(HaveLen(1))
+		g.Expect(c.deleted[0].(*v1alpha1.PodChaos).Name).To(Equal("old"))
+		return c.deleted
+	}
+
+	// the exceeded history is dropped by default
+	deleted := reconcile(false)
+	g.Expect(deleted[0].(*v1alpha1.PodChaos).Annotations).ToNot(HaveKey(v1alpha1.ArchiveAnnotationKey))
+
+	// the exceeded history is marked to archive before it's removed
+	deleted = reconcile(true)
+	g.Expect(deleted[0].(*v1alpha1.PodChaos).Annotations).To(HaveKeyWithValue(v1alpha1.ArchiveAnnotationKey, "true"))
+}
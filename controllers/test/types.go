@@ -33,7 +33,13 @@ type MockChaosDaemonClient struct{}
 
 // ExecStressors mocks executing pod stressors on chaos-daemon
 func (c *MockChaosDaemonClient) ExecStressors(ctx context.Context, in *chaosdaemon.ExecStressRequest, opts ...grpc.CallOption) (*chaosdaemon.ExecStressResponse, error) {
-	return nil, mockError("ExecStressors")
+	if err := mockError("ExecStressors"); err != nil {
+		return nil, err
+	}
+	if resp := mock.On("MockExecStressorsResponse"); resp != nil {
+		return resp.(*chaosdaemon.ExecStressResponse), nil
+	}
+	return nil, nil
 }
 
 // CancelStressors mocks canceling pod stressors on chaos-daemon
@@ -41,6 +47,14 @@ func (c *MockChaosDaemonClient) CancelStressors(ctx context.Context, in *chaosda
 	return nil, mockError("CancelStressors")
 }
 
+// GetStressorsStatus mocks getting the status of pod stressors on chaos-daemon
+func (c *MockChaosDaemonClient) GetStressorsStatus(ctx context.Context, in *chaosdaemon.StressorsStatusRequest, opts ...grpc.CallOption) (*chaosdaemon.StressorsStatusResponse, error) {
+	if resp := mock.On("MockGetStressorsStatusResponse"); resp != nil {
+		return resp.(*chaosdaemon.StressorsStatusResponse), nil
+	}
+	return &chaosdaemon.StressorsStatusResponse{}, mockError("GetStressorsStatus")
+}
+
 func (c *MockChaosDaemonClient) ContainerGetPid(ctx context.Context, in *chaosdaemon.ContainerRequest, opts ...grpc.CallOption) (*chaosdaemon.ContainerResponse, error) {
 	if resp := mock.On("MockContainerGetPidResponse"); resp != nil {
 		return resp.(*chaosdaemon.ContainerResponse), nil
@@ -71,7 +85,14 @@ func (c *MockChaosDaemonClient) RecoverTimeOffset(ctx context.Context, in *chaos
 	return nil, mockError("RecoverTimeOffset")
 }
 
+// ContainerKill mocks killing the container on chaos-daemon, the kill of the containers
+// in MockContainerKillErrors fails with the error of its container id
 func (c *MockChaosDaemonClient) ContainerKill(ctx context.Context, in *chaosdaemon.ContainerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if errs := mock.On("MockContainerKillErrors"); errs != nil {
+		if err, ok := errs.(map[string]error)[in.ContainerId]; ok {
+			return nil, err
+		}
+	}
 	return nil, mockError("ContainerKill")
 }
 
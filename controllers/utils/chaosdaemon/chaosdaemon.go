@@ -15,9 +15,13 @@ package chaosdaemon
 
 import (
 	"context"
+	"sync"
 
-	"github.com/pkg/errors"
 	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -45,6 +49,32 @@ func findIPOnEndpoints(e *v1.Endpoints, nodeName string) string {
 
 type ChaosDaemonClientBuilder struct {
 	client.Reader
+
+	// MaxInflight is how many RPCs to the same chaos daemon could be inflight at the same time, zero means unlimited
+	MaxInflight int
+
+	lock  sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// slotsOf returns the slots shared by all the connections to the chaos daemon, or nil if it's unlimited
+func (b *ChaosDaemonClientBuilder) slotsOf(daemonIP string) chan struct{} {
+	if b.MaxInflight <= 0 {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.slots == nil {
+		b.slots = make(map[string]chan struct{})
+	}
+	slots, ok := b.slots[daemonIP]
+	if !ok {
+		slots = make(chan struct{}, b.MaxInflight)
+		b.slots[daemonIP] = slots
+	}
+	return slots
 }
 
 func (b *ChaosDaemonClientBuilder) FindDaemonIP(ctx context.Context, pod *v1.Pod) (string, error) {
@@ -63,7 +93,8 @@ func (b *ChaosDaemonClientBuilder) FindDaemonIP(ctx context.Context, pod *v1.Pod
 
 	daemonIP := findIPOnEndpoints(&endpoints, nodeName)
 	if len(daemonIP) == 0 {
-		return "", errors.Errorf("cannot find daemonIP on node %s in related Endpoints %v", nodeName, endpoints)
+		// the chaos daemon on the node is not ready, or it's gone
+		return "", status.Errorf(codes.Unavailable, "cannot find daemonIP on node %s in related Endpoints %v", nodeName, endpoints)
 	}
 
 	return daemonIP, nil
@@ -77,21 +108,66 @@ func (b *ChaosDaemonClientBuilder) Build(ctx context.Context, pod *v1.Pod) (chao
 		return nil, err.(error)
 	}
 
+	cc, err := b.connect(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	return chaosdaemonclient.New(cc), nil
+}
+
+// CheckHealth checks the chaos daemon on the node of the pod with the gRPC health checking protocol. It returns
+// an error with the Unavailable code if the chaos daemon cannot be reached or it's not serving.
+func (b *ChaosDaemonClientBuilder) CheckHealth(ctx context.Context, pod *v1.Pod) error {
+	cc, err := b.connect(ctx, pod)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	resp, err := healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		// the chaos daemon of the earlier version doesn't serve the health checking
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return status.Errorf(codes.Unavailable, "chaos daemon on node %s is %s", pod.Spec.NodeName, resp.Status)
+	}
+	return nil
+}
+
+// idempotentMethods are the methods which set the desired state or only read it, so they are safe to be retried
+// even if the chaos daemon has handled the request. ContainerKill, ExecStressors, SetTimeOffset, ApplyIOChaos and
+// ApplyHttpChaos start a new kill or process on every call, and SetDNSServer counts the references of the DNS
+// server on every call, so they are not retried.
+var idempotentMethods = []string{
+	"/pb.ChaosDaemon/SetTcs",
+	"/pb.ChaosDaemon/FlushIPSets",
+	"/pb.ChaosDaemon/SetIptablesChains",
+	"/pb.ChaosDaemon/ContainerGetPid",
+	"/pb.ChaosDaemon/GetStressorsStatus",
+	"/grpc.health.v1.Health/Check",
+}
+
+func (b *ChaosDaemonClientBuilder) connect(ctx context.Context, pod *v1.Pod) (*grpc.ClientConn, error) {
 	daemonIP, err := b.FindDaemonIP(ctx, pod)
 	if err != nil {
 		return nil, err
 	}
-	builder := grpcUtils.Builder(daemonIP, config.ControllerCfg.ChaosDaemonPort).WithDefaultTimeout()
+	builder := grpcUtils.Builder(daemonIP, config.ControllerCfg.ChaosDaemonPort).
+		WithDefaultTimeout().
+		WithDefaultRetry(idempotentMethods...)
+	if slots := b.slotsOf(daemonIP); slots != nil {
+		builder.WithInflightLimit(slots)
+	}
 	if config.ControllerCfg.TLSConfig.ChaosMeshCACert != "" {
 		builder.TLSFromFile(config.ControllerCfg.TLSConfig.ChaosMeshCACert, config.ControllerCfg.TLSConfig.ChaosDaemonClientCert, config.ControllerCfg.TLSConfig.ChaosDaemonClientKey)
 	} else {
 		builder.Insecure()
 	}
-	cc, err := builder.Build()
-	if err != nil {
-		return nil, err
-	}
-	return chaosdaemonclient.New(cc), nil
+	return builder.Build()
 }
 
 type ChaosDaemonClientBuilderParams struct {
@@ -109,6 +185,7 @@ func New(params ChaosDaemonClientBuilderParams) *ChaosDaemonClientBuilder {
 		reader = params.NoCacheReader
 	}
 	return &ChaosDaemonClientBuilder{
-		Reader: reader,
+		Reader:      reader,
+		MaxInflight: config.ControllerCfg.MaxDaemonInflight,
 	}
 }
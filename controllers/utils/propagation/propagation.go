@@ -0,0 +1,47 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/chaos-mesh/chaos-mesh/controllers/config"
+)
+
+// Propagate copies the labels and annotations configured by `PROPAGATED_LABELS` and
+// `PROPAGATED_ANNOTATIONS` from the parent to the child, e.g. from a Schedule to the
+// chaos spawned by it. The existing labels and annotations of the child are kept.
+func Propagate(parent metav1.Object, child metav1.Object) {
+	child.SetLabels(propagate(parent.GetLabels(), child.GetLabels(), config.ControllerCfg.PropagatedLabels))
+	child.SetAnnotations(propagate(parent.GetAnnotations(), child.GetAnnotations(), config.ControllerCfg.PropagatedAnnotations))
+}
+
+func propagate(from map[string]string, to map[string]string, keys []string) map[string]string {
+	for _, key := range keys {
+		value, ok := from[key]
+		if !ok {
+			continue
+		}
+		if _, ok := to[key]; ok {
+			continue
+		}
+
+		if to == nil {
+			to = make(map[string]string)
+		}
+		to[key] = value
+	}
+
+	return to
+}
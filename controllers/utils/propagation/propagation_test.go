@@ -0,0 +1,67 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/config"
+)
+
+func TestPropagate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config.ControllerCfg.PropagatedLabels = []string{"team"}
+	config.ControllerCfg.PropagatedAnnotations = []string{"example.com/ticket"}
+	defer func() {
+		config.ControllerCfg.PropagatedLabels = nil
+		config.ControllerCfg.PropagatedAnnotations = nil
+	}()
+
+	schedule := &v1alpha1.Schedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "schedule",
+			Labels: map[string]string{
+				"team":       "storage",
+				"managed-by": "someone",
+				"unrelated":  "label",
+			},
+			Annotations: map[string]string{
+				"example.com/ticket": "CHAOS-1",
+				"unrelated":          "annotation",
+			},
+		},
+	}
+	chaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"managed-by": "schedule",
+			},
+		},
+	}
+
+	Propagate(schedule, chaos)
+
+	g.Expect(chaos.Labels).To(Equal(map[string]string{
+		"managed-by": "schedule",
+		"team":       "storage",
+	}))
+	g.Expect(chaos.Annotations).To(Equal(map[string]string{
+		"example.com/ticket": "CHAOS-1",
+	}))
+}
@@ -65,6 +65,113 @@ func (r NotSupported) Message() string {
 	return fmt.Sprintf("%s is not supported", r.Activity)
 }
 
+type SidecarInjected struct {
+	Config string
+}
+
+func (s SidecarInjected) Type() string {
+	return "Normal"
+}
+
+func (s SidecarInjected) Reason() string {
+	return "SidecarInjected"
+}
+
+func (s SidecarInjected) Message() string {
+	return fmt.Sprintf("Successfully inject sidecar config %s as ephemeral containers", s.Config)
+}
+
+// ContainerNotFound is recorded when a selected pod is skipped, as none of the named containers is found in it
+type ContainerNotFound struct {
+	Pod            string
+	ContainerNames []string
+}
+
+func (c ContainerNotFound) Type() string {
+	return "Warning"
+}
+
+func (c ContainerNotFound) Reason() string {
+	return "ContainerNotFound"
+}
+
+func (c ContainerNotFound) Message() string {
+	return fmt.Sprintf("Skip pod %s, as containers %v are not found in it", c.Pod, c.ContainerNames)
+}
+
+// MinMatchesNotMet is recorded when the selection fails, as fewer pods than the minimum matches are selected
+type MinMatchesNotMet struct {
+	Matched  int
+	Required int
+}
+
+func (m MinMatchesNotMet) Type() string {
+	return "Warning"
+}
+
+func (m MinMatchesNotMet) Reason() string {
+	return "MinMatchesNotMet"
+}
+
+func (m MinMatchesNotMet) Message() string {
+	return fmt.Sprintf("Only %d pods are selected, fewer than the minimum matches %d", m.Matched, m.Required)
+}
+
+// DaemonUnreachable is recorded when a record is quarantined, as the chaos daemon serving its target is unreachable
+type DaemonUnreachable struct {
+	Id string
+}
+
+func (d DaemonUnreachable) Type() string {
+	return "Warning"
+}
+
+func (d DaemonUnreachable) Reason() string {
+	return "DaemonUnreachable"
+}
+
+func (d DaemonUnreachable) Message() string {
+	return fmt.Sprintf("Quarantine %s, as the chaos daemon serving it is unreachable", d.Id)
+}
+
+// DaemonReachable is recorded when the chaos daemon serving a quarantined record is back
+type DaemonReachable struct {
+	Id string
+}
+
+func (d DaemonReachable) Type() string {
+	return "Normal"
+}
+
+func (d DaemonReachable) Reason() string {
+	return "DaemonReachable"
+}
+
+func (d DaemonReachable) Message() string {
+	return fmt.Sprintf("Chaos daemon serving %s is reachable again, retry it", d.Id)
+}
+
+// TrafficControlApplied is recorded on the network chaos when its traffic control is applied on a pod, with the
+// arguments of the qdisc rendered for the chaos daemon
+type TrafficControlApplied struct {
+	Pod   string
+	Tc    string
+	Peers string
+}
+
+func (t TrafficControlApplied) Type() string {
+	return "Normal"
+}
+
+func (t TrafficControlApplied) Reason() string {
+	return "TrafficControlApplied"
+}
+
+func (t TrafficControlApplied) Message() string {
+	return fmt.Sprintf("Apply tc %s on pod %s for the traffic to %s", t.Tc, t.Pod, t.Peers)
+}
+
 func init() {
-	register(Applied{}, Recovered{}, NotSupported{})
+	register(Applied{}, Recovered{}, NotSupported{}, SidecarInjected{}, ContainerNotFound{}, MinMatchesNotMet{},
+		DaemonUnreachable{}, DaemonReachable{}, TrafficControlApplied{})
 }
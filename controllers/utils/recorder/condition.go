@@ -0,0 +1,34 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+type RecoverTimedOut struct {
+	Timeout string
+}
+
+func (r RecoverTimedOut) Type() string {
+	return "Warning"
+}
+
+func (r RecoverTimedOut) Reason() string {
+	return "RecoverTimedOut"
+}
+
+func (r RecoverTimedOut) Message() string {
+	return "Chaos is not recovered in " + r.Timeout + " after the duration ends, some chaos may remain on the targets"
+}
+
+func init() {
+	register(RecoverTimedOut{})
+}
@@ -13,6 +13,10 @@
 
 package recorder
 
+import (
+	"fmt"
+)
+
 type Deleted struct {
 }
 
@@ -43,6 +47,24 @@ func (t TimeUp) Message() string {
 	return "Time up according to the duration"
 }
 
+// MaxActiveDurationExceeded is recorded when the chaos is force recovered, as it has been active longer than the
+// max active duration of the cluster
+type MaxActiveDurationExceeded struct {
+	MaxActiveDuration string
+}
+
+func (m MaxActiveDurationExceeded) Type() string {
+	return "Warning"
+}
+
+func (m MaxActiveDurationExceeded) Reason() string {
+	return "MaxActiveDurationExceeded"
+}
+
+func (m MaxActiveDurationExceeded) Message() string {
+	return fmt.Sprintf("Force recover the experiment, as it has been active longer than the max active duration %s", m.MaxActiveDuration)
+}
+
 type Paused struct {
 }
 
@@ -58,6 +80,21 @@ func (p Paused) Message() string {
 	return "Experiment has been paused"
 }
 
+type OutOfActiveWindows struct {
+}
+
+func (o OutOfActiveWindows) Type() string {
+	return "Normal"
+}
+
+func (o OutOfActiveWindows) Reason() string {
+	return "OutOfActiveWindows"
+}
+
+func (o OutOfActiveWindows) Message() string {
+	return "Experiment is out of the active windows"
+}
+
 type Started struct {
 }
 
@@ -74,5 +111,5 @@ func (p Started) Message() string {
 }
 
 func init() {
-	register(Deleted{}, TimeUp{}, Paused{}, Started{})
+	register(Deleted{}, TimeUp{}, MaxActiveDurationExceeded{}, Paused{}, OutOfActiveWindows{}, Started{})
 }
@@ -35,6 +35,26 @@ func (f Failed) Message() string {
 	return fmt.Sprintf("Failed to %s: %s", f.Activity, f.Err)
 }
 
+// RecordFailed is recorded when the chaos fails to be applied to or recovered from one of its records
+type RecordFailed struct {
+	Id       string
+	Activity string
+
+	Err string
+}
+
+func (f RecordFailed) Type() string {
+	return "Warning"
+}
+
+func (f RecordFailed) Reason() string {
+	return "Failed"
+}
+
+func (f RecordFailed) Message() string {
+	return fmt.Sprintf("Failed to %s for %s: %s", f.Activity, f.Id, f.Err)
+}
+
 func init() {
-	register(Failed{})
+	register(Failed{}, RecordFailed{})
 }
@@ -43,6 +43,22 @@ func (p FinalizerRemoved) Message() string {
 	return "Finalizer has been removed"
 }
 
+type FinalizerTimedOut struct {
+	Timeout string
+}
+
+func (p FinalizerTimedOut) Type() string {
+	return "Warning"
+}
+
+func (p FinalizerTimedOut) Reason() string {
+	return "FinalizerTimedOut"
+}
+
+func (p FinalizerTimedOut) Message() string {
+	return "Finalizer has been removed as the chaos is not recovered in " + p.Timeout + ", some chaos may remain on the targets"
+}
+
 func init() {
-	register(FinalizerInited{}, FinalizerRemoved{})
+	register(FinalizerInited{}, FinalizerRemoved{}, FinalizerTimedOut{})
 }
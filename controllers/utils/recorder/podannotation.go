@@ -0,0 +1,39 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"fmt"
+)
+
+// NetemDelayChaosCreated is recorded on the pod when the NetworkChaos requested by its netem-delay annotation is created
+type NetemDelayChaosCreated struct {
+	Name string
+}
+
+func (n NetemDelayChaosCreated) Type() string {
+	return "Normal"
+}
+
+func (n NetemDelayChaosCreated) Reason() string {
+	return "NetemDelayChaosCreated"
+}
+
+func (n NetemDelayChaosCreated) Message() string {
+	return fmt.Sprintf("Create networkchaos %s for the netem-delay annotation", n.Name)
+}
+
+func init() {
+	register(NetemDelayChaosCreated{})
+}
@@ -39,20 +39,33 @@ func TestGenerateAnnotations(t *testing.T) {
 
 		{map[string]string{"chaos-mesh.org/type": "deleted"}, Deleted{}},
 		{map[string]string{"chaos-mesh.org/type": "time-up"}, TimeUp{}},
+		{map[string]string{"chaos-mesh.org/max-active-duration": "24h0m0s", "chaos-mesh.org/type": "max-active-duration-exceeded"}, MaxActiveDurationExceeded{MaxActiveDuration: "24h0m0s"}},
 		{map[string]string{"chaos-mesh.org/type": "paused"}, Paused{}},
+		{map[string]string{"chaos-mesh.org/type": "out-of-active-windows"}, OutOfActiveWindows{}},
 		{map[string]string{"chaos-mesh.org/type": "started"}, Started{}},
 
 		{map[string]string{"chaos-mesh.org/activity": "test1", "chaos-mesh.org/err": "test2", "chaos-mesh.org/type": "failed"}, Failed{"test1", "test2"}},
+		{map[string]string{"chaos-mesh.org/id": "test0", "chaos-mesh.org/activity": "test1", "chaos-mesh.org/err": "test2", "chaos-mesh.org/type": "record-failed"}, RecordFailed{"test0", "test1", "test2"}},
 		{map[string]string{"chaos-mesh.org/type": "not-supported", "chaos-mesh.org/activity": "pausing a workflow schedule"}, NotSupported{Activity: "pausing a workflow schedule"}},
+		{map[string]string{"chaos-mesh.org/config": "chaosfs-sidecar", "chaos-mesh.org/type": "sidecar-injected"}, SidecarInjected{Config: "chaosfs-sidecar"}},
+		{map[string]string{"chaos-mesh.org/pod": "default/p0", "chaos-mesh.org/container-names": "[\"c0\"]", "chaos-mesh.org/type": "container-not-found"}, ContainerNotFound{Pod: "default/p0", ContainerNames: []string{"c0"}}},
+		{map[string]string{"chaos-mesh.org/matched": "1", "chaos-mesh.org/required": "2", "chaos-mesh.org/type": "min-matches-not-met"}, MinMatchesNotMet{Matched: 1, Required: 2}},
+		{map[string]string{"chaos-mesh.org/pod": "default/p0", "chaos-mesh.org/tc": "netem delay 100000", "chaos-mesh.org/peers": "all", "chaos-mesh.org/type": "traffic-control-applied"}, TrafficControlApplied{Pod: "default/p0", Tc: "netem delay 100000", Peers: "all"}},
+
+		{map[string]string{"chaos-mesh.org/name": "p0-netem-delay", "chaos-mesh.org/type": "netem-delay-chaos-created"}, NetemDelayChaosCreated{Name: "p0-netem-delay"}},
 
 		{map[string]string{"chaos-mesh.org/type": "finalizer-inited"}, FinalizerInited{}},
 		{map[string]string{"chaos-mesh.org/type": "finalizer-removed"}, FinalizerRemoved{}},
+		{map[string]string{"chaos-mesh.org/timeout": "1m0s", "chaos-mesh.org/type": "finalizer-timed-out"}, FinalizerTimedOut{Timeout: "1m0s"}},
+		{map[string]string{"chaos-mesh.org/timeout": "5m0s", "chaos-mesh.org/type": "recover-timed-out"}, RecoverTimedOut{Timeout: "5m0s"}},
 
 		{map[string]string{"chaos-mesh.org/missed-run": "2021-05-19T18:36:06Z", "chaos-mesh.org/type": "missed-schedule"}, MissedSchedule{MissedRun: missedRun}},
 		{map[string]string{"chaos-mesh.org/name": "test", "chaos-mesh.org/type": "schedule-spawn"}, ScheduleSpawn{Name: "test"}},
 		{map[string]string{"chaos-mesh.org/running-name": "test", "chaos-mesh.org/type": "schedule-forbid"}, ScheduleForbid{RunningName: "test"}},
 		{map[string]string{"chaos-mesh.org/running-name": "test", "chaos-mesh.org/type": "schedule-skip-remove-history"}, ScheduleSkipRemoveHistory{RunningName: "test"}},
 		{map[string]string{"chaos-mesh.org/type": "nodes-created", "chaos-mesh.org/child-nodes": "[\"node-a\",\"node-b\"]"}, NodesCreated{ChildNodes: []string{"node-a", "node-b"}}},
+		{map[string]string{"chaos-mesh.org/type": "child-nodes-failed", "chaos-mesh.org/child-nodes": "[\"node-a\"]"}, ChildNodesFailed{ChildNodes: []string{"node-a"}}},
+		{map[string]string{"chaos-mesh.org/type": "no-pod-selected"}, NoPodSelected{}},
 	}
 
 	for _, c := range testCases {
@@ -84,15 +97,20 @@ func TestParse(t *testing.T) {
 		{"Experiment has been deleted", Deleted{}},
 		{"Time up according to the duration", TimeUp{}},
 		{"Experiment has been paused", Paused{}},
+		{"Experiment is out of the active windows", OutOfActiveWindows{}},
 		{"Experiment has started", Started{}},
 
 		{"Failed to test1: test2", Failed{"test1", "test2"}},
+		{"Failed to test1 for test0: test2", RecordFailed{"test0", "test1", "test2"}},
+		{"Successfully inject sidecar config chaosfs-sidecar as ephemeral containers", SidecarInjected{Config: "chaosfs-sidecar"}},
 
 		{"Finalizer has been inited", FinalizerInited{}},
 		{"Finalizer has been removed", FinalizerRemoved{}},
+		{"Chaos is not recovered in 5m0s after the duration ends, some chaos may remain on the targets", RecoverTimedOut{Timeout: "5m0s"}},
 
 		{"Missed scheduled time to start a job: Wed, 19 May 2021 18:36:06 +0000", MissedSchedule{MissedRun: missedRun}},
 		{"Create new object: test", ScheduleSpawn{Name: "test"}},
+		{"Create networkchaos p0-netem-delay for the netem-delay annotation", NetemDelayChaosCreated{Name: "p0-netem-delay"}},
 		{"Forbid spawning new job because: test is still running", ScheduleForbid{RunningName: "test"}},
 		{"Skip removing history: test is still running", ScheduleSkipRemoveHistory{RunningName: "test"}},
 	}
@@ -182,6 +182,22 @@ func (it WorkflowAccomplished) Message() string {
 	return "workflow accomplished"
 }
 
+type WorkflowResumed struct {
+	FailedNodes []string
+}
+
+func (it WorkflowResumed) Type() string {
+	return corev1.EventTypeNormal
+}
+
+func (it WorkflowResumed) Reason() string {
+	return v1alpha1.WorkflowResumed
+}
+
+func (it WorkflowResumed) Message() string {
+	return fmt.Sprintf("workflow resumed from failed nodes, failed nodes: %s", it.FailedNodes)
+}
+
 type NodeAccomplished struct {
 }
 
@@ -197,6 +213,68 @@ func (it NodeAccomplished) Message() string {
 	return "node accomplished"
 }
 
+type ChildNodesFailed struct {
+	ChildNodes []string
+}
+
+func (it ChildNodesFailed) Type() string {
+	return corev1.EventTypeWarning
+}
+
+func (it ChildNodesFailed) Reason() string {
+	return v1alpha1.ChildNodeFailed
+}
+
+func (it ChildNodesFailed) Message() string {
+	return fmt.Sprintf("node failed because of the failed children nodes: %s", it.ChildNodes)
+}
+
+type NoPodSelected struct {
+}
+
+func (it NoPodSelected) Type() string {
+	return corev1.EventTypeWarning
+}
+
+func (it NoPodSelected) Reason() string {
+	return v1alpha1.NoPodSelected
+}
+
+func (it NoPodSelected) Message() string {
+	return "node failed because no pod is selected"
+}
+
+type ParentNodeFailedFast struct {
+	ParentNodeName string
+}
+
+func (it ParentNodeFailedFast) Type() string {
+	return corev1.EventTypeNormal
+}
+
+func (it ParentNodeFailedFast) Reason() string {
+	return v1alpha1.ParentNodeFailedFast
+}
+
+func (it ParentNodeFailedFast) Message() string {
+	return fmt.Sprintf("aborted because parent node %s failed fast", it.ParentNodeName)
+}
+
+type WorkflowFailed struct {
+}
+
+func (it WorkflowFailed) Type() string {
+	return corev1.EventTypeWarning
+}
+
+func (it WorkflowFailed) Reason() string {
+	return v1alpha1.WorkflowFailed
+}
+
+func (it WorkflowFailed) Message() string {
+	return "workflow accomplished with failed nodes"
+}
+
 type TaskPodSpawned struct {
 	PodName string
 }
@@ -288,7 +366,12 @@ func init() {
 		DeadlineExceed{},
 		ParentNodeDeadlineExceed{},
 		WorkflowAccomplished{},
+		WorkflowResumed{},
 		NodeAccomplished{},
+		ChildNodesFailed{},
+		NoPodSelected{},
+		ParentNodeFailedFast{},
+		WorkflowFailed{},
 		TaskPodSpawned{},
 		TaskPodSpawnFailed{},
 		TaskPodPodCompleted{},
@@ -67,7 +67,7 @@ func TestcaseIOMistakeDurationForATimeThenRecover(
 			},
 			// only inject read or write method. Other method may or may not run properly, but is not recommended
 			Methods:  []v1alpha1.IoMethod{v1alpha1.Read, v1alpha1.Write},
-			Duration: pointer.StringPtr("9m"),
+			Duration: (*v1alpha1.Duration)(pointer.StringPtr("9m")),
 		},
 	}
 	err = cli.Create(ctx, ioChaos)
@@ -139,7 +139,7 @@ func TestcaseIOMistakeDurationForATimePauseAndUnPause(
 			},
 			// only inject read or write method. Other method may or may not run properly, but is not recommended
 			Methods:  []v1alpha1.IoMethod{v1alpha1.Read, v1alpha1.Write},
-			Duration: pointer.StringPtr("9m"),
+			Duration: (*v1alpha1.Duration)(pointer.StringPtr("9m")),
 		},
 	}
 	err = cli.Create(ctx, ioChaos)
@@ -263,7 +263,7 @@ func TestcaseIOMistakeWithSpecifiedContainer(
 			},
 			// only inject read or write method. Other method may or may not run properly, but is not recommended
 			Methods:  []v1alpha1.IoMethod{v1alpha1.Read, v1alpha1.Write},
-			Duration: pointer.StringPtr("9m"),
+			Duration: (*v1alpha1.Duration)(pointer.StringPtr("9m")),
 		},
 	}
 	err = cli.Create(ctx, ioChaos)
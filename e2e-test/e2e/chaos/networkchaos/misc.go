@@ -131,7 +131,7 @@ func makeNetworkPartitionChaos(
 			Action:    v1alpha1.PartitionAction,
 			Direction: direction,
 			Target:    target,
-			Duration:  duration,
+			Duration:  (*v1alpha1.Duration)(duration),
 			PodSelector: v1alpha1.PodSelector{
 				Selector: v1alpha1.PodSelectorSpec{
 					Namespaces:     []string{namespace},
@@ -166,7 +166,7 @@ func makeNetworkDelayChaos(
 		Spec: v1alpha1.NetworkChaosSpec{
 			Action:      v1alpha1.DelayAction,
 			TcParameter: tcparam,
-			Duration:    duration,
+			Duration:    (*v1alpha1.Duration)(duration),
 			Target:      target,
 			Direction:   direction,
 			PodSelector: v1alpha1.PodSelector{
@@ -961,6 +961,12 @@ rules:
   - apiGroups: [ "" ]
     resources: [ "pods", "secrets"]
     verbs: [ "get", "list", "watch", "delete", "update", "patch" ]
+  - apiGroups: [ "" ]
+    resources: [ "services", "endpoints" ]
+    verbs: [ "get", "list", "watch" ]
+  - apiGroups: [ "apps" ]
+    resources: [ "replicasets" ]
+    verbs: [ "get", "list", "watch" ]
   - apiGroups:
       - ""
     resources:
@@ -973,6 +979,12 @@ rules:
       - "pods/log"
     verbs:
       - "get"
+  - apiGroups:
+      - ""
+    resources:
+      - "pods/ephemeralcontainers"
+    verbs:
+      - "update"
   - apiGroups:
       - ""
     resources:
@@ -1390,6 +1402,9 @@ spec:
             value: "true"
           - name: TZ
             value: ${timezone}
+          # enable the retry policy of the RPCs to the chaos daemon
+          - name: GRPC_GO_RETRY
+            value: "on"
           - name: CHAOS_DAEMON_SERVICE_PORT
             value: !!str 31767
           - name: BPFKI_PORT
@@ -1446,6 +1461,7 @@ webhooks:
         name: chaos-mesh-controller-manager
         namespace: "chaos-testing"
         path: "/inject-v1-pod"
+    admissionReviewVersions: ["v1", "v1beta1"]
     rules:
       - operations: [ "CREATE" ]
         apiGroups: [""]
@@ -1943,6 +1959,7 @@ webhooks:
         name: chaos-mesh-controller-manager
         namespace: "chaos-testing"
         path: /validate-auth
+    admissionReviewVersions: ["v1", "v1beta1"]
     failurePolicy: Fail
     name: vauth.kb.io
     rules:
@@ -1954,6 +1971,23 @@ webhooks:
           - CREATE
           - UPDATE
         resources: [ "*" ]
+  - clientConfig:
+      caBundle: "${CA_BUNDLE}"
+      service:
+        name: chaos-mesh-controller-manager
+        namespace: "chaos-testing"
+        path: /validate-dry-run
+    admissionReviewVersions: ["v1", "v1beta1"]
+    failurePolicy: Ignore
+    name: vdryrun.kb.io
+    rules:
+      - apiGroups:
+          - chaos-mesh.org
+        apiVersions:
+          - v1alpha1
+        operations:
+          - CREATE
+        resources: [ "*" ]
 EOF
     # chaos-mesh.yaml end
 }
@@ -0,0 +1,251 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/utils"
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+	config "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
+)
+
+const (
+	alertNameLabel = "alertname"
+
+	// LabelAlertMapping is the label of the chaos created by an alert, whose value is the name of the mapping
+	LabelAlertMapping = "chaos-mesh.org/alert-mapping"
+	// LabelAlertFingerprint is the label of the chaos created by an alert, whose value is the fingerprint of the alert
+	LabelAlertFingerprint = "chaos-mesh.org/alert-fingerprint"
+
+	statusFiring   = "firing"
+	statusResolved = "resolved"
+)
+
+// Service defines a handler service for the alerts of Prometheus.
+type Service struct {
+	conf     *config.ChaosDashboardConfig
+	mappings []*Mapping
+}
+
+// NewService returns an alert service instance, the mappings are loaded from the file in the config.
+func NewService(conf *config.ChaosDashboardConfig) (*Service, error) {
+	s := &Service{
+		conf: conf,
+	}
+	if conf.AlertMappingsFile == "" {
+		return s, nil
+	}
+
+	mappings, err := LoadMappings(conf.AlertMappingsFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert mappings %s: %v", conf.AlertMappingsFile, err)
+	}
+	s.mappings = mappings
+	return s, nil
+}
+
+// Register mounts our HTTP handler on the mux.
+func Register(r *gin.RouterGroup, s *Service) {
+	endpoint := r.Group("/alerts")
+
+	endpoint.POST("/webhook", s.receive)
+}
+
+// WebhookMessage is the payload sent by the webhook receiver of Alertmanager.
+type WebhookMessage struct {
+	Version string  `json:"version"`
+	Status  string  `json:"status"`
+	Alerts  []Alert `json:"alerts" binding:"required"`
+}
+
+// Alert is an alert in the payload of Alertmanager.
+type Alert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Fingerprint string            `json:"fingerprint"`
+}
+
+// Experiment is a chaos created or recovered by an alert.
+type Experiment struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Mapping   string `json:"mapping"`
+}
+
+// WebhookResponse is the chaos created or recovered by the alerts in the payload.
+type WebhookResponse struct {
+	Created   []Experiment `json:"created"`
+	Recovered []Experiment `json:"recovered"`
+}
+
+// @Summary Create or recover the chaos mapped by the alerts.
+// @Description Receive the webhook of Alertmanager, the chaos mapped by the firing alerts are created, and the chaos created by the resolved alerts are deleted to be recovered.
+// @Tags alerts
+// @Produce json
+// @Param request body WebhookMessage true "Request body"
+// @Success 200 {object} WebhookResponse
+// @Router /alerts/webhook [post]
+// @Failure 400 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+func (s *Service) receive(c *gin.Context) {
+	kubeCli, err := clientpool.ExtractTokenAndGetClient(c.Request.Header)
+	if err != nil {
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	msg := &WebhookMessage{}
+	if err := c.ShouldBindJSON(msg); err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	scope := utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces)
+	resp := WebhookResponse{
+		Created:   make([]Experiment, 0),
+		Recovered: make([]Experiment, 0),
+	}
+	for _, alert := range msg.Alerts {
+		status := alert.Status
+		if status == "" {
+			// the alerts share the status of the group in the earlier versions of Alertmanager
+			status = msg.Status
+		}
+		fingerprint := alert.Fingerprint
+		if len(validation.IsDNS1123Label(fingerprint)) > 0 {
+			// the fingerprint is a part of the name of chaos
+			fingerprint = fingerprintOf(alert.Labels)
+		}
+
+		for _, m := range s.mappings {
+			if !m.Matches(alert.Labels) || !scope.Allowed(m.Namespace()) {
+				continue
+			}
+
+			exp := Experiment{
+				Kind:      m.Kind(),
+				Namespace: m.Namespace(),
+				Name:      fmt.Sprintf("%s-%s", m.Name, fingerprint),
+				Mapping:   m.Name,
+			}
+			switch status {
+			case statusFiring:
+				created, err := createChaos(kubeCli, m, exp.Name, fingerprint)
+				if err != nil {
+					c.Status(http.StatusInternalServerError)
+					_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(err))
+					return
+				}
+				if created {
+					resp.Created = append(resp.Created, exp)
+				}
+			case statusResolved:
+				recovered, err := recoverChaos(kubeCli, m, exp.Name)
+				if err != nil {
+					c.Status(http.StatusInternalServerError)
+					_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(err))
+					return
+				}
+				if recovered {
+					resp.Recovered = append(resp.Recovered, exp)
+				}
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// createChaos creates the chaos of the mapping with the name, it returns false if the chaos has been created, as
+// Alertmanager repeats the notification of the firing alert.
+func createChaos(kubeCli client.Client, m *Mapping, name string, fingerprint string) (bool, error) {
+	chaos := m.chaos.DeepCopyObject()
+	meta := chaos.(metav1.Object)
+	labels := make(map[string]string)
+	for k, v := range meta.GetLabels() {
+		labels[k] = v
+	}
+	labels[LabelAlertMapping] = m.Name
+	labels[LabelAlertFingerprint] = fingerprint
+	reflect.ValueOf(chaos).Elem().FieldByName("ObjectMeta").Set(reflect.ValueOf(metav1.ObjectMeta{
+		Namespace:   meta.GetNamespace(),
+		Name:        name,
+		Labels:      labels,
+		Annotations: meta.GetAnnotations(),
+	}))
+
+	if err := kubeCli.Create(context.TODO(), chaos); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// recoverChaos deletes the chaos created by the mapping with the name, so that it's recovered. The chaos which isn't
+// created by the mapping is left untouched.
+func recoverChaos(kubeCli client.Client, m *Mapping, name string) (bool, error) {
+	chaos := m.chaos.DeepCopyObject()
+	if err := kubeCli.Get(context.TODO(), types.NamespacedName{Namespace: m.Namespace(), Name: name}, chaos); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if chaos.(metav1.Object).GetLabels()[LabelAlertMapping] != m.Name {
+		return false, nil
+	}
+
+	if err := kubeCli.Delete(context.TODO(), chaos); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// fingerprintOf identifies the alert by its labels, for the payload without valid fingerprints.
+func fingerprintOf(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := fnv.New64a()
+	for _, k := range keys {
+		_, _ = h.Write([]byte(k))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(labels[k]))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -0,0 +1,157 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/utils"
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+	clientpooltest "github.com/chaos-mesh/chaos-mesh/pkg/clientpool/test"
+	config "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
+)
+
+const testMappings = `- name: pod-failure
+  alert: HighErrorRate
+  labels:
+    severity: critical
+  template:
+    kind: PodChaos
+    metadata:
+      namespace: app
+    spec:
+      action: pod-failure
+      mode: one
+      duration: 30s
+      selector:
+        namespaces:
+          - app
+`
+
+func TestValidateMappings(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	template := json.RawMessage(`{"kind":"PodChaos","metadata":{"namespace":"app"},"spec":{"action":"pod-kill","mode":"one"}}`)
+	g.Expect(ValidateMappings([]*Mapping{{Name: "pod-kill", Alert: "HighErrorRate", Template: template}})).To(Succeed())
+
+	cases := map[string][]*Mapping{
+		"the name is required": {{Alert: "HighErrorRate", Template: template}},
+		"invalid name":         {{Name: "Pod_Kill", Alert: "HighErrorRate", Template: template}},
+		"duplicated name": {
+			{Name: "pod-kill", Alert: "HighErrorRate", Template: template},
+			{Name: "pod-kill", Alert: "HighLatency", Template: template},
+		},
+		"the alert is required":    {{Name: "pod-kill", Template: template}},
+		"the template is required": {{Name: "pod-kill", Alert: "HighErrorRate"}},
+		"is not supported": {{Name: "pod-kill", Alert: "HighErrorRate",
+			Template: json.RawMessage(`{"kind":"Pod","metadata":{"namespace":"app"}}`)}},
+		"the namespace of the template is required": {{Name: "pod-kill", Alert: "HighErrorRate",
+			Template: json.RawMessage(`{"kind":"PodChaos","spec":{"action":"pod-kill","mode":"one"}}`)}},
+		"parse duration field error": {{Name: "pod-kill", Alert: "HighErrorRate",
+			Template: json.RawMessage(`{"kind":"PodChaos","metadata":{"namespace":"app"},"spec":{"action":"pod-kill","mode":"one","duration":"10 minutes"}}`)}},
+	}
+	for message, mappings := range cases {
+		err := ValidateMappings(mappings)
+		g.Expect(err).To(HaveOccurred(), message)
+		g.Expect(err.Error()).To(ContainSubstring(message))
+	}
+}
+
+func TestCreateAndRecoverByAlerts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	dir, err := ioutil.TempDir("", "alert")
+	g.Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mappings.yaml")
+	g.Expect(ioutil.WriteFile(path, []byte(testMappings), 0644)).To(Succeed())
+
+	kubeCli := fake.NewFakeClientWithScheme(provider.NewScheme())
+	originalClients := clientpool.K8sClients
+	clientpool.K8sClients = clientpooltest.NewFakeClients(kubeCli).WithAuthClient(clientpooltest.NewFakeAuthClient(""))
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	s, err := NewService(&config.ChaosDashboardConfig{AlertMappingsFile: path})
+	g.Expect(err).ToNot(HaveOccurred())
+	router := gin.New()
+	router.Use(utils.MWHandleErrors())
+	Register(router.Group("/api"), s)
+	notify := func(status string) WebhookResponse {
+		body, err := json.Marshal(WebhookMessage{
+			Version: "4",
+			Status:  status,
+			Alerts: []Alert{
+				{
+					Status:      status,
+					Labels:      map[string]string{"alertname": "HighErrorRate", "severity": "critical"},
+					Fingerprint: "6f1d4e3a2b9c8d7e",
+				},
+				{
+					Status:      status,
+					Labels:      map[string]string{"alertname": "HighErrorRate", "severity": "warning"},
+					Fingerprint: "0a1b2c3d4e5f6a7b",
+				},
+			},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		req, _ := http.NewRequest(http.MethodPost, "/api/alerts/webhook", bytes.NewBuffer(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		g.Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+
+		resp := WebhookResponse{}
+		g.Expect(json.Unmarshal(rr.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+	expected := Experiment{Kind: "PodChaos", Namespace: "app", Name: "pod-failure-6f1d4e3a2b9c8d7e", Mapping: "pod-failure"}
+	key := types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}
+
+	// the firing alert creates the mapped chaos, and the alert without the labels of the mapping is ignored
+	resp := notify(statusFiring)
+	g.Expect(resp.Created).To(Equal([]Experiment{expected}))
+	chaos := &v1alpha1.PodChaos{}
+	g.Expect(kubeCli.Get(context.TODO(), key, chaos)).To(Succeed())
+	g.Expect(chaos.Spec.Action).To(Equal(v1alpha1.PodFailureAction))
+	g.Expect(chaos.Labels).To(HaveKeyWithValue(LabelAlertMapping, "pod-failure"))
+	g.Expect(chaos.Labels).To(HaveKeyWithValue(LabelAlertFingerprint, "6f1d4e3a2b9c8d7e"))
+
+	// the repeated notification doesn't create the chaos again
+	resp = notify(statusFiring)
+	g.Expect(resp.Created).To(BeEmpty())
+
+	// the resolved alert recovers the chaos
+	resp = notify(statusResolved)
+	g.Expect(resp.Recovered).To(Equal([]Experiment{expected}))
+	err = kubeCli.Get(context.TODO(), key, &v1alpha1.PodChaos{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// Mapping maps the alerts to the chaos created when they fire.
+type Mapping struct {
+	// Name identifies the mapping, it's the prefix of the names of the chaos created by the mapping
+	Name string `json:"name"`
+	// Alert is the name of the alerts, i.e. their `alertname` label
+	Alert string `json:"alert"`
+	// Labels are the other labels the alerts must have
+	Labels map[string]string `json:"labels,omitempty"`
+	// Template is the chaos created when the alert fires, e.g. a NetworkChaos with its kind, namespace and spec
+	Template json.RawMessage `json:"template"`
+
+	// chaos is the parsed template
+	chaos runtime.Object
+}
+
+// Matches returns whether the alert with the labels is mapped by the mapping.
+func (m *Mapping) Matches(labels map[string]string) bool {
+	if labels[alertNameLabel] != m.Alert {
+		return false
+	}
+	for k, v := range m.Labels {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Kind returns the kind of the chaos created by the mapping.
+func (m *Mapping) Kind() string {
+	return m.chaos.GetObjectKind().GroupVersionKind().Kind
+}
+
+// Namespace returns the namespace of the chaos created by the mapping.
+func (m *Mapping) Namespace() string {
+	return m.chaos.(metav1.Object).GetNamespace()
+}
+
+// LoadMappings reads the mappings from the YAML file and validates them.
+func LoadMappings(path string) ([]*Mapping, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var mappings []*Mapping
+	if err := yaml.Unmarshal(data, &mappings); err != nil {
+		return nil, err
+	}
+	if err := ValidateMappings(mappings); err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// ValidateMappings validates the mappings and parses their templates, the template is validated as the webhook
+// validates the created chaos.
+func ValidateMappings(mappings []*Mapping) error {
+	names := make(map[string]struct{})
+	for i, m := range mappings {
+		if m.Name == "" {
+			return fmt.Errorf("mappings[%d]: the name is required", i)
+		}
+		if errs := validation.IsDNS1123Label(m.Name); len(errs) > 0 {
+			return fmt.Errorf("mappings[%d]: invalid name %s: %v", i, m.Name, errs)
+		}
+		if _, ok := names[m.Name]; ok {
+			return fmt.Errorf("mappings[%d]: duplicated name %s", i, m.Name)
+		}
+		names[m.Name] = struct{}{}
+
+		if m.Alert == "" {
+			return fmt.Errorf("mapping %s: the alert is required", m.Name)
+		}
+
+		chaos, err := parseTemplate(m.Template)
+		if err != nil {
+			return fmt.Errorf("mapping %s: %v", m.Name, err)
+		}
+		m.chaos = chaos
+	}
+	return nil
+}
+
+func parseTemplate(template json.RawMessage) (runtime.Object, error) {
+	if len(template) == 0 {
+		return nil, fmt.Errorf("the template is required")
+	}
+
+	var meta metav1.TypeMeta
+	if err := json.Unmarshal(template, &meta); err != nil {
+		return nil, err
+	}
+	chaosKind, ok := v1alpha1.AllKinds()[meta.Kind]
+	if !ok {
+		return nil, fmt.Errorf("the kind %q of the template is not supported", meta.Kind)
+	}
+
+	chaos := chaosKind.Chaos.DeepCopyObject()
+	if err := json.Unmarshal(template, chaos); err != nil {
+		return nil, err
+	}
+	chaos.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(meta.Kind))
+	if chaos.(metav1.Object).GetNamespace() == "" {
+		return nil, fmt.Errorf("the namespace of the template is required")
+	}
+
+	if defaulter, ok := chaos.(webhook.Defaulter); ok {
+		defaulter.Default()
+	}
+	if validator, ok := chaos.(webhook.Validator); ok {
+		if err := validator.ValidateCreate(); err != nil {
+			return nil, err
+		}
+	}
+	return chaos, nil
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -97,6 +98,14 @@ type Archive struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// ExperimentArchive defines the basic information of an archived experiment.
+type ExperimentArchive struct {
+	Archive
+	StartTime  time.Time               `json:"start_time"`
+	FinishTime time.Time               `json:"finish_time"`
+	Status     utils.ChaosStatusString `json:"status"`
+}
+
 // Detail represents an archive instance.
 type Detail struct {
 	Archive
@@ -104,14 +113,19 @@ type Detail struct {
 }
 
 // @Summary Get archived chaos experiments.
-// @Description Get archived chaos experiments.
+// @Description Get archived chaos experiments, in the order they are recorded.
 // @Tags archives
 // @Produce json
 // @Param namespace query string false "namespace"
 // @Param name query string false "name"
 // @Param kind query string false "kind" Enums(PodChaos, IOChaos, NetworkChaos, TimeChaos, KernelChaos, StressChaos)
-// @Success 200 {array} Archive
+// @Param start query string false "The experiments started before it are excluded, in RFC3339 format"
+// @Param end query string false "The experiments finished after it are excluded, in RFC3339 format"
+// @Param limit query int false "The max length of the archives list"
+// @Param offset query int false "The number of the archives skipped"
+// @Success 200 {array} ExperimentArchive
 // @Router /archives [get]
+// @Failure 400 {object} utils.APIError
 // @Failure 500 {object} utils.APIError
 func (s *Service) list(c *gin.Context) {
 	kind := c.Query("kind")
@@ -122,28 +136,96 @@ func (s *Service) list(c *gin.Context) {
 		ns = s.conf.TargetNamespace
 	}
 
-	metas, err := s.archive.ListMeta(context.Background(), kind, ns, name, true)
+	start, err := parseTimeQuery(c, "start")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+	end, err := parseTimeQuery(c, "end")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+	limit, err := parseIntQuery(c, "limit")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+	offset, err := parseIntQuery(c, "offset")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	metas, err := s.archive.ListMetaByFilter(context.Background(), core.ExperimentFilter{
+		Kind:       kind,
+		Namespace:  ns,
+		Name:       name,
+		Archived:   true,
+		StartTime:  start,
+		FinishTime: end,
+		Limit:      limit,
+		Offset:     offset,
+	})
 	if err != nil {
 		c.Status(http.StatusInternalServerError)
-		_ = c.Error(utils.ErrInternalServer.NewWithNoMessage())
+		_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(err))
 		return
 	}
 
-	archives := make([]Archive, 0)
+	archives := make([]ExperimentArchive, 0)
 
 	for _, meta := range metas {
-		archives = append(archives, Archive{
-			UID:       meta.UID,
-			Kind:      meta.Kind,
-			Namespace: meta.Namespace,
-			Name:      meta.Name,
-			CreatedAt: meta.StartTime,
+		archives = append(archives, ExperimentArchive{
+			Archive: Archive{
+				UID:       meta.UID,
+				Kind:      meta.Kind,
+				Namespace: meta.Namespace,
+				Name:      meta.Name,
+				CreatedAt: meta.StartTime,
+			},
+			StartTime:  meta.StartTime,
+			FinishTime: meta.FinishTime,
+			// the experiment is archived after it's deleted, so it never runs again
+			Status: utils.Finished,
 		})
 	}
 
 	c.JSON(http.StatusOK, archives)
 }
 
+// parseTimeQuery parses the time in RFC3339 format from the query, it returns the zero time if it's absent
+func parseTimeQuery(c *gin.Context, key string) (time.Time, error) {
+	value := c.Query(key)
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("the format of %s is wrong: %v", key, err)
+	}
+	return t, nil
+}
+
+// parseIntQuery parses the non-negative integer from the query, it returns 0 if it's absent
+func parseIntQuery(c *gin.Context, key string) (int, error) {
+	value := c.Query(key)
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("%s should be a non-negative integer", key)
+	}
+	return i, nil
+}
+
 // @Summary Get the detail of an archived chaos experiment.
 // @Description Get the detail of an archived chaos experiment.
 // @Tags archives
@@ -30,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/utils"
 	config "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
 	"github.com/chaos-mesh/chaos-mesh/pkg/core"
 	pkgmock "github.com/chaos-mesh/chaos-mesh/pkg/mock"
@@ -38,6 +39,8 @@ import (
 // MockExperimentStore is a mock type for ExperimentStore
 type MockExperimentStore struct {
 	mock.Mock
+	// filter is the last filter passed to ListMetaByFilter
+	filter core.ExperimentFilter
 }
 
 // MockScheduleStore is a mock type for ScheduleStore
@@ -71,6 +74,11 @@ func (m *MockExperimentStore) ListMeta(ctx context.Context, kind, namespace, nam
 	return res, err
 }
 
+func (m *MockExperimentStore) ListMetaByFilter(ctx context.Context, filter core.ExperimentFilter) ([]*core.ExperimentMeta, error) {
+	m.filter = filter
+	return m.ListMeta(ctx, filter.Kind, filter.Namespace, filter.Name, filter.Archived)
+}
+
 func (m *MockExperimentStore) FindByUID(ctx context.Context, UID string) (*core.Experiment, error) {
 	var res *core.Experiment
 	var err error
@@ -228,7 +236,11 @@ func (m *MockExperimentStore) Delete(context.Context, *core.Experiment) error {
 	panic("implement me")
 }
 
-func (m *MockExperimentStore) DeleteByFinishTime(context.Context, time.Duration) error {
+func (m *MockExperimentStore) DeleteByFinishTime(context.Context, time.Duration, int) error {
+	panic("implement me")
+}
+
+func (m *MockExperimentStore) PurgeDeleted(context.Context, time.Duration, int) error {
 	panic("implement me")
 }
 
@@ -319,10 +331,11 @@ func (m *MockScheduleStore) DeleteIncompleteSchedules(context.Context) error {
 
 var _ = Describe("event", func() {
 	var router *gin.Engine
+	var mockExpStore *MockExperimentStore
 	BeforeEach(func() {
 		pkgmock.With("MockAuthRequired", true)
 
-		mockExpStore := new(MockExperimentStore)
+		mockExpStore = new(MockExperimentStore)
 		mockSchStore := new(MockScheduleStore)
 
 		s := Service{
@@ -351,13 +364,16 @@ var _ = Describe("event", func() {
 
 	Context("List", func() {
 		It("success", func() {
-			response := []Archive{
-				Archive{
-					UID:       "testUID",
-					Kind:      "testKind",
-					Namespace: "testNamespace",
-					Name:      "testName",
-					CreatedAt: time.Time{},
+			response := []ExperimentArchive{
+				{
+					Archive: Archive{
+						UID:       "testUID",
+						Kind:      "testKind",
+						Namespace: "testNamespace",
+						Name:      "testName",
+						CreatedAt: time.Time{},
+					},
+					Status: utils.Finished,
 				},
 			}
 			rr := httptest.NewRecorder()
@@ -375,6 +391,32 @@ var _ = Describe("event", func() {
 			router.ServeHTTP(rr, request)
 			Expect(rr.Code).Should(Equal(http.StatusInternalServerError))
 		})
+
+		It("passes the filters and the page to the store", func() {
+			rr := httptest.NewRecorder()
+			request, _ := http.NewRequest(http.MethodGet,
+				"/api/archives?kind=testKind&namespace=testNamespace&start=2021-06-02T00:00:00Z&end=2021-06-03T12:00:00Z&limit=2&offset=4", nil)
+			router.ServeHTTP(rr, request)
+			Expect(rr.Code).Should(Equal(http.StatusOK))
+			Expect(mockExpStore.filter).Should(Equal(core.ExperimentFilter{
+				Kind:       "testKind",
+				Namespace:  "testNamespace",
+				Archived:   true,
+				StartTime:  time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC),
+				FinishTime: time.Date(2021, 6, 3, 12, 0, 0, 0, time.UTC),
+				Limit:      2,
+				Offset:     4,
+			}))
+		})
+
+		It("rejects the malformed query", func() {
+			for _, query := range []string{"&limit=-1", "&offset=foo", "&start=yesterday", "&end=2021-06-02"} {
+				rr := httptest.NewRecorder()
+				request, _ := http.NewRequest(http.MethodGet, "/api/archives?kind=testKind"+query, nil)
+				router.ServeHTTP(rr, request)
+				Expect(rr.Code).Should(Equal(http.StatusBadRequest), query)
+			}
+		})
 	})
 
 	Context("Detail", func() {
@@ -65,6 +65,7 @@ func Register(r *gin.RouterGroup, s *Service) {
 // @Param object_id query string false "The UID of the object"
 // @Param kind query string false "kind" Enums(PodChaos, IOChaos, NetworkChaos, TimeChaos, KernelChaos, StressChaos, AWSChaos, GCPChaos, DNSChaos, Schedule)
 // @Param limit query string false "The max length of events list"
+// @Param pod query string false "The namespace/name of the pod targeted by the events"
 // @Success 200 {array} core.Event
 // @Router /events [get]
 // @Failure 500 {object} utils.APIError
@@ -82,6 +83,7 @@ func (s *Service) listEvents(c *gin.Context) {
 		ObjectID:      c.Query("object_id"),
 		Kind:          c.Query("kind"),
 		LimitStr:      c.Query("limit"),
+		Pod:           c.Query("pod"),
 	}
 
 	eventList, err := s.event.ListByFilter(context.Background(), filter)
@@ -109,7 +109,11 @@ func (m *MockEventService) DeleteByUIDs(context.Context, []string) error {
 	panic("implement me")
 }
 
-func (m *MockEventService) DeleteByCreateTime(context.Context, time.Duration) error {
+func (m *MockEventService) DeleteByCreateTime(context.Context, time.Duration, int) error {
+	panic("implement me")
+}
+
+func (m *MockEventService) PurgeDeleted(context.Context, time.Duration, int) error {
 	panic("implement me")
 }
 
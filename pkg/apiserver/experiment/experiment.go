@@ -35,6 +35,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/finalizers"
@@ -75,6 +76,7 @@ func Register(r *gin.RouterGroup, s *Service) {
 
 	endpoint.GET("", s.listExperiments)
 	endpoint.POST("/new", s.createExperiment)
+	endpoint.POST("/:namespace/:kind/:name/clone", s.cloneExperiment)
 	endpoint.GET("/detail/:uid", s.getExperimentDetail)
 	endpoint.DELETE("/:uid", s.deleteExperiment)
 	endpoint.DELETE("/", s.batchDeleteExperiment)
@@ -117,18 +119,31 @@ type Detail struct {
 type createExperimentFunc func(*core.ExperimentInfo, client.Client) error
 type updateExperimentFunc func(*core.KubeObjectDesc, client.Client) error
 
+// CloneRequest defines the target of cloning an experiment.
+type CloneRequest struct {
+	Namespace string `json:"namespace" binding:"required,NameValid"`
+	// Name is the name of the clone, it defaults to the name of the experiment.
+	Name string `json:"name,omitempty"`
+}
+
 // StatusResponse defines a common status struct.
 type StatusResponse struct {
 	Status string `json:"status"`
 }
 
 // @Summary Create a new chaos experiment.
-// @Description Create a new chaos experiment.
+// @Description Create a new chaos experiment. If the experiment is annotated with experiment.chaos-mesh.org/idempotency-key,
+// @Description and an experiment of the same kind with the same key and name already exists in the namespace, the
+// @Description existing one is returned as core.KubeObjectDesc instead. The creation is rejected with 409 if the
+// @Description experiment with the name exists without the key. The key only takes effect on this API, the
+// @Description experiments created by kubectl or other clients are not deduplicated.
 // @Tags experiments
 // @Produce json
 // @Param request body core.ExperimentInfo true "Request body"
 // @Success 200 {object} core.ExperimentInfo
 // @Failure 400 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
 // @Failure 500 {object} utils.APIError
 // @Router /experiments/new [post]
 func (s *Service) createExperiment(c *gin.Context) {
@@ -164,7 +179,34 @@ func (s *Service) createExperiment(c *gin.Context) {
 		return
 	}
 
+	if !utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces).Allowed(exp.Namespace) {
+		c.Status(http.StatusForbidden)
+		_ = c.Error(utils.ErrNoNamespacePrivilege.New("can't create experiments in namespace %s", exp.Namespace))
+		return
+	}
+
 	if err := f(exp, kubeCli); err != nil {
+		key := exp.Annotations[v1alpha1.IdempotencyKeyAnnotationKey]
+		if key != "" && apierrors.IsAlreadyExists(err) {
+			// the names are unique in the namespace, so the experiment with the same key is created by an
+			// earlier attempt of the same request, even if the attempts are concurrent
+			existing, getErr := s.getExperimentByName(kubeCli, exp.Target.Kind, exp.Namespace, exp.Name)
+			if getErr != nil {
+				c.Status(http.StatusInternalServerError)
+				_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(getErr))
+				return
+			}
+			if existing.Meta.Annotations[v1alpha1.IdempotencyKeyAnnotationKey] != key {
+				c.Status(http.StatusConflict)
+				_ = c.Error(utils.ErrInvalidRequest.New("the experiment %s/%s already exists without the idempotency key %s",
+					exp.Namespace, exp.Name, key))
+				return
+			}
+
+			c.JSON(http.StatusOK, existing)
+			return
+		}
+
 		c.Status(http.StatusInternalServerError)
 		_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(err))
 		return
@@ -173,6 +215,184 @@ func (s *Service) createExperiment(c *gin.Context) {
 	c.JSON(http.StatusOK, exp)
 }
 
+// getExperimentByName returns the experiment of the kind in the namespace with the name
+func (s *Service) getExperimentByName(kubeCli client.Client, kind string, ns string, name string) (*core.KubeObjectDesc, error) {
+	chaosKind, ok := v1alpha1.AllKinds()[kind]
+	if !ok {
+		return nil, fmt.Errorf("%s is not supported", kind)
+	}
+	chaos := chaosKind.Chaos.DeepCopyObject()
+	if err := kubeCli.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: name}, chaos); err != nil {
+		return nil, err
+	}
+
+	gvk, err := apiutil.GVKForObject(chaos, s.scheme)
+	if err != nil {
+		return nil, err
+	}
+	meta := chaos.(metav1.Object)
+	return &core.KubeObjectDesc{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+		},
+		Meta: core.KubeObjectMeta{
+			Name:        meta.GetName(),
+			Namespace:   meta.GetNamespace(),
+			Labels:      meta.GetLabels(),
+			Annotations: meta.GetAnnotations(),
+		},
+		Spec: reflect.ValueOf(chaos).Elem().FieldByName("Spec").Interface(),
+	}, nil
+}
+
+// @Summary Clone a chaos experiment into another namespace.
+// @Description Clone the spec of a chaos experiment into another namespace. The selector namespaces which refer to
+// @Description the namespace of the experiment are adjusted to the target namespace.
+// @Tags experiments
+// @Produce json
+// @Param namespace path string true "namespace"
+// @Param kind path string true "kind"
+// @Param name path string true "name"
+// @Param request body CloneRequest true "Request body"
+// @Success 200 {object} Base
+// @Failure 400 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /experiments/{namespace}/{kind}/{name}/clone [post]
+func (s *Service) cloneExperiment(c *gin.Context) {
+	kubeCli, err := clientpool.ExtractTokenAndGetClient(c.Request.Header)
+	if err != nil {
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	req := &CloneRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	ns, kind, name := c.Param("namespace"), c.Param("kind"), c.Param("name")
+	chaosKind, ok := v1alpha1.AllKinds()[kind]
+	if !ok {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.New(kind + " is not supported"))
+		return
+	}
+
+	scope := utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces)
+	if !scope.Allowed(ns) {
+		// never reveal the experiments out of the scope
+		c.Status(http.StatusNotFound)
+		_ = c.Error(utils.ErrNotFound.New("the experiment is not found"))
+		return
+	}
+	if !scope.Allowed(req.Namespace) {
+		c.Status(http.StatusForbidden)
+		_ = c.Error(utils.ErrNoNamespacePrivilege.New("can't create experiments in namespace %s", req.Namespace))
+		return
+	}
+
+	chaos := chaosKind.Chaos.DeepCopyObject()
+	if err := kubeCli.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: name}, chaos); err != nil {
+		if apierrors.IsNotFound(err) {
+			c.Status(http.StatusNotFound)
+			_ = c.Error(utils.ErrNotFound.New("the experiment is not found"))
+		} else {
+			c.Status(http.StatusInternalServerError)
+			_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(err))
+		}
+		return
+	}
+
+	if req.Name == "" {
+		req.Name = name
+	}
+	clone := cloneChaos(chaos, req.Namespace, req.Name)
+
+	// the clone is created by the clients of the dashboard, so it's validated here as the webhook does
+	if defaulter, ok := clone.(webhook.Defaulter); ok {
+		defaulter.Default()
+	}
+	if validator, ok := clone.(webhook.Validator); ok {
+		if err := validator.ValidateCreate(); err != nil {
+			c.Status(http.StatusBadRequest)
+			_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+			return
+		}
+	}
+
+	if err := kubeCli.Create(context.Background(), clone); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			c.Status(http.StatusBadRequest)
+			_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		} else {
+			c.Status(http.StatusInternalServerError)
+			_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, Base{
+		Kind:      kind,
+		Namespace: req.Namespace,
+		Name:      req.Name,
+	})
+}
+
+// cloneChaos returns a chaos in the namespace with the spec of the chaos, but without its status and the meta
+// managed by the cluster. The selectors which select the namespace of the chaos select the new namespace instead.
+func cloneChaos(chaos runtime.Object, namespace string, name string) runtime.Object {
+	clone := chaos.DeepCopyObject()
+	meta := clone.(metav1.Object)
+	from := meta.GetNamespace()
+
+	value := reflect.ValueOf(clone).Elem()
+	value.FieldByName("ObjectMeta").Set(reflect.ValueOf(metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   namespace,
+		Labels:      meta.GetLabels(),
+		Annotations: meta.GetAnnotations(),
+	}))
+	status := value.FieldByName("Status")
+	status.Set(reflect.Zero(status.Type()))
+
+	if selectorSpecs, ok := clone.(interface {
+		GetSelectorSpecs() map[string]interface{}
+	}); ok {
+		for _, spec := range selectorSpecs.GetSelectorSpecs() {
+			var selector *v1alpha1.PodSelector
+			switch spec := spec.(type) {
+			case *v1alpha1.ContainerSelector:
+				selector = &spec.PodSelector
+			case *v1alpha1.PodSelector:
+				selector = spec
+			}
+			if selector != nil {
+				moveNamespace(&selector.Selector, from, namespace)
+			}
+		}
+	}
+
+	return clone
+}
+
+// moveNamespace replaces the namespace selected by the selector with another one.
+func moveNamespace(selector *v1alpha1.PodSelectorSpec, from string, to string) {
+	for i, ns := range selector.Namespaces {
+		if ns == from {
+			selector.Namespaces[i] = to
+		}
+	}
+	if pods, ok := selector.Pods[from]; ok {
+		delete(selector.Pods, from)
+		selector.Pods[to] = pods
+	}
+}
+
 func (s *Service) createPodChaos(exp *core.ExperimentInfo, kubeCli client.Client) error {
 	chaos := &v1alpha1.PodChaos{
 		ObjectMeta: metav1.ObjectMeta{
@@ -196,7 +416,8 @@ func (s *Service) createPodChaos(exp *core.ExperimentInfo, kubeCli client.Client
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -238,7 +459,8 @@ func (s *Service) createNetworkChaos(exp *core.ExperimentInfo, kubeCli client.Cl
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -274,7 +496,8 @@ func (s *Service) createIOChaos(exp *core.ExperimentInfo, kubeCli client.Client)
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -303,7 +526,8 @@ func (s *Service) createTimeChaos(exp *core.ExperimentInfo, kubeCli client.Clien
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -328,7 +552,8 @@ func (s *Service) createKernelChaos(exp *core.ExperimentInfo, kubeCli client.Cli
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -372,7 +597,8 @@ func (s *Service) createStressChaos(exp *core.ExperimentInfo, kubeCli client.Cli
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -401,7 +627,8 @@ func (s *Service) createDNSChaos(exp *core.ExperimentInfo, kubeCli client.Client
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -428,7 +655,8 @@ func (s *Service) createAWSChaos(exp *core.ExperimentInfo, kubeCli client.Client
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -455,7 +683,8 @@ func (s *Service) createGCPChaos(exp *core.ExperimentInfo, kubeCli client.Client
 	}
 
 	if exp.Scheduler.Duration != "" {
-		chaos.Spec.Duration = &exp.Scheduler.Duration
+		duration := v1alpha1.Duration(exp.Scheduler.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return kubeCli.Create(context.Background(), chaos)
@@ -885,6 +1114,12 @@ func (s *Service) listExperiments(c *gin.Context) {
 	}
 
 	exps := make([]*Experiment, 0)
+	scope := utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces)
+	if len(ns) != 0 && !scope.Allowed(ns) {
+		c.JSON(http.StatusOK, exps)
+		return
+	}
+
 	for key, list := range v1alpha1.AllKinds() {
 		if kind != "" && key != kind {
 			continue
@@ -902,6 +1137,9 @@ func (s *Service) listExperiments(c *gin.Context) {
 			if name != "" && chaos.Name != name {
 				continue
 			}
+			if !scope.Allowed(chaos.Namespace) {
+				continue
+			}
 			status := utils.GetChaosState(item)
 			exps = append(exps, &Experiment{
 				Base: Base{
@@ -956,6 +1194,13 @@ func (s *Service) getExperimentDetail(c *gin.Context) {
 		return
 	}
 
+	if !utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces).Allowed(exp.Namespace) {
+		// never reveal the experiments out of the scope
+		c.Status(http.StatusInternalServerError)
+		_ = c.Error(utils.ErrInvalidRequest.New("the experiment is not found"))
+		return
+	}
+
 	kind := exp.Kind
 	ns := exp.Namespace
 	name := exp.Name
@@ -1025,6 +1270,13 @@ func (s *Service) deleteExperiment(c *gin.Context) {
 		return
 	}
 
+	if !utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces).Allowed(exp.Namespace) {
+		// never reveal the experiments out of the scope
+		c.Status(http.StatusInternalServerError)
+		_ = c.Error(utils.ErrInvalidRequest.New("the experiment is not found"))
+		return
+	}
+
 	kind := exp.Kind
 	ns := exp.Namespace
 	name := exp.Name
@@ -1116,8 +1368,13 @@ func (s *Service) batchDeleteExperiment(c *gin.Context) {
 		return
 	}
 
+	scope := utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces)
 	for _, uid := range uidSlice {
-		if exp, err = s.archive.FindByUID(context.Background(), uid); err != nil {
+		if exp, err = s.archive.FindByUID(context.Background(), uid); err == nil && !scope.Allowed(exp.Namespace) {
+			// never reveal the experiments out of the scope
+			err = gorm.ErrRecordNotFound
+		}
+		if err != nil {
 			if gorm.IsRecordNotFoundError(err) {
 				_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(fmt.Errorf("delete experiment uid (%s) error, because the experiment is not found", uid)))
 			} else {
@@ -1208,6 +1465,13 @@ func (s *Service) pauseExperiment(c *gin.Context) {
 		return
 	}
 
+	if !utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces).Allowed(experiment.Namespace) {
+		// never reveal the experiments out of the scope
+		c.Status(http.StatusInternalServerError)
+		_ = c.Error(utils.ErrInvalidRequest.New("the experiment is not found"))
+		return
+	}
+
 	exp := &Base{
 		Kind:      experiment.Kind,
 		Name:      experiment.Name,
@@ -1262,6 +1526,13 @@ func (s *Service) startExperiment(c *gin.Context) {
 		return
 	}
 
+	if !utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces).Allowed(experiment.Namespace) {
+		// never reveal the experiments out of the scope
+		c.Status(http.StatusInternalServerError)
+		_ = c.Error(utils.ErrInvalidRequest.New("the experiment is not found"))
+		return
+	}
+
 	exp := &Base{
 		Kind:      experiment.Kind,
 		Name:      experiment.Name,
@@ -1338,6 +1609,11 @@ func (s *Service) state(c *gin.Context) {
 	}
 
 	states := new(ChaosState)
+	scope := utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces)
+	if len(namespace) != 0 && !scope.Allowed(namespace) {
+		c.JSON(http.StatusOK, states)
+		return
+	}
 
 	g, ctx := errgroup.WithContext(context.Background())
 	m := &sync.Mutex{}
@@ -1361,6 +1637,9 @@ func (s *Service) state(c *gin.Context) {
 			items := reflect.ValueOf(list.ChaosList).Elem().FieldByName("Items")
 			for i := 0; i < items.Len(); i++ {
 				item := items.Index(i).Addr().Interface().(v1alpha1.InnerObject)
+				if !scope.Allowed(item.GetChaos().Namespace) {
+					continue
+				}
 				state := utils.GetChaosState(item)
 				if err != nil {
 					c.Status(http.StatusInternalServerError)
@@ -1399,6 +1678,7 @@ func (s *Service) state(c *gin.Context) {
 // @Param request body core.KubeObjectDesc true "Request body"
 // @Success 200 {object} core.KubeObjectDesc
 // @Failure 400 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
 // @Failure 500 {object} utils.APIError
 // @Router /experiments/update [put]
 func (s *Service) updateExperiment(c *gin.Context) {
@@ -1433,6 +1713,12 @@ func (s *Service) updateExperiment(c *gin.Context) {
 		_ = c.Error(utils.ErrInvalidRequest.New(exp.Kind + " is not supported"))
 		return
 	}
+
+	if !utils.NewNamespaceScope(c, s.conf.ExperimentsNamespaces).Allowed(exp.Meta.Namespace) {
+		c.Status(http.StatusForbidden)
+		_ = c.Error(utils.ErrNoNamespacePrivilege.New("can't update experiments in namespace %s", exp.Meta.Namespace))
+		return
+	}
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		return f(exp, kubeCli)
 	})
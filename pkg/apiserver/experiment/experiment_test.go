@@ -0,0 +1,459 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package experiment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/pkg/apivalidator"
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+	clientpooltest "github.com/chaos-mesh/chaos-mesh/pkg/clientpool/test"
+	dashboardconfig "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
+	"github.com/chaos-mesh/chaos-mesh/pkg/core"
+)
+
+func TestListExperimentsWithNamespaceScope(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	var objs []runtime.Object
+	for _, ns := range []string{"team-a", "team-b", "team-c"} {
+		objs = append(objs, &v1alpha1.PodChaos{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+				Name:      "pod-kill",
+			},
+			Spec: v1alpha1.PodChaosSpec{
+				Action: v1alpha1.PodKillAction,
+			},
+		})
+	}
+	kubeCli := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+	originalClients := clientpool.K8sClients
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	s := NewService(nil, nil, &dashboardconfig.ChaosDashboardConfig{
+		ClusterScoped:         true,
+		ExperimentsNamespaces: []string{"team-a", "team-b"},
+	}, provider.NewScheme())
+	router := gin.New()
+	Register(router.Group("/api"), s)
+
+	// list the experiments as the caller who can access the given namespaces
+	list := func(query string, accessible ...string) []string {
+		clientpool.K8sClients = clientpooltest.NewFakeClients(kubeCli).
+			WithAuthClient(clientpooltest.NewFakeAuthClient(accessible...))
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/experiments"+query, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		g.Expect(rr.Code).To(Equal(http.StatusOK))
+
+		var exps []Experiment
+		g.Expect(json.Unmarshal(rr.Body.Bytes(), &exps)).To(Succeed())
+		var namespaces []string
+		for _, exp := range exps {
+			namespaces = append(namespaces, exp.Namespace)
+		}
+		return namespaces
+	}
+
+	// the configured allowlist
+	g.Expect(list("", "team-a", "team-b", "team-c")).To(ConsistOf("team-a", "team-b"))
+	// the namespaces the caller can access narrow the allowlist, but never extend it
+	g.Expect(list("", "team-b", "team-c")).To(ConsistOf("team-b"))
+	// the namespace out of the scope is never revealed
+	g.Expect(list("?namespace=team-c", "team-a", "team-b", "team-c")).To(BeEmpty())
+}
+
+// fakeExperimentStore finds the experiments archived in memory
+type fakeExperimentStore struct {
+	core.ExperimentStore
+
+	experiments map[string]*core.Experiment
+}
+
+func (f *fakeExperimentStore) FindByUID(_ context.Context, uid string) (*core.Experiment, error) {
+	exp, ok := f.experiments[uid]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return exp, nil
+}
+
+// registerValidators registers the validators used by the bindings of the requests, see pkg/apiserver/server.go.
+func registerValidators(g *GomegaWithT) {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		g.Expect(v.RegisterValidation("NameValid", apivalidator.NameValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("NamespaceSelectorsValid", apivalidator.NamespaceSelectorsValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("MapSelectorsValid", apivalidator.MapSelectorsValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("RequirementSelectorsValid", apivalidator.RequirementSelectorsValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("PhaseSelectorsValid", apivalidator.PhaseSelectorsValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("CronValid", apivalidator.CronValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("DurationValid", apivalidator.DurationValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("ValueValid", apivalidator.ValueValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("PodsValid", apivalidator.PodsValid)).To(Succeed())
+		g.Expect(v.RegisterValidation("RequiredFieldEqual", apivalidator.RequiredFieldEqualValid, true)).To(Succeed())
+	}
+}
+
+func TestOperateExperimentsWithNamespaceScope(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	registerValidators(g)
+
+	var objs []runtime.Object
+	archive := &fakeExperimentStore{experiments: make(map[string]*core.Experiment)}
+	for _, ns := range []string{"team-a", "team-c"} {
+		objs = append(objs, &v1alpha1.PodChaos{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+				Name:      "pod-kill",
+			},
+			Spec: v1alpha1.PodChaosSpec{
+				Action: v1alpha1.PodKillAction,
+			},
+		})
+		archive.experiments[ns] = &core.Experiment{
+			ExperimentMeta: core.ExperimentMeta{
+				UID:       ns,
+				Kind:      v1alpha1.KindPodChaos,
+				Namespace: ns,
+				Name:      "pod-kill",
+			},
+		}
+	}
+	kubeCli := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+	originalClients := clientpool.K8sClients
+	// the caller could access all the namespaces, but the experiments out of the allowlist are still hidden
+	clientpool.K8sClients = clientpooltest.NewFakeClients(kubeCli).
+		WithAuthClient(clientpooltest.NewFakeAuthClient("team-a", "team-b", "team-c"))
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	s := NewService(archive, nil, &dashboardconfig.ChaosDashboardConfig{
+		ClusterScoped:         true,
+		ExperimentsNamespaces: []string{"team-a", "team-b"},
+	}, provider.NewScheme())
+	router := gin.New()
+	Register(router.Group("/api"), s)
+
+	serve := func(method string, path string, body interface{}) *httptest.ResponseRecorder {
+		var reqBody bytes.Buffer
+		if body != nil {
+			g.Expect(json.NewEncoder(&reqBody).Encode(body)).To(Succeed())
+		}
+		req, _ := http.NewRequest(method, "/api/experiments"+path, &reqBody)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+	exists := func(ns string) bool {
+		err := kubeCli.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "pod-kill"}, &v1alpha1.PodChaos{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		return true
+	}
+	paused := func(ns string) bool {
+		chaos := &v1alpha1.PodChaos{}
+		g.Expect(kubeCli.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "pod-kill"}, chaos)).To(Succeed())
+		return chaos.Annotations[v1alpha1.PauseAnnotationKey] == "true"
+	}
+
+	// only the experiments in the scope are counted
+	rr := serve(http.MethodGet, "/state", nil)
+	g.Expect(rr.Code).To(Equal(http.StatusOK))
+	var states ChaosState
+	g.Expect(json.Unmarshal(rr.Body.Bytes(), &states)).To(Succeed())
+	g.Expect(states.Injecting + states.Running + states.Finished + states.Paused).To(Equal(1))
+	rr = serve(http.MethodGet, "/state?namespace=team-c", nil)
+	g.Expect(rr.Code).To(Equal(http.StatusOK))
+	g.Expect(json.Unmarshal(rr.Body.Bytes(), &states)).To(Succeed())
+	g.Expect(states).To(Equal(ChaosState{}))
+
+	// the experiments out of the scope cannot be paused, started or deleted
+	g.Expect(serve(http.MethodPut, "/pause/team-c", nil).Code).To(Equal(http.StatusInternalServerError))
+	g.Expect(paused("team-c")).To(BeFalse())
+	g.Expect(serve(http.MethodPut, "/pause/team-a", nil).Code).To(Equal(http.StatusOK))
+	g.Expect(paused("team-a")).To(BeTrue())
+	g.Expect(serve(http.MethodPut, "/start/team-c", nil).Code).To(Equal(http.StatusInternalServerError))
+	g.Expect(serve(http.MethodPut, "/start/team-a", nil).Code).To(Equal(http.StatusOK))
+	g.Expect(paused("team-a")).To(BeFalse())
+
+	g.Expect(serve(http.MethodDelete, "/team-c", nil).Code).To(Equal(http.StatusInternalServerError))
+	g.Expect(exists("team-c")).To(BeTrue())
+	g.Expect(serve(http.MethodDelete, "/?uids=team-a,team-c", nil).Code).To(Equal(http.StatusInternalServerError))
+	g.Expect(exists("team-a")).To(BeFalse())
+	g.Expect(exists("team-c")).To(BeTrue())
+
+	// and no experiment could be created or updated out of the scope
+	rr = serve(http.MethodPost, "/new", core.ExperimentInfo{
+		Name:      "pod-failure",
+		Namespace: "team-c",
+		Target: core.TargetInfo{
+			Kind: v1alpha1.KindPodChaos,
+			PodChaos: &core.PodChaosInfo{
+				Action: string(v1alpha1.PodFailureAction),
+			},
+		},
+	})
+	g.Expect(rr.Code).To(Equal(http.StatusForbidden))
+	rr = serve(http.MethodPut, "/update", core.KubeObjectDesc{
+		TypeMeta: metav1.TypeMeta{Kind: v1alpha1.KindPodChaos},
+		Meta: core.KubeObjectMeta{
+			Namespace: "team-c",
+			Name:      "pod-kill",
+		},
+		Spec: v1alpha1.PodChaosSpec{
+			Action: v1alpha1.PodFailureAction,
+		},
+	})
+	g.Expect(rr.Code).To(Equal(http.StatusForbidden))
+	chaos := &v1alpha1.PodChaos{}
+	g.Expect(kubeCli.Get(context.TODO(), types.NamespacedName{Namespace: "team-c", Name: "pod-kill"}, chaos)).To(Succeed())
+	g.Expect(chaos.Spec.Action).To(Equal(v1alpha1.PodKillAction))
+}
+
+func TestCreateExperimentWithIdempotencyKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+	registerValidators(g)
+
+	kubeCli := fake.NewFakeClientWithScheme(provider.NewScheme())
+	originalClients := clientpool.K8sClients
+	clientpool.K8sClients = clientpooltest.NewFakeClients(kubeCli).WithAuthClient(clientpooltest.NewFakeAuthClient(""))
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	s := NewService(nil, nil, &dashboardconfig.ChaosDashboardConfig{}, provider.NewScheme())
+	router := gin.New()
+	Register(router.Group("/api"), s)
+
+	create := func(name string, key string) *httptest.ResponseRecorder {
+		var reqBody bytes.Buffer
+		info := core.ExperimentInfo{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			Target: core.TargetInfo{
+				Kind: v1alpha1.KindPodChaos,
+				PodChaos: &core.PodChaosInfo{
+					Action: string(v1alpha1.PodKillAction),
+				},
+			},
+		}
+		if key != "" {
+			info.Annotations = map[string]string{v1alpha1.IdempotencyKeyAnnotationKey: key}
+		}
+		g.Expect(json.NewEncoder(&reqBody).Encode(info)).To(Succeed())
+		req, _ := http.NewRequest(http.MethodPost, "/api/experiments/new", &reqBody)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := create("pod-kill", "retried-creation")
+	g.Expect(rr.Code).To(Equal(http.StatusOK))
+	var exp core.ExperimentInfo
+	g.Expect(json.Unmarshal(rr.Body.Bytes(), &exp)).To(Succeed())
+	g.Expect(exp.Name).To(Equal("pod-kill"))
+
+	// the retried creation returns the existing experiment
+	rr = create("pod-kill", "retried-creation")
+	g.Expect(rr.Code).To(Equal(http.StatusOK))
+	var existing struct {
+		Kind string `json:"kind"`
+		Meta struct {
+			Name        string            `json:"name"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Spec v1alpha1.PodChaosSpec `json:"spec"`
+	}
+	g.Expect(json.Unmarshal(rr.Body.Bytes(), &existing)).To(Succeed())
+	g.Expect(existing.Kind).To(Equal(v1alpha1.KindPodChaos))
+	g.Expect(existing.Meta.Name).To(Equal("pod-kill"))
+	g.Expect(existing.Meta.Annotations).To(HaveKeyWithValue(v1alpha1.IdempotencyKeyAnnotationKey, "retried-creation"))
+	g.Expect(existing.Spec.Action).To(Equal(v1alpha1.PodKillAction))
+
+	// the experiment which exists without the key isn't taken as created by the request
+	g.Expect(create("pod-failure", "").Code).To(Equal(http.StatusOK))
+	g.Expect(create("pod-failure", "another-creation").Code).To(Equal(http.StatusConflict))
+
+	// the concurrent attempts of the same request create a single experiment
+	var wg sync.WaitGroup
+	codes := make([]int, 8)
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes[i] = create("pod-kill-concurrent", "concurrent-creation").Code
+		}(i)
+	}
+	wg.Wait()
+	for _, code := range codes {
+		g.Expect(code).To(Equal(http.StatusOK))
+	}
+
+	var chaosList v1alpha1.PodChaosList
+	g.Expect(kubeCli.List(context.TODO(), &chaosList)).To(Succeed())
+	var names []string
+	for _, chaos := range chaosList.Items {
+		names = append(names, chaos.Name)
+	}
+	g.Expect(names).To(ConsistOf("pod-kill", "pod-failure", "pod-kill-concurrent"))
+}
+
+func TestCloneExperiment(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+	registerValidators(g)
+
+	chaos := &v1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "staging",
+			Name:       "partition",
+			Labels:     map[string]string{"app": "web"},
+			Finalizers: []string{"chaos-mesh/records"},
+		},
+		Spec: v1alpha1.NetworkChaosSpec{
+			PodSelector: v1alpha1.PodSelector{
+				Selector: v1alpha1.PodSelectorSpec{
+					Namespaces:     []string{"staging"},
+					LabelSelectors: map[string]string{"app": "web"},
+				},
+				Mode: v1alpha1.AllPodMode,
+			},
+			Action:    v1alpha1.PartitionAction,
+			Direction: v1alpha1.To,
+			Target: &v1alpha1.PodSelector{
+				Selector: v1alpha1.PodSelectorSpec{
+					Namespaces: []string{"staging", "shared"},
+					Pods:       map[string][]string{"staging": {"db-0"}},
+				},
+				Mode: v1alpha1.AllPodMode,
+			},
+		},
+		Status: v1alpha1.NetworkChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "staging/web-0", Phase: v1alpha1.Injected},
+					},
+				},
+			},
+		},
+	}
+	invalid := &v1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "staging",
+			Name:      "invalid",
+		},
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.DelayAction,
+			TcParameter: v1alpha1.TcParameter{
+				Delay: &v1alpha1.DelaySpec{
+					Latency: "a while",
+				},
+			},
+		},
+	}
+	kubeCli := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos, invalid)
+	originalClients := clientpool.K8sClients
+	clientpool.K8sClients = clientpooltest.NewFakeClients(kubeCli).WithAuthClient(clientpooltest.NewFakeAuthClient(""))
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	s := NewService(nil, nil, &dashboardconfig.ChaosDashboardConfig{
+		ClusterScoped:         true,
+		ExperimentsNamespaces: []string{"staging", "prod"},
+	}, provider.NewScheme())
+	router := gin.New()
+	Register(router.Group("/api"), s)
+
+	clone := func(path string, body CloneRequest) *httptest.ResponseRecorder {
+		var reqBody bytes.Buffer
+		g.Expect(json.NewEncoder(&reqBody).Encode(body)).To(Succeed())
+		req, _ := http.NewRequest(http.MethodPost, "/api/experiments/"+path+"/clone", &reqBody)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := clone("staging/NetworkChaos/partition", CloneRequest{Namespace: "prod"})
+	g.Expect(rr.Code).To(Equal(http.StatusOK))
+	var base Base
+	g.Expect(json.Unmarshal(rr.Body.Bytes(), &base)).To(Succeed())
+	g.Expect(base).To(Equal(Base{Kind: v1alpha1.KindNetworkChaos, Namespace: "prod", Name: "partition"}))
+
+	cloned := &v1alpha1.NetworkChaos{}
+	g.Expect(kubeCli.Get(context.TODO(), types.NamespacedName{Namespace: "prod", Name: "partition"}, cloned)).To(Succeed())
+	g.Expect(cloned.Labels).To(Equal(chaos.Labels))
+	g.Expect(cloned.Finalizers).To(BeEmpty())
+	g.Expect(cloned.Status.Experiment.Records).To(BeEmpty())
+	g.Expect(cloned.Spec.Action).To(Equal(v1alpha1.PartitionAction))
+	// the clone targets the new namespace
+	g.Expect(cloned.Spec.Selector.Namespaces).To(Equal([]string{"prod"}))
+	g.Expect(cloned.Spec.Target.Selector.Namespaces).To(Equal([]string{"prod", "shared"}))
+	g.Expect(cloned.Spec.Target.Selector.Pods).To(Equal(map[string][]string{"prod": {"db-0"}}))
+
+	// the original experiment is untouched
+	original := &v1alpha1.NetworkChaos{}
+	g.Expect(kubeCli.Get(context.TODO(), types.NamespacedName{Namespace: "staging", Name: "partition"}, original)).To(Succeed())
+	g.Expect(original.Spec.Selector.Namespaces).To(Equal([]string{"staging"}))
+	g.Expect(original.Status.Experiment.Records).To(HaveLen(1))
+
+	// the clone is renamed
+	g.Expect(clone("staging/NetworkChaos/partition", CloneRequest{Namespace: "prod", Name: "partition-2"}).Code).To(Equal(http.StatusOK))
+	g.Expect(kubeCli.Get(context.TODO(), types.NamespacedName{Namespace: "prod", Name: "partition-2"}, &v1alpha1.NetworkChaos{})).To(Succeed())
+	// but never overwrites the existing one
+	g.Expect(clone("staging/NetworkChaos/partition", CloneRequest{Namespace: "prod"}).Code).To(Equal(http.StatusBadRequest))
+
+	// the clone is validated
+	g.Expect(clone("staging/NetworkChaos/invalid", CloneRequest{Namespace: "prod"}).Code).To(Equal(http.StatusBadRequest))
+	err := kubeCli.Get(context.TODO(), types.NamespacedName{Namespace: "prod", Name: "invalid"}, &v1alpha1.NetworkChaos{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// and the experiments out of the scope are neither cloned nor cloned into
+	g.Expect(clone("staging/NetworkChaos/partition", CloneRequest{Namespace: "team-c"}).Code).To(Equal(http.StatusForbidden))
+	g.Expect(clone("team-c/NetworkChaos/partition", CloneRequest{Namespace: "prod"}).Code).To(Equal(http.StatusNotFound))
+	g.Expect(clone("staging/NetworkChaos/missing", CloneRequest{Namespace: "prod"}).Code).To(Equal(http.StatusNotFound))
+	g.Expect(clone("staging/UnknownChaos/partition", CloneRequest{Namespace: "prod"}).Code).To(Equal(http.StatusBadRequest))
+}
@@ -16,10 +16,12 @@ package apiserver
 import (
 	"go.uber.org/fx"
 
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/alert"
 	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/archive"
 	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/common"
 	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/event"
 	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/experiment"
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/inject"
 	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/schedule"
 	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/workflow"
 )
@@ -32,6 +34,8 @@ var handlerModule = fx.Options(
 		archive.NewService,
 		workflow.NewService,
 		schedule.NewService,
+		inject.NewService,
+		alert.NewService,
 	),
 	fx.Invoke(
 		common.Register,
@@ -40,5 +44,7 @@ var handlerModule = fx.Options(
 		archive.Register,
 		workflow.Register,
 		schedule.Register,
+		inject.Register,
+		alert.Register,
 	),
 )
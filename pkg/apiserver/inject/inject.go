@@ -0,0 +1,211 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/utils"
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+	dashboardconfig "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config/watcher"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/inject"
+)
+
+// Service defines a handler service for the injection of sidecars.
+type Service struct {
+	conf *dashboardconfig.ChaosDashboardConfig
+	// injectConfig is the config of the inject webhook, the preview creates the patch with it as the webhook does
+	injectConfig *config.Config
+}
+
+// NewService returns an inject service instance.
+func NewService(conf *dashboardconfig.ChaosDashboardConfig, injectConfig *config.Config) *Service {
+	return &Service{
+		conf:         conf,
+		injectConfig: injectConfig,
+	}
+}
+
+// Register mounts our HTTP handler on the mux.
+func Register(r *gin.RouterGroup, s *Service) {
+	endpoint := r.Group("/inject")
+
+	endpoint.POST("/preview", s.preview)
+	endpoint.GET("/pods", s.listInjectedPods)
+}
+
+// InjectedPod defines a pod and the name of the injection config it received.
+type InjectedPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Config    string `json:"config"`
+}
+
+// PreviewRequest defines the pod and the name of the injection config to preview.
+type PreviewRequest struct {
+	Pod    corev1.Pod `json:"pod"`
+	Config string     `json:"config" binding:"required"`
+}
+
+// @Summary Preview the JSON patch of injecting the config into the pod.
+// @Description Preview the JSON patch of injecting the config into the pod, the patch is not applied.
+// @Tags inject
+// @Produce json
+// @Param request body PreviewRequest true "Request body"
+// @Success 200 {array} object
+// @Router /inject/preview [post]
+// @Failure 400 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+func (s *Service) preview(c *gin.Context) {
+	kubeCli, err := clientpool.ExtractTokenAndGetClient(c.Request.Header)
+	if err != nil {
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	req := &PreviewRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+	if req.Pod.Namespace == "" {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.New("the namespace of the pod is required"))
+		return
+	}
+
+	inj, err := s.getInjectionConfig(kubeCli, req.Pod.Namespace, strings.ToLower(req.Config))
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		utils.SetErrorForGinCtx(c, err)
+		return
+	}
+	if inj == nil {
+		c.Status(http.StatusNotFound)
+		_ = c.Error(utils.ErrNotFound.New("the injection config %s is not found in namespace %s", req.Config, req.Pod.Namespace))
+		return
+	}
+
+	patch, err := inject.CreatePatch(&req.Pod, inj, kubeCli, s.injectConfig)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		_ = c.Error(utils.ErrInternalServer.WrapWithNoMessage(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, json.RawMessage(patch))
+}
+
+// @Summary List the pods and the names of the injection configs they received.
+// @Description List the pods and the names of the injection configs they received, for auditing the rollout of sidecars.
+// @Tags inject
+// @Produce json
+// @Param namespace query string true "The namespace of the pods"
+// @Success 200 {array} InjectedPod
+// @Router /inject/pods [get]
+// @Failure 400 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+func (s *Service) listInjectedPods(c *gin.Context) {
+	kubeCli, err := clientpool.ExtractTokenAndGetClient(c.Request.Header)
+	if err != nil {
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.New("the namespace is required"))
+		return
+	}
+
+	var podList corev1.PodList
+	if err := kubeCli.List(context.TODO(), &podList, client.InNamespace(namespace)); err != nil {
+		c.Status(http.StatusInternalServerError)
+		utils.SetErrorForGinCtx(c, err)
+		return
+	}
+
+	configKey := s.injectConfig.InjectedConfigAnnotationKey()
+	pods := make([]InjectedPod, 0)
+	for _, pod := range podList.Items {
+		name, ok := pod.Annotations[configKey]
+		if !ok {
+			continue
+		}
+		pods = append(pods, InjectedPod{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Config:    name,
+		})
+	}
+
+	c.JSON(http.StatusOK, pods)
+}
+
+// getInjectionConfig renders the injection config in the namespace from the configmaps watched by the webhook,
+// it returns nil if the config or its template is not found.
+func (s *Service) getInjectionConfig(kubeCli client.Client, namespace string, name string) (*config.InjectionConfig, error) {
+	watcherConfig := s.conf.WatcherConfig
+	if watcherConfig == nil {
+		watcherConfig = watcher.NewConfig()
+	}
+
+	var configList corev1.ConfigMapList
+	if err := kubeCli.List(context.TODO(), &configList,
+		client.InNamespace(namespace), client.MatchingLabels(watcherConfig.ConfigLabels)); err != nil {
+		return nil, err
+	}
+
+	var args *config.TemplateArgs
+	for _, item := range configList.Items {
+		for _, payload := range item.Data {
+			conf, err := config.LoadTemplateArgs(strings.NewReader(payload))
+			if err != nil || conf.Name != name {
+				continue
+			}
+			conf.Namespace = item.Namespace
+			args = conf
+		}
+	}
+	if args == nil {
+		return nil, nil
+	}
+
+	var templateList corev1.ConfigMapList
+	if err := kubeCli.List(context.TODO(), &templateList,
+		client.InNamespace(watcherConfig.TemplateNamespace), client.MatchingLabels(watcherConfig.TemplateLabels)); err != nil {
+		return nil, err
+	}
+
+	for _, temp := range templateList.Items {
+		if temp.Name == args.Template {
+			return watcher.RenderInjectionConfig(temp.Data[watcher.TemplateItemKey], args)
+		}
+	}
+
+	// the webhook skips the config without template, too
+	return nil, nil
+}
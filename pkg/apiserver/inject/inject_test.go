@@ -0,0 +1,203 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/utils"
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+	clientpooltest "github.com/chaos-mesh/chaos-mesh/pkg/clientpool/test"
+	controllerconfig "github.com/chaos-mesh/chaos-mesh/pkg/config"
+	dashboardconfig "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config/watcher"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/inject"
+)
+
+const (
+	testTemplate = `initContainers:
+  - name: {{.InitName}}
+    image: busybox
+env:
+  - name: INJECTED
+    value: "true"
+volumeMounts:
+  - name: injected-vol
+    mountPath: /opt/injected
+volumes:
+  - name: injected-vol
+    emptyDir: {}
+`
+	testTemplateArgs = `name: test-sidecar
+template: test-sidecar-template
+arguments:
+  InitName: test-init
+`
+)
+
+func TestPreviewMatchesWebhook(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	watcherConfig := &watcher.Config{
+		TemplateNamespace: "chaos-testing",
+		TemplateLabels:    map[string]string{"app.kubernetes.io/component": "template"},
+		ConfigLabels:      map[string]string{"app.kubernetes.io/component": "webhook"},
+	}
+	objs := []runtime.Object{
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "chaos-testing",
+				Name:      "test-sidecar-template",
+				Labels:    watcherConfig.TemplateLabels,
+			},
+			Data: map[string]string{watcher.TemplateItemKey: testTemplate},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "app",
+				Name:      "test-sidecar",
+				Labels:    watcherConfig.ConfigLabels,
+			},
+			Data: map[string]string{"test-sidecar": testTemplateArgs},
+		},
+	}
+	kubeCli := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+	originalClients := clientpool.K8sClients
+	clientpool.K8sClients = clientpooltest.NewFakeClients(kubeCli)
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	// the preview must follow the config of the webhook rather than the defaults
+	cfg := config.NewConfigWatcherConf()
+	cfg.AnnotationNamespace = "test.chaos-mesh.org"
+	newPod := func() corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "app",
+				Name:        "p0",
+				Annotations: map[string]string{cfg.RequestAnnotationKey(): "test-sidecar"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "c0", Image: "app"}},
+			},
+		}
+	}
+
+	// the patch produced by the webhook
+	args, err := config.LoadTemplateArgs(bytes.NewBufferString(testTemplateArgs))
+	g.Expect(err).ToNot(HaveOccurred())
+	inj, err := watcher.RenderInjectionConfig(testTemplate, args)
+	g.Expect(err).ToNot(HaveOccurred())
+	cfg.ReplaceInjectionConfigs(map[string][]*config.InjectionConfig{"app": {inj}})
+
+	raw, err := json.Marshal(newPod())
+	g.Expect(err).ToNot(HaveOccurred())
+	res := inject.Inject(&admissionv1beta1.AdmissionRequest{
+		Namespace: "app",
+		Object:    runtime.RawExtension{Raw: raw},
+	}, kubeCli, cfg, &controllerconfig.ChaosControllerConfig{}, nil)
+	g.Expect(res.Allowed).To(BeTrue())
+	g.Expect(res.Patch).ToNot(BeEmpty())
+
+	// the patch previewed by the apiserver
+	router := gin.New()
+	router.Use(utils.MWHandleErrors())
+	Register(router.Group("/api"), NewService(&dashboardconfig.ChaosDashboardConfig{
+		WatcherConfig: watcherConfig,
+	}, cfg))
+	preview := func(name string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(PreviewRequest{Pod: newPod(), Config: name})
+		g.Expect(err).ToNot(HaveOccurred())
+		req, _ := http.NewRequest(http.MethodPost, "/api/inject/preview", bytes.NewBuffer(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := preview("test-sidecar")
+	g.Expect(rr.Code).To(Equal(http.StatusOK))
+	g.Expect(rr.Body.String()).To(MatchJSON(res.Patch))
+	g.Expect(rr.Body.String()).To(ContainSubstring("test.chaos-mesh.org~1injected-config"))
+
+	rr = preview("not-exist")
+	g.Expect(rr.Code).To(Equal(http.StatusNotFound))
+}
+
+func TestListInjectedPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.NewConfigWatcherConf()
+	cfg.AnnotationNamespace = "test.chaos-mesh.org"
+	newPod := func(namespace, name string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+	kubeCli := fake.NewFakeClientWithScheme(provider.NewScheme(),
+		newPod("app", "p0", map[string]string{
+			cfg.StatusAnnotationKey():         inject.StatusInjected,
+			cfg.InjectedConfigAnnotationKey(): "test-sidecar",
+		}),
+		newPod("app", "p1", nil),
+		newPod("other", "p2", map[string]string{cfg.InjectedConfigAnnotationKey(): "test-sidecar"}),
+	)
+	originalClients := clientpool.K8sClients
+	clientpool.K8sClients = clientpooltest.NewFakeClients(kubeCli)
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	router := gin.New()
+	router.Use(utils.MWHandleErrors())
+	Register(router.Group("/api"), NewService(&dashboardconfig.ChaosDashboardConfig{}, cfg))
+	list := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/api/inject/pods"+query, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := list("?namespace=app")
+	g.Expect(rr.Code).To(Equal(http.StatusOK))
+	var pods []InjectedPod
+	g.Expect(json.Unmarshal(rr.Body.Bytes(), &pods)).To(Succeed())
+	g.Expect(pods).To(Equal([]InjectedPod{{Namespace: "app", Name: "p0", Config: "test-sidecar"}}))
+
+	rr = list("")
+	g.Expect(rr.Code).To(Equal(http.StatusBadRequest))
+}
@@ -210,7 +210,8 @@ func parsePodChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -254,7 +255,8 @@ func parseNetworkChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -292,7 +294,8 @@ func parseIOChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -323,7 +326,8 @@ func parseTimeChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -350,7 +354,8 @@ func parseKernelChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -399,7 +404,8 @@ func parseStressChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -430,7 +436,8 @@ func parseDNSChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -459,7 +466,8 @@ func parseAWSChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -488,7 +496,8 @@ func parseGCPChaos(exp *core.ScheduleInfo) v1alpha1.ScheduleItem {
 	}
 
 	if exp.Duration != "" {
-		chaos.Spec.Duration = &exp.Duration
+		duration := v1alpha1.Duration(exp.Duration)
+		chaos.Spec.Duration = &duration
 	}
 
 	return v1alpha1.ScheduleItem{
@@ -0,0 +1,94 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"github.com/gin-gonic/gin"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+)
+
+var log = ctrl.Log.WithName("namespace scope")
+
+// NamespaceScope is the set of namespaces whose experiments are exposed to a request. A namespace is in the
+// scope if it's in the configured allowlist, and the caller identified by the token of the request can list
+// the chaos in it.
+type NamespaceScope struct {
+	// allowlist is nil if all namespaces are allowed
+	allowlist map[string]struct{}
+	// authCli is nil if the caller cannot be identified, then no namespace is in the scope
+	authCli authorizationv1client.AuthorizationV1Interface
+	// reviewed caches whether the caller can access the namespace
+	reviewed map[string]bool
+}
+
+// NewNamespaceScope returns the scope of the request, which is the allowlist narrowed by the namespaces the caller
+// can access. An empty allowlist means all namespaces are allowed, and the scope is empty if the request carries
+// no token.
+func NewNamespaceScope(c *gin.Context, allowlist []string) *NamespaceScope {
+	scope := &NamespaceScope{reviewed: make(map[string]bool)}
+	if len(allowlist) > 0 {
+		scope.allowlist = make(map[string]struct{})
+		for _, ns := range allowlist {
+			scope.allowlist[ns] = struct{}{}
+		}
+	}
+
+	authCli, err := clientpool.ExtractTokenAndGetAuthClient(c.Request.Header)
+	if err != nil {
+		log.Info("fail to identify the caller, no experiment is exposed", "error", err)
+		return scope
+	}
+	scope.authCli = authCli
+
+	return scope
+}
+
+// Allowed returns whether the experiments in the namespace could be exposed
+func (s *NamespaceScope) Allowed(namespace string) bool {
+	if s.allowlist != nil {
+		if _, ok := s.allowlist[namespace]; !ok {
+			return false
+		}
+	}
+	if s.authCli == nil {
+		return false
+	}
+
+	if allowed, ok := s.reviewed[namespace]; ok {
+		return allowed
+	}
+
+	sar := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     "chaos-mesh.org",
+				Resource:  "*",
+			},
+		},
+	}
+	response, err := s.authCli.SelfSubjectAccessReviews().Create(sar)
+	if err != nil {
+		log.Error(err, "fail to review the access of the caller", "namespace", namespace)
+		return false
+	}
+
+	s.reviewed[namespace] = response.Status.Allowed
+	return response.Status.Allowed
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+	clientpooltest "github.com/chaos-mesh/chaos-mesh/pkg/clientpool/test"
+)
+
+func newContext(token string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		c.Request.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c
+}
+
+func TestNamespaceScope(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	originalClients := clientpool.K8sClients
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	// the scope is the allowlist narrowed by the namespaces the caller can access
+	clientpool.K8sClients = clientpooltest.NewFakeClients(nil).
+		WithAuthClient(clientpooltest.NewFakeAuthClient("team-b", "team-c"))
+	scope := NewNamespaceScope(newContext("token"), []string{"team-a", "team-b"})
+	g.Expect(scope.Allowed("team-a")).To(BeFalse())
+	g.Expect(scope.Allowed("team-b")).To(BeTrue())
+	g.Expect(scope.Allowed("team-c")).To(BeFalse())
+
+	scope = NewNamespaceScope(newContext("token"), nil)
+	g.Expect(scope.Allowed("team-a")).To(BeFalse())
+	g.Expect(scope.Allowed("team-c")).To(BeTrue())
+
+	// the caller without a token cannot be identified, so nothing is in the scope
+	pool, err := clientpool.NewClientPool(&rest.Config{Host: "localhost"}, runtime.NewScheme(), 1)
+	g.Expect(err).ToNot(HaveOccurred())
+	clientpool.K8sClients = pool
+	scope = NewNamespaceScope(newContext(""), []string{"team-a", "team-b"})
+	g.Expect(scope.Allowed("team-a")).To(BeFalse())
+	g.Expect(scope.Allowed("team-b")).To(BeFalse())
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/core"
+)
+
+// GraphFormatDOT is the Graphviz DOT language
+const GraphFormatDOT = "dot"
+
+// renderDOT renders the topology of a workflow as a Graphviz directed graph.
+//
+// Children of a parallel node are all linked from the parallel node. The first child of a
+// serial node is linked from the serial node and the following children are chained in their
+// order with dashed edges. Children which have not been created yet are drawn as dashed nodes,
+// they are identified with their parent and their index as the template could be used repeatedly.
+func renderDOT(name string, topology core.Topology) string {
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "digraph %q {\n", name)
+
+	for _, node := range topology.Nodes {
+		fmt.Fprintf(builder, "  %q [label=%q];\n", node.Name, fmt.Sprintf("%s\n%s", node.Template, node.Type))
+	}
+
+	var edges []string
+	declareChild := func(id string, child core.NodeNameWithTemplate) {
+		if len(child.Name) == 0 {
+			fmt.Fprintf(builder, "  %q [label=%q, style=dashed];\n", id, child.Template)
+		}
+	}
+	for _, node := range topology.Nodes {
+		if node.Serial != nil {
+			previous := ""
+			for i, child := range node.Serial.Children {
+				id := childID(node.Name, "children", i, child)
+				declareChild(id, child)
+				if len(previous) == 0 {
+					edges = append(edges, fmt.Sprintf("  %q -> %q;\n", node.Name, id))
+				} else {
+					edges = append(edges, fmt.Sprintf("  %q -> %q [style=dashed];\n", previous, id))
+				}
+				previous = id
+			}
+		}
+		if node.Parallel != nil {
+			for i, child := range node.Parallel.Children {
+				id := childID(node.Name, "children", i, child)
+				declareChild(id, child)
+				edges = append(edges, fmt.Sprintf("  %q -> %q;\n", node.Name, id))
+			}
+		}
+		for i, branch := range node.ConditionalBranches {
+			id := childID(node.Name, "branches", i, branch.NodeNameWithTemplate)
+			declareChild(id, branch.NodeNameWithTemplate)
+			edges = append(edges, fmt.Sprintf("  %q -> %q [label=%q];\n", node.Name, id, branch.Expression))
+		}
+	}
+
+	for _, edge := range edges {
+		builder.WriteString(edge)
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// childID returns the name of the child node, or a synthetic id made of the parent and the index if it
+// has not been created yet.
+func childID(parent string, kind string, index int, child core.NodeNameWithTemplate) string {
+	if len(child.Name) == 0 {
+		return fmt.Sprintf("%s/%s/%d", parent, kind, index)
+	}
+	return child.Name
+}
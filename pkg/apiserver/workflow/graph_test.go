@@ -0,0 +1,166 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/utils"
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+	clientpooltest "github.com/chaos-mesh/chaos-mesh/pkg/clientpool/test"
+	dashboardconfig "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
+	"github.com/chaos-mesh/chaos-mesh/pkg/core"
+)
+
+func TestRenderDOT(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	topology := core.Topology{
+		Nodes: []core.Node{
+			{
+				Name:     "entry-abcde",
+				Type:     core.SerialNode,
+				Template: "entry",
+				Serial: &core.NodeSerial{
+					Children: []core.NodeNameWithTemplate{
+						{Name: "network-delay-fghij", Template: "network-delay"},
+						{Name: "parallel-klmno", Template: "parallel"},
+						{Template: "cleanup"},
+					},
+				},
+			},
+			{
+				Name:     "network-delay-fghij",
+				Type:     core.ChaosNode,
+				Template: "network-delay",
+			},
+			{
+				Name:     "parallel-klmno",
+				Type:     core.ParallelNode,
+				Template: "parallel",
+				Parallel: &core.NodeParallel{
+					Children: []core.NodeNameWithTemplate{
+						{Name: "pod-kill-pqrst", Template: "pod-kill"},
+						{Template: "io-delay"},
+					},
+				},
+			},
+		},
+	}
+
+	dot := renderDOT("workflow", topology)
+
+	g.Expect(dot).To(HavePrefix("digraph \"workflow\" {\n"))
+	g.Expect(dot).To(HaveSuffix("}\n"))
+	g.Expect(dot).To(ContainSubstring(`"entry-abcde" [label="entry\nSerialNode"];`))
+	g.Expect(dot).To(ContainSubstring(`"entry-abcde/children/2" [label="cleanup", style=dashed];`))
+	g.Expect(dot).To(ContainSubstring(`"parallel-klmno/children/1" [label="io-delay", style=dashed];`))
+	for _, edge := range []string{
+		`"entry-abcde" -> "network-delay-fghij";`,
+		`"network-delay-fghij" -> "parallel-klmno" [style=dashed];`,
+		`"parallel-klmno" -> "entry-abcde/children/2" [style=dashed];`,
+		`"parallel-klmno" -> "pod-kill-pqrst";`,
+		`"parallel-klmno" -> "parallel-klmno/children/1";`,
+	} {
+		g.Expect(dot).To(ContainSubstring(edge))
+	}
+	g.Expect(dot).ToNot(ContainSubstring(`"entry-abcde" -> "parallel-klmno";`))
+}
+
+func TestRenderDOTWithRepeatedTemplates(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	topology := core.Topology{
+		Nodes: []core.Node{
+			{
+				Name:     "entry-abcde",
+				Type:     core.SerialNode,
+				Template: "entry",
+				Serial: &core.NodeSerial{
+					Children: []core.NodeNameWithTemplate{
+						{Name: "pod-kill-fghij", Template: "pod-kill"},
+						{Template: "pod-kill"},
+						{Template: "pod-kill"},
+					},
+				},
+			},
+			{
+				Name:     "pod-kill-fghij",
+				Type:     core.ChaosNode,
+				Template: "pod-kill",
+			},
+		},
+	}
+
+	dot := renderDOT("workflow", topology)
+
+	for _, edge := range []string{
+		`"entry-abcde" -> "pod-kill-fghij";`,
+		`"pod-kill-fghij" -> "entry-abcde/children/1" [style=dashed];`,
+		`"entry-abcde/children/1" -> "entry-abcde/children/2" [style=dashed];`,
+	} {
+		g.Expect(dot).To(ContainSubstring(edge))
+	}
+	g.Expect(dot).ToNot(ContainSubstring(`-> "pod-kill"`))
+	g.Expect(dot).ToNot(ContainSubstring(`"pod-kill" ->`))
+}
+
+func TestGetWorkflowGraph(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "workflow-0"},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "entry",
+			Templates: []v1alpha1.Template{
+				{Name: "entry", Type: v1alpha1.TypeSuspend},
+			},
+		},
+	})
+	originalClients := clientpool.K8sClients
+	clientpool.K8sClients = clientpooltest.NewFakeClients(kubeClient)
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	router := gin.New()
+	router.Use(utils.MWHandleErrors())
+	Register(router.Group("/api"), NewService(&dashboardconfig.ChaosDashboardConfig{}, nil))
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/api/workflows/default/workflow-0/graph")
+	g.Expect(rr.Code).To(Equal(http.StatusOK))
+	g.Expect(rr.Body.String()).To(HavePrefix("digraph \"workflow-0\" {\n"))
+
+	rr = get("/api/workflows/default/workflow-0/graph?format=svg")
+	g.Expect(rr.Code).To(Equal(http.StatusBadRequest))
+
+	rr = get("/api/workflows/default/not-exist/graph")
+	g.Expect(rr.Code).To(Equal(http.StatusNotFound))
+}
@@ -0,0 +1,54 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// findNodePod returns the latest pod spawned by the workflow node, e.g. the pod of a task node.
+// It returns nil if the node hasn't spawned any pod yet.
+func findNodePod(ctx context.Context, kubeClient client.Client, namespace, workflowName, nodeName string) (*corev1.Pod, error) {
+	node := v1alpha1.WorkflowNode{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: nodeName}, &node); err != nil {
+		return nil, err
+	}
+	if node.Spec.WorkflowName != workflowName {
+		return nil, apierrors.NewNotFound(v1alpha1.GroupVersion.WithResource("workflownodes").GroupResource(), nodeName)
+	}
+
+	// labeling the pods, see pkg/workflow/controllers/task_reconciler.go
+	var pods corev1.PodList
+	if err := kubeClient.List(ctx, &pods, client.InNamespace(namespace), client.MatchingLabels{
+		v1alpha1.LabelControlledBy: node.Name,
+	}); err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+	return &pods.Items[0], nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/utils"
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+	clientpooltest "github.com/chaos-mesh/chaos-mesh/pkg/clientpool/test"
+	dashboardconfig "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
+)
+
+func TestFindNodePod(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	newNode := func(name, workflowName string) *v1alpha1.WorkflowNode {
+		return &v1alpha1.WorkflowNode{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: v1alpha1.WorkflowNodeSpec{
+				WorkflowName: workflowName,
+				Type:         v1alpha1.TypeTask,
+			},
+		}
+	}
+	newPod := func(namespace, name, nodeName string, createdAfter time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				Labels:            map[string]string{v1alpha1.LabelControlledBy: nodeName},
+				CreationTimestamp: metav1.NewTime(now.Add(createdAfter)),
+			},
+		}
+	}
+
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), []runtime.Object{
+		newNode("task-0", "workflow-0"),
+		newNode("task-1", "workflow-0"),
+		newNode("task-2", "workflow-1"),
+		newPod("default", "task-0-old", "task-0", 0),
+		newPod("default", "task-0-new", "task-0", time.Minute),
+		newPod("another", "task-1-in-another-namespace", "task-1", 0),
+		newPod("default", "task-2-pod", "task-2", 0),
+	}...)
+
+	// the latest pod is selected
+	pod, err := findNodePod(context.TODO(), kubeClient, "default", "workflow-0", "task-0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pod).ToNot(BeNil())
+	g.Expect(pod.Name).To(Equal("task-0-new"))
+
+	// the node hasn't spawned a pod in its namespace yet
+	pod, err = findNodePod(context.TODO(), kubeClient, "default", "workflow-0", "task-1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pod).To(BeNil())
+
+	// the node belongs to another workflow
+	_, err = findNodePod(context.TODO(), kubeClient, "default", "workflow-0", "task-2")
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	_, err = findNodePod(context.TODO(), kubeClient, "default", "workflow-0", "not-exist")
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestGetWorkflowNodeLogsNotFound(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	newNode := func(name, workflowName string) *v1alpha1.WorkflowNode {
+		return &v1alpha1.WorkflowNode{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: v1alpha1.WorkflowNodeSpec{
+				WorkflowName: workflowName,
+				Type:         v1alpha1.TypeTask,
+			},
+		}
+	}
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(),
+		newNode("task-0", "workflow-0"),
+		newNode("task-1", "workflow-1"),
+	)
+
+	originalClients := clientpool.K8sClients
+	clientpool.K8sClients = clientpooltest.NewFakeClients(kubeClient).
+		WithCoreClient(kubernetesfake.NewSimpleClientset().CoreV1())
+	defer func() {
+		clientpool.K8sClients = originalClients
+	}()
+
+	router := gin.New()
+	router.Use(utils.MWHandleErrors())
+	Register(router.Group("/api"), NewService(&dashboardconfig.ChaosDashboardConfig{}, nil))
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// the node hasn't spawned a pod yet
+	rr := get("/api/workflows/default/workflow-0/nodes/task-0/logs")
+	g.Expect(rr.Code).To(Equal(http.StatusNotFound))
+
+	// the node belongs to another workflow
+	rr = get("/api/workflows/default/workflow-0/nodes/task-1/logs")
+	g.Expect(rr.Code).To(Equal(http.StatusNotFound))
+
+	rr = get("/api/workflows/another/workflow-0/nodes/task-0/logs")
+	g.Expect(rr.Code).To(Equal(http.StatusNotFound))
+}
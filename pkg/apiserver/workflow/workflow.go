@@ -14,12 +14,15 @@
 package workflow
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"sort"
 
 	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -28,6 +31,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
 	config "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
 	"github.com/chaos-mesh/chaos-mesh/pkg/core"
+	"github.com/chaos-mesh/chaos-mesh/pkg/workflow/validation"
 )
 
 var log = ctrl.Log.WithName("workflow api")
@@ -37,13 +41,30 @@ type StatusResponse struct {
 	Status string `json:"status"`
 }
 
+// ValidationResponse defines the result of validating a workflow.
+type ValidationResponse struct {
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"`
+}
+
 func Register(r *gin.RouterGroup, s *Service) {
 	endpoint := r.Group("/workflows")
 	endpoint.GET("", s.listWorkflows)
 	endpoint.POST("", s.createWorkflow)
+	endpoint.POST("/validate", s.validateWorkflow)
 	endpoint.GET("/:uid", s.getWorkflowDetailByUID)
 	endpoint.PUT("/:uid", s.updateWorkflow)
 	endpoint.DELETE("/:uid", s.deleteWorkflow)
+
+	// the wildcards in the same segment must share the name, so the namespace of the workflows addressed by
+	// namespace and name is the wildcard of uid above, see namespaceParam
+	endpoint.GET("/:uid/:name/graph", s.getWorkflowGraph)
+	endpoint.GET("/:uid/:name/nodes/:node/logs", s.getWorkflowNodeLogs)
+}
+
+// namespaceParam returns the namespace of the workflow addressed by namespace and name in the path
+func namespaceParam(c *gin.Context) string {
+	return c.Param("uid")
 }
 
 // Service defines a handler service for workflows.
@@ -175,6 +196,118 @@ func (it *Service) getWorkflowDetailByUID(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// @Summary Export the topology of the specified workflow as a graph.
+// @Description Export the topology of the specified workflow as a graph. Only the Graphviz DOT format is supported now.
+// @Tags workflows
+// @Produce plain
+// @Param namespace path string true "namespace"
+// @Param name path string true "name"
+// @Param format query string false "format" Enums(dot)
+// @Router /workflows/{namespace}/{name}/graph [GET]
+// @Success 200 {string} string
+// @Failure 400 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+func (it *Service) getWorkflowGraph(c *gin.Context) {
+	namespace := namespaceParam(c)
+	name := c.Param("name")
+	format := c.DefaultQuery("format", GraphFormatDOT)
+	if format != GraphFormatDOT {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.New("unsupported graph format %s", format))
+		return
+	}
+
+	kubeClient, err := clientpool.ExtractTokenAndGetClient(c.Request.Header)
+	if err != nil {
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	repo := core.NewKubeWorkflowRepository(kubeClient)
+
+	workflowCRInKubernetes, err := repo.Get(c.Request.Context(), namespace, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			c.Status(http.StatusNotFound)
+			_ = c.Error(utils.ErrNotFound.WrapWithNoMessage(err))
+			return
+		}
+		utils.SetErrorForGinCtx(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(renderDOT(name, workflowCRInKubernetes.Topology)))
+}
+
+// @Summary Get the logs of the pod spawned by the specified workflow node.
+// @Description Get the logs of the pod spawned by the specified workflow node, e.g. the pod of a task node.
+// @Tags workflows
+// @Produce plain
+// @Param namespace path string true "namespace"
+// @Param name path string true "name"
+// @Param node path string true "the name of the workflow node"
+// @Param container query string false "the container of the pod, it could be omitted if there is only one container"
+// @Param follow query bool false "follow the logs"
+// @Router /workflows/{namespace}/{name}/nodes/{node}/logs [GET]
+// @Success 200 {string} string
+// @Failure 400 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+func (it *Service) getWorkflowNodeLogs(c *gin.Context) {
+	namespace := namespaceParam(c)
+	name := c.Param("name")
+	nodeName := c.Param("node")
+
+	kubeClient, err := clientpool.ExtractTokenAndGetClient(c.Request.Header)
+	if err != nil {
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+	coreClient, err := clientpool.ExtractTokenAndGetCoreClient(c.Request.Header)
+	if err != nil {
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	pod, err := findNodePod(c.Request.Context(), kubeClient, namespace, name, nodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			c.Status(http.StatusNotFound)
+		}
+		utils.SetErrorForGinCtx(c, err)
+		return
+	}
+	if pod == nil {
+		c.Status(http.StatusNotFound)
+		_ = c.Error(utils.ErrNotFound.New("the workflow node %s has not spawned a pod yet", nodeName))
+		return
+	}
+
+	stream, err := coreClient.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: c.Query("container"),
+		Follow:    c.Query("follow") == "true",
+	}).Context(c.Request.Context()).Stream()
+	if err != nil {
+		utils.SetErrorForGinCtx(c, err)
+		return
+	}
+	defer stream.Close()
+
+	reader := bufio.NewReader(stream)
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Stream(func(w io.Writer) bool {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			_, _ = w.Write(line)
+		}
+		if err != nil && err != io.EOF {
+			log.Error(err, "fail to read the logs", "pod", fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+		return err == nil
+	})
+}
+
 // @Summary Create a new workflow.
 // @Description Create a new workflow.
 // @Tags workflows
@@ -209,6 +342,32 @@ func (it *Service) createWorkflow(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// @Summary Validate a workflow.
+// @Description Check the workflow statically without creating it, e.g. the templates referred by it exist.
+// @Tags workflows
+// @Produce json
+// @Param request body v1alpha1.Workflow true "Request body"
+// @Success 200 {object} ValidationResponse
+// @Failure 400 {object} utils.APIError
+// @Router /workflows/validate [post]
+func (it *Service) validateWorkflow(c *gin.Context) {
+	payload := v1alpha1.Workflow{}
+
+	err := json.NewDecoder(c.Request.Body).Decode(&payload)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.Wrap(err, "failed to parse request body"))
+		return
+	}
+
+	result := ValidationResponse{Valid: true}
+	for _, err := range validation.ValidateWorkflow(payload) {
+		result.Valid = false
+		result.Errors = append(result.Errors, err.Error())
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // @Summary Delete the specified workflow.
 // @Description Delete the specified workflow.
 // @Tags workflows
@@ -0,0 +1,76 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/apiserver/utils"
+	dashboardconfig "github.com/chaos-mesh/chaos-mesh/pkg/config/dashboard"
+)
+
+func TestValidateWorkflow(t *testing.T) {
+	g := NewGomegaWithT(t)
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(utils.MWHandleErrors())
+	Register(router.Group("/api"), NewService(&dashboardconfig.ChaosDashboardConfig{}, nil))
+	validate := func(workflow v1alpha1.Workflow) ValidationResponse {
+		body, err := json.Marshal(workflow)
+		g.Expect(err).ToNot(HaveOccurred())
+		req, _ := http.NewRequest(http.MethodPost, "/api/workflows/validate", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		g.Expect(rr.Code).To(Equal(http.StatusOK))
+
+		result := ValidationResponse{}
+		g.Expect(json.Unmarshal(rr.Body.Bytes(), &result)).To(Succeed())
+		return result
+	}
+
+	deadline := "1m"
+	workflow := v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "workflow-0"},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "entry",
+			Templates: []v1alpha1.Template{
+				{Name: "entry", Type: v1alpha1.TypeSerial, Children: []string{"suspend"}},
+				{Name: "suspend", Type: v1alpha1.TypeSuspend, Deadline: &deadline},
+			},
+		},
+	}
+	g.Expect(validate(workflow)).To(Equal(ValidationResponse{Valid: true}))
+
+	workflow.Spec.Templates[0].Children = append(workflow.Spec.Templates[0].Children, "dangling")
+	result := validate(workflow)
+	g.Expect(result.Valid).To(BeFalse())
+	g.Expect(result.Errors).To(HaveLen(1))
+	g.Expect(result.Errors[0]).To(ContainSubstring("no such template"))
+	g.Expect(result.Errors[0]).To(ContainSubstring("dangling"))
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/workflows/validate", bytes.NewReader([]byte("not a workflow")))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	g.Expect(rr.Code).To(Equal(http.StatusBadRequest))
+}
@@ -104,6 +104,8 @@ func (m *BackgroundProcessManager) StartProcess(cmd *ManagedProcess) (*process.P
 		return nil, err
 	}
 
+	cmd.exited = make(chan struct{})
+
 	pid := cmd.Process.Pid
 	procState, err := process.NewProcess(int32(cmd.Process.Pid))
 	if err != nil {
@@ -161,6 +163,7 @@ func (m *BackgroundProcessManager) StartProcess(cmd *ManagedProcess) (*process.P
 
 		log.Info("process stopped")
 
+		close(cmd.exited)
 		deathChannel <- true
 		m.deathSig.Delete(pair)
 		if io, loaded := m.stdio.LoadAndDelete(pair); loaded {
@@ -417,4 +420,12 @@ type ManagedProcess struct {
 	// If the identifier is not nil, process manager should make sure no other
 	// process with this identifier is running when executing this command
 	Identifier *string
+
+	exited chan struct{}
+}
+
+// Exited returns a channel which will be closed after the process started by
+// BackgroundProcessManager exits. `ProcessState` could be read safely after that.
+func (p *ManagedProcess) Exited() <-chan struct{} {
+	return p.exited
 }
@@ -0,0 +1,124 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosctl/common"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosctl/explain"
+	"github.com/chaos-mesh/chaos-mesh/pkg/grpc"
+)
+
+type ExplainOptions struct {
+	logger     logr.Logger
+	namespace  string
+	CaCertFile string
+	CertFile   string
+	KeyFile    string
+	Insecure   bool
+}
+
+func NewExplainCommand(logger logr.Logger) (*cobra.Command, error) {
+	o := &ExplainOptions{
+		logger: logger,
+	}
+
+	explainCmd := &cobra.Command{
+		Use:   `explain (CHAOSTYPE) [CHAOSNAME] [-n NAMESPACE]`,
+		Short: `Print the effective rules applied to each target of certain chaos`,
+		Long: `Print the effective rules applied to each target of certain chaos, which are queried from the chaos daemon of the target.
+Currently support networkchaos (tc qdiscs), httpchaos (iptables), dnschaos (resolv.conf) and stresschaos (stress-ng args).
+
+Examples:
+  # Return the rules of all networkchaos in default namespace
+  chaosctl explain networkchaos
+
+  # Return the rules of certain dnschaos
+  chaosctl explain dnschaos CHAOSNAME -n NAMESPACE`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientset, err := common.InitClientSet()
+			if err != nil {
+				return err
+			}
+			return o.Run(args, clientset)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 1 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			clientset, err := common.InitClientSet()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			return listChaos(args[0], o.namespace, toComplete, clientset.CtrlCli)
+		},
+	}
+
+	explainCmd.Flags().StringVarP(&o.namespace, "namespace", "n", "default", "namespace to find chaos")
+	explainCmd.Flags().StringVar(&o.CaCertFile, "cacert", "", "file path to cacert file")
+	explainCmd.Flags().StringVar(&o.CertFile, "cert", "", "file path to cert file")
+	explainCmd.Flags().StringVar(&o.KeyFile, "key", "", "file path to key file")
+	explainCmd.Flags().BoolVarP(&o.Insecure, "insecure", "i", false, "Insecure mode will use unauthorized grpc")
+	err := explainCmd.RegisterFlagCompletionFunc("namespace", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		clientset, err := common.InitClientSet()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return listNamespace(toComplete, clientset.KubeCli)
+	})
+	return explainCmd, err
+}
+
+// Run explain
+func (o *ExplainOptions) Run(args []string, c *common.ClientSet) error {
+	chaosType := args[0]
+	if _, err := explain.CollectorFor(chaosType); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chaosName := ""
+	if len(args) == 2 {
+		chaosName = args[1]
+	}
+
+	chaosList, chaosNameList, err := common.GetChaosList(ctx, chaosType, chaosName, o.namespace, c.CtrlCli)
+	if err != nil {
+		return err
+	}
+	var result []common.ChaosResult
+	common.TLSFiles = grpc.TLSFile{CaCert: o.CaCertFile, Cert: o.CertFile, Key: o.KeyFile}
+	common.Insecure = o.Insecure
+
+	for i, chaos := range chaosList {
+		chaosResult := common.ChaosResult{Name: chaosNameList[i]}
+		err := explain.Explain(ctx, chaosType, chaos, c, &chaosResult)
+		result = append(result, chaosResult)
+		if err != nil {
+			common.PrintResult(result)
+			return errors.Wrapf(err, "failed to explain chaos %s", chaosNameList[i])
+		}
+	}
+	common.PrintResult(result)
+	return nil
+}
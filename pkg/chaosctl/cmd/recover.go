@@ -0,0 +1,111 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	cm "github.com/chaos-mesh/chaos-mesh/pkg/chaosctl/common"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosctl/recovery"
+)
+
+type recoverOptions struct {
+	logger    logr.Logger
+	namespace string
+	force     bool
+}
+
+func NewRecoverCommand(logger logr.Logger) (*cobra.Command, error) {
+	o := &recoverOptions{
+		logger: logger,
+	}
+
+	recoverCmd := &cobra.Command{
+		Use:   `recover [-n NAMESPACE] [--force]`,
+		Short: `Recover the chaos stuck on the pods which no longer exist`,
+		Long: `Recover the chaos stuck on the pods which no longer exist, e.g. the chaos daemon crashed before recovering them.
+The injected records on these pods are marked as not injected, and the finalizer of the deleted chaos is removed then.
+It only prints the stuck chaos by default, use --force to actually recover them.
+
+Examples:
+  # Print the stuck chaos in default namespace
+  chaosctl recover
+
+  # Recover the stuck chaos in namespace NAMESPACE
+  chaosctl recover -n NAMESPACE --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientset, err := cm.InitClientSet()
+			if err != nil {
+				return err
+			}
+			return o.Run(clientset)
+		},
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+		ValidArgsFunction: noCompletions,
+	}
+
+	recoverCmd.Flags().StringVarP(&o.namespace, "namespace", "n", "default", "namespace to find chaos")
+	recoverCmd.Flags().BoolVar(&o.force, "force", false, "recover the stuck chaos rather than only printing them")
+	err := recoverCmd.RegisterFlagCompletionFunc("namespace", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		clientset, err := cm.InitClientSet()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return listNamespace(toComplete, clientset.KubeCli)
+	})
+	return recoverCmd, err
+}
+
+// Run recover
+func (o *recoverOptions) Run(c *cm.ClientSet) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stuckChaos, err := recovery.FindStuckChaos(ctx, c.CtrlCli, o.namespace)
+	if err != nil {
+		return err
+	}
+	if len(stuckChaos) == 0 {
+		cm.PrettyPrint(fmt.Sprintf("no stuck chaos is found in namespace %s", o.namespace), 0, cm.Green)
+		return nil
+	}
+
+	for _, stuck := range stuckChaos {
+		title := fmt.Sprintf("[%s]: %s", stuck.Kind, stuck.Name)
+		if stuck.Deleting {
+			title += " (deleting)"
+		}
+		cm.PrettyPrint(title, 0, cm.Blue)
+		cm.PrettyPrint("stale records: "+strings.Join(stuck.StaleRecords, ", "), 1, cm.NoColor)
+		if !o.force {
+			continue
+		}
+		if err := recovery.Recover(ctx, c.CtrlCli, stuck); err != nil {
+			return errors.Wrapf(err, "failed to recover %s %s", stuck.Kind, stuck.Name)
+		}
+		cm.PrettyPrint("recovered", 1, cm.Green)
+	}
+	if !o.force {
+		cm.PrettyPrint("dry run, use --force to recover the chaos above", 0, cm.Cyan)
+	}
+	return nil
+}
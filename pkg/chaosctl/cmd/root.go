@@ -33,8 +33,14 @@ Interacting with chaos mesh
   # show debug info
   chaosctl debug networkchaos
 
+  # show the effective rules applied to the targets
+  chaosctl explain networkchaos
+
   # show logs of all chaos-mesh components
-  chaosctl logs`,
+  chaosctl logs
+
+  # recover the chaos stuck on the pods which no longer exist
+  chaosctl recover --force`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -73,6 +79,28 @@ func Execute() {
 	}
 
 	rootCmd.AddCommand(debugCommand)
+
+	explainCommand, err := NewExplainCommand(rootLogger.WithName("cmd-explain"))
+	if err != nil {
+		rootLogger.Error(err, "failed to initialize cmd",
+			"cmd", "explain",
+			"errorVerbose", fmt.Sprintf("%+v", err),
+		)
+		os.Exit(1)
+	}
+
+	rootCmd.AddCommand(explainCommand)
+
+	recoverCommand, err := NewRecoverCommand(rootLogger.WithName("cmd-recover"))
+	if err != nil {
+		rootLogger.Error(err, "failed to initialize cmd",
+			"cmd", "recover",
+			"errorVerbose", fmt.Sprintf("%+v", err),
+		)
+		os.Exit(1)
+	}
+
+	rootCmd.AddCommand(recoverCommand)
 	rootCmd.AddCommand(completionCmd)
 	if err := rootCmd.Execute(); err != nil {
 		rootLogger.Error(err, "failed to execute cmd",
@@ -104,8 +104,13 @@ func init() {
 }
 
 func upperCaseChaos(str string) string {
-	parts := regexp.MustCompile("(.*)(chaos)").FindStringSubmatch(str)
-	return strings.Title(parts[1]) + strings.Title(parts[2])
+	// the kinds like "IOChaos" and "DNSChaos" can't be recovered by capitalizing the words
+	for kind := range v1alpha1.AllKinds() {
+		if strings.EqualFold(kind, str) {
+			return kind
+		}
+	}
+	return str
 }
 
 // PrettyPrint print with tab number and color
@@ -217,6 +222,9 @@ func GetPods(ctx context.Context, chaosName string, status v1alpha1.ChaosStatus,
 func GetChaosList(ctx context.Context, chaosType string, chaosName string, ns string, c client.Client) ([]runtime.Object, []string, error) {
 	chaosType = upperCaseChaos(strings.ToLower(chaosType))
 	allKinds := v1alpha1.AllKinds()
+	if allKinds[chaosType] == nil {
+		return nil, nil, fmt.Errorf("chaos type %s is not supported", chaosType)
+	}
 	chaosListInterface := allKinds[chaosType].ChaosList
 
 	if err := c.List(ctx, chaosListInterface, client.InNamespace(ns)); err != nil {
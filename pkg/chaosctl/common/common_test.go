@@ -194,3 +194,12 @@ func TestGetPods(t *testing.T) {
 		})
 	}
 }
+
+func TestUpperCaseChaos(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(upperCaseChaos("networkchaos")).To(Equal(v1alpha1.KindNetworkChaos))
+	g.Expect(upperCaseChaos("iochaos")).To(Equal(v1alpha1.KindIOChaos))
+	g.Expect(upperCaseChaos("dnschaos")).To(Equal(v1alpha1.KindDNSChaos))
+	g.Expect(upperCaseChaos("foo")).To(Equal("foo"))
+}
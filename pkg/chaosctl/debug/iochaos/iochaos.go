@@ -15,11 +15,14 @@ package iochaos
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	cm "github.com/chaos-mesh/chaos-mesh/pkg/chaosctl/common"
@@ -57,6 +60,7 @@ func debugEachPod(ctx context.Context, pod v1.Pod, daemon v1.Pod, chaos *v1alpha
 		return errors.Wrapf(err, "run command '%s' failed", cmd)
 	}
 	result.Items = append(result.Items, cm.ItemResult{Name: "mount information", Value: string(out)})
+	mounts := string(out)
 
 	pids, commands, err := cm.GetPidFromPS(ctx, pod, daemon, c.KubeCli)
 	if err != nil {
@@ -76,5 +80,116 @@ func debugEachPod(ctx context.Context, pod v1.Pod, daemon v1.Pod, chaos *v1alpha
 		result.Items = append(result.Items, cm.ItemResult{Name: fmt.Sprintf("file descriptors of PID: %s, COMMAND: %s", pids[i], commands[i]), Value: itemValue})
 	}
 
+	// toda receives the actions from chaos-daemon without any config file,
+	// so the injected config is the PodIOChaos which chaos-daemon applies
+	podIOChaos := &v1alpha1.PodIOChaos{}
+	objectKey := client.ObjectKey{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+	}
+	if err = c.CtrlCli.Get(ctx, objectKey, podIOChaos); err != nil {
+		return errors.Wrapf(err, "failed to get io chaos %s/%s", pod.Namespace, pod.Name)
+	}
+	result.Items = append(result.Items, cm.ItemResult{Name: "injected io chaos", Value: formatPodIOChaos(&podIOChaos.Spec)})
+	result.Items = append(result.Items, checkFuseMount(ctx, pod, daemon, c, &podIOChaos.Spec, mounts))
+
 	return nil
 }
+
+// formatPodIOChaos prints the faults of the injected actions, one action per line
+func formatPodIOChaos(spec *v1alpha1.PodIOChaosSpec) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "volume: %s\n", spec.VolumeMountPath)
+	if spec.Container != nil {
+		fmt.Fprintf(&b, "container: %s\n", *spec.Container)
+	}
+	if len(spec.Actions) == 0 {
+		b.WriteString("no active actions\n")
+		return b.String()
+	}
+
+	for _, action := range spec.Actions {
+		methods := "all"
+		if len(action.Methods) > 0 {
+			names := make([]string, 0, len(action.Methods))
+			for _, method := range action.Methods {
+				names = append(names, string(method))
+			}
+			methods = strings.Join(names, ",")
+		}
+		fmt.Fprintf(&b, "%s: path=%s methods=%s percent=%d", action.Type, action.Path, methods, action.Percent)
+
+		switch action.Type {
+		case v1alpha1.IoLatency:
+			fmt.Fprintf(&b, " latency=%s", action.Latency)
+		case v1alpha1.IoFaults:
+			for _, fault := range action.Faults {
+				fmt.Fprintf(&b, " errno=%d(weight=%d)", fault.Errno, fault.Weight)
+			}
+		case v1alpha1.IoAttrOverride:
+			if action.AttrOverrideSpec != nil {
+				if attr, err := json.Marshal(action.AttrOverrideSpec); err == nil {
+					fmt.Fprintf(&b, " attr=%s", attr)
+				}
+			}
+		case v1alpha1.IoMistake:
+			if action.MistakeSpec != nil {
+				fmt.Fprintf(&b, " filling=%s maxOccurrences=%d maxLength=%d",
+					action.MistakeSpec.Filling, action.MistakeSpec.MaxOccurrences, action.MistakeSpec.MaxLength)
+			}
+		}
+		if len(action.Source) > 0 {
+			fmt.Fprintf(&b, " source=%s", action.Source)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// checkFuseMount warns when the fuse isn't mounted on the volume of the active actions,
+// or the fuse is stale, i.e. it's still mounted but toda has gone
+func checkFuseMount(ctx context.Context, pod v1.Pod, daemon v1.Pod, c *cm.ClientSet, spec *v1alpha1.PodIOChaosSpec, mounts string) cm.ItemResult {
+	itemResult := cm.ItemResult{Name: "fuse mount", Value: spec.VolumeMountPath}
+	mounted := fuseMounted(mounts, spec.VolumeMountPath)
+
+	switch {
+	case len(spec.Actions) > 0 && !mounted:
+		itemResult.Status = cm.ItemFailure
+		itemResult.ErrInfo = fmt.Sprintf("fuse is not mounted on %s, the io chaos isn't injected", spec.VolumeMountPath)
+	case !mounted:
+		itemResult.Status = cm.ItemSuccess
+		itemResult.SucInfo = "no fuse is mounted as no action is active"
+	default:
+		// the stale fuse fails every access with ENOTCONN
+		cmd := fmt.Sprintf("stat %s", spec.VolumeMountPath)
+		_, err := cm.ExecBypass(ctx, pod, daemon, cmd, c.KubeCli)
+		if err != nil && strings.Contains(err.Error(), "Transport endpoint is not connected") {
+			itemResult.Status = cm.ItemFailure
+			itemResult.ErrInfo = fmt.Sprintf("fuse mounted on %s is stale", spec.VolumeMountPath)
+		} else if err != nil {
+			itemResult.Status = cm.ItemFailure
+			itemResult.ErrInfo = err.Error()
+		} else if len(spec.Actions) == 0 {
+			itemResult.Status = cm.ItemFailure
+			itemResult.ErrInfo = fmt.Sprintf("fuse is still mounted on %s while no action is active", spec.VolumeMountPath)
+		} else {
+			itemResult.Status = cm.ItemSuccess
+			itemResult.SucInfo = fmt.Sprintf("fuse is mounted on %s", spec.VolumeMountPath)
+		}
+	}
+	return itemResult
+}
+
+// fuseMounted returns whether a fuse filesystem is mounted on the path in /proc/mounts
+func fuseMounted(mounts string, path string) bool {
+	for _, line := range strings.Split(mounts, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		if fields[1] == path && strings.HasPrefix(fields[2], "fuse") {
+			return true
+		}
+	}
+	return false
+}
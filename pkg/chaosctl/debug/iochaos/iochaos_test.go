@@ -0,0 +1,57 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iochaos
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func TestFormatPodIOChaos(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := &v1alpha1.PodIOChaosSpec{VolumeMountPath: "/var/run/data"}
+	g.Expect(formatPodIOChaos(spec)).To(Equal("volume: /var/run/data\nno active actions\n"))
+
+	spec.Actions = []v1alpha1.IOChaosAction{
+		{
+			Type:    v1alpha1.IoLatency,
+			Filter:  v1alpha1.Filter{Path: "/var/run/data/*", Percent: 50},
+			Latency: "10ms",
+			Source:  "default/io-latency",
+		},
+		{
+			Type:   v1alpha1.IoFaults,
+			Filter: v1alpha1.Filter{Path: "/var/run/data/*", Methods: []v1alpha1.IoMethod{v1alpha1.Read, v1alpha1.Write}, Percent: 100},
+			Faults: []v1alpha1.IoFault{{Errno: 5, Weight: 1}, {Errno: 28, Weight: 2}},
+		},
+	}
+	g.Expect(formatPodIOChaos(spec)).To(Equal("volume: /var/run/data\n" +
+		"latency: path=/var/run/data/* methods=all percent=50 latency=10ms source=default/io-latency\n" +
+		"fault: path=/var/run/data/* methods=read,write percent=100 errno=5(weight=1) errno=28(weight=2)\n"))
+}
+
+func TestFuseMounted(t *testing.T) {
+	g := NewWithT(t)
+
+	mounts := "overlay / overlay rw,relatime 0 0\n" +
+		"/dev/sda1 /var/run/__chaosfs__data__ ext4 rw,relatime 0 0\n" +
+		"todafs /var/run/data fuse.todafs rw,nosuid,nodev,relatime 0 0\n"
+	g.Expect(fuseMounted(mounts, "/var/run/data")).To(BeTrue())
+	g.Expect(fuseMounted(mounts, "/var/run/__chaosfs__data__")).To(BeFalse())
+	g.Expect(fuseMounted(mounts, "/var/run/log")).To(BeFalse())
+}
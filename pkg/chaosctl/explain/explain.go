@@ -0,0 +1,170 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/grpclog"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	cm "github.com/chaos-mesh/chaos-mesh/pkg/chaosctl/common"
+)
+
+// Collector collects the rules applied to a target pod of the chaos, by querying the chaos daemon on its node
+type Collector interface {
+	// Collect returns the rules applied to the pod
+	Collect(ctx context.Context, pod v1.Pod, daemon v1.Pod, c *cm.ClientSet) ([]cm.ItemResult, error)
+}
+
+var collectors = map[string]Collector{
+	v1alpha1.KindNetworkChaos: tcCollector{},
+	v1alpha1.KindHTTPChaos:    iptablesCollector{},
+	v1alpha1.KindDNSChaos:     resolvConfCollector{},
+	v1alpha1.KindStressChaos:  stressCollector{},
+}
+
+// CollectorFor returns the collector of the chaos type, which is case insensitive, e.g. "networkchaos"
+func CollectorFor(chaosType string) (Collector, error) {
+	for kind, collector := range collectors {
+		if strings.EqualFold(kind, chaosType) {
+			return collector, nil
+		}
+	}
+	return nil, fmt.Errorf("chaos type %s is not supported to explain", chaosType)
+}
+
+// Explain gets the rules applied to each target of the chaos
+func Explain(ctx context.Context, chaosType string, chaos runtime.Object, c *cm.ClientSet, result *cm.ChaosResult) error {
+	collector, err := CollectorFor(chaosType)
+	if err != nil {
+		return err
+	}
+	// To disable printing irrelevant log from grpc/clientconn.go
+	// See grpc/grpc-go#3918 for detail. Could be resolved in the future
+	grpclog.SetLoggerV2(grpclog.NewLoggerV2(ioutil.Discard, ioutil.Discard, ioutil.Discard))
+
+	statefulObject, ok := chaos.(v1alpha1.StatefulObject)
+	if !ok {
+		return fmt.Errorf("chaos %s has no status", result.Name)
+	}
+	selector, err := selectorOf(chaos)
+	if err != nil {
+		return err
+	}
+
+	pods, daemons, err := cm.GetPods(ctx, result.Name, *statefulObject.GetStatus(), selector, c.CtrlCli)
+	if err != nil {
+		return err
+	}
+
+	for i := range pods {
+		podResult := cm.PodResult{Name: pods[i].Name}
+		items, err := collector.Collect(ctx, pods[i], daemons[i], c)
+		if err != nil {
+			podResult.Items = append(podResult.Items, cm.ItemResult{Name: "error", Status: cm.ItemFailure, ErrInfo: err.Error()})
+		}
+		podResult.Items = append(items, podResult.Items...)
+		result.Pods = append(result.Pods, podResult)
+	}
+	return nil
+}
+
+// selectorOf returns the pod selector of the chaos
+func selectorOf(chaos runtime.Object) (v1alpha1.PodSelectorSpec, error) {
+	selectorSpecs, ok := chaos.(interface {
+		GetSelectorSpecs() map[string]interface{}
+	})
+	if ok {
+		switch selector := selectorSpecs.GetSelectorSpecs()["."].(type) {
+		case *v1alpha1.ContainerSelector:
+			return selector.Selector, nil
+		case *v1alpha1.PodSelector:
+			return selector.Selector, nil
+		}
+	}
+	return v1alpha1.PodSelectorSpec{}, fmt.Errorf("chaos doesn't select pods")
+}
+
+// tcCollector collects the tc qdiscs in the network namespace of the pod
+type tcCollector struct{}
+
+func (tcCollector) Collect(ctx context.Context, pod v1.Pod, daemon v1.Pod, c *cm.ClientSet) ([]cm.ItemResult, error) {
+	return execInNetNS(ctx, pod, daemon, c, "tc qdisc list")
+}
+
+// iptablesCollector collects the iptables rules in the network namespace of the pod
+type iptablesCollector struct{}
+
+func (iptablesCollector) Collect(ctx context.Context, pod v1.Pod, daemon v1.Pod, c *cm.ClientSet) ([]cm.ItemResult, error) {
+	return execInNetNS(ctx, pod, daemon, c, "iptables-save")
+}
+
+// resolvConfCollector collects the /etc/resolv.conf of the pod
+type resolvConfCollector struct{}
+
+func (resolvConfCollector) Collect(ctx context.Context, pod v1.Pod, daemon v1.Pod, c *cm.ClientSet) ([]cm.ItemResult, error) {
+	cmd := "cat /etc/resolv.conf"
+	out, err := cm.ExecBypass(ctx, pod, daemon, cmd, c.KubeCli)
+	if err != nil {
+		return nil, errors.Wrapf(err, "run command %s failed", cmd)
+	}
+	return []cm.ItemResult{{Name: cmd, Value: out}}, nil
+}
+
+// stressCollector collects the arguments of the stress-ng running in the pod
+type stressCollector struct{}
+
+func (stressCollector) Collect(ctx context.Context, pod v1.Pod, daemon v1.Pod, c *cm.ClientSet) ([]cm.ItemResult, error) {
+	cmd := "ps"
+	out, err := cm.ExecBypass(ctx, pod, daemon, cmd, c.KubeCli)
+	if err != nil {
+		return nil, errors.Wrapf(err, "run command %s failed", cmd)
+	}
+
+	var stressors []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "stress-ng") {
+			stressors = append(stressors, line)
+		}
+	}
+	if len(stressors) == 0 {
+		return nil, fmt.Errorf("could not find stress-ng, StressChaos failed")
+	}
+	return []cm.ItemResult{{Name: "stress-ng", Value: strings.Join(stressors, "\n")}}, nil
+}
+
+// execInNetNS executes the commands in the network namespace of the pod on its chaos daemon
+func execInNetNS(ctx context.Context, pod v1.Pod, daemon v1.Pod, c *cm.ClientSet, cmds ...string) ([]cm.ItemResult, error) {
+	pid, err := cm.GetPidFromPod(ctx, pod, daemon)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []cm.ItemResult
+	for _, cmd := range cmds {
+		out, err := cm.Exec(ctx, daemon, fmt.Sprintf("/usr/bin/nsenter -n/proc/%d/ns/net -- %s", pid, cmd), c.KubeCli)
+		if err != nil {
+			return items, errors.Wrapf(err, "run command %s failed", cmd)
+		}
+		items = append(items, cm.ItemResult{Name: cmd, Value: out})
+	}
+	return items, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func TestCollectorFor(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		chaosType string
+		expected  Collector
+	}{
+		{chaosType: "networkchaos", expected: tcCollector{}},
+		{chaosType: "NetworkChaos", expected: tcCollector{}},
+		{chaosType: "httpchaos", expected: iptablesCollector{}},
+		{chaosType: "dnschaos", expected: resolvConfCollector{}},
+		{chaosType: "stresschaos", expected: stressCollector{}},
+	}
+	for _, test := range tests {
+		collector, err := CollectorFor(test.chaosType)
+		g.Expect(err).ToNot(HaveOccurred(), test.chaosType)
+		g.Expect(collector).To(Equal(test.expected), test.chaosType)
+	}
+
+	_, err := CollectorFor("podchaos")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestSelectorOf(t *testing.T) {
+	g := NewWithT(t)
+
+	selector := v1alpha1.PodSelectorSpec{Namespaces: []string{"busybox"}}
+	tests := []struct {
+		name  string
+		chaos runtime.Object
+	}{
+		{
+			name: "container selector",
+			chaos: &v1alpha1.DNSChaos{
+				Spec: v1alpha1.DNSChaosSpec{
+					ContainerSelector: v1alpha1.ContainerSelector{
+						PodSelector: v1alpha1.PodSelector{Selector: selector},
+					},
+				},
+			},
+		},
+		{
+			name: "pod selector",
+			chaos: &v1alpha1.HTTPChaos{
+				Spec: v1alpha1.HTTPChaosSpec{
+					PodSelector: v1alpha1.PodSelector{Selector: selector},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		actual, err := selectorOf(test.chaos)
+		g.Expect(err).ToNot(HaveOccurred(), test.name)
+		g.Expect(actual).To(Equal(selector), test.name)
+	}
+
+	_, err := selectorOf(&v1alpha1.AWSChaos{})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/finalizers"
+)
+
+// StuckChaos is a chaos whose records are left injected on the pods which no longer exist, e.g. the chaos daemon
+// crashed before recovering them. The controller would retry recovering these records forever.
+type StuckChaos struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// StaleRecords are the ids of the injected records whose target pod no longer exists
+	StaleRecords []string
+	// Deleting is true if the chaos is deleted but kept by its finalizer
+	Deleting bool
+}
+
+// FindStuckChaos lists the chaos of every kind in the namespace, and returns the ones with stale records.
+func FindStuckChaos(ctx context.Context, c client.Client, namespace string) ([]StuckChaos, error) {
+	allKinds := v1alpha1.AllKinds()
+	var kinds []string
+	for kind := range allKinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var result []StuckChaos
+	for _, kind := range kinds {
+		chaosList := allKinds[kind].ChaosList.DeepCopyObject().(v1alpha1.ChaosList)
+		if err := c.List(ctx, chaosList, client.InNamespace(namespace)); err != nil {
+			return nil, errors.Wrapf(err, "failed to list %s in namespace %s", kind, namespace)
+		}
+
+		for _, instance := range chaosList.ListChaos() {
+			chaos, ok := allKinds[kind].Chaos.DeepCopyObject().(v1alpha1.InnerObject)
+			if !ok {
+				// the kinds without records, e.g. the workflow
+				break
+			}
+			err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: instance.Name}, chaos)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get %s %s/%s", kind, namespace, instance.Name)
+			}
+
+			staleRecords, err := findStaleRecords(ctx, c, chaos.GetStatus().Experiment.Records)
+			if err != nil {
+				return nil, err
+			}
+			if len(staleRecords) == 0 {
+				continue
+			}
+			result = append(result, StuckChaos{
+				Kind:         kind,
+				Namespace:    namespace,
+				Name:         instance.Name,
+				StaleRecords: staleRecords,
+				Deleting:     chaos.IsDeleted(),
+			})
+		}
+	}
+	return result, nil
+}
+
+// findStaleRecords returns the ids of the injected records whose target pod no longer exists
+func findStaleRecords(ctx context.Context, c client.Client, records []*v1alpha1.Record) ([]string, error) {
+	var staleRecords []string
+	for _, record := range records {
+		if record.Phase == v1alpha1.NotInjected {
+			continue
+		}
+		pod, ok := podOfRecord(record.Id)
+		if !ok {
+			continue
+		}
+		err := c.Get(ctx, pod, &v1.Pod{})
+		if apierrors.IsNotFound(err) {
+			staleRecords = append(staleRecords, record.Id)
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get pod %s", pod)
+		}
+	}
+	return staleRecords, nil
+}
+
+// podOfRecord returns the target pod of the record, whose id is "namespace/pod" or "namespace/pod/container".
+// The records of other targets, e.g. the instances of cloud providers, are not related to any pod.
+func podOfRecord(id string) (types.NamespacedName, bool) {
+	if strings.HasPrefix(id, "{") {
+		return types.NamespacedName{}, false
+	}
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 && len(parts) != 3 {
+		return types.NamespacedName{}, false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return types.NamespacedName{}, false
+		}
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, true
+}
+
+// Recover marks the stale records of the chaos as not injected, as there is nothing left to recover on the pods
+// which no longer exist. If the chaos is deleted and all of its records are recovered then, its finalizer is removed.
+func Recover(ctx context.Context, c client.Client, stuck StuckChaos) error {
+	kind, ok := v1alpha1.AllKinds()[stuck.Kind]
+	if !ok {
+		return fmt.Errorf("chaos type %s is not supported", stuck.Kind)
+	}
+	key := types.NamespacedName{Namespace: stuck.Namespace, Name: stuck.Name}
+	staleRecords := make(map[string]struct{})
+	for _, id := range stuck.StaleRecords {
+		staleRecords[id] = struct{}{}
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		chaos := kind.Chaos.DeepCopyObject().(v1alpha1.InnerObject)
+		if err := c.Get(ctx, key, chaos); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		recovered := true
+		for _, record := range chaos.GetStatus().Experiment.Records {
+			if _, ok := staleRecords[record.Id]; ok && record.Phase != v1alpha1.NotInjected {
+				record.Phase = v1alpha1.NotInjected
+				record.Message = "the target pod no longer exists, recovered by chaosctl"
+			}
+			if record.Phase != v1alpha1.NotInjected {
+				recovered = false
+			}
+		}
+
+		if chaos.IsDeleted() && recovered {
+			var remaining []string
+			for _, finalizer := range chaos.GetObjectMeta().Finalizers {
+				if finalizer != finalizers.RecordFinalizer {
+					remaining = append(remaining, finalizer)
+				}
+			}
+			chaos.GetObjectMeta().Finalizers = remaining
+		}
+		return client.IgnoreNotFound(c.Update(ctx, chaos))
+	})
+}
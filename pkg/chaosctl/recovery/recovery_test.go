@@ -0,0 +1,124 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/finalizers"
+)
+
+func TestPodOfRecord(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		id       string
+		expected types.NamespacedName
+		ok       bool
+	}{
+		{id: "default/foo", expected: types.NamespacedName{Namespace: "default", Name: "foo"}, ok: true},
+		{id: "default/foo/app", expected: types.NamespacedName{Namespace: "default", Name: "foo"}, ok: true},
+		{id: `{"awsRegion":"us-east-1","ec2Instance":"i-0123"}`},
+		{id: "foo"},
+		{id: "default//app"},
+	}
+	for _, test := range tests {
+		pod, ok := podOfRecord(test.id)
+		g.Expect(ok).To(Equal(test.ok), test.id)
+		g.Expect(pod).To(Equal(test.expected), test.id)
+	}
+}
+
+func TestRecoverStuckChaos(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	now := metav1.Now()
+	newPodChaos := func(name string, deletionTimestamp *metav1.Time, records ...*v1alpha1.Record) *v1alpha1.PodChaos {
+		return &v1alpha1.PodChaos{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         metav1.NamespaceDefault,
+				Name:              name,
+				Finalizers:        []string{finalizers.RecordFinalizer},
+				DeletionTimestamp: deletionTimestamp,
+			},
+			Spec: v1alpha1.PodChaosSpec{Action: v1alpha1.PodFailureAction},
+			Status: v1alpha1.PodChaosStatus{
+				ChaosStatus: v1alpha1.ChaosStatus{
+					Experiment: v1alpha1.ExperimentStatus{Records: records},
+				},
+			},
+		}
+	}
+	alive := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "alive"}}
+
+	// the chaos on the living pod is never touched
+	running := newPodChaos("running", nil, &v1alpha1.Record{Id: "default/alive", Phase: v1alpha1.Injected})
+	// the record on the pod which no longer exists could never be recovered by the chaos daemon
+	stale := newPodChaos("stale", nil,
+		&v1alpha1.Record{Id: "default/alive", Phase: v1alpha1.Injected},
+		&v1alpha1.Record{Id: "default/gone", Phase: v1alpha1.Injected},
+	)
+	deleting := newPodChaos("deleting", &now,
+		&v1alpha1.Record{Id: "default/alive/app", Phase: v1alpha1.NotInjected},
+		&v1alpha1.Record{Id: "default/gone/app", Phase: v1alpha1.Injected},
+	)
+
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), alive, running, stale, deleting)
+	stuckChaos, err := FindStuckChaos(ctx, c, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stuckChaos).To(ConsistOf(
+		StuckChaos{
+			Kind:         v1alpha1.KindPodChaos,
+			Namespace:    metav1.NamespaceDefault,
+			Name:         "deleting",
+			StaleRecords: []string{"default/gone/app"},
+			Deleting:     true,
+		},
+		StuckChaos{
+			Kind:         v1alpha1.KindPodChaos,
+			Namespace:    metav1.NamespaceDefault,
+			Name:         "stale",
+			StaleRecords: []string{"default/gone"},
+		},
+	))
+
+	for _, stuck := range stuckChaos {
+		g.Expect(Recover(ctx, c, stuck)).To(Succeed())
+	}
+
+	recovered := &v1alpha1.PodChaos{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "stale"}, recovered)).To(Succeed())
+	g.Expect(recovered.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(recovered.Status.Experiment.Records[1].Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(recovered.Finalizers).To(ConsistOf(finalizers.RecordFinalizer))
+
+	recovered = &v1alpha1.PodChaos{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "deleting"}, recovered)).To(Succeed())
+	g.Expect(recovered.Status.Experiment.Records[1].Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(recovered.Finalizers).To(BeEmpty())
+
+	stuckChaos, err = FindStuckChaos(ctx, c, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stuckChaos).To(BeEmpty())
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -55,7 +56,7 @@ func (s *DaemonServer) ContainerGetPid(ctx context.Context, req *pb.ContainerReq
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
 	if err != nil {
 		log.Error(err, "error while getting pid from container")
-		return nil, err
+		return nil, errcode.Error(errcode.ContainerNotFound, err)
 	}
 
 	return &pb.ContainerResponse{Pid: pid}, nil
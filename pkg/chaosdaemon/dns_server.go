@@ -16,16 +16,28 @@ package chaosdaemon
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
 const (
 	// DNSServerConfFile is the default config file for DNS server
 	DNSServerConfFile = "/etc/resolv.conf"
+
+	// DNSServerBackupFile keeps the original config file before any DNS chaos is injected
+	DNSServerBackupFile = DNSServerConfFile + ".chaos.bak"
+
+	// DNSServerRefsFile keeps the names of the DNS chaos injected into the container, one per line
+	DNSServerRefsFile = DNSServerConfFile + ".chaos.refs"
+
+	// DNSServerChaosFile is bind mounted over the config file if the chaos daemon runs with `--dns-bind-mount`
+	DNSServerChaosFile = DNSServerConfFile + ".chaos"
 )
 
 func (s *DaemonServer) SetDNSServer(ctx context.Context,
@@ -34,65 +46,216 @@ func (s *DaemonServer) SetDNSServer(ctx context.Context,
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
 	if err != nil {
 		log.Error(err, "GetPidFromContainerID")
-		return nil, err
+		return nil, errcode.Error(errcode.ContainerNotFound, err)
+	}
+
+	if req.Enable && len(req.DnsServer) == 0 {
+		return nil, errcode.Errorf(errcode.InvalidRequest, "invalid set dns server request %v", req)
 	}
 
+	if req.EnterNS {
+		// the container may exit after its pid is fetched
+		if _, err := os.Stat(bpm.GetNsPath(pid, bpm.MountNS)); err != nil {
+			return nil, errcode.Error(errcode.NamespaceEnterFailed, err)
+		}
+	}
+
+	err = s.setDNSServer(ctx, pid, req)
+	// the config file is recovered even if it mismatches the snapshot
+	if reason, _ := errcode.ReasonOf(err); err == nil || reason == errcode.RecoverMismatched {
+		if req.Enable {
+			s.activeRules.set(dnsRuleKind, req.ContainerId, 1)
+		} else {
+			s.activeRules.set(dnsRuleKind, req.ContainerId, 0)
+		}
+	}
+	if err != nil {
+		if _, ok := errcode.ReasonOf(err); ok {
+			return nil, err
+		}
+		return nil, errcode.Error(errcode.RuleApplyFailed, err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// setDNSServer sets or recovers the dns server in the /etc/resolv.conf of the container
+func (s *DaemonServer) setDNSServer(ctx context.Context, pid uint32, req *pb.SetDNSServerRequest) error {
+	snapshotKey := snapshotKey(dnsSnapshotKind, req.ContainerId)
 	if req.Enable {
 		// set dns server to the chaos dns server's address
 
-		if len(req.DnsServer) == 0 {
-			return &empty.Empty{}, fmt.Errorf("invalid set dns server request %v", req)
+		if err := s.captureDNSServerConf(ctx, pid, req, snapshotKey); err != nil {
+			return err
 		}
 
-		// backup the /etc/resolv.conf
-		processBuilder := bpm.DefaultProcessBuilder("sh", "-c", fmt.Sprintf("ls %s.chaos.bak || cp %s %s.chaos.bak", DNSServerConfFile, DNSServerConfFile, DNSServerConfFile)).SetContext(ctx)
-		if req.EnterNS {
-			processBuilder = processBuilder.SetNS(pid, bpm.MountNS)
+		// record this DNS chaos, so that the chaos dns server is kept until the last one is recovered
+		if len(req.Name) != 0 {
+			output, err := execDNSCommand(ctx, pid, req, fmt.Sprintf("touch %s && (grep -q -x -F '%s' %s || echo '%s' >> %s)", DNSServerRefsFile, req.Name, DNSServerRefsFile, req.Name, DNSServerRefsFile))
+			if err != nil {
+				return encodeOutputToError(output, err)
+			}
 		}
 
-		cmd := processBuilder.Build()
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			log.Error(err, "execute command error", "command", cmd.String(), "output", output)
-			return nil, encodeOutputToError(output, err)
+		if s.dnsBindMount {
+			mounted, err := bindMountDNSServerConf(ctx, pid, req)
+			if err != nil {
+				return err
+			}
+			if mounted {
+				return nil
+			}
+			log.Info("fall back to modify the config file of dns server in place")
 		}
-		if len(output) != 0 {
-			log.Info("command output", "output", string(output))
+
+		// backup the /etc/resolv.conf, the backup of another DNS chaos shouldn't be overwritten by the modified one
+		output, err := execDNSCommand(ctx, pid, req, fmt.Sprintf("ls %s || cp %s %s", DNSServerBackupFile, DNSServerConfFile, DNSServerBackupFile))
+		if err != nil {
+			return encodeOutputToError(output, err)
 		}
 
 		// add chaos dns server to the first line of /etc/resolv.conf
 		// Note: can not replace the /etc/resolv.conf like `mv temp resolv.conf`, will execute with error `Device or resource busy`
-		processBuilder = bpm.DefaultProcessBuilder("sh", "-c", fmt.Sprintf("cp %s temp && sed -i 's/.*nameserver.*/nameserver %s/' temp && cat temp > %s", DNSServerConfFile, req.DnsServer, DNSServerConfFile)).SetContext(ctx)
-		if req.EnterNS {
-			processBuilder = processBuilder.SetNS(pid, bpm.MountNS)
+		output, err = execDNSCommand(ctx, pid, req, fmt.Sprintf("cp %s temp && sed -i 's/.*nameserver.*/nameserver %s/' temp && cat temp > %s", DNSServerConfFile, req.DnsServer, DNSServerConfFile))
+		if err != nil {
+			return encodeOutputToError(output, err)
+		}
+	} else {
+		// release this DNS chaos, and keep the chaos dns server if it's still used by others
+		if len(req.Name) != 0 {
+			output, err := execDNSCommand(ctx, pid, req, fmt.Sprintf("if [ -f %s ]; then grep -v -x -F '%s' %s > %s.tmp; mv %s.tmp %s && cat %s; fi", DNSServerRefsFile, req.Name, DNSServerRefsFile, DNSServerRefsFile, DNSServerRefsFile, DNSServerRefsFile, DNSServerRefsFile))
+			if err != nil {
+				return encodeOutputToError(output, err)
+			}
+			if remaining := strings.Fields(string(output)); len(remaining) != 0 {
+				log.Info("dns server is still used by other DNS chaos", "chaos", remaining)
+				return nil
+			}
 		}
 
-		cmd = processBuilder.Build()
-		output, err = cmd.CombinedOutput()
+		// umount the chaos config file, it's only mounted with `--dns-bind-mount`
+		output, err := execDNSCommand(ctx, pid, req, fmt.Sprintf("[ -f %s ] && echo mounted || true", DNSServerChaosFile))
 		if err != nil {
-			log.Error(err, "execute command error", "command", cmd.String(), "output", output)
-			return nil, encodeOutputToError(output, err)
+			return encodeOutputToError(output, err)
 		}
-		if len(output) != 0 {
-			log.Info("command output", "output", string(output))
+		if strings.TrimSpace(string(output)) == "mounted" {
+			output, err = execDNSProcess(ctx, pid, req, bpm.DefaultProcessBuilder("umount", DNSServerConfFile).EnableLocalMnt())
+			if err != nil {
+				return encodeOutputToError(output, err)
+			}
+			output, err = execDNSCommand(ctx, pid, req, fmt.Sprintf("rm -f %s", DNSServerChaosFile))
+			if err != nil {
+				return encodeOutputToError(output, err)
+			}
 		}
-	} else {
+
 		// recover the dns server's address
-		processBuilder := bpm.DefaultProcessBuilder("sh", "-c", fmt.Sprintf("ls %s.chaos.bak && cat %s.chaos.bak > %s || true", DNSServerConfFile, DNSServerConfFile, DNSServerConfFile)).SetContext(ctx)
-		if req.EnterNS {
-			processBuilder = processBuilder.SetNS(pid, bpm.MountNS)
+		output, err = execDNSCommand(ctx, pid, req, fmt.Sprintf("ls %s && cat %s > %s && rm %s; rm -f %s", DNSServerBackupFile, DNSServerBackupFile, DNSServerConfFile, DNSServerBackupFile, DNSServerRefsFile))
+		if err != nil {
+			return encodeOutputToError(output, err)
+		}
+
+		// the backup may be missing, so make sure the chaos dns server has been removed
+		if len(req.DnsServer) != 0 {
+			if err := verifyDNSServerRecovered(ctx, pid, req); err != nil {
+				log.Error(err, "verify dns server recovered")
+				return err
+			}
 		}
 
-		cmd := processBuilder.Build()
-		output, err := cmd.CombinedOutput()
+		output, err = execDNSCommand(ctx, pid, req, fmt.Sprintf("cat %s", DNSServerConfFile))
 		if err != nil {
-			log.Error(err, "execute command error", "command", cmd.String(), "output", output)
-			return nil, encodeOutputToError(output, err)
+			return encodeOutputToError(output, err)
 		}
-		if len(output) != 0 {
-			log.Info("command output", "output", string(output))
+		if err := s.snapshots.verify(snapshotKey, string(output)); err != nil {
+			log.Error(err, "verify dns server recovered")
+			return errcode.Error(errcode.RecoverMismatched, err)
 		}
 	}
 
-	return &empty.Empty{}, nil
+	return nil
+}
+
+// captureDNSServerConf captures the /etc/resolv.conf before the first DNS chaos is injected into the container
+func (s *DaemonServer) captureDNSServerConf(ctx context.Context, pid uint32, req *pb.SetDNSServerRequest, snapshotKey string) error {
+	// the config file has been modified or mounted over if the backup or the chaos config file exists
+	output, err := execDNSCommand(ctx, pid, req, fmt.Sprintf("if [ -f %s ] || [ -f %s ]; then echo injected; fi", DNSServerBackupFile, DNSServerChaosFile))
+	if err != nil {
+		return encodeOutputToError(output, err)
+	}
+	if strings.TrimSpace(string(output)) == "injected" {
+		return nil
+	}
+
+	output, err = execDNSCommand(ctx, pid, req, fmt.Sprintf("cat %s", DNSServerConfFile))
+	if err != nil {
+		return encodeOutputToError(output, err)
+	}
+	s.snapshots.capture(snapshotKey, string(output))
+	return nil
+}
+
+// bindMountDNSServerConf bind mounts a config file with the chaos dns server over the /etc/resolv.conf,
+// so that the original one is untouched. It returns false if the bind mount isn't possible.
+func bindMountDNSServerConf(ctx context.Context, pid uint32, req *pb.SetDNSServerRequest) (bool, error) {
+	// the chaos config file exists only if it has been mounted by another DNS chaos
+	output, err := execDNSCommand(ctx, pid, req, fmt.Sprintf("if [ -f %s ]; then echo mounted; else sed 's/.*nameserver.*/nameserver %s/' %s > %s; fi", DNSServerChaosFile, req.DnsServer, DNSServerConfFile, DNSServerChaosFile))
+	if err != nil {
+		return false, encodeOutputToError(output, err)
+	}
+	if strings.TrimSpace(string(output)) == "mounted" {
+		return true, nil
+	}
+
+	output, err = execDNSProcess(ctx, pid, req, bpm.DefaultProcessBuilder("mount", "--bind", DNSServerChaosFile, DNSServerConfFile).EnableLocalMnt())
+	if err != nil {
+		log.Error(encodeOutputToError(output, err), "fail to bind mount the config file of dns server")
+
+		output, err = execDNSCommand(ctx, pid, req, fmt.Sprintf("rm -f %s", DNSServerChaosFile))
+		if err != nil {
+			return false, encodeOutputToError(output, err)
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// verifyDNSServerRecovered returns an error if the chaos dns server is still in the /etc/resolv.conf
+func verifyDNSServerRecovered(ctx context.Context, pid uint32, req *pb.SetDNSServerRequest) error {
+	// grep exits with 1 if no line is selected
+	output, err := execDNSCommand(ctx, pid, req, fmt.Sprintf("grep -c -F -x 'nameserver %s' %s || [ $? -eq 1 ]", req.DnsServer, DNSServerConfFile))
+	if err != nil {
+		return encodeOutputToError(output, err)
+	}
+	if strings.TrimSpace(string(output)) != "0" {
+		return fmt.Errorf("fail to recover %s, the chaos dns server %s is still in use", DNSServerConfFile, req.DnsServer)
+	}
+
+	return nil
+}
+
+// execDNSCommand executes the shell command in the mount namespace of the container if required
+func execDNSCommand(ctx context.Context, pid uint32, req *pb.SetDNSServerRequest, command string) ([]byte, error) {
+	return execDNSProcess(ctx, pid, req, bpm.DefaultProcessBuilder("sh", "-c", command))
+}
+
+// execDNSProcess executes the process in the mount namespace of the container if required
+func execDNSProcess(ctx context.Context, pid uint32, req *pb.SetDNSServerRequest, processBuilder *bpm.ProcessBuilder) ([]byte, error) {
+	processBuilder = processBuilder.SetContext(ctx)
+	if req.EnterNS {
+		processBuilder = processBuilder.SetNS(pid, bpm.MountNS)
+	}
+
+	cmd := processBuilder.Build()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		log.Error(err, "execute command error", "command", cmd.String(), "output", output)
+		return output, err
+	}
+	if len(output) != 0 {
+		log.Info("command output", "output", string(output))
+	}
+
+	return output, nil
 }
@@ -0,0 +1,321 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/crclients"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/crclients/test"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+var _ = Describe("dns server", func() {
+	defer mock.With("MockContainerdClient", &test.MockClient{})()
+	s, _ := newDaemonServer(crclients.ContainerRuntimeContainerd)
+
+	Context("SetDNSServer", func() {
+		var (
+			dir      string
+			confFile string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "dns-server")
+			Expect(err).To(BeNil())
+			confFile = filepath.Join(dir, "resolv.conf")
+			Expect(ioutil.WriteFile(confFile, []byte("search default.svc.cluster.local\nnameserver 10.96.0.10\n"), 0644)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(dir)
+		})
+
+		// mockConfFile runs the commands against the conf file in the temp dir rather than /etc/resolv.conf
+		mockConfFile := func() mock.Finalizer {
+			return mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				Expect(cmd).To(Equal("sh"))
+				Expect(args[0]).To(Equal("-c"))
+				c := exec.Command("sh", "-c", strings.ReplaceAll(args[1], DNSServerConfFile, confFile))
+				c.Dir = dir
+				return c
+			})
+		}
+
+		It("should recover from the backup", func() {
+			defer mockConfFile()()
+
+			_, err := s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+				ContainerId: "containerd://container-id",
+				DnsServer:   "10.96.0.20",
+				Enable:      true,
+			})
+			Expect(err).To(BeNil())
+			content, err := ioutil.ReadFile(confFile)
+			Expect(err).To(BeNil())
+			Expect(string(content)).To(ContainSubstring("nameserver 10.96.0.20"))
+
+			_, err = s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+				ContainerId: "containerd://container-id",
+				DnsServer:   "10.96.0.20",
+				Enable:      false,
+			})
+			Expect(err).To(BeNil())
+			content, err = ioutil.ReadFile(confFile)
+			Expect(err).To(BeNil())
+			Expect(string(content)).To(ContainSubstring("nameserver 10.96.0.10"))
+			Expect(string(content)).ToNot(ContainSubstring("nameserver 10.96.0.20"))
+		})
+
+		It("should recover after the last overlapping DNS chaos is recovered", func() {
+			defer mockConfFile()()
+
+			setDNSServer := func(name string, enable bool) {
+				_, err := s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+					ContainerId: "containerd://container-id",
+					DnsServer:   "10.96.0.20",
+					Enable:      enable,
+					Name:        name,
+				})
+				Expect(err).To(BeNil())
+			}
+			readConfFile := func() string {
+				content, err := ioutil.ReadFile(confFile)
+				Expect(err).To(BeNil())
+				return string(content)
+			}
+
+			setDNSServer("default/dns-a", true)
+			setDNSServer("default/dns-b", true)
+			// the same DNS chaos may be applied again
+			setDNSServer("default/dns-b", true)
+			backup, err := ioutil.ReadFile(confFile + ".chaos.bak")
+			Expect(err).To(BeNil())
+			Expect(string(backup)).To(ContainSubstring("nameserver 10.96.0.10"))
+
+			setDNSServer("default/dns-a", false)
+			Expect(readConfFile()).To(ContainSubstring("nameserver 10.96.0.20"))
+
+			setDNSServer("default/dns-b", false)
+			Expect(readConfFile()).To(ContainSubstring("nameserver 10.96.0.10"))
+			Expect(readConfFile()).ToNot(ContainSubstring("nameserver 10.96.0.20"))
+			Expect(confFile + ".chaos.bak").ToNot(BeAnExistingFile())
+			Expect(confFile + ".chaos.refs").ToNot(BeAnExistingFile())
+
+			// recovering again is a no-op
+			setDNSServer("default/dns-b", false)
+			Expect(readConfFile()).To(ContainSubstring("nameserver 10.96.0.10"))
+		})
+
+		It("should fail with the reason of the failure", func() {
+			defer mock.With("LoadContainerError", errors.New("container not found"))()
+
+			_, err := s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+				ContainerId: "containerd://container-id",
+				DnsServer:   "10.96.0.20",
+				Enable:      true,
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+			reason, ok := errcode.ReasonOf(err)
+			Expect(ok).To(BeTrue())
+			Expect(reason).To(Equal(errcode.ContainerNotFound))
+			Expect(errcode.Retryable(err)).To(BeTrue())
+		})
+
+		It("should reject the request without the dns server", func() {
+			_, err := s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+				ContainerId: "containerd://container-id",
+				Enable:      true,
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			reason, _ := errcode.ReasonOf(err)
+			Expect(reason).To(Equal(errcode.InvalidRequest))
+			Expect(errcode.Retryable(err)).To(BeFalse())
+		})
+
+		It("should fail to recover without the backup", func() {
+			defer mockConfFile()()
+
+			_, err := s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+				ContainerId: "containerd://container-id",
+				DnsServer:   "10.96.0.20",
+				Enable:      true,
+			})
+			Expect(err).To(BeNil())
+			Expect(os.Remove(confFile + ".chaos.bak")).To(Succeed())
+
+			_, err = s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+				ContainerId: "containerd://container-id",
+				DnsServer:   "10.96.0.20",
+				Enable:      false,
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("10.96.0.20 is still in use"))
+			reason, _ := errcode.ReasonOf(err)
+			Expect(reason).To(Equal(errcode.RuleApplyFailed))
+		})
+
+		It("should detect the recovered config file mismatched with the snapshot", func() {
+			defer mockConfFile()()
+
+			req := &pb.SetDNSServerRequest{
+				ContainerId: "containerd://mismatched-container-id",
+				DnsServer:   "10.96.0.20",
+				Enable:      true,
+			}
+			_, err := s.SetDNSServer(context.TODO(), req)
+			Expect(err).To(BeNil())
+			// the backup is corrupted, so the original search domain is lost on recovery
+			Expect(ioutil.WriteFile(confFile+".chaos.bak", []byte("nameserver 10.96.0.10\n"), 0644)).To(Succeed())
+
+			req.Enable = false
+			_, err = s.SetDNSServer(context.TODO(), req)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring(`missing: ["search default.svc.cluster.local"]`))
+			reason, _ := errcode.ReasonOf(err)
+			Expect(reason).To(Equal(errcode.RecoverMismatched))
+
+			// the mismatch is reported once
+			_, err = s.SetDNSServer(context.TODO(), req)
+			Expect(err).To(BeNil())
+		})
+
+		Context("with bind mount", func() {
+			var (
+				// hidden is the original content of the conf file hidden by the bind mount
+				hidden      []byte
+				mountFails  bool
+				mountCalls  int
+				umountCalls int
+			)
+
+			BeforeEach(func() {
+				s.dnsBindMount = true
+				hidden = nil
+				mountFails = false
+				mountCalls = 0
+				umountCalls = 0
+			})
+
+			AfterEach(func() {
+				s.dnsBindMount = false
+			})
+
+			// mockBindMount simulates the bind mount by replacing the content of the conf file, which is restored on umount
+			mockBindMount := func() mock.Finalizer {
+				return mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+					switch cmd {
+					case "mount":
+						Expect(args).To(Equal([]string{"--bind", DNSServerChaosFile, DNSServerConfFile}))
+						mountCalls++
+						if mountFails {
+							return exec.Command("sh", "-c", "echo 'mount: permission denied'; exit 32")
+						}
+
+						original, err := ioutil.ReadFile(confFile)
+						Expect(err).To(BeNil())
+						chaos, err := ioutil.ReadFile(confFile + ".chaos")
+						Expect(err).To(BeNil())
+						Expect(ioutil.WriteFile(confFile, chaos, 0644)).To(Succeed())
+						hidden = original
+						return exec.Command("true")
+					case "umount":
+						Expect(args).To(Equal([]string{DNSServerConfFile}))
+						Expect(hidden).ToNot(BeNil())
+						umountCalls++
+
+						Expect(ioutil.WriteFile(confFile, hidden, 0644)).To(Succeed())
+						hidden = nil
+						return exec.Command("true")
+					}
+
+					Expect(cmd).To(Equal("sh"))
+					Expect(args[0]).To(Equal("-c"))
+					c := exec.Command("sh", "-c", strings.ReplaceAll(args[1], DNSServerConfFile, confFile))
+					c.Dir = dir
+					return c
+				})
+			}
+
+			setDNSServer := func(name string, enable bool) {
+				_, err := s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+					ContainerId: "containerd://container-id",
+					DnsServer:   "10.96.0.20",
+					Enable:      enable,
+					Name:        name,
+				})
+				Expect(err).To(BeNil())
+			}
+			readConfFile := func() string {
+				content, err := ioutil.ReadFile(confFile)
+				Expect(err).To(BeNil())
+				return string(content)
+			}
+
+			It("should umount after the last DNS chaos is recovered", func() {
+				defer mockBindMount()()
+
+				setDNSServer("default/dns-a", true)
+				setDNSServer("default/dns-b", true)
+				Expect(mountCalls).To(Equal(1))
+				Expect(readConfFile()).To(ContainSubstring("nameserver 10.96.0.20"))
+				Expect(string(hidden)).To(ContainSubstring("nameserver 10.96.0.10"))
+				Expect(confFile + ".chaos.bak").ToNot(BeAnExistingFile())
+
+				setDNSServer("default/dns-a", false)
+				Expect(umountCalls).To(Equal(0))
+				Expect(readConfFile()).To(ContainSubstring("nameserver 10.96.0.20"))
+
+				setDNSServer("default/dns-b", false)
+				Expect(umountCalls).To(Equal(1))
+				Expect(readConfFile()).To(ContainSubstring("nameserver 10.96.0.10"))
+				Expect(readConfFile()).ToNot(ContainSubstring("nameserver 10.96.0.20"))
+				Expect(confFile + ".chaos").ToNot(BeAnExistingFile())
+				Expect(confFile + ".chaos.refs").ToNot(BeAnExistingFile())
+			})
+
+			It("should fall back to modify the conf file in place", func() {
+				defer mockBindMount()()
+				mountFails = true
+
+				setDNSServer("default/dns-a", true)
+				Expect(mountCalls).To(Equal(1))
+				Expect(readConfFile()).To(ContainSubstring("nameserver 10.96.0.20"))
+				Expect(confFile + ".chaos").ToNot(BeAnExistingFile())
+				Expect(confFile + ".chaos.bak").To(BeAnExistingFile())
+
+				setDNSServer("default/dns-a", false)
+				Expect(umountCalls).To(Equal(0))
+				Expect(readConfFile()).To(ContainSubstring("nameserver 10.96.0.10"))
+				Expect(readConfFile()).ToNot(ContainSubstring("nameserver 10.96.0.20"))
+			})
+		})
+	})
+})
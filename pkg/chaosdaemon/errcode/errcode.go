@@ -0,0 +1,137 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errcode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reason tells why a request to the chaos daemon fails. It's carried in the details of the gRPC status,
+// so that the controller could tell the failures apart without parsing the message.
+type Reason string
+
+const (
+	// ContainerNotFound means the target container cannot be found by the container runtime
+	ContainerNotFound Reason = "ContainerNotFound"
+
+	// NamespaceEnterFailed means the namespace of the target container cannot be entered
+	NamespaceEnterFailed Reason = "NamespaceEnterFailed"
+
+	// RuleApplyFailed means the rules, e.g. tc qdiscs, iptables chains or the dns server, cannot be applied
+	RuleApplyFailed Reason = "RuleApplyFailed"
+
+	// InvalidRequest means the request is malformed
+	InvalidRequest Reason = "InvalidRequest"
+
+	// RecoverMismatched means the state recovered by the chaos doesn't match the one before the chaos is applied
+	RecoverMismatched Reason = "RecoverMismatched"
+)
+
+var reasonCodes = map[Reason]codes.Code{
+	ContainerNotFound:    codes.NotFound,
+	NamespaceEnterFailed: codes.FailedPrecondition,
+	RuleApplyFailed:      codes.Internal,
+	InvalidRequest:       codes.InvalidArgument,
+	RecoverMismatched:    codes.Aborted,
+}
+
+// Code returns the gRPC status code of the reason
+func (r Reason) Code() codes.Code {
+	if code, ok := reasonCodes[r]; ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+// Retryable returns true if the failure may disappear by itself, e.g. the container is restarting or
+// the device is busy. Only the malformed requests will fail again on retry.
+func (r Reason) Retryable() bool {
+	return r != InvalidRequest
+}
+
+// Error returns a gRPC status error with the reason in its details
+func Error(reason Reason, err error) error {
+	return newStatus(reason, err.Error()).Err()
+}
+
+// Errorf returns a gRPC status error with the reason in its details
+func Errorf(reason Reason, format string, a ...interface{}) error {
+	return newStatus(reason, fmt.Sprintf(format, a...)).Err()
+}
+
+func newStatus(reason Reason, message string) *status.Status {
+	s := status.New(reason.Code(), message)
+	withReason, err := s.WithDetails(&wrappers.StringValue{Value: string(reason)})
+	if err != nil {
+		return s
+	}
+	return withReason
+}
+
+// ReasonOf returns the reason carried by the gRPC status in the chain of the error. The error may be
+// wrapped by `errors.Wrap` or `fmt.Errorf` after it's returned from the chaos daemon.
+func ReasonOf(err error) (Reason, bool) {
+	s, ok := statusOf(err)
+	if !ok {
+		return "", false
+	}
+	for _, detail := range s.Details() {
+		if reason, ok := detail.(*wrappers.StringValue); ok {
+			return Reason(reason.Value), true
+		}
+	}
+	return "", false
+}
+
+// Unreachable returns true if the request fails because the chaos daemon cannot be reached, e.g. it's down or
+// its address cannot be found. Such a failure is reported by the gRPC client, so it carries no reason.
+func Unreachable(err error) bool {
+	s, ok := statusOf(err)
+	if !ok || s.Code() != codes.Unavailable {
+		return false
+	}
+	_, withReason := ReasonOf(err)
+	return !withReason
+}
+
+// statusOf returns the first gRPC status in the chain of the error
+func statusOf(err error) (*status.Status, bool) {
+	for err != nil {
+		if s, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+			return s.GRPCStatus(), true
+		}
+
+		if cause, ok := err.(interface{ Cause() error }); ok {
+			err = cause.Cause()
+		} else {
+			err = errors.Unwrap(err)
+		}
+	}
+	return nil, false
+}
+
+// Retryable returns true if the request which fails with the error is worth retrying. The errors
+// without a reason are always retried.
+func Retryable(err error) bool {
+	reason, ok := ReasonOf(err)
+	if !ok {
+		return true
+	}
+	return reason.Retryable()
+}
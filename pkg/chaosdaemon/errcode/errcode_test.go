@@ -0,0 +1,80 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errcode
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReasonOf(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	err := Errorf(ContainerNotFound, "container %s not found", "c0")
+	g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+	g.Expect(err.Error()).To(ContainSubstring("container c0 not found"))
+
+	// the reason is kept after the error is wrapped by the controller
+	for _, wrapped := range []error{
+		err,
+		errors.Wrap(err, "set dns server"),
+		fmt.Errorf("apply chaos: %w", err),
+	} {
+		reason, ok := ReasonOf(wrapped)
+		g.Expect(ok).To(BeTrue())
+		g.Expect(reason).To(Equal(ContainerNotFound))
+		g.Expect(Retryable(wrapped)).To(BeTrue())
+	}
+
+	err = Error(RuleApplyFailed, errors.New("RTNETLINK answers: Invalid argument"))
+	g.Expect(status.Code(err)).To(Equal(codes.Internal))
+	g.Expect(Retryable(errors.Wrap(err, "set tcs"))).To(BeTrue())
+
+	err = Errorf(InvalidRequest, "unknown action %s", "corrupt")
+	g.Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	g.Expect(Retryable(errors.Wrap(err, "set tcs"))).To(BeFalse())
+
+	// the errors without a reason are always retried
+	for _, plain := range []error{
+		errors.New("connection refused"),
+		status.Error(codes.Unavailable, "transport is closing"),
+	} {
+		_, ok := ReasonOf(plain)
+		g.Expect(ok).To(BeFalse())
+		g.Expect(Retryable(plain)).To(BeTrue())
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// the failures of connecting to the chaos daemon are reported by the gRPC client
+	unavailable := status.Error(codes.Unavailable, "connection error: dial tcp 10.0.0.1:31767: connect: connection refused")
+	g.Expect(Unreachable(unavailable)).To(BeTrue())
+	g.Expect(Unreachable(errors.Wrap(unavailable, "recover chaos"))).To(BeTrue())
+
+	// the failures reported by the chaos daemon itself mean it's reachable
+	for _, err := range []error{
+		errors.New("connection refused"),
+		Errorf(ContainerNotFound, "container %s not found", "c0"),
+		status.Error(codes.Internal, "RTNETLINK answers: Invalid argument"),
+	} {
+		g.Expect(Unreachable(err)).To(BeFalse(), err.Error())
+	}
+}
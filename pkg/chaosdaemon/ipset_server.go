@@ -21,6 +21,7 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -36,7 +37,7 @@ func (s *DaemonServer) FlushIPSets(ctx context.Context, req *pb.IPSetsRequest) (
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
 	if err != nil {
 		log.Error(err, "error while getting PID")
-		return nil, err
+		return nil, errcode.Error(errcode.ContainerNotFound, err)
 	}
 
 	for _, ipset := range req.Ipsets {
@@ -53,7 +54,7 @@ func (s *DaemonServer) FlushIPSets(ctx context.Context, req *pb.IPSetsRequest) (
 		err := flushIPSet(ctx, req.EnterNS, pid, ipset)
 		s.IPSetLocker.Unlock(ipset.Name)
 		if err != nil {
-			return nil, err
+			return nil, errcode.Error(errcode.RuleApplyFailed, err)
 		}
 	}
 
@@ -21,6 +21,7 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -28,6 +29,7 @@ const (
 	iptablesCmd = "iptables"
 
 	iptablesChainAlreadyExistErr = "iptables: Chain already exists."
+	iptablesChainNotFoundErr     = "No chain/target/match by that name."
 )
 
 func (s *DaemonServer) SetIptablesChains(ctx context.Context, req *pb.IptablesChainsRequest) (*empty.Empty, error) {
@@ -36,20 +38,54 @@ func (s *DaemonServer) SetIptablesChains(ctx context.Context, req *pb.IptablesCh
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
 	if err != nil {
 		log.Error(err, "error while getting PID")
-		return nil, err
+		return nil, errcode.Error(errcode.ContainerNotFound, err)
 	}
 
 	iptables := buildIptablesClient(ctx, req.EnterNS, pid)
+	snapshotKey := snapshotKey(iptablesSnapshotKind, req.ContainerId)
+	if len(req.Chains) > 0 {
+		state, err := iptables.state()
+		if err != nil {
+			log.Error(err, "error while capturing iptables")
+			return nil, errcode.Error(errcode.RuleApplyFailed, err)
+		}
+		// the rules which have been injected by the chaos aren't the original state of the container
+		if !strings.Contains(state, "-A CHAOS-") {
+			s.snapshots.capture(snapshotKey, state)
+		}
+	}
+
 	err = iptables.initializeEnv()
 	if err != nil {
 		log.Error(err, "error while initializing iptables")
-		return nil, err
+		return nil, errcode.Error(errcode.RuleApplyFailed, err)
 	}
 
 	err = iptables.setIptablesChains(req.Chains)
 	if err != nil {
 		log.Error(err, "error while setting iptables chains")
-		return nil, err
+		return nil, errcode.Error(errcode.RuleApplyFailed, err)
+	}
+
+	// only the chains removed from the request are recovered, the others, including the ones of the tc, keep working
+	err = iptables.removeStaleChains(isPartitionChain, req.Chains)
+	if err != nil {
+		log.Error(err, "error while removing stale iptables chains")
+		return nil, errcode.Error(errcode.RuleApplyFailed, err)
+	}
+	s.activeRules.set(iptablesRuleKind, req.ContainerId, len(req.Chains))
+
+	// all the chains are recovered, which should restore the rules before the chaos
+	if len(req.Chains) == 0 {
+		state, err := iptables.state()
+		if err != nil {
+			log.Error(err, "error while capturing iptables")
+			return nil, errcode.Error(errcode.RuleApplyFailed, err)
+		}
+		if err := s.snapshots.verify(snapshotKey, state); err != nil {
+			log.Error(err, "error while verifying recovered iptables")
+			return nil, errcode.Error(errcode.RecoverMismatched, err)
+		}
 	}
 
 	return &empty.Empty{}, nil
@@ -120,6 +156,11 @@ func (iptables *iptablesClient) setIptablesChain(chain *pb.Chain) error {
 		}
 	}
 
+	// the packets are matched randomly with the probability, e.g. to delay a part of them
+	if chain.Probability > 0 {
+		protocolAndPort = strings.TrimSpace(fmt.Sprintf("%s -m statistic --mode random --probability %f", protocolAndPort, chain.Probability/100))
+	}
+
 	rules := []string{}
 
 	if len(chain.Ipsets) == 0 {
@@ -146,7 +187,7 @@ func (iptables *iptablesClient) setIptablesChain(chain *pb.Chain) error {
 			return err
 		}
 	} else if chain.Direction == pb.Chain_OUTPUT {
-		iptables.ensureRule(&iptablesChain{
+		err := iptables.ensureRule(&iptablesChain{
 			Name: "CHAOS-OUTPUT",
 		}, "-A CHAOS-OUTPUT -j "+chain.Name)
 		if err != nil {
@@ -158,16 +199,15 @@ func (iptables *iptablesClient) setIptablesChain(chain *pb.Chain) error {
 	return nil
 }
 
+// initializeEnv creates the CHAOS-INPUT and CHAOS-OUTPUT chains if they don't exist. The rules in them are kept, as
+// they're shared by the chains of all the chaos on the container, and the stale ones are removed by removeStaleChains
 func (iptables *iptablesClient) initializeEnv() error {
 	for _, direction := range []string{"INPUT", "OUTPUT"} {
 		chainName := "CHAOS-" + direction
 
-		err := iptables.createNewChain(&iptablesChain{
-			Name:  chainName,
-			Rules: []string{},
-		})
-		if err != nil {
-			return err
+		out, err := iptables.run("-w", "-N", chainName)
+		if err != nil && !strings.Contains(string(out), iptablesChainAlreadyExistErr) {
+			return encodeOutputToError(out, err)
 		}
 
 		iptables.ensureRule(&iptablesChain{
@@ -179,6 +219,66 @@ func (iptables *iptablesClient) initializeEnv() error {
 	return nil
 }
 
+// removeStaleChains removes the chains owned by the caller but not in the chains any more, with the rules jumping
+// to them. It's idempotent, and the chains of the others are left untouched.
+func (iptables *iptablesClient) removeStaleChains(owned func(name string) bool, chains []*pb.Chain) error {
+	wanted := make(map[string]bool)
+	for _, chain := range chains {
+		wanted[chain.Name] = true
+	}
+
+	for _, base := range []string{"CHAOS-INPUT", "CHAOS-OUTPUT"} {
+		out, err := iptables.run("-w", "-S", base)
+		if err != nil {
+			if strings.Contains(string(out), iptablesChainNotFoundErr) {
+				// nothing has been injected
+				continue
+			}
+			return encodeOutputToError(out, err)
+		}
+
+		for _, rule := range strings.Split(string(out), "\n") {
+			// the rule jumping to the chain looks like "-A CHAOS-INPUT -j INPUT/foo"
+			fields := strings.Fields(rule)
+			if len(fields) != 4 || fields[0] != "-A" || fields[2] != "-j" {
+				continue
+			}
+			name := fields[3]
+			if !owned(name) || wanted[name] {
+				continue
+			}
+
+			log.Info("removing stale iptables chain", "chain", name)
+			for _, args := range [][]string{{"-w", "-D", base, "-j", name}, {"-w", "-F", name}, {"-w", "-X", name}} {
+				if out, err := iptables.run(args...); err != nil {
+					return encodeOutputToError(out, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// run executes iptables with the arguments in the network namespace of the container
+func (iptables *iptablesClient) run(args ...string) ([]byte, error) {
+	processBuilder := bpm.DefaultProcessBuilder(iptablesCmd, args...).SetContext(iptables.ctx)
+	if iptables.enterNS {
+		processBuilder = processBuilder.SetNS(iptables.pid, bpm.NetNS)
+	}
+	return processBuilder.Build().CombinedOutput()
+}
+
+// isPartitionChain returns whether the chain is created by SetIptablesChains for the partition
+func isPartitionChain(name string) bool {
+	return strings.HasPrefix(name, "INPUT/") || strings.HasPrefix(name, "OUTPUT/")
+}
+
+// isTcChain returns whether the chain is created by SetTcs to classify the packets
+func isTcChain(name string) bool {
+	return strings.HasPrefix(name, "TC-TABLES-")
+}
+
 // createNewChain will cover existing chain
 func (iptables *iptablesClient) createNewChain(chain *iptablesChain) error {
 	processBuilder := bpm.DefaultProcessBuilder(iptablesCmd, "-w", "-N", chain.Name).SetContext(iptables.ctx)
@@ -260,3 +360,51 @@ func (iptables *iptablesClient) flushIptablesChain(chain *iptablesChain) error {
 
 	return nil
 }
+
+// state returns the iptables rules of the container except the ones in the chains created by the chaos, whose
+// rules are left over but unreachable once the CHAOS-INPUT and CHAOS-OUTPUT chains are flushed
+func (iptables *iptablesClient) state() (string, error) {
+	processBuilder := bpm.DefaultProcessBuilder(iptablesCmd, "-w", "-S").SetContext(iptables.ctx)
+	if iptables.enterNS {
+		processBuilder = processBuilder.SetNS(iptables.pid, bpm.NetNS)
+	}
+	cmd := processBuilder.Build()
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", encodeOutputToError(out, err)
+	}
+
+	return filterChaosRules(string(out)), nil
+}
+
+// filterChaosRules removes the chains created by the chaos and the rules jumping to them from the base chains
+func filterChaosRules(rules string) string {
+	isChaosChain := func(name string) bool {
+		return strings.HasPrefix(name, "CHAOS-") || isPartitionChain(name) || isTcChain(name)
+	}
+
+	var kept []string
+	for _, rule := range strings.Split(rules, "\n") {
+		rule = strings.TrimSpace(rule)
+		fields := strings.Fields(rule)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) >= 2 && fields[0] == "-N" && isChaosChain(fields[1]) {
+			continue
+		}
+		if len(fields) >= 2 && fields[0] == "-A" && isChaosChain(fields[1]) && !strings.HasPrefix(fields[1], "CHAOS-") {
+			continue
+		}
+		// the chains of the tc are recovered by SetTcs rather than SetIptablesChains
+		if len(fields) == 4 && fields[0] == "-A" && strings.HasPrefix(fields[1], "CHAOS-") && isTcChain(fields[3]) {
+			continue
+		}
+		if rule == "-A INPUT -j CHAOS-INPUT" || rule == "-A OUTPUT -j CHAOS-OUTPUT" {
+			continue
+		}
+		kept = append(kept, rule)
+	}
+
+	return strings.Join(kept, "\n")
+}
@@ -15,10 +15,15 @@ package chaosdaemon
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -118,4 +123,188 @@ exit 1
 			Expect(err).ToNot(BeNil())
 		})
 	})
+
+	Context("overlapping chaos", func() {
+		var statePath string
+
+		BeforeEach(func() {
+			dir, err := ioutil.TempDir("", "iptables")
+			Expect(err).To(BeNil())
+			statePath = filepath.Join(dir, "state.json")
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(filepath.Dir(statePath))).To(Succeed())
+		})
+
+		It("recovers one chaos without breaking the other", func() {
+			defer mock.With("pid", 9527)()
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				// the tc commands always succeed, and the iptables commands are run by the fake iptables
+				args = append([]string{cmd}, args...)
+				for i, arg := range args {
+					if arg == iptablesCmd {
+						fake := exec.Command(os.Args[0], append([]string{"-test.run=TestFakeIptables", "--"}, args[i+1:]...)...)
+						fake.Env = append(os.Environ(), "FAKE_IPTABLES_STATE="+statePath)
+						return fake
+					}
+				}
+				return exec.Command("echo", "-n")
+			})()
+			chain := func(name string, direction pb.Chain_Direction, ipset string) *pb.Chain {
+				return &pb.Chain{Name: name, Direction: direction, Ipsets: []string{ipset}, Target: "DROP"}
+			}
+			setChains := func(chains ...*pb.Chain) {
+				_, err := s.SetIptablesChains(context.TODO(), &pb.IptablesChainsRequest{
+					Chains:      chains,
+					ContainerId: "containerd://overlapping",
+					EnterNS:     true,
+				})
+				Expect(err).To(BeNil())
+			}
+			setTcs := func(tcs ...*pb.Tc) {
+				_, err := s.SetTcs(context.TODO(), &pb.TcsRequest{
+					Tcs:         tcs,
+					ContainerId: "containerd://overlapping",
+					EnterNS:     true,
+				})
+				Expect(err).To(BeNil())
+			}
+			chainA := chain("INPUT/chaos-a", pb.Chain_INPUT, "chaos-a_src")
+			chainB := chain("OUTPUT/chaos-b", pb.Chain_OUTPUT, "chaos-b_tgt")
+			delay := &pb.Tc{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 1000}, Ipset: "chaos-c_tgt"}
+
+			// the partitions of chaos a and b, and the delay of chaos c are injected into the same container
+			setChains(chainA, chainB)
+			setTcs(delay)
+			state := readFakeIptables(statePath)
+			Expect(state["CHAOS-INPUT"]).To(ConsistOf("-A CHAOS-INPUT -j INPUT/chaos-a"))
+			Expect(state["CHAOS-OUTPUT"]).To(ConsistOf("-A CHAOS-OUTPUT -j OUTPUT/chaos-b", "-A CHAOS-OUTPUT -j TC-TABLES-0"))
+
+			// recovering chaos a leaves chaos b and c working, no matter how many times it's recovered
+			for i := 0; i < 2; i++ {
+				setChains(chainB)
+				state = readFakeIptables(statePath)
+				Expect(state).ToNot(HaveKey("INPUT/chaos-a"))
+				Expect(state["CHAOS-INPUT"]).To(BeEmpty())
+				Expect(state["CHAOS-OUTPUT"]).To(ConsistOf("-A CHAOS-OUTPUT -j OUTPUT/chaos-b", "-A CHAOS-OUTPUT -j TC-TABLES-0"))
+				Expect(state["OUTPUT/chaos-b"]).To(ConsistOf("-A OUTPUT/chaos-b -m set --match-set chaos-b_tgt dst -j DROP -w 5"))
+				Expect(state["TC-TABLES-0"]).To(ConsistOf("-A TC-TABLES-0 -m set --match-set chaos-c_tgt dst -j CLASSIFY --set-class 1:4 -w 5"))
+			}
+
+			// recovering chaos c leaves chaos b working
+			setTcs()
+			state = readFakeIptables(statePath)
+			Expect(state).ToNot(HaveKey("TC-TABLES-0"))
+			Expect(state["CHAOS-OUTPUT"]).To(ConsistOf("-A CHAOS-OUTPUT -j OUTPUT/chaos-b"))
+
+			// the delay of chaos c is kept while all the partitions are recovered
+			setTcs(delay)
+			setChains()
+			state = readFakeIptables(statePath)
+			Expect(state).ToNot(HaveKey("OUTPUT/chaos-b"))
+			Expect(state["CHAOS-OUTPUT"]).To(ConsistOf("-A CHAOS-OUTPUT -j TC-TABLES-0"))
+		})
+	})
 })
+
+// readFakeIptables reads the chains and their rules of the fake iptables
+func readFakeIptables(path string) map[string][]string {
+	state := map[string][]string{}
+	data, err := ioutil.ReadFile(path)
+	Expect(err).To(BeNil())
+	Expect(json.Unmarshal(data, &state)).To(Succeed())
+	return state
+}
+
+// TestFakeIptables runs as the fake iptables with the arguments after "--", which keeps the chains and their rules
+// in the file of FAKE_IPTABLES_STATE
+func TestFakeIptables(t *testing.T) {
+	path := os.Getenv("FAKE_IPTABLES_STATE")
+	if len(path) == 0 {
+		return
+	}
+
+	state := map[string][]string{"INPUT": {}, "OUTPUT": {}}
+	if data, err := ioutil.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &state); err != nil {
+			panic(err)
+		}
+	}
+	fail := func(msg string) {
+		fmt.Println(msg)
+		os.Exit(1)
+	}
+
+	var args []string
+	for i, arg := range os.Args {
+		if arg == "--" {
+			args = os.Args[i+1:]
+			break
+		}
+	}
+	if len(args) > 0 && args[0] == "-w" {
+		args = args[1:]
+	}
+	if len(args) < 1 {
+		fail("no command")
+	}
+	_, exist := state[args[len(args)-1]]
+	if len(args) > 1 {
+		_, exist = state[args[1]]
+	}
+
+	switch args[0] {
+	case "-N":
+		if exist {
+			fail(iptablesChainAlreadyExistErr)
+		}
+		state[args[1]] = []string{}
+	case "-F", "-X", "-A", "-D":
+		if !exist {
+			fail("iptables: " + iptablesChainNotFoundErr)
+		}
+		rule := strings.Join(append([]string{"-A"}, args[1:]...), " ")
+		switch args[0] {
+		case "-F":
+			state[args[1]] = []string{}
+		case "-X":
+			delete(state, args[1])
+		case "-A":
+			state[args[1]] = append(state[args[1]], rule)
+		case "-D":
+			var kept []string
+			for _, r := range state[args[1]] {
+				if r != rule {
+					kept = append(kept, r)
+				}
+			}
+			state[args[1]] = kept
+		}
+	case "-S":
+		var lines []string
+		for name, rules := range state {
+			if len(args) > 1 && name != args[1] {
+				continue
+			}
+			if name == "INPUT" || name == "OUTPUT" {
+				lines = append(lines, "-P "+name+" ACCEPT")
+			} else {
+				lines = append(lines, "-N "+name)
+			}
+			lines = append(lines, rules...)
+		}
+		fmt.Println(strings.Join(lines, "\n"))
+	default:
+		fail("unknown command " + args[0])
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		panic(err)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	tcRuleKind       = "tc"
+	iptablesRuleKind = "iptables"
+	dnsRuleKind      = "dns"
+	timeRuleKind     = "time"
+	stressRuleKind   = "stress"
+)
+
+var activeRulesDesc = prometheus.NewDesc(
+	"chaos_daemon_active_rules",
+	"Number of the rules applied by the chaos daemon and not recovered yet",
+	[]string{"kind"}, nil,
+)
+
+// activeRules counts the rules applied in each container, e.g. the tc qdiscs or the iptables chains.
+// The rules of a container are replaced as a whole by every request, so they are counted per container.
+type activeRules struct {
+	sync.Mutex
+
+	// rules are keyed by the kind of the rules and the container id
+	rules map[string]map[string]int
+}
+
+// set records the number of the rules of the kind in the container, zero means they are all recovered
+func (r *activeRules) set(kind string, containerID string, count int) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.rules == nil {
+		r.rules = make(map[string]map[string]int)
+	}
+	if count == 0 {
+		delete(r.rules[kind], containerID)
+		return
+	}
+	if r.rules[kind] == nil {
+		r.rules[kind] = make(map[string]int)
+	}
+	r.rules[kind][containerID] = count
+}
+
+// count returns the number of the rules of the kind in all the containers
+func (r *activeRules) count(kind string) int {
+	r.Lock()
+	defer r.Unlock()
+
+	total := 0
+	for _, count := range r.rules[kind] {
+		total += count
+	}
+	return total
+}
+
+// metricsCollector collects the active rules of the daemon server
+type metricsCollector struct {
+	server *DaemonServer
+}
+
+// Describe implements prometheus.Collector
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeRulesDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, kind := range []string{tcRuleKind, iptablesRuleKind, dnsRuleKind, timeRuleKind} {
+		ch <- prometheus.MustNewConstMetric(activeRulesDesc, prometheus.GaugeValue,
+			float64(c.server.activeRules.count(kind)), kind)
+	}
+
+	stressors := 0
+	c.server.stressors.Range(func(_, _ interface{}) bool {
+		stressors++
+		return true
+	})
+	ch <- prometheus.MustNewConstMetric(activeRulesDesc, prometheus.GaugeValue, float64(stressors), stressRuleKind)
+}
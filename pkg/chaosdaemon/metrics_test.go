@@ -0,0 +1,139 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/crclients"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/crclients/test"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+// gatherValue returns the value of the metric with the labels, or nil if it's not found
+func gatherValue(g prometheus.Gatherer, name string, labels map[string]string) *float64 {
+	families, err := g.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if !matchLabels(metric, labels) {
+				continue
+			}
+			var value float64
+			switch {
+			case metric.GetCounter() != nil:
+				value = metric.GetCounter().GetValue()
+			case metric.GetGauge() != nil:
+				value = metric.GetGauge().GetValue()
+			}
+			return &value
+		}
+	}
+	return nil
+}
+
+func matchLabels(metric *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, pair := range metric.GetLabel() {
+		if value, ok := labels[pair.GetName()]; ok && value == pair.GetValue() {
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
+
+var _ = Describe("metrics", func() {
+	Context("rpc metrics", func() {
+		It("should count the rpc by method", func() {
+			defer mock.With("MockContainerdClient", &test.MockClient{})()
+			reg := prometheus.NewRegistry()
+			s, err := newGRPCServer(&Config{Runtime: crclients.ContainerRuntimeContainerd}, reg)
+			Expect(err).ToNot(HaveOccurred())
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			go s.Serve(listener)
+			defer s.Stop()
+
+			conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			handled := map[string]string{"grpc_method": "ContainerGetPid", "grpc_code": "OK"}
+			Expect(*gatherValue(reg, "grpc_server_handled_total", handled)).To(BeZero())
+
+			_, err = pb.NewChaosDaemonClient(conn).ContainerGetPid(context.TODO(), &pb.ContainerRequest{
+				Action:      &pb.ContainerAction{Action: pb.ContainerAction_GETPID},
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*gatherValue(reg, "grpc_server_handled_total", handled)).To(Equal(float64(1)))
+
+			// the other methods are untouched
+			Expect(*gatherValue(reg, "grpc_server_handled_total",
+				map[string]string{"grpc_method": "SetDNSServer", "grpc_code": "OK"})).To(BeZero())
+		})
+	})
+})
+
+func TestActiveRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := &DaemonServer{}
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(&metricsCollector{server: s})
+
+	s.activeRules.set(tcRuleKind, "containerd://c0", 2)
+	s.activeRules.set(tcRuleKind, "containerd://c1", 1)
+	s.activeRules.set(dnsRuleKind, "containerd://c0", 1)
+	s.stressors.Store("stressor", struct{}{})
+
+	value := func(kind string) float64 {
+		families, err := reg.Gather()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(families).To(HaveLen(1))
+		for _, metric := range families[0].GetMetric() {
+			if matchLabels(metric, map[string]string{"kind": kind}) {
+				return metric.GetGauge().GetValue()
+			}
+		}
+		t.Fatalf("no active rules of %s", kind)
+		return 0
+	}
+	g.Expect(value(tcRuleKind)).To(Equal(float64(3)))
+	g.Expect(value(dnsRuleKind)).To(Equal(float64(1)))
+	g.Expect(value(iptablesRuleKind)).To(BeZero())
+	g.Expect(value(stressRuleKind)).To(Equal(float64(1)))
+
+	// the rules of the container are replaced as a whole
+	s.activeRules.set(tcRuleKind, "containerd://c0", 1)
+	g.Expect(value(tcRuleKind)).To(Equal(float64(2)))
+	s.activeRules.set(tcRuleKind, "containerd://c0", 0)
+	s.activeRules.set(dnsRuleKind, "containerd://c0", 0)
+	g.Expect(value(tcRuleKind)).To(Equal(float64(1)))
+	g.Expect(value(dnsRuleKind)).To(BeZero())
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netem
+
+import (
+	"fmt"
+	"strings"
+
+	chaosdaemon "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// NetemArgs converts the netem to the arguments of `tc qdisc add ... netem`
+func NetemArgs(netem *chaosdaemon.Netem) string {
+	args := ""
+	if netem.Time > 0 {
+		args = fmt.Sprintf("delay %d", netem.Time)
+		if netem.Jitter > 0 {
+			args = fmt.Sprintf("%s %d", args, netem.Jitter)
+
+			if netem.DelayCorr > 0 {
+				args = fmt.Sprintf("%s %f", args, netem.DelayCorr)
+			}
+		}
+
+		// reordering not possible without specifying some delay
+		if netem.Reorder > 0 {
+			args = fmt.Sprintf("%s reorder %f", args, netem.Reorder)
+			if netem.ReorderCorr > 0 {
+				args = fmt.Sprintf("%s %f", args, netem.ReorderCorr)
+			}
+
+			if netem.Gap > 0 {
+				args = fmt.Sprintf("%s gap %d", args, netem.Gap)
+			}
+		}
+	}
+
+	if netem.Limit > 0 {
+		args = fmt.Sprintf("%s limit %d", args, netem.Limit)
+	}
+
+	if netem.Loss > 0 {
+		args = fmt.Sprintf("%s loss %f", args, netem.Loss)
+		if netem.LossCorr > 0 {
+			args = fmt.Sprintf("%s %f", args, netem.LossCorr)
+		}
+	}
+
+	if netem.Duplicate > 0 {
+		args = fmt.Sprintf("%s duplicate %f", args, netem.Duplicate)
+		if netem.DuplicateCorr > 0 {
+			args = fmt.Sprintf("%s %f", args, netem.DuplicateCorr)
+		}
+	}
+
+	if netem.Corrupt > 0 {
+		args = fmt.Sprintf("%s corrupt %f", args, netem.Corrupt)
+		if netem.CorruptCorr > 0 {
+			args = fmt.Sprintf("%s %f", args, netem.CorruptCorr)
+		}
+	}
+
+	trimedArgs := []string{}
+
+	for _, part := range strings.Split(args, " ") {
+		if len(part) > 0 {
+			trimedArgs = append(trimedArgs, part)
+		}
+	}
+
+	return strings.Join(trimedArgs, " ")
+}
+
+// TbfArgs converts the tbf to the arguments of `tc qdisc add ... tbf`
+func TbfArgs(tbf *chaosdaemon.Tbf) string {
+	args := fmt.Sprintf("rate %d burst %d", tbf.Rate, tbf.Buffer)
+	if tbf.Limit > 0 {
+		args = fmt.Sprintf("%s limit %d", args, tbf.Limit)
+	}
+	if tbf.PeakRate > 0 {
+		args = fmt.Sprintf("%s peakrate %d mtu %d", args, tbf.PeakRate, tbf.MinBurst)
+	}
+
+	return args
+}
@@ -212,7 +212,7 @@ func (x Tc_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Tc_Type.Descriptor instead.
 func (Tc_Type) EnumDescriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{27, 0}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{29, 0}
 }
 
 type TcHandle struct {
@@ -1276,6 +1276,7 @@ type Chain struct {
 	SourcePorts      string          `protobuf:"bytes,6,opt,name=source_ports,json=sourcePorts,proto3" json:"source_ports,omitempty"`
 	DestinationPorts string          `protobuf:"bytes,7,opt,name=destination_ports,json=destinationPorts,proto3" json:"destination_ports,omitempty"`
 	TcpFlags         string          `protobuf:"bytes,8,opt,name=tcp_flags,json=tcpFlags,proto3" json:"tcp_flags,omitempty"`
+	Probability      float32         `protobuf:"fixed32,9,opt,name=probability,proto3" json:"probability,omitempty"`
 }
 
 func (x *Chain) Reset() {
@@ -1366,6 +1367,13 @@ func (x *Chain) GetTcpFlags() string {
 	return ""
 }
 
+func (x *Chain) GetProbability() float32 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
 type TimeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1489,10 +1497,11 @@ type ExecStressRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Scope     ExecStressRequest_Scope `protobuf:"varint,1,opt,name=scope,proto3,enum=pb.ExecStressRequest_Scope" json:"scope,omitempty"`
-	Target    string                  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	Stressors string                  `protobuf:"bytes,3,opt,name=stressors,proto3" json:"stressors,omitempty"`
-	EnterNS   bool                    `protobuf:"varint,4,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	Scope       ExecStressRequest_Scope `protobuf:"varint,1,opt,name=scope,proto3,enum=pb.ExecStressRequest_Scope" json:"scope,omitempty"`
+	Target      string                  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Stressors   string                  `protobuf:"bytes,3,opt,name=stressors,proto3" json:"stressors,omitempty"`
+	EnterNS     bool                    `protobuf:"varint,4,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	ProcessName string                  `protobuf:"bytes,5,opt,name=processName,proto3" json:"processName,omitempty"`
 }
 
 func (x *ExecStressRequest) Reset() {
@@ -1555,6 +1564,13 @@ func (x *ExecStressRequest) GetEnterNS() bool {
 	return false
 }
 
+func (x *ExecStressRequest) GetProcessName() string {
+	if x != nil {
+		return x.ProcessName
+	}
+	return ""
+}
+
 type ExecStressResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1665,6 +1681,116 @@ func (x *CancelStressRequest) GetStartTime() int64 {
 	return 0
 }
 
+type StressorsStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instance  string `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	StartTime int64  `protobuf:"varint,2,opt,name=startTime,proto3" json:"startTime,omitempty"`
+}
+
+func (x *StressorsStatusRequest) Reset() {
+	*x = StressorsStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StressorsStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StressorsStatusRequest) ProtoMessage() {}
+
+func (x *StressorsStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StressorsStatusRequest.ProtoReflect.Descriptor instead.
+func (*StressorsStatusRequest) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StressorsStatusRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *StressorsStatusRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+type StressorsStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exited  bool   `protobuf:"varint,1,opt,name=exited,proto3" json:"exited,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *StressorsStatusResponse) Reset() {
+	*x = StressorsStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StressorsStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StressorsStatusResponse) ProtoMessage() {}
+
+func (x *StressorsStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StressorsStatusResponse.ProtoReflect.Descriptor instead.
+func (*StressorsStatusResponse) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StressorsStatusResponse) GetExited() bool {
+	if x != nil {
+		return x.Exited
+	}
+	return false
+}
+
+func (x *StressorsStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type ApplyIOChaosRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1681,7 +1807,7 @@ type ApplyIOChaosRequest struct {
 func (x *ApplyIOChaosRequest) Reset() {
 	*x = ApplyIOChaosRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_chaosdaemon_proto_msgTypes[22]
+		mi := &file_chaosdaemon_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1694,7 +1820,7 @@ func (x *ApplyIOChaosRequest) String() string {
 func (*ApplyIOChaosRequest) ProtoMessage() {}
 
 func (x *ApplyIOChaosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chaosdaemon_proto_msgTypes[22]
+	mi := &file_chaosdaemon_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1707,7 +1833,7 @@ func (x *ApplyIOChaosRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApplyIOChaosRequest.ProtoReflect.Descriptor instead.
 func (*ApplyIOChaosRequest) Descriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{22}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ApplyIOChaosRequest) GetActions() string {
@@ -1764,7 +1890,7 @@ type ApplyIOChaosResponse struct {
 func (x *ApplyIOChaosResponse) Reset() {
 	*x = ApplyIOChaosResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_chaosdaemon_proto_msgTypes[23]
+		mi := &file_chaosdaemon_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1777,7 +1903,7 @@ func (x *ApplyIOChaosResponse) String() string {
 func (*ApplyIOChaosResponse) ProtoMessage() {}
 
 func (x *ApplyIOChaosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chaosdaemon_proto_msgTypes[23]
+	mi := &file_chaosdaemon_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1790,7 +1916,7 @@ func (x *ApplyIOChaosResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApplyIOChaosResponse.ProtoReflect.Descriptor instead.
 func (*ApplyIOChaosResponse) Descriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{23}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ApplyIOChaosResponse) GetInstance() int64 {
@@ -1823,7 +1949,7 @@ type ApplyHttpChaosRequest struct {
 func (x *ApplyHttpChaosRequest) Reset() {
 	*x = ApplyHttpChaosRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_chaosdaemon_proto_msgTypes[24]
+		mi := &file_chaosdaemon_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1836,7 +1962,7 @@ func (x *ApplyHttpChaosRequest) String() string {
 func (*ApplyHttpChaosRequest) ProtoMessage() {}
 
 func (x *ApplyHttpChaosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chaosdaemon_proto_msgTypes[24]
+	mi := &file_chaosdaemon_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1849,7 +1975,7 @@ func (x *ApplyHttpChaosRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApplyHttpChaosRequest.ProtoReflect.Descriptor instead.
 func (*ApplyHttpChaosRequest) Descriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{24}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ApplyHttpChaosRequest) GetRules() string {
@@ -1908,7 +2034,7 @@ type ApplyHttpChaosResponse struct {
 func (x *ApplyHttpChaosResponse) Reset() {
 	*x = ApplyHttpChaosResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_chaosdaemon_proto_msgTypes[25]
+		mi := &file_chaosdaemon_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1921,7 +2047,7 @@ func (x *ApplyHttpChaosResponse) String() string {
 func (*ApplyHttpChaosResponse) ProtoMessage() {}
 
 func (x *ApplyHttpChaosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chaosdaemon_proto_msgTypes[25]
+	mi := &file_chaosdaemon_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1934,7 +2060,7 @@ func (x *ApplyHttpChaosResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApplyHttpChaosResponse.ProtoReflect.Descriptor instead.
 func (*ApplyHttpChaosResponse) Descriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{25}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ApplyHttpChaosResponse) GetInstance() int64 {
@@ -1979,7 +2105,7 @@ type TcsRequest struct {
 func (x *TcsRequest) Reset() {
 	*x = TcsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_chaosdaemon_proto_msgTypes[26]
+		mi := &file_chaosdaemon_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1992,7 +2118,7 @@ func (x *TcsRequest) String() string {
 func (*TcsRequest) ProtoMessage() {}
 
 func (x *TcsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chaosdaemon_proto_msgTypes[26]
+	mi := &file_chaosdaemon_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2005,7 +2131,7 @@ func (x *TcsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TcsRequest.ProtoReflect.Descriptor instead.
 func (*TcsRequest) Descriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{26}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *TcsRequest) GetTcs() []*Tc {
@@ -2041,19 +2167,21 @@ type Tc struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type       Tc_Type `protobuf:"varint,1,opt,name=type,proto3,enum=pb.Tc_Type" json:"type,omitempty"`
-	Netem      *Netem  `protobuf:"bytes,2,opt,name=netem,proto3" json:"netem,omitempty"`
-	Tbf        *Tbf    `protobuf:"bytes,3,opt,name=tbf,proto3" json:"tbf,omitempty"`
-	Ipset      string  `protobuf:"bytes,4,opt,name=ipset,proto3" json:"ipset,omitempty"`
-	Protocol   string  `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
-	SourcePort string  `protobuf:"bytes,6,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
-	EgressPort string  `protobuf:"bytes,7,opt,name=egress_port,json=egressPort,proto3" json:"egress_port,omitempty"`
+	Type        Tc_Type `protobuf:"varint,1,opt,name=type,proto3,enum=pb.Tc_Type" json:"type,omitempty"`
+	Netem       *Netem  `protobuf:"bytes,2,opt,name=netem,proto3" json:"netem,omitempty"`
+	Tbf         *Tbf    `protobuf:"bytes,3,opt,name=tbf,proto3" json:"tbf,omitempty"`
+	Ipset       string  `protobuf:"bytes,4,opt,name=ipset,proto3" json:"ipset,omitempty"`
+	Protocol    string  `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	SourcePort  string  `protobuf:"bytes,6,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	EgressPort  string  `protobuf:"bytes,7,opt,name=egress_port,json=egressPort,proto3" json:"egress_port,omitempty"`
+	Child       *Tc     `protobuf:"bytes,8,opt,name=child,proto3" json:"child,omitempty"`
+	Probability float32 `protobuf:"fixed32,9,opt,name=probability,proto3" json:"probability,omitempty"`
 }
 
 func (x *Tc) Reset() {
 	*x = Tc{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_chaosdaemon_proto_msgTypes[27]
+		mi := &file_chaosdaemon_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2066,7 +2194,7 @@ func (x *Tc) String() string {
 func (*Tc) ProtoMessage() {}
 
 func (x *Tc) ProtoReflect() protoreflect.Message {
-	mi := &file_chaosdaemon_proto_msgTypes[27]
+	mi := &file_chaosdaemon_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2079,7 +2207,7 @@ func (x *Tc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tc.ProtoReflect.Descriptor instead.
 func (*Tc) Descriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{27}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *Tc) GetType() Tc_Type {
@@ -2131,6 +2259,20 @@ func (x *Tc) GetEgressPort() string {
 	return ""
 }
 
+func (x *Tc) GetChild() *Tc {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+func (x *Tc) GetProbability() float32 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
 type SetDNSServerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2140,12 +2282,13 @@ type SetDNSServerRequest struct {
 	DnsServer   string `protobuf:"bytes,2,opt,name=dns_server,json=dnsServer,proto3" json:"dns_server,omitempty"`
 	Enable      bool   `protobuf:"varint,3,opt,name=enable,proto3" json:"enable,omitempty"`
 	EnterNS     bool   `protobuf:"varint,4,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	Name        string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
 }
 
 func (x *SetDNSServerRequest) Reset() {
 	*x = SetDNSServerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_chaosdaemon_proto_msgTypes[28]
+		mi := &file_chaosdaemon_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2158,7 +2301,7 @@ func (x *SetDNSServerRequest) String() string {
 func (*SetDNSServerRequest) ProtoMessage() {}
 
 func (x *SetDNSServerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chaosdaemon_proto_msgTypes[28]
+	mi := &file_chaosdaemon_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2171,7 +2314,7 @@ func (x *SetDNSServerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetDNSServerRequest.ProtoReflect.Descriptor instead.
 func (*SetDNSServerRequest) Descriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{28}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SetDNSServerRequest) GetContainerId() string {
@@ -2202,6 +2345,13 @@ func (x *SetDNSServerRequest) GetEnterNS() bool {
 	return false
 }
 
+func (x *SetDNSServerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 var File_chaosdaemon_proto protoreflect.FileDescriptor
 
 var file_chaosdaemon_proto_rawDesc = []byte{
@@ -2324,7 +2474,7 @@ var file_chaosdaemon_proto_rawDesc = []byte{
 	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
 	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65,
 	0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e,
-	0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0xab, 0x02, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12,
+	0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0xcd, 0x02, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12,
 	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
 	0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x69,
@@ -2340,167 +2490,191 @@ var file_chaosdaemon_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x72,
 	0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x63, 0x70, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x22,
-	0x22, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05,
-	0x49, 0x4e, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x55, 0x54, 0x50, 0x55,
-	0x54, 0x10, 0x01, 0x22, 0x78, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x73, 0x65, 0x63, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6e, 0x73, 0x65, 0x63, 0x12, 0x20, 0x0a, 0x0c, 0x63,
-	0x6c, 0x6b, 0x5f, 0x69, 0x64, 0x73, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0a, 0x63, 0x6c, 0x6b, 0x49, 0x64, 0x73, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x65, 0x0a,
-	0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x32, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1e, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08,
-	0x0a, 0x04, 0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x45, 0x54, 0x50,
-	0x49, 0x44, 0x10, 0x01, 0x22, 0xb7, 0x01, 0x0a, 0x11, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x63,
-	0x6f, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x45,
-	0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x73, 0x73, 0x6f,
-	0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x73, 0x73,
-	0x6f, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0x1f, 0x0a,
-	0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49,
-	0x4e, 0x45, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4f, 0x44, 0x10, 0x01, 0x22, 0x4e,
-	0x0a, 0x12, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x4f,
-	0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22,
-	0xbe, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e,
-	0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53,
-	0x22, 0x50, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x63, 0x70, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x22, 0x22, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x09,
+	0x0a, 0x05, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x55, 0x54,
+	0x50, 0x55, 0x54, 0x10, 0x01, 0x22, 0x78, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x73, 0x65,
+	0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6e, 0x73, 0x65, 0x63, 0x12, 0x20, 0x0a,
+	0x0c, 0x63, 0x6c, 0x6b, 0x5f, 0x69, 0x64, 0x73, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x6c, 0x6b, 0x49, 0x64, 0x73, 0x4d, 0x61, 0x73, 0x6b, 0x22,
+	0x65, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1e, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x08, 0x0a, 0x04, 0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x45,
+	0x54, 0x50, 0x49, 0x44, 0x10, 0x01, 0x22, 0xd9, 0x01, 0x0a, 0x11, 0x45, 0x78, 0x65, 0x63, 0x53,
+	0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x62,
+	0x2e, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65,
+	0x73, 0x73, 0x6f, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x12,
+	0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4e, 0x61, 0x6d,
+	0x65, 0x22, 0x1f, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f,
+	0x4e, 0x54, 0x41, 0x49, 0x4e, 0x45, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4f, 0x44,
+	0x10, 0x01, 0x22, 0x4e, 0x0a, 0x12, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74,
 	0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
 	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
-	0x6d, 0x65, 0x22, 0xc5, 0x01, 0x0a, 0x15, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70,
-	0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6c,
-	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x70, 0x6f, 0x72, 0x74,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x50, 0x6f,
-	0x72, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61,
-	0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0x88, 0x01, 0x0a, 0x16, 0x41,
-	0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x7b, 0x0a, 0x0a, 0x54, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x03, 0x74, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x06, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x52, 0x03, 0x74, 0x63, 0x73, 0x12, 0x21, 0x0a,
-	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65,
-	0x72, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72,
-	0x4e, 0x53, 0x22, 0xf7, 0x01, 0x0a, 0x02, 0x54, 0x63, 0x12, 0x1f, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x2e,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x6e, 0x65,
-	0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4e,
-	0x65, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x12, 0x19, 0x0a, 0x03, 0x74,
-	0x62, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x62,
-	0x66, 0x52, 0x03, 0x74, 0x62, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x70, 0x73, 0x65, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x70, 0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x67, 0x72,
-	0x65, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x20, 0x0a, 0x04, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x45, 0x54, 0x45, 0x4d, 0x10, 0x00, 0x12, 0x0d, 0x0a,
-	0x09, 0x42, 0x41, 0x4e, 0x44, 0x57, 0x49, 0x44, 0x54, 0x48, 0x10, 0x01, 0x22, 0x89, 0x01, 0x0a,
-	0x13, 0x53, 0x65, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x32, 0xa1, 0x06, 0x0a, 0x0b, 0x43, 0x68, 0x61,
-	0x6f, 0x73, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x06, 0x53, 0x65, 0x74, 0x54,
-	0x63, 0x73, 0x12, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b,
-	0x46, 0x6c, 0x75, 0x73, 0x68, 0x49, 0x50, 0x53, 0x65, 0x74, 0x73, 0x12, 0x11, 0x2e, 0x70, 0x62,
-	0x2e, 0x49, 0x50, 0x53, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x6d, 0x65, 0x22, 0x4f, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x74, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0x52, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x73,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x4b, 0x0a, 0x17, 0x53, 0x74, 0x72, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0xbe, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f,
+	0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0x50, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f,
+	0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xc5, 0x01, 0x0a, 0x15, 0x41, 0x70, 0x70, 0x6c,
+	0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x78, 0x79,
+	0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x78, 0x79, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x22,
+	0x88, 0x01, 0x0a, 0x16, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61,
+	0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f,
+	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x7b, 0x0a, 0x0a, 0x54, 0x63,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x03, 0x74, 0x63, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x52, 0x03, 0x74,
+	0x63, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0xb7, 0x02, 0x0a, 0x02, 0x54, 0x63, 0x12, 0x1f,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x63, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x1f, 0x0a, 0x05, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x6e, 0x65, 0x74, 0x65, 0x6d,
+	0x12, 0x19, 0x0a, 0x03, 0x74, 0x62, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e,
+	0x70, 0x62, 0x2e, 0x54, 0x62, 0x66, 0x52, 0x03, 0x74, 0x62, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x70, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x70, 0x73, 0x65,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x72, 0x74, 0x12,
+	0x1c, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06,
+	0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x20, 0x0a,
+	0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22,
+	0x20, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x45, 0x54, 0x45, 0x4d,
+	0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x41, 0x4e, 0x44, 0x57, 0x49, 0x44, 0x54, 0x48, 0x10,
+	0x01, 0x22, 0x9d, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x32, 0xf2, 0x06, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x44, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x12, 0x32, 0x0a, 0x06, 0x53, 0x65, 0x74, 0x54, 0x63, 0x73, 0x12, 0x0e, 0x2e, 0x70, 0x62,
+	0x2e, 0x54, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x49, 0x50,
+	0x53, 0x65, 0x74, 0x73, 0x12, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x49, 0x50, 0x53, 0x65, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x48, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x49, 0x70, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x49, 0x70, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0d, 0x53,
+	0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x0f, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x0f, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x4b, 0x69, 0x6c, 0x6c, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x49,
-	0x70, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x19, 0x2e,
-	0x70, 0x62, 0x2e, 0x49, 0x70, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x47, 0x65, 0x74, 0x50, 0x69, 0x64, 0x12, 0x14, 0x2e, 0x70, 0x62,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x45, 0x78,
+	0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x73, 0x12, 0x15, 0x2e, 0x70, 0x62,
+	0x2e, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0f,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x73, 0x12,
+	0x17, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x73,
 	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e,
-	0x0a, 0x11, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3f,
-	0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4b, 0x69, 0x6c, 0x6c, 0x12,
-	0x14, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
-	0x40, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x47, 0x65, 0x74, 0x50,
-	0x69, 0x64, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x40, 0x0a, 0x0d, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x6f,
-	0x72, 0x73, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65,
-	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x45,
-	0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0f, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x74, 0x72,
-	0x65, 0x73, 0x73, 0x6f, 0x72, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x63,
-	0x65, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x41, 0x70, 0x70,
-	0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x41,
-	0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43,
-	0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49,
-	0x0a, 0x0e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73,
-	0x12, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43,
-	0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x62,
-	0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x53, 0x65, 0x74,
-	0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x53,
-	0x65, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x00, 0x12, 0x4f, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x6f,
+	0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73,
+	0x6f, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68,
+	0x61, 0x6f, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f,
+	0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70,
+	0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0e, 0x41, 0x70, 0x70, 0x6c,
+	0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x12, 0x19, 0x2e, 0x70, 0x62, 0x2e,
+	0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x44, 0x4e, 0x53, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2516,42 +2690,44 @@ func file_chaosdaemon_proto_rawDescGZIP() []byte {
 }
 
 var file_chaosdaemon_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_chaosdaemon_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_chaosdaemon_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_chaosdaemon_proto_goTypes = []interface{}{
-	(Chain_Direction)(0),           // 0: pb.Chain.Direction
-	(ContainerAction_Action)(0),    // 1: pb.ContainerAction.Action
-	(ExecStressRequest_Scope)(0),   // 2: pb.ExecStressRequest.Scope
-	(Tc_Type)(0),                   // 3: pb.Tc.Type
-	(*TcHandle)(nil),               // 4: pb.TcHandle
-	(*ContainerRequest)(nil),       // 5: pb.ContainerRequest
-	(*ContainerResponse)(nil),      // 6: pb.ContainerResponse
-	(*NetemRequest)(nil),           // 7: pb.NetemRequest
-	(*Netem)(nil),                  // 8: pb.Netem
-	(*TbfRequest)(nil),             // 9: pb.TbfRequest
-	(*Tbf)(nil),                    // 10: pb.Tbf
-	(*QdiscRequest)(nil),           // 11: pb.QdiscRequest
-	(*Qdisc)(nil),                  // 12: pb.Qdisc
-	(*EmatchFilterRequest)(nil),    // 13: pb.EmatchFilterRequest
-	(*EmatchFilter)(nil),           // 14: pb.EmatchFilter
-	(*TcFilterRequest)(nil),        // 15: pb.TcFilterRequest
-	(*TcFilter)(nil),               // 16: pb.TcFilter
-	(*IPSetsRequest)(nil),          // 17: pb.IPSetsRequest
-	(*IPSet)(nil),                  // 18: pb.IPSet
-	(*IptablesChainsRequest)(nil),  // 19: pb.IptablesChainsRequest
-	(*Chain)(nil),                  // 20: pb.Chain
-	(*TimeRequest)(nil),            // 21: pb.TimeRequest
-	(*ContainerAction)(nil),        // 22: pb.ContainerAction
-	(*ExecStressRequest)(nil),      // 23: pb.ExecStressRequest
-	(*ExecStressResponse)(nil),     // 24: pb.ExecStressResponse
-	(*CancelStressRequest)(nil),    // 25: pb.CancelStressRequest
-	(*ApplyIOChaosRequest)(nil),    // 26: pb.ApplyIOChaosRequest
-	(*ApplyIOChaosResponse)(nil),   // 27: pb.ApplyIOChaosResponse
-	(*ApplyHttpChaosRequest)(nil),  // 28: pb.ApplyHttpChaosRequest
-	(*ApplyHttpChaosResponse)(nil), // 29: pb.ApplyHttpChaosResponse
-	(*TcsRequest)(nil),             // 30: pb.TcsRequest
-	(*Tc)(nil),                     // 31: pb.Tc
-	(*SetDNSServerRequest)(nil),    // 32: pb.SetDNSServerRequest
-	(*empty.Empty)(nil),            // 33: google.protobuf.Empty
+	(Chain_Direction)(0),            // 0: pb.Chain.Direction
+	(ContainerAction_Action)(0),     // 1: pb.ContainerAction.Action
+	(ExecStressRequest_Scope)(0),    // 2: pb.ExecStressRequest.Scope
+	(Tc_Type)(0),                    // 3: pb.Tc.Type
+	(*TcHandle)(nil),                // 4: pb.TcHandle
+	(*ContainerRequest)(nil),        // 5: pb.ContainerRequest
+	(*ContainerResponse)(nil),       // 6: pb.ContainerResponse
+	(*NetemRequest)(nil),            // 7: pb.NetemRequest
+	(*Netem)(nil),                   // 8: pb.Netem
+	(*TbfRequest)(nil),              // 9: pb.TbfRequest
+	(*Tbf)(nil),                     // 10: pb.Tbf
+	(*QdiscRequest)(nil),            // 11: pb.QdiscRequest
+	(*Qdisc)(nil),                   // 12: pb.Qdisc
+	(*EmatchFilterRequest)(nil),     // 13: pb.EmatchFilterRequest
+	(*EmatchFilter)(nil),            // 14: pb.EmatchFilter
+	(*TcFilterRequest)(nil),         // 15: pb.TcFilterRequest
+	(*TcFilter)(nil),                // 16: pb.TcFilter
+	(*IPSetsRequest)(nil),           // 17: pb.IPSetsRequest
+	(*IPSet)(nil),                   // 18: pb.IPSet
+	(*IptablesChainsRequest)(nil),   // 19: pb.IptablesChainsRequest
+	(*Chain)(nil),                   // 20: pb.Chain
+	(*TimeRequest)(nil),             // 21: pb.TimeRequest
+	(*ContainerAction)(nil),         // 22: pb.ContainerAction
+	(*ExecStressRequest)(nil),       // 23: pb.ExecStressRequest
+	(*ExecStressResponse)(nil),      // 24: pb.ExecStressResponse
+	(*CancelStressRequest)(nil),     // 25: pb.CancelStressRequest
+	(*StressorsStatusRequest)(nil),  // 26: pb.StressorsStatusRequest
+	(*StressorsStatusResponse)(nil), // 27: pb.StressorsStatusResponse
+	(*ApplyIOChaosRequest)(nil),     // 28: pb.ApplyIOChaosRequest
+	(*ApplyIOChaosResponse)(nil),    // 29: pb.ApplyIOChaosResponse
+	(*ApplyHttpChaosRequest)(nil),   // 30: pb.ApplyHttpChaosRequest
+	(*ApplyHttpChaosResponse)(nil),  // 31: pb.ApplyHttpChaosResponse
+	(*TcsRequest)(nil),              // 32: pb.TcsRequest
+	(*Tc)(nil),                      // 33: pb.Tc
+	(*SetDNSServerRequest)(nil),     // 34: pb.SetDNSServerRequest
+	(*empty.Empty)(nil),             // 35: google.protobuf.Empty
 }
 var file_chaosdaemon_proto_depIdxs = []int32{
 	22, // 0: pb.ContainerRequest.action:type_name -> pb.ContainerAction
@@ -2574,39 +2750,42 @@ var file_chaosdaemon_proto_depIdxs = []int32{
 	0,  // 17: pb.Chain.direction:type_name -> pb.Chain.Direction
 	1,  // 18: pb.ContainerAction.action:type_name -> pb.ContainerAction.Action
 	2,  // 19: pb.ExecStressRequest.scope:type_name -> pb.ExecStressRequest.Scope
-	31, // 20: pb.TcsRequest.tcs:type_name -> pb.Tc
+	33, // 20: pb.TcsRequest.tcs:type_name -> pb.Tc
 	3,  // 21: pb.Tc.type:type_name -> pb.Tc.Type
 	8,  // 22: pb.Tc.netem:type_name -> pb.Netem
 	10, // 23: pb.Tc.tbf:type_name -> pb.Tbf
-	30, // 24: pb.ChaosDaemon.SetTcs:input_type -> pb.TcsRequest
-	17, // 25: pb.ChaosDaemon.FlushIPSets:input_type -> pb.IPSetsRequest
-	19, // 26: pb.ChaosDaemon.SetIptablesChains:input_type -> pb.IptablesChainsRequest
-	21, // 27: pb.ChaosDaemon.SetTimeOffset:input_type -> pb.TimeRequest
-	21, // 28: pb.ChaosDaemon.RecoverTimeOffset:input_type -> pb.TimeRequest
-	5,  // 29: pb.ChaosDaemon.ContainerKill:input_type -> pb.ContainerRequest
-	5,  // 30: pb.ChaosDaemon.ContainerGetPid:input_type -> pb.ContainerRequest
-	23, // 31: pb.ChaosDaemon.ExecStressors:input_type -> pb.ExecStressRequest
-	25, // 32: pb.ChaosDaemon.CancelStressors:input_type -> pb.CancelStressRequest
-	26, // 33: pb.ChaosDaemon.ApplyIOChaos:input_type -> pb.ApplyIOChaosRequest
-	28, // 34: pb.ChaosDaemon.ApplyHttpChaos:input_type -> pb.ApplyHttpChaosRequest
-	32, // 35: pb.ChaosDaemon.SetDNSServer:input_type -> pb.SetDNSServerRequest
-	33, // 36: pb.ChaosDaemon.SetTcs:output_type -> google.protobuf.Empty
-	33, // 37: pb.ChaosDaemon.FlushIPSets:output_type -> google.protobuf.Empty
-	33, // 38: pb.ChaosDaemon.SetIptablesChains:output_type -> google.protobuf.Empty
-	33, // 39: pb.ChaosDaemon.SetTimeOffset:output_type -> google.protobuf.Empty
-	33, // 40: pb.ChaosDaemon.RecoverTimeOffset:output_type -> google.protobuf.Empty
-	33, // 41: pb.ChaosDaemon.ContainerKill:output_type -> google.protobuf.Empty
-	6,  // 42: pb.ChaosDaemon.ContainerGetPid:output_type -> pb.ContainerResponse
-	24, // 43: pb.ChaosDaemon.ExecStressors:output_type -> pb.ExecStressResponse
-	33, // 44: pb.ChaosDaemon.CancelStressors:output_type -> google.protobuf.Empty
-	27, // 45: pb.ChaosDaemon.ApplyIOChaos:output_type -> pb.ApplyIOChaosResponse
-	29, // 46: pb.ChaosDaemon.ApplyHttpChaos:output_type -> pb.ApplyHttpChaosResponse
-	33, // 47: pb.ChaosDaemon.SetDNSServer:output_type -> google.protobuf.Empty
-	36, // [36:48] is the sub-list for method output_type
-	24, // [24:36] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	33, // 24: pb.Tc.child:type_name -> pb.Tc
+	32, // 25: pb.ChaosDaemon.SetTcs:input_type -> pb.TcsRequest
+	17, // 26: pb.ChaosDaemon.FlushIPSets:input_type -> pb.IPSetsRequest
+	19, // 27: pb.ChaosDaemon.SetIptablesChains:input_type -> pb.IptablesChainsRequest
+	21, // 28: pb.ChaosDaemon.SetTimeOffset:input_type -> pb.TimeRequest
+	21, // 29: pb.ChaosDaemon.RecoverTimeOffset:input_type -> pb.TimeRequest
+	5,  // 30: pb.ChaosDaemon.ContainerKill:input_type -> pb.ContainerRequest
+	5,  // 31: pb.ChaosDaemon.ContainerGetPid:input_type -> pb.ContainerRequest
+	23, // 32: pb.ChaosDaemon.ExecStressors:input_type -> pb.ExecStressRequest
+	25, // 33: pb.ChaosDaemon.CancelStressors:input_type -> pb.CancelStressRequest
+	26, // 34: pb.ChaosDaemon.GetStressorsStatus:input_type -> pb.StressorsStatusRequest
+	28, // 35: pb.ChaosDaemon.ApplyIOChaos:input_type -> pb.ApplyIOChaosRequest
+	30, // 36: pb.ChaosDaemon.ApplyHttpChaos:input_type -> pb.ApplyHttpChaosRequest
+	34, // 37: pb.ChaosDaemon.SetDNSServer:input_type -> pb.SetDNSServerRequest
+	35, // 38: pb.ChaosDaemon.SetTcs:output_type -> google.protobuf.Empty
+	35, // 39: pb.ChaosDaemon.FlushIPSets:output_type -> google.protobuf.Empty
+	35, // 40: pb.ChaosDaemon.SetIptablesChains:output_type -> google.protobuf.Empty
+	35, // 41: pb.ChaosDaemon.SetTimeOffset:output_type -> google.protobuf.Empty
+	35, // 42: pb.ChaosDaemon.RecoverTimeOffset:output_type -> google.protobuf.Empty
+	35, // 43: pb.ChaosDaemon.ContainerKill:output_type -> google.protobuf.Empty
+	6,  // 44: pb.ChaosDaemon.ContainerGetPid:output_type -> pb.ContainerResponse
+	24, // 45: pb.ChaosDaemon.ExecStressors:output_type -> pb.ExecStressResponse
+	35, // 46: pb.ChaosDaemon.CancelStressors:output_type -> google.protobuf.Empty
+	27, // 47: pb.ChaosDaemon.GetStressorsStatus:output_type -> pb.StressorsStatusResponse
+	29, // 48: pb.ChaosDaemon.ApplyIOChaos:output_type -> pb.ApplyIOChaosResponse
+	31, // 49: pb.ChaosDaemon.ApplyHttpChaos:output_type -> pb.ApplyHttpChaosResponse
+	35, // 50: pb.ChaosDaemon.SetDNSServer:output_type -> google.protobuf.Empty
+	38, // [38:51] is the sub-list for method output_type
+	25, // [25:38] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_chaosdaemon_proto_init() }
@@ -2880,7 +3059,7 @@ func file_chaosdaemon_proto_init() {
 			}
 		}
 		file_chaosdaemon_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApplyIOChaosRequest); i {
+			switch v := v.(*StressorsStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2892,7 +3071,7 @@ func file_chaosdaemon_proto_init() {
 			}
 		}
 		file_chaosdaemon_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApplyIOChaosResponse); i {
+			switch v := v.(*StressorsStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2904,7 +3083,7 @@ func file_chaosdaemon_proto_init() {
 			}
 		}
 		file_chaosdaemon_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApplyHttpChaosRequest); i {
+			switch v := v.(*ApplyIOChaosRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2916,7 +3095,7 @@ func file_chaosdaemon_proto_init() {
 			}
 		}
 		file_chaosdaemon_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApplyHttpChaosResponse); i {
+			switch v := v.(*ApplyIOChaosResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2928,7 +3107,7 @@ func file_chaosdaemon_proto_init() {
 			}
 		}
 		file_chaosdaemon_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TcsRequest); i {
+			switch v := v.(*ApplyHttpChaosRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2940,7 +3119,7 @@ func file_chaosdaemon_proto_init() {
 			}
 		}
 		file_chaosdaemon_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tc); i {
+			switch v := v.(*ApplyHttpChaosResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2952,6 +3131,30 @@ func file_chaosdaemon_proto_init() {
 			}
 		}
 		file_chaosdaemon_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TcsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tc); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SetDNSServerRequest); i {
 			case 0:
 				return &v.state
@@ -2970,7 +3173,7 @@ func file_chaosdaemon_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_chaosdaemon_proto_rawDesc,
 			NumEnums:      4,
-			NumMessages:   29,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -3006,6 +3209,7 @@ type ChaosDaemonClient interface {
 	ContainerGetPid(ctx context.Context, in *ContainerRequest, opts ...grpc.CallOption) (*ContainerResponse, error)
 	ExecStressors(ctx context.Context, in *ExecStressRequest, opts ...grpc.CallOption) (*ExecStressResponse, error)
 	CancelStressors(ctx context.Context, in *CancelStressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetStressorsStatus(ctx context.Context, in *StressorsStatusRequest, opts ...grpc.CallOption) (*StressorsStatusResponse, error)
 	ApplyIOChaos(ctx context.Context, in *ApplyIOChaosRequest, opts ...grpc.CallOption) (*ApplyIOChaosResponse, error)
 	ApplyHttpChaos(ctx context.Context, in *ApplyHttpChaosRequest, opts ...grpc.CallOption) (*ApplyHttpChaosResponse, error)
 	SetDNSServer(ctx context.Context, in *SetDNSServerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
@@ -3100,6 +3304,15 @@ func (c *chaosDaemonClient) CancelStressors(ctx context.Context, in *CancelStres
 	return out, nil
 }
 
+func (c *chaosDaemonClient) GetStressorsStatus(ctx context.Context, in *StressorsStatusRequest, opts ...grpc.CallOption) (*StressorsStatusResponse, error) {
+	out := new(StressorsStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/GetStressorsStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *chaosDaemonClient) ApplyIOChaos(ctx context.Context, in *ApplyIOChaosRequest, opts ...grpc.CallOption) (*ApplyIOChaosResponse, error) {
 	out := new(ApplyIOChaosResponse)
 	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/ApplyIOChaos", in, out, opts...)
@@ -3138,6 +3351,7 @@ type ChaosDaemonServer interface {
 	ContainerGetPid(context.Context, *ContainerRequest) (*ContainerResponse, error)
 	ExecStressors(context.Context, *ExecStressRequest) (*ExecStressResponse, error)
 	CancelStressors(context.Context, *CancelStressRequest) (*empty.Empty, error)
+	GetStressorsStatus(context.Context, *StressorsStatusRequest) (*StressorsStatusResponse, error)
 	ApplyIOChaos(context.Context, *ApplyIOChaosRequest) (*ApplyIOChaosResponse, error)
 	ApplyHttpChaos(context.Context, *ApplyHttpChaosRequest) (*ApplyHttpChaosResponse, error)
 	SetDNSServer(context.Context, *SetDNSServerRequest) (*empty.Empty, error)
@@ -3174,6 +3388,9 @@ func (*UnimplementedChaosDaemonServer) ExecStressors(context.Context, *ExecStres
 func (*UnimplementedChaosDaemonServer) CancelStressors(context.Context, *CancelStressRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelStressors not implemented")
 }
+func (*UnimplementedChaosDaemonServer) GetStressorsStatus(context.Context, *StressorsStatusRequest) (*StressorsStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStressorsStatus not implemented")
+}
 func (*UnimplementedChaosDaemonServer) ApplyIOChaos(context.Context, *ApplyIOChaosRequest) (*ApplyIOChaosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplyIOChaos not implemented")
 }
@@ -3350,6 +3567,24 @@ func _ChaosDaemon_CancelStressors_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChaosDaemon_GetStressorsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StressorsStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).GetStressorsStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/GetStressorsStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).GetStressorsStatus(ctx, req.(*StressorsStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ChaosDaemon_ApplyIOChaos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ApplyIOChaosRequest)
 	if err := dec(in); err != nil {
@@ -3444,6 +3679,10 @@ var _ChaosDaemon_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CancelStressors",
 			Handler:    _ChaosDaemon_CancelStressors_Handler,
 		},
+		{
+			MethodName: "GetStressorsStatus",
+			Handler:    _ChaosDaemon_GetStressorsStatus_Handler,
+		},
 		{
 			MethodName: "ApplyIOChaos",
 			Handler:    _ChaosDaemon_ApplyIOChaos_Handler,
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"sync"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -28,6 +29,8 @@ import (
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -49,6 +52,10 @@ type Config struct {
 	Runtime   string
 	Profiling bool
 
+	// DNSBindMount bind mounts the config file of the chaos dns server over the /etc/resolv.conf
+	// rather than modifying it in place
+	DNSBindMount bool
+
 	tlsConfig
 }
 
@@ -75,6 +82,17 @@ type DaemonServer struct {
 	backgroundProcessManager bpm.BackgroundProcessManager
 
 	IPSetLocker *locker.Locker
+
+	// stressors keeps the stress-ng started by ExecStressors, keyed by bpm.ProcessPair
+	stressors sync.Map
+
+	// snapshots keeps the state of the containers before the chaos is applied, to verify the recovery
+	snapshots snapshotStore
+
+	// activeRules counts the rules applied in the containers, which are exposed as metrics
+	activeRules activeRules
+
+	dnsBindMount bool
 }
 
 func newDaemonServer(containerRuntime string) (*DaemonServer, error) {
@@ -95,17 +113,18 @@ func NewDaemonServerWithCRClient(crClient crclients.ContainerRuntimeInfoClient)
 	}
 }
 
-func newGRPCServer(containerRuntime string, reg prometheus.Registerer, tlsConf tlsConfig) (*grpc.Server, error) {
-	ds, err := newDaemonServer(containerRuntime)
+func newGRPCServer(conf *Config, reg prometheus.Registerer) (*grpc.Server, error) {
+	ds, err := newDaemonServer(conf.Runtime)
 	if err != nil {
 		return nil, err
 	}
+	ds.dnsBindMount = conf.DNSBindMount
 
 	grpcMetrics := grpc_prometheus.NewServerMetrics()
 	grpcMetrics.EnableHandlingTimeHistogram(
 		grpc_prometheus.WithHistogramBuckets([]float64{0.001, 0.01, 0.1, 0.3, 0.6, 1, 3, 6, 10}),
 	)
-	reg.MustRegister(grpcMetrics)
+	reg.MustRegister(grpcMetrics, &metricsCollector{server: ds})
 
 	grpcOpts := []grpc.ServerOption{
 		grpc_middleware.WithUnaryServerChain(
@@ -114,6 +133,7 @@ func newGRPCServer(containerRuntime string, reg prometheus.Registerer, tlsConf t
 		),
 	}
 
+	tlsConf := conf.tlsConfig
 	if tlsConf != (tlsConfig{}) {
 		caCert, err := ioutil.ReadFile(tlsConf.CaCert)
 		if err != nil {
@@ -137,11 +157,14 @@ func newGRPCServer(containerRuntime string, reg prometheus.Registerer, tlsConf t
 	}
 
 	s := grpc.NewServer(grpcOpts...)
-	grpcMetrics.InitializeMetrics(s)
-
 	pb.RegisterChaosDaemonServer(s, ds)
+	// the controller manager checks whether the chaos daemon is reachable before retrying the quarantined records
+	healthpb.RegisterHealthServer(s, health.NewServer())
 	reflection.Register(s)
 
+	// the metrics of the methods are initialized after the service is registered
+	grpcMetrics.InitializeMetrics(s)
+
 	return s, nil
 }
 
@@ -165,7 +188,7 @@ func StartServer(conf *Config, reg RegisterGatherer) error {
 		return err
 	}
 
-	grpcServer, err := newGRPCServer(conf.Runtime, reg, conf.tlsConfig)
+	grpcServer, err := newGRPCServer(conf, reg)
 	if err != nil {
 		log.Error(err, "failed to create grpc server")
 		return err
@@ -0,0 +1,109 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	iptablesSnapshotKind = "iptables"
+	dnsSnapshotKind      = "dns"
+)
+
+// snapshotStore keeps the state of the containers captured before the chaos is applied, e.g. the iptables
+// rules or the /etc/resolv.conf, so that the state after the recovery could be verified against it
+type snapshotStore struct {
+	sync.Mutex
+
+	// snapshots are keyed by the kind of the state and the container id
+	snapshots map[string]string
+}
+
+func snapshotKey(kind string, containerID string) string {
+	return kind + "/" + containerID
+}
+
+// capture keeps the state as the snapshot of the container, unless there is already one captured
+// by the chaos applied before
+func (s *snapshotStore) capture(key string, state string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.snapshots == nil {
+		s.snapshots = make(map[string]string)
+	}
+	if _, ok := s.snapshots[key]; ok {
+		return
+	}
+	s.snapshots[key] = state
+}
+
+// verify returns an error if the recovered state doesn't match the snapshot. The snapshot is released after
+// it's verified, so that the mismatch is reported once rather than blocking the retried recovery forever, as
+// the container may modify the state by itself. There is nothing to verify if no snapshot is captured, e.g.
+// the chaos daemon restarted after the chaos is applied.
+func (s *snapshotStore) verify(key string, state string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	snapshot, ok := s.snapshots[key]
+	if !ok {
+		return nil
+	}
+	delete(s.snapshots, key)
+
+	missing, unexpected := diffLines(snapshot, state)
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("the recovered %s doesn't match the snapshot before the chaos, missing: %q, unexpected: %q",
+		key, missing, unexpected)
+}
+
+// diffLines returns the non-empty lines which are only in the snapshot, and the ones only in the state
+func diffLines(snapshot string, state string) (missing []string, unexpected []string) {
+	count := make(map[string]int)
+	for _, line := range nonEmptyLines(snapshot) {
+		count[line]++
+	}
+	for _, line := range nonEmptyLines(state) {
+		if count[line] > 0 {
+			count[line]--
+			continue
+		}
+		unexpected = append(unexpected, line)
+	}
+	for _, line := range nonEmptyLines(snapshot) {
+		if count[line] > 0 {
+			count[line]--
+			missing = append(missing, line)
+		}
+	}
+
+	return
+}
+
+func nonEmptyLines(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSnapshotStore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := snapshotStore{}
+	key := snapshotKey(iptablesSnapshotKind, "containerd://c0")
+	g.Expect(s.verify(key, "-P INPUT ACCEPT")).To(Succeed())
+
+	s.capture(key, "-P INPUT ACCEPT\n-A INPUT -s 10.0.0.1/32 -j DROP\n")
+	// the state modified by the chaos isn't captured again
+	s.capture(key, "-P INPUT ACCEPT\n-A CHAOS-INPUT -j INPUT/foo\n")
+
+	// the rule of the container is lost on recovery
+	err := s.verify(key, "-P INPUT ACCEPT\n-A CHAOS-INPUT -j INPUT/foo\n")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring(`missing: ["-A INPUT -s 10.0.0.1/32 -j DROP"]`))
+	g.Expect(err.Error()).To(ContainSubstring(`unexpected: ["-A CHAOS-INPUT -j INPUT/foo"]`))
+
+	// the snapshot is released after it's verified
+	g.Expect(s.verify(key, "")).To(Succeed())
+
+	s.capture(key, "-P INPUT ACCEPT\n-A INPUT -s 10.0.0.1/32 -j DROP\n")
+	g.Expect(s.verify(key, "-A INPUT -s 10.0.0.1/32 -j DROP\n-P INPUT ACCEPT")).To(Succeed())
+}
+
+func TestFilterChaosRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	rules := `-P INPUT ACCEPT
+-P OUTPUT ACCEPT
+-N CHAOS-INPUT
+-N CHAOS-OUTPUT
+-N INPUT/foo
+-N TC-TABLES-0
+-A INPUT -s 10.0.0.1/32 -j DROP
+-A INPUT -j CHAOS-INPUT
+-A OUTPUT -j CHAOS-OUTPUT
+-A INPUT/foo -m set --match-set foo src -j DROP -w 5
+-A TC-TABLES-0 -j CLASSIFY --set-class 1:4
+`
+	g.Expect(filterChaosRules(rules)).To(Equal("-P INPUT ACCEPT\n-P OUTPUT ACCEPT\n-A INPUT -s 10.0.0.1/32 -j DROP"))
+
+	// the rules in the CHAOS-INPUT and CHAOS-OUTPUT chains are kept, as they're reachable
+	g.Expect(filterChaosRules(rules + "-A CHAOS-INPUT -j INPUT/foo\n")).To(ContainSubstring("-A CHAOS-INPUT -j INPUT/foo"))
+}
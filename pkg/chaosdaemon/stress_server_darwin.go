@@ -28,3 +28,7 @@ func (s *DaemonServer) ExecStressors(context.Context, *pb.ExecStressRequest) (*p
 func (s *DaemonServer) CancelStressors(context.Context, *pb.CancelStressRequest) (*empty.Empty, error) {
 	return &empty.Empty{}, nil
 }
+
+func (s *DaemonServer) GetStressorsStatus(context.Context, *pb.StressorsStatusRequest) (*pb.StressorsStatusResponse, error) {
+	return &pb.StressorsStatusResponse{}, nil
+}
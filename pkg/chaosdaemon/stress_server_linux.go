@@ -14,9 +14,13 @@
 package chaosdaemon
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io/ioutil"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -25,22 +29,141 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
 	daemonCgroups "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/cgroups"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
+// stressorsStartupPeriod is the longest time the stressors are watched after being resumed.
+// stress-ng exits immediately with an unsupported stressor or without permission, and the
+// reason should be returned to the controller rather than being swallowed in the background.
+const stressorsStartupPeriod = 500 * time.Millisecond
+
+// stressorsPollInterval is the interval to check whether stress-ng has forked the workers
+const stressorsPollInterval = 10 * time.Millisecond
+
+// stressorsRetention is how long the exited stressors are kept, so that the exit status could
+// still be queried by the controller checking them periodically
+const stressorsRetention = time.Minute
+
+// stderrLimit is the max size of the stderr kept for stress-ng, only the tail is kept
+const stderrLimit = 4 * 1024
+
+// stderrBuffer keeps the last stderrLimit bytes of the stderr of stress-ng
+type stderrBuffer struct {
+	sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *stderrBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	n := len(p)
+	if n >= stderrLimit {
+		b.buf.Reset()
+		p = p[n-stderrLimit:]
+	} else if overflow := b.buf.Len() + n - stderrLimit; overflow > 0 {
+		b.buf.Next(overflow)
+	}
+	if _, err := b.buf.Write(p); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (b *stderrBuffer) Read(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.Read(p)
+}
+
+// Close does nothing, so that the stderr could still be read after the process exits
+func (b *stderrBuffer) Close() error {
+	return nil
+}
+
+func (b *stderrBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return strings.TrimSpace(b.buf.String())
+}
+
+// stressors is a stress-ng started by chaos daemon, the exit status and the stderr of which
+// could be queried until it is canceled
+type stressors struct {
+	cmd    *bpm.ManagedProcess
+	stderr *stderrBuffer
+}
+
+// exitError returns an error with the exit status and the stderr, if the stressors have
+// exited unsuccessfully
+func (s *stressors) exitError() error {
+	select {
+	case <-s.cmd.Exited():
+		if s.cmd.ProcessState.Success() {
+			return nil
+		}
+		return fmt.Errorf("stress-ng %s: %s", s.cmd.ProcessState, s.stderr)
+	default:
+		return nil
+	}
+}
+
+// hasChildProcess returns true if the process has forked any child process. It returns false
+// if the children cannot be read, e.g. the kernel is built without CONFIG_PROC_CHILDREN.
+func hasChildProcess(pid int) bool {
+	children, err := ioutil.ReadFile(fmt.Sprintf("%s/%d/task/%d/children", bpm.DefaultProcPrefix, pid, pid))
+	if err != nil {
+		return false
+	}
+	return len(strings.TrimSpace(string(children))) > 0
+}
+
+// waitStressorsStartup returns an error with the exit status and the stderr, if the stressors
+// exit unsuccessfully before the workers are forked. It returns as soon as the workers are
+// forked, or after the period, so that the apply isn't blocked by the healthy stressors.
+func waitStressorsStartup(cmd *bpm.ManagedProcess, stderr *stderrBuffer, period time.Duration) error {
+	s := &stressors{cmd: cmd, stderr: stderr}
+
+	timeout := time.After(period)
+	ticker := time.NewTicker(stressorsPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cmd.Exited():
+			return s.exitError()
+		case <-timeout:
+			return nil
+		case <-ticker.C:
+			if hasChildProcess(cmd.Process.Pid) {
+				return nil
+			}
+		}
+	}
+}
+
 func (s *DaemonServer) ExecStressors(ctx context.Context,
 	req *pb.ExecStressRequest) (*pb.ExecStressResponse, error) {
 	log.Info("Executing stressors", "request", req)
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.Target)
 	if err != nil {
-		return nil, err
+		return nil, errcode.Error(errcode.ContainerNotFound, err)
+	}
+	if len(req.ProcessName) != 0 {
+		pid, err = FindMatchedProcess(pid, req.ProcessName)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Found the matched process", "pid", pid, "processName", req.ProcessName)
 	}
 	control, err := cgroups.Load(daemonCgroups.V1, daemonCgroups.PidPath(int(pid)))
 	if err != nil {
 		return nil, err
 	}
 
+	stderr := &stderrBuffer{}
 	processBuilder := bpm.DefaultProcessBuilder("stress-ng", strings.Fields(req.Stressors)...).
+		SetStderr(stderr).
 		EnablePause()
 	if req.EnterNS {
 		processBuilder = processBuilder.SetNS(pid, bpm.PidNS)
@@ -67,6 +190,9 @@ func (s *DaemonServer) ExecStressors(ctx context.Context,
 	for {
 		// TODO: find a better way to resume pause process
 		if err := cmd.Process.Signal(syscall.SIGCONT); err != nil {
+			if exitErr := waitStressorsStartup(cmd, stderr, stressorsStartupPeriod); exitErr != nil {
+				return nil, exitErr
+			}
 			return nil, err
 		}
 
@@ -75,6 +201,9 @@ func (s *DaemonServer) ExecStressors(ctx context.Context,
 
 		comm, err := ReadCommName(cmd.Process.Pid)
 		if err != nil {
+			if exitErr := waitStressorsStartup(cmd, stderr, stressorsStartupPeriod); exitErr != nil {
+				return nil, exitErr
+			}
 			return nil, err
 		}
 		if comm != "pause\n" {
@@ -84,12 +213,32 @@ func (s *DaemonServer) ExecStressors(ctx context.Context,
 		log.Info("the process hasn't resumed, step into the following loop", "comm", comm)
 	}
 
+	if err := waitStressorsStartup(cmd, stderr, stressorsStartupPeriod); err != nil {
+		log.Error(err, "stressors exited unexpectedly", "request", req)
+		return nil, err
+	}
+
+	s.storeStressors(bpm.ProcessPair{Pid: cmd.Process.Pid, CreateTime: ct}, &stressors{
+		cmd:    cmd,
+		stderr: stderr,
+	}, stressorsRetention)
+
 	return &pb.ExecStressResponse{
 		Instance:  strconv.Itoa(cmd.Process.Pid),
 		StartTime: ct,
 	}, nil
 }
 
+// storeStressors keeps the stressors until they are canceled, or the retention passes after they exit
+func (s *DaemonServer) storeStressors(key bpm.ProcessPair, process *stressors, retention time.Duration) {
+	s.stressors.Store(key, process)
+	go func() {
+		<-process.cmd.Exited()
+		time.Sleep(retention)
+		s.stressors.Delete(key)
+	}()
+}
+
 func (s *DaemonServer) CancelStressors(ctx context.Context,
 	req *pb.CancelStressRequest) (*empty.Empty, error) {
 	pid, err := strconv.Atoi(req.Instance)
@@ -98,6 +247,8 @@ func (s *DaemonServer) CancelStressors(ctx context.Context,
 	}
 	log.Info("Canceling stressors", "request", req)
 
+	// the stressors are forgotten even if they cannot be killed, e.g. they have exited
+	s.stressors.Delete(bpm.ProcessPair{Pid: pid, CreateTime: req.StartTime})
 	err = s.backgroundProcessManager.KillBackgroundProcess(ctx, pid, req.StartTime)
 	if err != nil {
 		return nil, err
@@ -105,3 +256,32 @@ func (s *DaemonServer) CancelStressors(ctx context.Context,
 	log.Info("killing stressor successfully")
 	return &empty.Empty{}, nil
 }
+
+// GetStressorsStatus returns whether the stressors have exited, and the reason if they have
+// exited unsuccessfully. The stressors unknown to the daemon, e.g. the ones started before the
+// daemon restarts, are reported as running.
+func (s *DaemonServer) GetStressorsStatus(ctx context.Context,
+	req *pb.StressorsStatusRequest) (*pb.StressorsStatusResponse, error) {
+	pid, err := strconv.Atoi(req.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := s.stressors.Load(bpm.ProcessPair{Pid: pid, CreateTime: req.StartTime})
+	if !ok {
+		return &pb.StressorsStatusResponse{}, nil
+	}
+	process := value.(*stressors)
+
+	select {
+	case <-process.cmd.Exited():
+	default:
+		return &pb.StressorsStatusResponse{}, nil
+	}
+
+	resp := &pb.StressorsStatusResponse{Exited: true}
+	if err := process.exitError(); err != nil {
+		resp.Message = err.Error()
+	}
+	return resp, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+var _ = Describe("stress server", func() {
+	m := bpm.NewBackgroundProcessManager()
+
+	Context("waitStressorsStartup", func() {
+		It("should return the exit status and stderr", func() {
+			stderr := &stderrBuffer{}
+			cmd := bpm.DefaultProcessBuilder("sh", "-c", "echo 'unknown stressor' >&2; exit 2").
+				SetStderr(stderr).
+				Build()
+			_, err := m.StartProcess(cmd)
+			Expect(err).To(BeNil())
+
+			err = waitStressorsStartup(cmd, stderr, 5*time.Second)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("exit status 2"))
+			Expect(err.Error()).To(ContainSubstring("unknown stressor"))
+		})
+
+		It("should ignore running stressors", func() {
+			stderr := &stderrBuffer{}
+			cmd := bpm.DefaultProcessBuilder("sleep", "10").
+				SetStderr(stderr).
+				Build()
+			procState, err := m.StartProcess(cmd)
+			Expect(err).To(BeNil())
+
+			Expect(waitStressorsStartup(cmd, stderr, 100*time.Millisecond)).To(BeNil())
+
+			ct, err := procState.CreateTime()
+			Expect(err).To(BeNil())
+			Expect(m.KillBackgroundProcess(context.Background(), cmd.Process.Pid, ct)).To(BeNil())
+		})
+
+		It("should return once the workers are forked", func() {
+			stderr := &stderrBuffer{}
+			cmd := bpm.DefaultProcessBuilder("sh", "-c", "sleep 10 & wait").
+				SetStderr(stderr).
+				Build()
+			procState, err := m.StartProcess(cmd)
+			Expect(err).To(BeNil())
+
+			start := time.Now()
+			Expect(waitStressorsStartup(cmd, stderr, 5*time.Second)).To(BeNil())
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+
+			ct, err := procState.CreateTime()
+			Expect(err).To(BeNil())
+			Expect(m.KillBackgroundProcess(context.Background(), cmd.Process.Pid, ct)).To(BeNil())
+		})
+	})
+
+	Context("GetStressorsStatus", func() {
+		It("should report the stressors exiting after startup", func() {
+			s := &DaemonServer{backgroundProcessManager: m}
+
+			stderr := &stderrBuffer{}
+			cmd := bpm.DefaultProcessBuilder("sh", "-c", "sleep 0.5; echo 'cannot allocate memory' >&2; exit 1").
+				SetStderr(stderr).
+				Build()
+			procState, err := m.StartProcess(cmd)
+			Expect(err).To(BeNil())
+			ct, err := procState.CreateTime()
+			Expect(err).To(BeNil())
+			s.storeStressors(bpm.ProcessPair{Pid: cmd.Process.Pid, CreateTime: ct}, &stressors{cmd: cmd, stderr: stderr}, time.Hour)
+
+			req := &pb.StressorsStatusRequest{
+				Instance:  strconv.Itoa(cmd.Process.Pid),
+				StartTime: ct,
+			}
+			resp, err := s.GetStressorsStatus(context.Background(), req)
+			Expect(err).To(BeNil())
+			Expect(resp.Exited).To(BeFalse())
+
+			<-cmd.Exited()
+			resp, err = s.GetStressorsStatus(context.Background(), req)
+			Expect(err).To(BeNil())
+			Expect(resp.Exited).To(BeTrue())
+			Expect(resp.Message).To(ContainSubstring("exit status 1"))
+			Expect(resp.Message).To(ContainSubstring("cannot allocate memory"))
+
+			_, err = s.CancelStressors(context.Background(), &pb.CancelStressRequest{
+				Instance:  req.Instance,
+				StartTime: req.StartTime,
+			})
+			Expect(err).To(BeNil())
+			resp, err = s.GetStressorsStatus(context.Background(), req)
+			Expect(err).To(BeNil())
+			Expect(resp.Exited).To(BeFalse())
+		})
+
+		It("should forget the exited stressors after the retention", func() {
+			s := &DaemonServer{backgroundProcessManager: m}
+
+			cmd := bpm.DefaultProcessBuilder("sh", "-c", "exit 1").
+				SetStderr(&stderrBuffer{}).
+				Build()
+			procState, err := m.StartProcess(cmd)
+			Expect(err).To(BeNil())
+			ct, err := procState.CreateTime()
+			Expect(err).To(BeNil())
+			key := bpm.ProcessPair{Pid: cmd.Process.Pid, CreateTime: ct}
+			s.storeStressors(key, &stressors{cmd: cmd, stderr: &stderrBuffer{}}, 100*time.Millisecond)
+
+			Eventually(func() bool {
+				_, ok := s.stressors.Load(key)
+				return ok
+			}, time.Second*5, time.Millisecond*50).Should(BeFalse())
+		})
+	})
+
+	Context("stderrBuffer", func() {
+		It("should keep the tail of the stderr", func() {
+			stderr := &stderrBuffer{}
+			line := strings.Repeat("x", stderrLimit/4-1) + "\n"
+			for i := 0; i < 8; i++ {
+				n, err := stderr.Write([]byte(line))
+				Expect(err).To(BeNil())
+				Expect(n).To(Equal(len(line)))
+			}
+			stderr.Write([]byte("cannot allocate memory"))
+			Expect(stderr.buf.Len()).To(Equal(stderrLimit))
+			Expect(stderr.String()).To(HaveSuffix("cannot allocate memory"))
+
+			n, err := stderr.Write([]byte(strings.Repeat("y", stderrLimit*2)))
+			Expect(err).To(BeNil())
+			Expect(n).To(Equal(stderrLimit * 2))
+			Expect(stderr.String()).To(Equal(strings.Repeat("y", stderrLimit)))
+		})
+	})
+})
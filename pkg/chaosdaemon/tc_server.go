@@ -16,12 +16,12 @@ package chaosdaemon
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
+	netemutils "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/netem"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -79,7 +79,7 @@ func (s *DaemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Em
 
 	pid, err := s.crClient.GetPidFromContainerID(ctx, in.ContainerId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get pid from containerID error: %v", err)
+		return nil, errcode.Error(errcode.ContainerNotFound, err)
 	}
 
 	setDefaultTcsRequest(in)
@@ -88,7 +88,7 @@ func (s *DaemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Em
 	err = tcCli.flush(in.Device)
 	if err != nil {
 		log.Error(err, "error while flushing client")
-		return &empty.Empty{}, err
+		return &empty.Empty{}, errcode.Error(errcode.RuleApplyFailed, err)
 	}
 
 	// tc rules are split into two different kinds according to whether it has filter.
@@ -114,6 +114,21 @@ func (s *DaemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Em
 	//  iptables -A TC-TABLES-0 -m set --match-set A dst -j CLASSIFY --set-class 3:4 -w 5
 	//  tc qdisc add dev eth0 parent 3:5 handle 8: netem delay 100000
 	//  iptables -A TC-TABLES-1 -m set --match-set B dst -j CLASSIFY --set-class 3:5 -w 5
+	//
+	// The tc with a probability is a `filterTc` as well, whose packets are classified randomly by iptables, e.g.
+	// a NETEM tc with 50% probability generates:
+	//  iptables -A TC-TABLES-0 -j CLASSIFY --set-class 1:4 -w 5 -m statistic --mode random --probability 0.500000
+	//
+	// The `filterTc` which only filters the packets by the protocol and the ports is connected by a u32 filter on the
+	// PRIO qdisc instead of iptables, e.g. a NETEM tc for the tcp packets sent to the port 3306 generates:
+	//  tc filter add dev eth0 parent 1: protocol ip prio 1 u32 match ip protocol 6 0xff match ip dport 3306 0xffff flowid 1:4
+	// The filters are removed along with the PRIO qdisc when the tc rules are flushed, so the recovery never touches
+	// the filters which aren't added by the chaos.
+	//
+	// The tc with a child is expanded into a chain of qdiscs in place, e.g. a BANDWIDTH tc with a NETEM child
+	// without filter generates:
+	//  tc qdisc add dev eth0 root handle 1: tbf rate 1000 burst 100 limit 100
+	//  tc qdisc add dev eth0 parent 1: handle 2: netem delay 50000
 
 	globalTc := []*pb.Tc{}
 	filterTc := make(map[string][]*pb.Tc)
@@ -121,30 +136,47 @@ func (s *DaemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Em
 	for _, tc := range in.Tcs {
 		filter := abstractTcFilter(tc)
 		if len(filter) > 0 {
-			filterTc[filter] = append(filterTc[filter], tc)
+			filterTc[filter] = append(filterTc[filter], qdiscChain(tc)...)
 			continue
 		}
-		globalTc = append(globalTc, tc)
+		globalTc = append(globalTc, qdiscChain(tc)...)
 	}
 
 	if len(globalTc) > 0 {
 		if err := s.setGlobalTcs(tcCli, globalTc, in.Device); err != nil {
 			log.Error(err, "error while setting global tc")
-			return &empty.Empty{}, err
+			return &empty.Empty{}, errcode.Error(errcode.RuleApplyFailed, err)
 		}
 	}
 
+	iptablesCli := buildIptablesClient(ctx, in.EnterNS, pid)
+	var tcChains []*pb.Chain
 	if len(filterTc) > 0 {
-		iptablesCli := buildIptablesClient(ctx, in.EnterNS, pid)
-		if err := s.setFilterTcs(tcCli, iptablesCli, filterTc, in.Device, len(globalTc)); err != nil {
+		tcChains, err = s.setFilterTcs(tcCli, iptablesCli, filterTc, in.Device, len(globalTc))
+		if err != nil {
 			log.Error(err, "error while setting filter tc")
-			return &empty.Empty{}, err
+			return &empty.Empty{}, errcode.Error(errcode.RuleApplyFailed, err)
 		}
 	}
+	// the chains of the removed filter tcs would classify the packets into the flushed qdiscs
+	if err := iptablesCli.removeStaleChains(isTcChain, tcChains); err != nil {
+		log.Error(err, "error while removing stale tc chains")
+		return &empty.Empty{}, errcode.Error(errcode.RuleApplyFailed, err)
+	}
+	s.activeRules.set(tcRuleKind, in.ContainerId, len(in.Tcs))
 
 	return &empty.Empty{}, nil
 }
 
+// qdiscChain expands the tc and its descendants into a chain of qdiscs, in which every qdisc is the parent of the next one
+func qdiscChain(tc *pb.Tc) []*pb.Tc {
+	var chain []*pb.Tc
+	for ; tc != nil; tc = tc.Child {
+		chain = append(chain, tc)
+	}
+	return chain
+}
+
 func (s *DaemonServer) setGlobalTcs(cli tcClient, tcs []*pb.Tc, device string) error {
 	for index, tc := range tcs {
 		parentArg := "root"
@@ -170,21 +202,20 @@ func (s *DaemonServer) setFilterTcs(
 	filterTc map[string][]*pb.Tc,
 	device string,
 	baseIndex int,
-) error {
+) ([]*pb.Chain, error) {
 	parent := baseIndex
 	band := 3 + len(filterTc) // 3 handlers for normal sfq on prio qdisc
 	if err := tcCli.addPrio(device, parent, band); err != nil {
 		log.Error(err, "error while adding prio")
-		return err
+		return nil, err
 	}
 
 	parent++
 	index := 0
 	currentHandler := parent + 3 // 3 handlers for sfq on prio qdisc
 
-	// iptables chain has been initialized by previous grpc request to set iptables
-	// and iptables rules are recovered by previous call too, so there is no need
-	// to remove these rules here
+	// iptables chain has been initialized by previous grpc request to set iptables,
+	// and the chains of the removed filter tcs are removed by the caller
 	chains := []*pb.Chain{}
 	for _, tcs := range filterTc {
 		for i, tc := range tcs {
@@ -199,17 +230,27 @@ func (s *DaemonServer) setFilterTcs(
 			err := tcCli.addTc(device, parentArg, handleArg, tc)
 			if err != nil {
 				log.Error(err, "error while adding tc")
-				return err
+				return nil, err
 			}
 		}
 
+		tc := tcs[0]
+		if matches, ok := u32Matches(tc); ok {
+			if err := tcCli.addU32Filter(device, parent, index+4, matches); err != nil {
+				log.Error(err, "error while adding u32 filter")
+				return nil, err
+			}
+
+			index++
+			continue
+		}
+
 		ch := &pb.Chain{
 			Name:      fmt.Sprintf("TC-TABLES-%d", index),
 			Direction: pb.Chain_OUTPUT,
 			Target:    fmt.Sprintf("CLASSIFY --set-class %d:%d", parent, index+4),
 		}
 
-		tc := tcs[0]
 		if len(tc.Ipset) > 0 {
 			ch.Ipsets = []string{tc.Ipset}
 		}
@@ -217,6 +258,7 @@ func (s *DaemonServer) setFilterTcs(
 		ch.Protocol = tc.Protocol
 		ch.SourcePorts = tc.SourcePort
 		ch.DestinationPorts = tc.EgressPort
+		ch.Probability = tc.Probability
 
 		chains = append(chains, ch)
 
@@ -224,10 +266,10 @@ func (s *DaemonServer) setFilterTcs(
 	}
 	if err := iptablesCli.setIptablesChains(chains); err != nil {
 		log.Error(err, "error while setting iptables")
-		return err
+		return nil, err
 	}
 
-	return nil
+	return chains, nil
 }
 
 type tcClient struct {
@@ -328,7 +370,7 @@ func (c *tcClient) addPrio(device string, parent int, band int) error {
 func (c *tcClient) addNetem(device string, parent string, handle string, netem *pb.Netem) error {
 	log.Info("adding netem", "device", device, "parent", parent, "handle", handle)
 
-	args := fmt.Sprintf("qdisc add dev %s %s %s netem %s", device, parent, handle, convertNetemToArgs(netem))
+	args := fmt.Sprintf("qdisc add dev %s %s %s netem %s", device, parent, handle, netemutils.NetemArgs(netem))
 	processBuilder := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetContext(c.ctx)
 	if c.enterNS {
 		processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
@@ -344,7 +386,7 @@ func (c *tcClient) addNetem(device string, parent string, handle string, netem *
 func (c *tcClient) addTbf(device string, parent string, handle string, tbf *pb.Tbf) error {
 	log.Info("adding tbf", "device", device, "parent", parent, "handle", handle)
 
-	args := fmt.Sprintf("qdisc add dev %s %s %s tbf %s", device, parent, handle, convertTbfToArgs(tbf))
+	args := fmt.Sprintf("qdisc add dev %s %s %s tbf %s", device, parent, handle, netemutils.TbfArgs(tbf))
 	processBuilder := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetContext(c.ctx)
 	if c.enterNS {
 		processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
@@ -357,77 +399,56 @@ func (c *tcClient) addTbf(device string, parent string, handle string, tbf *pb.T
 	return nil
 }
 
-func convertNetemToArgs(netem *pb.Netem) string {
-	args := ""
-	if netem.Time > 0 {
-		args = fmt.Sprintf("delay %d", netem.Time)
-		if netem.Jitter > 0 {
-			args = fmt.Sprintf("%s %d", args, netem.Jitter)
-
-			if netem.DelayCorr > 0 {
-				args = fmt.Sprintf("%s %f", args, netem.DelayCorr)
-			}
-		}
-
-		// reordering not possible without specifying some delay
-		if netem.Reorder > 0 {
-			args = fmt.Sprintf("%s reorder %f", args, netem.Reorder)
-			if netem.ReorderCorr > 0 {
-				args = fmt.Sprintf("%s %f", args, netem.ReorderCorr)
-			}
-
-			if netem.Gap > 0 {
-				args = fmt.Sprintf("%s gap %d", args, netem.Gap)
-			}
-		}
-	}
-
-	if netem.Limit > 0 {
-		args = fmt.Sprintf("%s limit %d", args, netem.Limit)
-	}
+// addU32Filter classifies the packets matched by the u32 matches into the band of the PRIO qdisc
+func (c *tcClient) addU32Filter(device string, parent int, band int, matches string) error {
+	log.Info("adding u32 filter", "device", device, "parent", parent, "band", band, "matches", matches)
 
-	if netem.Loss > 0 {
-		args = fmt.Sprintf("%s loss %f", args, netem.Loss)
-		if netem.LossCorr > 0 {
-			args = fmt.Sprintf("%s %f", args, netem.LossCorr)
-		}
+	args := fmt.Sprintf("filter add dev %s parent %d: protocol ip prio 1 u32 %s flowid %d:%d", device, parent, matches, parent, band)
+	processBuilder := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetContext(c.ctx)
+	if c.enterNS {
+		processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
 	}
-
-	if netem.Duplicate > 0 {
-		args = fmt.Sprintf("%s duplicate %f", args, netem.Duplicate)
-		if netem.DuplicateCorr > 0 {
-			args = fmt.Sprintf("%s %f", args, netem.DuplicateCorr)
-		}
+	cmd := processBuilder.Build()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return encodeOutputToError(output, err)
 	}
+	return nil
+}
 
-	if netem.Corrupt > 0 {
-		args = fmt.Sprintf("%s corrupt %f", args, netem.Corrupt)
-		if netem.CorruptCorr > 0 {
-			args = fmt.Sprintf("%s %f", args, netem.CorruptCorr)
+// u32Matches translates the protocol and the ports of the tc into the matches of a u32 filter. It returns false if
+// the packets can't be matched by u32 alone, i.e. the tc has an ipset or a probability, or the ports are lists or
+// ranges, and then the packets are classified by iptables.
+func u32Matches(tc *pb.Tc) (string, bool) {
+	if len(tc.Ipset) > 0 || tc.Probability > 0 {
+		return "", false
+	}
+
+	var protocol int
+	switch tc.Protocol {
+	case "tcp":
+		protocol = 6
+	case "udp":
+		protocol = 17
+	default:
+		return "", false
+	}
+	matches := fmt.Sprintf("match ip protocol %d 0xff", protocol)
+
+	for _, port := range []struct {
+		field string
+		value string
+	}{{"sport", tc.SourcePort}, {"dport", tc.EgressPort}} {
+		if len(port.value) == 0 {
+			continue
 		}
-	}
-
-	trimedArgs := []string{}
-
-	for _, part := range strings.Split(args, " ") {
-		if len(part) > 0 {
-			trimedArgs = append(trimedArgs, part)
+		if _, err := strconv.ParseUint(port.value, 10, 16); err != nil {
+			return "", false
 		}
+		matches += fmt.Sprintf(" match ip %s %s 0xffff", port.field, port.value)
 	}
 
-	return strings.Join(trimedArgs, " ")
-}
-
-func convertTbfToArgs(tbf *pb.Tbf) string {
-	args := fmt.Sprintf("rate %d burst %d", tbf.Rate, tbf.Buffer)
-	if tbf.Limit > 0 {
-		args = fmt.Sprintf("%s limit %d", args, tbf.Limit)
-	}
-	if tbf.PeakRate > 0 {
-		args = fmt.Sprintf("%s peakrate %d mtu %d", args, tbf.PeakRate, tbf.MinBurst)
-	}
-
-	return args
+	return matches, true
 }
 
 func abstractTcFilter(tc *pb.Tc) string {
@@ -442,7 +463,11 @@ func abstractTcFilter(tc *pb.Tc) string {
 	}
 
 	if len(tc.SourcePort) > 0 {
-		filter += "-" + tc.EgressPort
+		filter += "-" + tc.SourcePort
+	}
+
+	if tc.Probability > 0 {
+		filter += fmt.Sprintf("-%f", tc.Probability)
 	}
 
 	return filter
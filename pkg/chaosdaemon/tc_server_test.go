@@ -14,11 +14,15 @@
 package chaosdaemon
 
 import (
+	"context"
+	"os/exec"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
 
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
 )
 
 func Test_generateQdiscArgs(t *testing.T) {
@@ -52,121 +56,140 @@ func Test_generateQdiscArgs(t *testing.T) {
 	})
 }
 
-func Test_convertNetemToArgs(t *testing.T) {
+func Test_qdiscChain(t *testing.T) {
 	g := NewWithT(t)
 
-	t.Run("convert network delay", func(t *testing.T) {
-		args := convertNetemToArgs(&pb.Netem{
-			Time: 1000,
-		})
-		g.Expect(args).To(Equal("delay 1000"))
-
-		args = convertNetemToArgs(&pb.Netem{
-			Time:      1000,
-			DelayCorr: 25,
-		})
-		g.Expect(args).To(Equal("delay 1000"))
+	netem := &pb.Tc{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}}
+	tbf := &pb.Tc{Type: pb.Tc_BANDWIDTH, Tbf: &pb.Tbf{Rate: 1000, Buffer: 100}, Child: netem}
+	g.Expect(qdiscChain(tbf)).To(Equal([]*pb.Tc{tbf, netem}))
+	g.Expect(qdiscChain(netem)).To(Equal([]*pb.Tc{netem}))
+	g.Expect(qdiscChain(nil)).To(BeEmpty())
+}
 
-		args = convertNetemToArgs(&pb.Netem{
-			Time:      1000,
-			Jitter:    10000,
-			DelayCorr: 25,
-		})
-		g.Expect(args).To(Equal("delay 1000 10000 25.000000"))
-	})
+func Test_setGlobalTcsWithChild(t *testing.T) {
+	g := NewWithT(t)
 
-	t.Run("convert packet limit", func(t *testing.T) {
-		args := convertNetemToArgs(&pb.Netem{
-			Limit: 1000,
-		})
-		g.Expect(args).To(Equal("limit 1000"))
+	var commands []string
+	defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+		commands = append(commands, cmd+" "+strings.Join(args, " "))
+		return exec.Command("echo", "-n")
+	})()
+	if mock.On("MockProcessBuild") == nil {
+		t.Skip("failpoints are not enabled, run it with `make test`")
+	}
+
+	s := &DaemonServer{}
+	tcs := qdiscChain(&pb.Tc{
+		Type: pb.Tc_BANDWIDTH,
+		Tbf:  &pb.Tbf{Rate: 1000, Buffer: 100, Limit: 100},
+		Child: &pb.Tc{
+			Type:  pb.Tc_NETEM,
+			Netem: &pb.Netem{Time: 50000},
+		},
 	})
+	g.Expect(s.setGlobalTcs(buildTcClient(context.TODO(), false, 0), tcs, "eth0")).To(Succeed())
+	g.Expect(commands).To(Equal([]string{
+		"tc qdisc add dev eth0 root handle 1: tbf rate 1000 burst 100 limit 100",
+		"tc qdisc add dev eth0 parent 1: handle 2: netem delay 50000",
+	}))
+}
 
-	t.Run("convert packet loss", func(t *testing.T) {
-		args := convertNetemToArgs(&pb.Netem{
-			Loss: 100,
-		})
-		g.Expect(args).To(Equal("loss 100.000000"))
-
-		args = convertNetemToArgs(&pb.Netem{
-			Loss:     50,
-			LossCorr: 12,
-		})
-		g.Expect(args).To(Equal("loss 50.000000 12.000000"))
-	})
+func Test_setFilterTcsWithProbability(t *testing.T) {
+	g := NewWithT(t)
 
-	t.Run("convert packet reorder", func(t *testing.T) {
-		args := convertNetemToArgs(&pb.Netem{
-			Reorder:     5,
-			ReorderCorr: 10,
-		})
-		g.Expect(args).To(Equal(""))
-
-		args = convertNetemToArgs(&pb.Netem{
-			Time:        1000,
-			Jitter:      10000,
-			DelayCorr:   25,
-			Reorder:     5,
-			ReorderCorr: 10,
-			Gap:         10,
-		})
-		g.Expect(args).To(Equal("delay 1000 10000 25.000000 reorder 5.000000 10.000000 gap 10"))
-
-		args = convertNetemToArgs(&pb.Netem{
-			Time:        1000,
-			Jitter:      10000,
-			DelayCorr:   25,
-			Reorder:     5,
-			ReorderCorr: 10,
-			Gap:         10,
-		})
-		g.Expect(args).To(Equal("delay 1000 10000 25.000000 reorder 5.000000 10.000000 gap 10"))
-
-		args = convertNetemToArgs(&pb.Netem{
-			Time:      1000,
-			Jitter:    10000,
-			DelayCorr: 25,
-			Reorder:   5,
-			Gap:       10,
-		})
-		g.Expect(args).To(Equal("delay 1000 10000 25.000000 reorder 5.000000 gap 10"))
-	})
+	var commands []string
+	defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+		commands = append(commands, cmd+" "+strings.Join(args, " "))
+		return exec.Command("echo", "-n")
+	})()
+	if mock.On("MockProcessBuild") == nil {
+		t.Skip("failpoints are not enabled, run it with `make test`")
+	}
+
+	tc := &pb.Tc{
+		Type:        pb.Tc_NETEM,
+		Netem:       &pb.Netem{Time: 100000},
+		Probability: 50,
+	}
+	g.Expect(abstractTcFilter(tc)).To(Equal("-50.000000"))
+
+	s := &DaemonServer{}
+	filterTc := map[string][]*pb.Tc{abstractTcFilter(tc): {tc}}
+	chains, err := s.setFilterTcs(buildTcClient(context.TODO(), false, 0), buildIptablesClient(context.TODO(), false, 0), filterTc, "eth0", 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(chains).To(HaveLen(1))
+	g.Expect(commands).To(ContainElements(
+		"tc qdisc add dev eth0 root handle 1: prio bands 4 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1",
+		"tc qdisc add dev eth0 parent 1:4 handle 5: netem delay 100000",
+		"iptables -w -A TC-TABLES-0 -j CLASSIFY --set-class 1:4 -w 5 -m statistic --mode random --probability 0.500000",
+	))
+}
 
-	t.Run("convert packet duplication", func(t *testing.T) {
-		args := convertNetemToArgs(&pb.Netem{
-			Duplicate: 10,
-		})
-		g.Expect(args).To(Equal("duplicate 10.000000"))
+func Test_setFilterTcsWithPort(t *testing.T) {
+	g := NewWithT(t)
 
-		args = convertNetemToArgs(&pb.Netem{
-			Duplicate:     10,
-			DuplicateCorr: 50,
-		})
-		g.Expect(args).To(Equal("duplicate 10.000000 50.000000"))
-	})
+	var commands []string
+	defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+		commands = append(commands, cmd+" "+strings.Join(args, " "))
+		return exec.Command("echo", "-n")
+	})()
+	if mock.On("MockProcessBuild") == nil {
+		t.Skip("failpoints are not enabled, run it with `make test`")
+	}
+
+	tc := &pb.Tc{
+		Type:       pb.Tc_NETEM,
+		Netem:      &pb.Netem{Time: 100000},
+		Protocol:   "tcp",
+		EgressPort: "3306",
+	}
+
+	s := &DaemonServer{}
+	filterTc := map[string][]*pb.Tc{abstractTcFilter(tc): {tc}}
+	chains, err := s.setFilterTcs(buildTcClient(context.TODO(), false, 0), buildIptablesClient(context.TODO(), false, 0), filterTc, "eth0", 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(chains).To(BeEmpty())
+	g.Expect(commands).To(ContainElements(
+		"tc qdisc add dev eth0 root handle 1: prio bands 4 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1",
+		"tc qdisc add dev eth0 parent 1:4 handle 5: netem delay 100000",
+		"tc filter add dev eth0 parent 1: protocol ip prio 1 u32 match ip protocol 6 0xff match ip dport 3306 0xffff flowid 1:4",
+	))
+}
 
-	t.Run("convert packet corrupt", func(t *testing.T) {
-		args := convertNetemToArgs(&pb.Netem{
-			Corrupt: 10,
-		})
-		g.Expect(args).To(Equal("corrupt 10.000000"))
+func Test_u32Matches(t *testing.T) {
+	g := NewWithT(t)
 
-		args = convertNetemToArgs(&pb.Netem{
-			Corrupt:     10,
-			CorruptCorr: 50,
-		})
-		g.Expect(args).To(Equal("corrupt 10.000000 50.000000"))
-	})
+	matches, ok := u32Matches(&pb.Tc{Protocol: "tcp", EgressPort: "3306"})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(matches).To(Equal("match ip protocol 6 0xff match ip dport 3306 0xffff"))
+
+	matches, ok = u32Matches(&pb.Tc{Protocol: "udp", SourcePort: "53", EgressPort: "5353"})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(matches).To(Equal("match ip protocol 17 0xff match ip sport 53 0xffff match ip dport 5353 0xffff"))
+
+	// the packets which can't be matched by u32 alone are left to iptables
+	for _, tc := range []*pb.Tc{
+		{Protocol: "tcp", EgressPort: "3306", Ipset: "tgt"},
+		{Protocol: "tcp", EgressPort: "3306", Probability: 50},
+		{Protocol: "tcp", EgressPort: "80,443"},
+		{Protocol: "tcp", EgressPort: "1000:2000"},
+		{Protocol: "icmp"},
+		{},
+	} {
+		_, ok := u32Matches(tc)
+		g.Expect(ok).To(BeFalse(), "tc: %v", tc)
+	}
+}
 
-	t.Run("complicate cases", func(t *testing.T) {
-		args := convertNetemToArgs(&pb.Netem{
-			Time:        1000,
-			Jitter:      10000,
-			Reorder:     5,
-			Gap:         10,
-			Corrupt:     10,
-			CorruptCorr: 50,
-		})
-		g.Expect(args).To(Equal("delay 1000 10000 reorder 5.000000 gap 10 corrupt 10.000000 50.000000"))
-	})
+func TestAbstractTcFilterWithPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := &pb.Tc{
+		Type:       pb.Tc_NETEM,
+		Ipset:      "tgt",
+		Protocol:   "tcp",
+		EgressPort: "80",
+		SourcePort: "8080",
+	}
+	g.Expect(abstractTcFilter(tc)).To(Equal("tgt-tcp-80-8080"))
 }
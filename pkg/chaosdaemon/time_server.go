@@ -20,6 +20,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/errcode"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -29,7 +30,7 @@ func (s *DaemonServer) SetTimeOffset(ctx context.Context, req *pb.TimeRequest) (
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
 	if err != nil {
 		log.Error(err, "error while getting PID")
-		return nil, err
+		return nil, errcode.Error(errcode.ContainerNotFound, err)
 	}
 
 	childPids, err := GetChildProcesses(pid)
@@ -46,6 +47,7 @@ func (s *DaemonServer) SetTimeOffset(ctx context.Context, req *pb.TimeRequest) (
 			return nil, err
 		}
 	}
+	s.activeRules.set(timeRuleKind, req.ContainerId, 1)
 
 	return &empty.Empty{}, nil
 }
@@ -56,7 +58,7 @@ func (s *DaemonServer) RecoverTimeOffset(ctx context.Context, req *pb.TimeReques
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
 	if err != nil {
 		log.Error(err, "error while getting PID")
-		return nil, err
+		return nil, errcode.Error(errcode.ContainerNotFound, err)
 	}
 
 	childPids, err := GetChildProcesses(pid)
@@ -74,6 +76,7 @@ func (s *DaemonServer) RecoverTimeOffset(ctx context.Context, req *pb.TimeReques
 			return nil, err
 		}
 	}
+	s.activeRules.set(timeRuleKind, req.ContainerId, 0)
 
 	return &empty.Empty{}, nil
 }
@@ -17,7 +17,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
@@ -108,6 +111,45 @@ func GetChildProcesses(ppid uint32) ([]uint32, error) {
 	}
 }
 
+// FindMatchedProcess returns the first process in the process tree of pid, whose command name or command line
+// matches the pattern. The process itself is checked before its children, and the children are checked in the
+// order of their pids.
+func FindMatchedProcess(pid uint32, pattern string) (uint32, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	childPids, err := GetChildProcesses(pid)
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(childPids, func(i, j int) bool {
+		return childPids[i] < childPids[j]
+	})
+
+	for _, candidate := range append([]uint32{pid}, childPids...) {
+		comm, err := ReadCommName(int(candidate))
+		if err != nil {
+			// the process may have exited
+			continue
+		}
+		if re.MatchString(strings.TrimSpace(comm)) {
+			return candidate, nil
+		}
+
+		cmdline, err := ioutil.ReadFile(fmt.Sprintf("%s/%d/cmdline", bpm.DefaultProcPrefix, candidate))
+		if err != nil {
+			continue
+		}
+		if re.MatchString(strings.TrimSpace(strings.ReplaceAll(string(cmdline), "\x00", " "))) {
+			return candidate, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no process matches %q in the process tree of %d", pattern, pid)
+}
+
 func encodeOutputToError(output []byte, err error) error {
 	return fmt.Errorf("error code: %v, msg: %s", err, string(output))
 }
@@ -0,0 +1,60 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"os"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("util", func() {
+	Context("FindMatchedProcess", func() {
+		var child *exec.Cmd
+
+		BeforeEach(func() {
+			child = exec.Command("sleep", "60")
+			Expect(child.Start()).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = child.Process.Kill()
+			_ = child.Wait()
+		})
+
+		It("should select the matched child rather than the main process", func() {
+			pid, err := FindMatchedProcess(uint32(os.Getpid()), "^sleep$")
+			Expect(err).To(BeNil())
+			Expect(pid).To(Equal(uint32(child.Process.Pid)))
+		})
+
+		It("should match the command line", func() {
+			pid, err := FindMatchedProcess(uint32(os.Getpid()), "sleep 6[0-9]")
+			Expect(err).To(BeNil())
+			Expect(pid).To(Equal(uint32(child.Process.Pid)))
+		})
+
+		It("should fail when no process matches", func() {
+			_, err := FindMatchedProcess(uint32(os.Getpid()), "^no-such-process$")
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should fail with an invalid pattern", func() {
+			_, err := FindMatchedProcess(uint32(os.Getpid()), "(")
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
@@ -22,6 +22,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"k8s.io/apimachinery/pkg/runtime"
 	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	pkgclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,6 +35,7 @@ var K8sClients Clients
 type Clients interface {
 	Client(token string) (pkgclient.Client, error)
 	AuthClient(token string) (authorizationv1.AuthorizationV1Interface, error)
+	CoreClient(token string) (corev1.CoreV1Interface, error)
 	Num() int
 	Contains(token string) bool
 }
@@ -41,6 +43,7 @@ type Clients interface {
 type LocalClient struct {
 	client     pkgclient.Client
 	authClient authorizationv1.AuthorizationV1Interface
+	coreClient corev1.CoreV1Interface
 }
 
 func NewLocalClient(localConfig *rest.Config, scheme *runtime.Scheme) (Clients, error) {
@@ -56,9 +59,15 @@ func NewLocalClient(localConfig *rest.Config, scheme *runtime.Scheme) (Clients,
 		return nil, err
 	}
 
+	coreCli, err := corev1.NewForConfig(localConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &LocalClient{
 		client:     client,
 		authClient: authCli,
+		coreClient: coreCli,
 	}, nil
 }
 
@@ -71,6 +80,11 @@ func (c *LocalClient) AuthClient(token string) (authorizationv1.AuthorizationV1I
 	return c.authClient, nil
 }
 
+// CoreClient returns the local core client, which is used to operate on the subresources like logs
+func (c *LocalClient) CoreClient(token string) (corev1.CoreV1Interface, error) {
+	return c.coreClient, nil
+}
+
 // Num returns the num of clients
 func (c *LocalClient) Num() int {
 	return 1
@@ -89,6 +103,7 @@ type ClientsPool struct {
 	localConfig *rest.Config
 	clients     *lru.Cache
 	authClients *lru.Cache
+	coreClients *lru.Cache
 }
 
 // New creates a new Clients
@@ -103,11 +118,17 @@ func NewClientPool(localConfig *rest.Config, scheme *runtime.Scheme, maxClientNu
 		return nil, err
 	}
 
+	coreClients, err := lru.New(maxClientNum)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ClientsPool{
 		localConfig: localConfig,
 		scheme:      scheme,
 		clients:     clients,
 		authClients: authClients,
+		coreClients: coreClients,
 	}, nil
 }
 
@@ -174,6 +195,34 @@ func (c *ClientsPool) AuthClient(token string) (authorizationv1.AuthorizationV1I
 	return authCli, nil
 }
 
+// CoreClient returns a core client according to the token, which is used to operate on the subresources like logs
+func (c *ClientsPool) CoreClient(token string) (corev1.CoreV1Interface, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(token) == 0 {
+		return nil, errors.New("token is empty")
+	}
+
+	value, ok := c.coreClients.Get(token)
+	if ok {
+		return value.(corev1.CoreV1Interface), nil
+	}
+
+	config := rest.CopyConfig(c.localConfig)
+	config.BearerToken = token
+	config.BearerTokenFile = ""
+
+	coreCli, err := corev1.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = c.coreClients.Add(token, coreCli)
+
+	return coreCli, nil
+}
+
 // Num returns the num of clients
 func (c *ClientsPool) Num() int {
 	return c.clients.Len()
@@ -209,3 +258,9 @@ func ExtractTokenAndGetAuthClient(header http.Header) (authorizationv1.Authoriza
 	token := ExtractTokenFromHeader(header)
 	return K8sClients.AuthClient(token)
 }
+
+// ExtractTokenAndGetCoreClient extracts token from http header, and get the core client of this token
+func ExtractTokenAndGetCoreClient(header http.Header) (corev1.CoreV1Interface, error) {
+	token := ExtractTokenFromHeader(header)
+	return K8sClients.CoreClient(token)
+}
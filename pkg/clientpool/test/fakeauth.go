@@ -0,0 +1,42 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// NewFakeAuthClient returns an authorization client which allows the access to the given namespaces only,
+// the empty namespace stands for the access to the whole cluster, which covers all the namespaces.
+func NewFakeAuthClient(namespaces ...string) authorizationv1client.AuthorizationV1Interface {
+	allowed := make(map[string]struct{})
+	for _, ns := range namespaces {
+		allowed[ns] = struct{}{}
+	}
+
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).DeepCopy()
+			_, cluster := allowed[""]
+			_, namespaced := allowed[sar.Spec.ResourceAttributes.Namespace]
+			sar.Status.Allowed = cluster || namespaced
+			return true, sar, nil
+		})
+	return clientset.AuthorizationV1()
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"errors"
+
+	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/clientpool"
+)
+
+var _ clientpool.Clients = &FakeClients{}
+
+// FakeClients returns the same clients for every token, the clients which are not set are unavailable.
+type FakeClients struct {
+	client     client.Client
+	authClient authorizationv1.AuthorizationV1Interface
+	coreClient corev1.CoreV1Interface
+}
+
+// NewFakeClients returns a FakeClients with the kubernetes client
+func NewFakeClients(client client.Client) *FakeClients {
+	return &FakeClients{client: client}
+}
+
+// WithAuthClient sets the authorization client
+func (f *FakeClients) WithAuthClient(authClient authorizationv1.AuthorizationV1Interface) *FakeClients {
+	f.authClient = authClient
+	return f
+}
+
+// WithCoreClient sets the core client
+func (f *FakeClients) WithCoreClient(coreClient corev1.CoreV1Interface) *FakeClients {
+	f.coreClient = coreClient
+	return f
+}
+
+func (f *FakeClients) Client(string) (client.Client, error) {
+	if f.client == nil {
+		return nil, errors.New("the kubernetes client is not set")
+	}
+	return f.client, nil
+}
+
+func (f *FakeClients) AuthClient(string) (authorizationv1.AuthorizationV1Interface, error) {
+	if f.authClient == nil {
+		return nil, errors.New("the authorization client is not set")
+	}
+	return f.authClient, nil
+}
+
+func (f *FakeClients) CoreClient(string) (corev1.CoreV1Interface, error) {
+	if f.coreClient == nil {
+		return nil, errors.New("the core client is not set")
+	}
+	return f.coreClient, nil
+}
+
+func (f *FakeClients) Num() int {
+	return 1
+}
+
+func (f *FakeClients) Contains(string) bool {
+	return true
+}
@@ -89,7 +89,8 @@ func (r *ChaosCollector) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		r.Log.Error(nil, "failed to get chaos meta information")
 	}
 
-	if chaosMeta.GetLabels()["managed-by"] != "" {
+	// the chaos managed by a schedule is archived only if the schedule keeps its history
+	if chaosMeta.GetLabels()["managed-by"] != "" && chaosMeta.GetAnnotations()[v1alpha1.ArchiveAnnotationKey] != "true" {
 		manageFlag = true
 	}
 
@@ -0,0 +1,96 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/pkg/core"
+)
+
+// fakeExperimentStore archives the experiments in memory
+type fakeExperimentStore struct {
+	core.ExperimentStore
+	archived []string
+}
+
+func (f *fakeExperimentStore) Archive(_ context.Context, namespace, name string) error {
+	f.archived = append(f.archived, namespace+"/"+name)
+	return nil
+}
+
+// fakeEventStore deletes the events in memory
+type fakeEventStore struct {
+	core.EventStore
+	deleted []string
+}
+
+func (f *fakeEventStore) DeleteByUID(_ context.Context, uid string) error {
+	f.deleted = append(f.deleted, uid)
+	return nil
+}
+
+func TestArchiveScheduledExperiment(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "schedule-1"}
+	now := metav1.Now()
+	chaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         key.Namespace,
+			Name:              key.Name,
+			UID:               "uid-1",
+			Labels:            map[string]string{"managed-by": "schedule"},
+			DeletionTimestamp: &now,
+		},
+		Spec: v1alpha1.PodChaosSpec{Action: v1alpha1.PodKillAction},
+	}
+
+	reconcile := func(annotations map[string]string) (*fakeExperimentStore, *fakeEventStore) {
+		chaos := chaos.DeepCopy()
+		chaos.Annotations = annotations
+		archive := &fakeExperimentStore{}
+		event := &fakeEventStore{}
+		r := &ChaosCollector{
+			Client:  fake.NewFakeClientWithScheme(provider.NewScheme(), chaos),
+			Log:     zap.New(zap.UseDevMode(true)),
+			apiType: &v1alpha1.PodChaos{},
+			archive: archive,
+			event:   event,
+		}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		return archive, event
+	}
+
+	// the history of the scheduled experiment is dropped by default
+	archive, event := reconcile(nil)
+	g.Expect(archive.archived).To(BeEmpty())
+	g.Expect(event.deleted).To(ConsistOf("uid-1"))
+
+	// the scheduled experiment cleaned up by the history limit is kept in the archive
+	archive, event = reconcile(map[string]string{v1alpha1.ArchiveAnnotationKey: "true"})
+	g.Expect(archive.archived).To(ConsistOf("default/schedule-1"))
+	g.Expect(event.deleted).To(BeEmpty())
+}
@@ -15,18 +15,21 @@ package collector
 
 import (
 	"context"
+	"strings"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 	"github.com/chaos-mesh/chaos-mesh/pkg/core"
 )
 
@@ -81,6 +84,7 @@ func (r *EventCollector) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		Name:      event.InvolvedObject.Name,
 		Namespace: event.InvolvedObject.Namespace,
 		ObjectID:  string(event.InvolvedObject.UID),
+		Pod:       targetPod(event),
 	}
 	if err := r.event.Create(context.Background(), &et); err != nil {
 		r.Log.Error(err, "failed to save event", "event", et)
@@ -89,6 +93,34 @@ func (r *EventCollector) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
+// targetPod returns the "namespace/name" of the pod targeted by the event. The records of the chaos on pods are
+// identified by the namespace and the name of the pod, optionally followed by the container or other suffixes.
+func targetPod(event *v1.Event) string {
+	ev, err := recorder.FromAnnotations(event.Annotations)
+	if err != nil {
+		return ""
+	}
+
+	var id string
+	switch ev := ev.(type) {
+	case recorder.Applied:
+		id = ev.Id
+	case recorder.Recovered:
+		id = ev.Id
+	case recorder.RecordFailed:
+		id = ev.Id
+	default:
+		return ""
+	}
+
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) < 2 ||
+		len(validation.IsDNS1123Label(parts[0])) > 0 || len(validation.IsDNS1123Subdomain(parts[1])) > 0 {
+		return ""
+	}
+	return parts[0] + "/" + parts[1]
+}
+
 // Setup setups collectors by Manager.
 func (r *EventCollector) Setup(mgr ctrl.Manager, apiType runtime.Object) error {
 	r.apiType = apiType
@@ -0,0 +1,45 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTargetPod(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newEvent := func(annotations map[string]string) *v1.Event {
+		return &v1.Event{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+
+	cases := []struct {
+		annotations map[string]string
+		pod         string
+	}{
+		{map[string]string{"chaos-mesh.org/type": "applied", "chaos-mesh.org/id": "default/pod-0"}, "default/pod-0"},
+		{map[string]string{"chaos-mesh.org/type": "recovered", "chaos-mesh.org/id": "default/pod-0/container-0"}, "default/pod-0"},
+		{map[string]string{"chaos-mesh.org/type": "record-failed", "chaos-mesh.org/id": "default/pod-0", "chaos-mesh.org/activity": "apply chaos", "chaos-mesh.org/err": "timeout"}, "default/pod-0"},
+		{map[string]string{"chaos-mesh.org/type": "failed", "chaos-mesh.org/activity": "update records", "chaos-mesh.org/err": "conflict"}, ""},
+		{map[string]string{"chaos-mesh.org/type": "applied", "chaos-mesh.org/id": `{"awsRegion":"us-east-1"}`}, ""},
+		{nil, ""},
+	}
+	for _, c := range cases {
+		g.Expect(targetPod(newEvent(c.annotations))).To(Equal(c.pod), "%v", c.annotations)
+	}
+}
@@ -65,6 +65,9 @@ type ChaosControllerConfig struct {
 	// TargetNamespace is the target namespace to injecting chaos.
 	// It only works with ClusterScoped is false;
 	TargetNamespace string `envconfig:"TARGET_NAMESPACE" default:""`
+	// PercentRoundingMode is how the number of pods in the fixed-percent and random-max-percent modes is rounded,
+	// one of round, ceil and floor
+	PercentRoundingMode string `envconfig:"PERCENT_ROUNDING_MODE" default:"floor"`
 
 	// DNSServiceName is the name of DNS service, which is used for DNS chaos
 	DNSServiceName string `envconfig:"CHAOS_DNS_SERVICE_NAME" default:""`
@@ -79,8 +82,41 @@ type ChaosControllerConfig struct {
 	// AllowHostNetworkTesting removes the restriction on chaos testing pods with `hostNetwork` set to true
 	AllowHostNetworkTesting bool `envconfig:"ALLOW_HOST_NETWORK_TESTING" default:"false"`
 
+	// FinalizerTimeout is how long the finalizer waits for the chaos to be recovered after it's deleted.
+	// After that, the finalizer is removed even if some records are not recovered. Zero means waiting forever
+	FinalizerTimeout time.Duration `envconfig:"FINALIZER_TIMEOUT" default:"0"`
+
+	// MinRequeueInterval is the minimum interval to requeue the chaos with a duration or active windows, which
+	// bounds how often it's reconciled. Zero means the chaos is requeued exactly when it should be stopped
+	MinRequeueInterval time.Duration `envconfig:"MIN_REQUEUE_INTERVAL" default:"0"`
+
+	// MaxActiveDuration is the cap of how long any chaos could be active since it's injected, the chaos over it is
+	// recovered no matter what its spec says. Zero means unlimited
+	MaxActiveDuration time.Duration `envconfig:"MAX_ACTIVE_DURATION" default:"0"`
+
+	// EnableEphemeralInjection enables injecting the sidecars into the running pods as ephemeral containers,
+	// which requires the EphemeralContainers feature gate of the cluster
+	EnableEphemeralInjection bool `envconfig:"ENABLE_EPHEMERAL_INJECTION" default:"false"`
+
 	// PodFailurePauseImage is used to set a custom image for pod failure
 	PodFailurePauseImage string `envconfig:"POD_FAILURE_PAUSE_IMAGE" default:"gcr.io/google-containers/pause:latest"`
+
+	// RequireDuration makes the webhook reject any chaos without a duration, except the one-shot chaos
+	RequireDuration bool `envconfig:"REQUIRE_DURATION" default:"false"`
+	// MaxDuration makes the webhook reject any chaos whose duration exceeds it, zero means unlimited
+	MaxDuration time.Duration `envconfig:"MAX_DURATION" default:"0"`
+
+	// MaxInjectConcurrency is how many records of a chaos could be applied and recovered concurrently in a reconcile,
+	// the records are processed one by one if it's not greater than one
+	MaxInjectConcurrency int `envconfig:"MAX_INJECT_CONCURRENCY" default:"8"`
+	// MaxDaemonInflight is how many RPCs to the same chaos daemon could be inflight at the same time, across all the
+	// controllers, zero means unlimited
+	MaxDaemonInflight int `envconfig:"MAX_DAEMON_INFLIGHT" default:"0"`
+
+	// PropagatedLabels are the keys of labels copied from a Schedule or Workflow to the objects created by it
+	PropagatedLabels []string `envconfig:"PROPAGATED_LABELS"`
+	// PropagatedAnnotations are the keys of annotations copied from a Schedule or Workflow to the objects created by it
+	PropagatedAnnotations []string `envconfig:"PROPAGATED_ANNOTATIONS"`
 }
 
 // EnvironChaosController returns the settings from the environment.
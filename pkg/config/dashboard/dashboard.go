@@ -14,11 +14,13 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/ttlcontroller"
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config/watcher"
 )
 
 // ChaosDashboardConfig defines the configuration for Chaos Dashboard
@@ -34,6 +36,10 @@ type ChaosDashboardConfig struct {
 	// TargetNamespace is the target namespace to injecting chaos.
 	// It only works with ClusterScoped is false;
 	TargetNamespace string `envconfig:"TARGET_NAMESPACE" default:"" json:"target_namespace"`
+	// ExperimentsNamespaces limits the experiments exposed by the API to these namespaces.
+	// Empty means the experiments in all namespaces are exposed. The experiments exposed to a request
+	// are further limited to the namespaces where the caller can list the chaos.
+	ExperimentsNamespaces []string `envconfig:"EXPERIMENTS_NAMESPACES" json:"experiments_namespaces"`
 	// EnableFilterNamespace will filter namespace with annotation. Only the pods/containers in namespace
 	// annotated with `chaos-mesh.org/inject=enabled` will be injected
 	EnableFilterNamespace bool `envconfig:"ENABLE_FILTER_NAMESPACE" default:"false"`
@@ -41,6 +47,10 @@ type ChaosDashboardConfig struct {
 	SecurityMode    bool   `envconfig:"SECURITY_MODE" default:"true" json:"security_mode"`
 	DNSServerCreate bool   `envconfig:"DNS_SERVER_CREATE" default:"false" json:"dns_server_create"`
 	Version         string `json:"version"`
+	// WatcherConfig locates the configmaps of the injection configs, which are used to preview the injection
+	WatcherConfig *watcher.Config `json:"-"`
+	// AlertMappingsFile is the file mapping the alerts of Prometheus to the chaos created when they fire
+	AlertMappingsFile string `envconfig:"ALERT_MAPPINGS_FILE" default:"" json:"-"`
 }
 
 // PersistTTLConfig defines the configuration of ttl
@@ -48,6 +58,10 @@ type PersistTTLConfig struct {
 	SyncPeriod string `envconfig:"CLEAN_SYNC_PERIOD" default:"12h"`
 	Event      string `envconfig:"TTL_EVENT"       default:"168h"` // one week
 	Experiment string `envconfig:"TTL_EXPERIMENT"  default:"336h"` // two weeks
+	// GracePeriod is the time the expired data is kept soft deleted before it's deleted permanently
+	GracePeriod string `envconfig:"TTL_GRACE_PERIOD" default:"24h"` // one day
+	// BatchSize limits the rows deleted at a time, zero means no limit
+	BatchSize int `envconfig:"CLEAN_BATCH_SIZE" default:"1000"`
 }
 
 // DatabaseConfig defines the configuration for databases
@@ -83,9 +97,20 @@ func ParsePersistTTLConfig(config *PersistTTLConfig) (*ttlcontroller.TTLconfig,
 		return nil, err
 	}
 
+	GracePeriod, err := time.ParseDuration(config.GracePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.BatchSize < 0 {
+		return nil, fmt.Errorf("invalid batch size %d, it must not be negative", config.BatchSize)
+	}
+
 	return &ttlcontroller.TTLconfig{
 		DatabaseTTLResyncPeriod: SyncPeriod,
 		EventTTL:                Event,
 		ArchiveExperimentTTL:    Experiment,
+		GracePeriod:             GracePeriod,
+		BatchSize:               config.BatchSize,
 	}, nil
 }
@@ -41,8 +41,13 @@ type EventStore interface {
 	// Create persists a new event to the datastore.
 	Create(context.Context, *Event) error
 
-	// DeleteByCreateTime deletes events whose time difference is greater than the given time from CreateTime.
-	DeleteByCreateTime(context.Context, time.Duration) error
+	// DeleteByCreateTime soft deletes events whose time difference is greater than the given time from CreateTime,
+	// at most the given number of events at a time.
+	DeleteByCreateTime(context.Context, time.Duration, int) error
+
+	// PurgeDeleted hard deletes events which have been soft deleted for longer than the given time,
+	// at most the given number of events at a time.
+	PurgeDeleted(context.Context, time.Duration, int) error
 
 	// DeleteByUID deletes events list by the UID.
 	DeleteByUID(context.Context, string) error
@@ -62,6 +67,10 @@ type Event struct {
 	Name      string    `json:"name"`
 	Namespace string    `json:"namespace"`
 	ObjectID  string    `gorm:"index:object_id" json:"object_id"`
+	// Pod is the "namespace/name" of the pod targeted by the event, it's empty if the event doesn't target a pod
+	Pod string `gorm:"index:pod" json:"pod,omitempty"`
+	// DeletedAt is set when the event is soft deleted, and the event is hard deleted after a grace period
+	DeletedAt *time.Time `sql:"index" json:"-"`
 }
 
 // Filter represents the filter to list events
@@ -72,4 +81,6 @@ type Filter struct {
 	ObjectID      string
 	Kind          string
 	LimitStr      string
+	// Pod is the "namespace/name" of a pod targeted by the events
+	Pod string
 }
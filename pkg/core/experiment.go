@@ -30,6 +30,10 @@ type ExperimentStore interface {
 	// ListMeta returns experiment metadata list from the datastore.
 	ListMeta(ctx context.Context, kind, namespace, name string, archived bool) ([]*ExperimentMeta, error)
 
+	// ListMetaByFilter returns the experiment metadata list matching the filter from the datastore,
+	// in the same order as ListMeta.
+	ListMetaByFilter(ctx context.Context, filter ExperimentFilter) ([]*ExperimentMeta, error)
+
 	// FindByUID returns an experiment by UID.
 	FindByUID(ctx context.Context, UID string) (*Experiment, error)
 
@@ -45,8 +49,13 @@ type ExperimentStore interface {
 	// Delete deletes the archive from the datastore.
 	Delete(context.Context, *Experiment) error
 
-	// DeleteByFinishTime deletes archives which time difference is greater than the given time from FinishTime.
-	DeleteByFinishTime(context.Context, time.Duration) error
+	// DeleteByFinishTime soft deletes archives which time difference is greater than the given time from FinishTime,
+	// at most the given number of archives at a time.
+	DeleteByFinishTime(context.Context, time.Duration, int) error
+
+	// PurgeDeleted hard deletes archives which have been soft deleted for longer than the given time,
+	// at most the given number of archives at a time.
+	PurgeDeleted(context.Context, time.Duration, int) error
 
 	// DeleteByUIDs deletes archives by the uid list.
 	DeleteByUIDs(context.Context, []string) error
@@ -77,6 +86,20 @@ type ExperimentMeta struct {
 	Archived   bool      `json:"archived"`
 }
 
+// ExperimentFilter represents the filter to list experiments, the empty or zero fields match all.
+type ExperimentFilter struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Archived  bool
+	// StartTime excludes the experiments started before it
+	StartTime time.Time
+	// FinishTime excludes the experiments finished after it
+	FinishTime time.Time
+	Limit      int
+	Offset     int
+}
+
 // ExperimentInfo defines a form data of Experiment from API.
 type ExperimentInfo struct {
 	Name        string            `json:"name" binding:"required,NameValid"`
@@ -97,14 +120,15 @@ type ScopeInfo struct {
 
 // SelectorInfo defines the selector options of the Experiment.
 type SelectorInfo struct {
-	Namespaces          []string                          `json:"namespaces,omitempty" binding:"NamespaceSelectorsValid"`
-	Nodes               []string                          `json:"nodes,omitempty"`
-	NodeSelectors       map[string]string                 `json:"node_selectors,omitempty"`
-	FieldSelectors      map[string]string                 `json:"field_selectors,omitempty" binding:"MapSelectorsValid"`
-	LabelSelectors      map[string]string                 `json:"label_selectors,omitempty" binding:"MapSelectorsValid"`
-	ExpressionSelectors []metav1.LabelSelectorRequirement `json:"expression_selectors,omitempty" binding:"RequirementSelectorsValid"`
-	AnnotationSelectors map[string]string                 `json:"annotation_selectors,omitempty" binding:"MapSelectorsValid"`
-	PodPhaseSelectors   []string                          `json:"phase_selectors,omitempty" binding:"PhaseSelectorsValid"`
+	Namespaces                    []string                          `json:"namespaces,omitempty" binding:"NamespaceSelectorsValid"`
+	Nodes                         []string                          `json:"nodes,omitempty"`
+	NodeSelectors                 map[string]string                 `json:"node_selectors,omitempty"`
+	FieldSelectors                map[string]string                 `json:"field_selectors,omitempty" binding:"MapSelectorsValid"`
+	LabelSelectors                map[string]string                 `json:"label_selectors,omitempty" binding:"MapSelectorsValid"`
+	ExpressionSelectors           []metav1.LabelSelectorRequirement `json:"expression_selectors,omitempty" binding:"RequirementSelectorsValid"`
+	AnnotationSelectors           map[string]string                 `json:"annotation_selectors,omitempty" binding:"MapSelectorsValid"`
+	AnnotationExpressionSelectors []metav1.LabelSelectorRequirement `json:"annotation_expression_selectors,omitempty" binding:"RequirementSelectorsValid"`
+	PodPhaseSelectors             []string                          `json:"phase_selectors,omitempty" binding:"PhaseSelectorsValid"`
 
 	// Pods is a map of string keys and a set values that used to select pods.
 	// The key defines the namespace which pods belong,
@@ -129,6 +153,8 @@ func (s *SelectorInfo) ParseSelector() v1alpha1.PodSelectorSpec {
 		selector.AnnotationSelectors[key] = val
 	}
 
+	selector.AnnotationExpressionSelectors = append(selector.AnnotationExpressionSelectors, s.AnnotationExpressionSelectors...)
+
 	selector.FieldSelectors = make(map[string]string)
 	for key, val := range s.FieldSelectors {
 		selector.FieldSelectors[key] = val
@@ -16,6 +16,7 @@ package core
 import (
 	"context"
 	"encoding/json"
+	"sort"
 	"strings"
 	"time"
 
@@ -69,14 +70,32 @@ type WorkflowDetail struct {
 // Topology describes the process of a workflow.
 type Topology struct {
 	Nodes []Node `json:"nodes"`
+	// Tree organizes the nodes by their parents, the roots of it are the nodes created by the workflow directly.
+	Tree []TreeNode `json:"tree"`
+}
+
+// TreeNode refers to a node in Topology.Nodes with its children, which are in the order of creation.
+type TreeNode struct {
+	Name     string     `json:"name"`
+	Children []TreeNode `json:"children,omitempty"`
 }
 
 type NodeState string
 
 const (
-	NodeRunning NodeState = "Running"
-	NodeSucceed NodeState = "Succeed"
-	NodeFailed  NodeState = "Failed"
+	// NodeWaitingForSchedule means the node has been created, but not been processed by the controllers yet.
+	NodeWaitingForSchedule NodeState = "WaitingForSchedule"
+	NodeRunning            NodeState = "Running"
+	// NodeHolding means the chaos has been injected, or the suspend node is waiting for its deadline.
+	NodeHolding NodeState = "Holding"
+	// NodeWaitingForChild means the serial, parallel or task node is waiting for its children.
+	NodeWaitingForChild NodeState = "WaitingForChild"
+	// NodeEvaluating means the task pod has completed, but the conditional branches have not been evaluated yet.
+	NodeEvaluating NodeState = "Evaluating"
+	NodeSucceed    NodeState = "Succeed"
+	// NodeFailed means the chaos custom resource or the task pod of the node could not be created, or any child of
+	// the serial or parallel node failed.
+	NodeFailed NodeState = "Failed"
 )
 
 // Node defines a single step of a workflow.
@@ -111,6 +130,8 @@ type NodeParallel struct {
 type ConditionalBranch struct {
 	NodeNameWithTemplate `json:",inline,omitempty"`
 	Expression           string `json:"expression,omitempty"`
+	// EvaluationResult is the result of the expression after the task is completed, the branch is taken if it's True.
+	EvaluationResult corev1.ConditionStatus `json:"evaluation_result,omitempty"`
 }
 
 // NodeType represents the type of a workflow node.
@@ -267,7 +288,11 @@ func convertWorkflow(kubeWorkflow v1alpha1.Workflow) WorkflowMeta {
 		result.EndTime = kubeWorkflow.Status.EndTime.Format(time.RFC3339)
 	}
 
-	if wfcontrollers.WorkflowConditionEqualsTo(kubeWorkflow.Status, v1alpha1.WorkflowConditionAccomplished, corev1.ConditionTrue) {
+	if accomplished := wfcontrollers.GetWorkflowCondition(kubeWorkflow.Status, v1alpha1.WorkflowConditionAccomplished); accomplished != nil &&
+		accomplished.Status == corev1.ConditionTrue && accomplished.Reason == v1alpha1.WorkflowDeadlineExceed {
+		// the workflow is accomplished by its deadline, with the running nodes finished
+		result.Status = WorkflowFailed
+	} else if wfcontrollers.WorkflowConditionEqualsTo(kubeWorkflow.Status, v1alpha1.WorkflowConditionAccomplished, corev1.ConditionTrue) {
 		result.Status = WorkflowSucceed
 	} else if wfcontrollers.WorkflowConditionEqualsTo(kubeWorkflow.Status, v1alpha1.WorkflowConditionScheduled, corev1.ConditionTrue) {
 		result.Status = WorkflowRunning
@@ -275,8 +300,6 @@ func convertWorkflow(kubeWorkflow v1alpha1.Workflow) WorkflowMeta {
 		result.Status = WorkflowUnknown
 	}
 
-	// TODO: status failed
-
 	return result
 }
 
@@ -296,6 +319,7 @@ func convertWorkflowDetail(kubeWorkflow v1alpha1.Workflow, kubeNodes []v1alpha1.
 		WorkflowMeta: convertWorkflow(kubeWorkflow),
 		Topology: Topology{
 			Nodes: nodes,
+			Tree:  buildNodeTree(kubeNodes),
 		},
 		KubeObject: KubeObjectDesc{
 			TypeMeta: kubeWorkflow.TypeMeta,
@@ -312,6 +336,56 @@ func convertWorkflowDetail(kubeWorkflow v1alpha1.Workflow, kubeNodes []v1alpha1.
 	return result, nil
 }
 
+// buildNodeTree organizes the workflow nodes by their parents, which are the controllers of the nodes.
+func buildNodeTree(kubeNodes []v1alpha1.WorkflowNode) []TreeNode {
+	sortedNodes := make([]v1alpha1.WorkflowNode, len(kubeNodes))
+	copy(sortedNodes, kubeNodes)
+	sort.Stable(wfcontrollers.SortByCreationTimestamp(sortedNodes))
+
+	names := make(map[string]struct{})
+	for _, node := range sortedNodes {
+		names[node.Name] = struct{}{}
+	}
+
+	var roots []string
+	children := make(map[string][]string)
+	for _, node := range sortedNodes {
+		parent := parentNodeName(node)
+		if _, ok := names[parent]; ok && parent != node.Name {
+			children[parent] = append(children[parent], node.Name)
+		} else {
+			// the node is created by the workflow, or its parent has gone
+			roots = append(roots, node.Name)
+		}
+	}
+
+	var build func(names []string) []TreeNode
+	build = func(names []string) []TreeNode {
+		var result []TreeNode
+		for _, name := range names {
+			result = append(result, TreeNode{
+				Name:     name,
+				Children: build(children[name]),
+			})
+		}
+		return result
+	}
+
+	tree := build(roots)
+	if tree == nil {
+		return []TreeNode{}
+	}
+	return tree
+}
+
+// parentNodeName returns the name of the parent node, or an empty string if the node is created by the workflow.
+func parentNodeName(kubeWorkflowNode v1alpha1.WorkflowNode) string {
+	if owner := metav1.GetControllerOf(&kubeWorkflowNode); owner != nil && owner.Kind == wfcontrollers.KindWorkflowNode {
+		return owner.Name
+	}
+	return ""
+}
+
 func convertWorkflowNode(kubeWorkflowNode v1alpha1.WorkflowNode) (Node, error) {
 	templateType, err := mappingTemplateType(kubeWorkflowNode.Spec.Type)
 	if err != nil {
@@ -357,18 +431,66 @@ func convertWorkflowNode(kubeWorkflowNode v1alpha1.WorkflowNode) (Node, error) {
 		for _, child := range kubeWorkflowNode.Status.ActiveChildren {
 			nodes = append(nodes, child.Name)
 		}
-		result.ConditionalBranches = composeTaskConditionalBranches(kubeWorkflowNode.Spec.ConditionalBranches, nodes)
+		result.ConditionalBranches = composeTaskConditionalBranches(kubeWorkflowNode.Spec.ConditionalBranches, kubeWorkflowNode.Status.ConditionalBranchesStatus, nodes)
 	}
 
-	if wfcontrollers.WorkflowNodeFinished(kubeWorkflowNode.Status) {
-		result.State = NodeSucceed
-	} else {
-		result.State = NodeRunning
-	}
+	result.State = convertWorkflowNodeState(kubeWorkflowNode)
 
 	return result, nil
 }
 
+// nodeFailureReasons are the reasons of the unsatisfied conditions which mean the node has failed.
+var nodeFailureReasons = map[string]bool{
+	v1alpha1.ChaosCRCreateFailed: true,
+	v1alpha1.TaskPodSpawnFailed:  true,
+}
+
+// convertWorkflowNodeState infers the state of the workflow node from its status.
+func convertWorkflowNodeState(kubeWorkflowNode v1alpha1.WorkflowNode) NodeState {
+	status := kubeWorkflowNode.Status
+	for _, condition := range status.Conditions {
+		if condition.Status == corev1.ConditionFalse && nodeFailureReasons[condition.Reason] {
+			return NodeFailed
+		}
+	}
+	if wfcontrollers.WorkflowNodeChildFailed(status) {
+		return NodeFailed
+	}
+
+	if wfcontrollers.WorkflowNodeFinished(status) {
+		return NodeSucceed
+	}
+
+	if len(status.Conditions) == 0 && len(status.ActiveChildren) == 0 && len(status.FinishedChildren) == 0 &&
+		status.ConditionalBranchesStatus == nil {
+		return NodeWaitingForSchedule
+	}
+
+	switch kubeWorkflowNode.Spec.Type {
+	case v1alpha1.TypeSerial, v1alpha1.TypeParallel:
+		if len(status.ActiveChildren) > 0 {
+			return NodeWaitingForChild
+		}
+	case v1alpha1.TypeTask:
+		if len(status.ActiveChildren) > 0 {
+			return NodeWaitingForChild
+		}
+		accomplished := wfcontrollers.GetCondition(status, v1alpha1.ConditionAccomplished)
+		if accomplished != nil && accomplished.Reason == v1alpha1.TaskPodPodCompleted &&
+			!wfcontrollers.ConditionalBranchesEvaluated(kubeWorkflowNode) {
+			return NodeEvaluating
+		}
+	case v1alpha1.TypeSuspend:
+		return NodeHolding
+	default:
+		if wfcontrollers.ConditionEqualsTo(status, v1alpha1.ConditionChaosInjected, corev1.ConditionTrue) {
+			return NodeHolding
+		}
+	}
+
+	return NodeRunning
+}
+
 // composeSerialTaskAndNodes need nodes to be ordered with its creation time
 func composeSerialTaskAndNodes(children []string, nodes []string) []NodeNameWithTemplate {
 	var result []NodeNameWithTemplate
@@ -402,22 +524,28 @@ func composeParallelTaskAndNodes(children []string, nodes []string) []NodeNameWi
 	return result
 }
 
-func composeTaskConditionalBranches(conditionalBranches []v1alpha1.ConditionalBranch, nodes []string) []ConditionalBranch {
+func composeTaskConditionalBranches(conditionalBranches []v1alpha1.ConditionalBranch, branchesStatus *v1alpha1.ConditionalBranchesStatus, nodes []string) []ConditionalBranch {
 	var result []ConditionalBranch
-	for _, item := range conditionalBranches {
+	for index, item := range conditionalBranches {
 		nodeName := ""
 		for _, node := range nodes {
 			if strings.HasPrefix(node, item.Target) {
 				nodeName = node
 			}
 		}
+		// the branches are evaluated in the order of the spec
+		var evaluationResult corev1.ConditionStatus
+		if branchesStatus != nil && index < len(branchesStatus.Branches) && branchesStatus.Branches[index].Target == item.Target {
+			evaluationResult = branchesStatus.Branches[index].EvaluationResult
+		}
 		result = append(result,
 			ConditionalBranch{
 				NodeNameWithTemplate: NodeNameWithTemplate{
 					Name:     nodeName,
 					Template: item.Target,
 				},
-				Expression: item.Expression,
+				Expression:       item.Expression,
+				EvaluationResult: evaluationResult,
 			})
 	}
 
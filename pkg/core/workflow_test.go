@@ -16,6 +16,7 @@ package core
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -185,6 +186,40 @@ func Test_convertWorkflow(t *testing.T) {
 				Entry:     "an-entry",
 				Status:    WorkflowSucceed,
 			},
+		}, {
+			name: "workflow exceeded its deadline",
+			args: args{
+				v1alpha1.Workflow{
+					TypeMeta: metav1.TypeMeta{},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "fake-workflow-0",
+					},
+					Spec: v1alpha1.WorkflowSpec{
+						Entry: "an-entry",
+					},
+					Status: v1alpha1.WorkflowStatus{
+						Conditions: []v1alpha1.WorkflowCondition{
+							{
+								Type:   v1alpha1.WorkflowConditionAccomplished,
+								Status: corev1.ConditionTrue,
+								Reason: v1alpha1.WorkflowDeadlineExceed,
+							},
+							{
+								Type:   v1alpha1.WorkflowConditionScheduled,
+								Status: corev1.ConditionTrue,
+								Reason: "",
+							},
+						},
+					},
+				},
+			},
+			want: WorkflowMeta{
+				Namespace: "fake-namespace",
+				Name:      "fake-workflow-0",
+				Entry:     "an-entry",
+				Status:    WorkflowFailed,
+			},
 		}, {
 			name: "converting UID",
 			args: args{
@@ -269,6 +304,7 @@ func Test_convertWorkflowDetail(t *testing.T) {
 				},
 				Topology: Topology{
 					Nodes: []Node{},
+					Tree:  []TreeNode{},
 				},
 				KubeObject: KubeObjectDesc{
 					Meta: KubeObjectMeta{
@@ -297,6 +333,72 @@ func Test_convertWorkflowDetail(t *testing.T) {
 	}
 }
 
+func Test_buildNodeTree(t *testing.T) {
+	now := time.Now()
+	newNode := func(name string, parent string, createdAfter time.Duration) v1alpha1.WorkflowNode {
+		node := v1alpha1.WorkflowNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "fake-namespace",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(createdAfter)),
+			},
+		}
+		isController := true
+		if len(parent) == 0 {
+			node.OwnerReferences = []metav1.OwnerReference{{
+				Kind:       "Workflow",
+				Name:       "fake-workflow",
+				Controller: &isController,
+			}}
+		} else {
+			node.OwnerReferences = []metav1.OwnerReference{{
+				Kind:       "WorkflowNode",
+				Name:       parent,
+				Controller: &isController,
+			}}
+		}
+		return node
+	}
+
+	// entry(serial) -> [parallel -> [chaos-a, chaos-b], suspend]
+	kubeNodes := []v1alpha1.WorkflowNode{
+		newNode("suspend-0", "entry-0", 4*time.Second),
+		newNode("chaos-b-0", "parallel-0", 3*time.Second),
+		newNode("entry-0", "", 0),
+		newNode("chaos-a-0", "parallel-0", 2*time.Second),
+		newNode("parallel-0", "entry-0", time.Second),
+	}
+	want := []TreeNode{
+		{
+			Name: "entry-0",
+			Children: []TreeNode{
+				{
+					Name: "parallel-0",
+					Children: []TreeNode{
+						{Name: "chaos-a-0"},
+						{Name: "chaos-b-0"},
+					},
+				},
+				{Name: "suspend-0"},
+			},
+		},
+	}
+	if got := buildNodeTree(kubeNodes); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildNodeTree() = %v, want %v", got, want)
+	}
+
+	// the node whose parent is not found is regarded as a root
+	orphan := newNode("orphan-0", "gone-0", 5*time.Second)
+	want = append(want, TreeNode{Name: "orphan-0"})
+	if got := buildNodeTree(append(kubeNodes, orphan)); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildNodeTree() = %v, want %v", got, want)
+	}
+
+	if got := buildNodeTree(nil); !reflect.DeepEqual(got, []TreeNode{}) {
+		t.Errorf("buildNodeTree() = %v, want empty tree", got)
+	}
+}
+
 func Test_convertWorkflowNode(t *testing.T) {
 	type args struct {
 		kubeWorkflowNode v1alpha1.WorkflowNode
@@ -328,7 +430,7 @@ func Test_convertWorkflowNode(t *testing.T) {
 				Serial:   nil,
 				Parallel: nil,
 				Template: "fake-template-0",
-				State:    NodeRunning,
+				State:    NodeWaitingForSchedule,
 			},
 		}, {
 			name: "serial node",
@@ -359,7 +461,7 @@ func Test_convertWorkflowNode(t *testing.T) {
 				},
 				Parallel: nil,
 				Template: "fake-serial-node",
-				State:    NodeRunning,
+				State:    NodeWaitingForSchedule,
 			},
 		},
 		{
@@ -391,7 +493,7 @@ func Test_convertWorkflowNode(t *testing.T) {
 					},
 				},
 				Template: "parallel-node",
-				State:    NodeRunning,
+				State:    NodeWaitingForSchedule,
 			},
 		},
 		{
@@ -431,7 +533,7 @@ func Test_convertWorkflowNode(t *testing.T) {
 				Serial:   nil,
 				Parallel: nil,
 				Template: "io-chaos",
-				State:    NodeRunning,
+				State:    NodeWaitingForSchedule,
 			},
 		},
 		{
@@ -594,21 +696,23 @@ func Test_convertWorkflowNode(t *testing.T) {
 			want: Node{
 				Name:  "mocking-task-node-0",
 				Type:  TaskNode,
-				State: NodeRunning,
+				State: NodeWaitingForChild,
 				ConditionalBranches: []ConditionalBranch{
 					{
 						NodeNameWithTemplate: NodeNameWithTemplate{
 							Template: "one-node",
 							Name:     "",
 						},
-						Expression: "exitCode == 0",
+						Expression:       "exitCode == 0",
+						EvaluationResult: corev1.ConditionFalse,
 					},
 					{
 						NodeNameWithTemplate: NodeNameWithTemplate{
 							Template: "another-node",
 							Name:     "another-node-0",
 						},
-						Expression: "exitCode != 0",
+						Expression:       "exitCode != 0",
+						EvaluationResult: corev1.ConditionTrue,
 					},
 				},
 				Template: "mocking-task-node",
@@ -630,6 +734,253 @@ func Test_convertWorkflowNode(t *testing.T) {
 	}
 }
 
+func Test_mappingTemplateType(t *testing.T) {
+	tests := []struct {
+		templateType v1alpha1.TemplateType
+		want         NodeType
+		wantErr      bool
+	}{
+		{templateType: v1alpha1.TypeSerial, want: SerialNode},
+		{templateType: v1alpha1.TypeParallel, want: ParallelNode},
+		{templateType: v1alpha1.TypeSuspend, want: SuspendNode},
+		{templateType: v1alpha1.TypeTask, want: TaskNode},
+		{templateType: v1alpha1.TypeSchedule, want: ChaosNode},
+		{templateType: v1alpha1.TypeAWSChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeDNSChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeGCPChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeHTTPChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeIOChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeJVMChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeKernelChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeNetworkChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypePodChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeStressChaos, want: ChaosNode},
+		{templateType: v1alpha1.TypeTimeChaos, want: ChaosNode},
+		{templateType: "UnknownType", wantErr: true},
+		{templateType: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.templateType), func(t *testing.T) {
+			got, err := mappingTemplateType(tt.templateType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mappingTemplateType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("mappingTemplateType() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_convertWorkflowNodeState(t *testing.T) {
+	deadlineNotExceed := v1alpha1.WorkflowNodeCondition{
+		Type:   v1alpha1.ConditionDeadlineExceed,
+		Status: corev1.ConditionFalse,
+		Reason: v1alpha1.NodeDeadlineNotExceed,
+	}
+	branches := []v1alpha1.ConditionalBranch{
+		{Target: "one-node", Expression: "exitCode == 0"},
+	}
+
+	tests := []struct {
+		name   string
+		spec   v1alpha1.WorkflowNodeSpec
+		status v1alpha1.WorkflowNodeStatus
+		want   NodeState
+	}{
+		{
+			name: "not processed node",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypePodChaos},
+			want: NodeWaitingForSchedule,
+		},
+		{
+			name: "injecting chaos",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypePodChaos},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{deadlineNotExceed},
+			},
+			want: NodeRunning,
+		},
+		{
+			name: "injected chaos",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeNetworkChaos},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{deadlineNotExceed, {
+					Type:   v1alpha1.ConditionChaosInjected,
+					Status: corev1.ConditionTrue,
+					Reason: v1alpha1.ChaosCRCreated,
+				}},
+			},
+			want: NodeHolding,
+		},
+		{
+			name: "created schedule",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeSchedule},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{{
+					Type:   v1alpha1.ConditionChaosInjected,
+					Status: corev1.ConditionTrue,
+					Reason: v1alpha1.ChaosCRCreated,
+				}},
+			},
+			want: NodeHolding,
+		},
+		{
+			name: "suspend node",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeSuspend},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{deadlineNotExceed},
+			},
+			want: NodeHolding,
+		},
+		{
+			name: "serial node with active child",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeSerial},
+			status: v1alpha1.WorkflowNodeStatus{
+				ActiveChildren: []corev1.LocalObjectReference{{Name: "child-0"}},
+			},
+			want: NodeWaitingForChild,
+		},
+		{
+			name: "parallel node without active child",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeParallel},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{{
+					Type:   v1alpha1.ConditionAccomplished,
+					Status: corev1.ConditionFalse,
+				}},
+			},
+			want: NodeRunning,
+		},
+		{
+			name: "running task",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeTask, ConditionalBranches: branches},
+			status: v1alpha1.WorkflowNodeStatus{
+				ConditionalBranchesStatus: &v1alpha1.ConditionalBranchesStatus{
+					Branches: []v1alpha1.ConditionalBranchStatus{
+						{Target: "one-node", EvaluationResult: corev1.ConditionUnknown},
+					},
+				},
+			},
+			want: NodeRunning,
+		},
+		{
+			name: "evaluating task",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeTask, ConditionalBranches: branches},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{{
+					Type:   v1alpha1.ConditionAccomplished,
+					Status: corev1.ConditionFalse,
+					Reason: v1alpha1.TaskPodPodCompleted,
+				}},
+				ConditionalBranchesStatus: &v1alpha1.ConditionalBranchesStatus{
+					Branches: []v1alpha1.ConditionalBranchStatus{
+						{Target: "one-node", EvaluationResult: corev1.ConditionUnknown},
+					},
+				},
+			},
+			want: NodeEvaluating,
+		},
+		{
+			name: "evaluated task",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeTask, ConditionalBranches: branches},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{{
+					Type:   v1alpha1.ConditionAccomplished,
+					Status: corev1.ConditionFalse,
+					Reason: v1alpha1.TaskPodPodCompleted,
+				}},
+				ConditionalBranchesStatus: &v1alpha1.ConditionalBranchesStatus{
+					Branches: []v1alpha1.ConditionalBranchStatus{
+						{Target: "one-node", EvaluationResult: corev1.ConditionFalse},
+					},
+				},
+			},
+			want: NodeRunning,
+		},
+		{
+			name: "task failed to spawn pod",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeTask, ConditionalBranches: branches},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{{
+					Type:   v1alpha1.ConditionAccomplished,
+					Status: corev1.ConditionFalse,
+					Reason: v1alpha1.TaskPodSpawnFailed,
+				}},
+			},
+			want: NodeFailed,
+		},
+		{
+			name: "chaos failed to create",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypePodChaos},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{deadlineNotExceed, {
+					Type:   v1alpha1.ConditionChaosInjected,
+					Status: corev1.ConditionFalse,
+					Reason: v1alpha1.ChaosCRCreateFailed,
+				}},
+			},
+			want: NodeFailed,
+		},
+		{
+			name: "parallel accomplished with failed child",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeParallel},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{{
+					Type:   v1alpha1.ConditionAccomplished,
+					Status: corev1.ConditionTrue,
+					Reason: v1alpha1.ChildNodeFailed,
+				}},
+				FinishedChildren: []corev1.LocalObjectReference{{Name: "one-node-0"}},
+				FailedChildren:   []corev1.LocalObjectReference{{Name: "one-node-0"}},
+			},
+			want: NodeFailed,
+		},
+		{
+			name: "task without conditional branches",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeTask},
+			status: v1alpha1.WorkflowNodeStatus{
+				ConditionalBranchesStatus: &v1alpha1.ConditionalBranchesStatus{},
+			},
+			want: NodeRunning,
+		},
+		{
+			name: "task with active child",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeTask, ConditionalBranches: branches},
+			status: v1alpha1.WorkflowNodeStatus{
+				ConditionalBranchesStatus: &v1alpha1.ConditionalBranchesStatus{
+					Branches: []v1alpha1.ConditionalBranchStatus{
+						{Target: "one-node", EvaluationResult: corev1.ConditionTrue},
+					},
+				},
+				ActiveChildren: []corev1.LocalObjectReference{{Name: "one-node-0"}},
+			},
+			want: NodeWaitingForChild,
+		},
+		{
+			name: "accomplished node",
+			spec: v1alpha1.WorkflowNodeSpec{Type: v1alpha1.TypeTask, ConditionalBranches: branches},
+			status: v1alpha1.WorkflowNodeStatus{
+				Conditions: []v1alpha1.WorkflowNodeCondition{{
+					Type:   v1alpha1.ConditionAccomplished,
+					Status: corev1.ConditionTrue,
+				}},
+				FinishedChildren: []corev1.LocalObjectReference{{Name: "one-node-0"}},
+			},
+			want: NodeSucceed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := v1alpha1.WorkflowNode{Spec: tt.spec, Status: tt.status}
+			if got := convertWorkflowNodeState(node); got != tt.want {
+				t.Errorf("convertWorkflowNodeState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_composeTaskAndNodes(t *testing.T) {
 	type args struct {
 		children []string
@@ -723,6 +1074,7 @@ func Test_composeParallelTaskAndNodes(t *testing.T) {
 func Test_composeTaskConditionalBranches(t *testing.T) {
 	type args struct {
 		conditionalBranches []v1alpha1.ConditionalBranch
+		branchesStatus      *v1alpha1.ConditionalBranchesStatus
 		nodes               []string
 	}
 	tests := []struct {
@@ -865,10 +1217,58 @@ func Test_composeTaskConditionalBranches(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "the evaluation results of the branches",
+			args: args{
+				conditionalBranches: []v1alpha1.ConditionalBranch{
+					{
+						Target:     "next",
+						Expression: "exitCode == 0",
+					},
+					{
+						Target:     "rollback",
+						Expression: "exitCode != 0",
+					},
+				},
+				branchesStatus: &v1alpha1.ConditionalBranchesStatus{
+					Branches: []v1alpha1.ConditionalBranchStatus{
+						{
+							Target:           "next",
+							EvaluationResult: corev1.ConditionFalse,
+						},
+						{
+							Target:           "rollback",
+							EvaluationResult: corev1.ConditionTrue,
+						},
+					},
+				},
+				nodes: []string{
+					"rollback-0",
+				},
+			},
+			want: []ConditionalBranch{
+				{
+					NodeNameWithTemplate: NodeNameWithTemplate{
+						Name:     "",
+						Template: "next",
+					},
+					Expression:       "exitCode == 0",
+					EvaluationResult: corev1.ConditionFalse,
+				},
+				{
+					NodeNameWithTemplate: NodeNameWithTemplate{
+						Name:     "rollback-0",
+						Template: "rollback",
+					},
+					Expression:       "exitCode != 0",
+					EvaluationResult: corev1.ConditionTrue,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := composeTaskConditionalBranches(tt.args.conditionalBranches, tt.args.nodes); !reflect.DeepEqual(got, tt.want) {
+			if got := composeTaskConditionalBranches(tt.args.conditionalBranches, tt.args.branchesStatus, tt.args.nodes); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("composeTaskConditionalBranches() = %v, want %v", got, tt.want)
 			}
 		})
@@ -17,14 +17,17 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 // DefaultRPCTimeout specifies default timeout of RPC between controller and chaos-operator
@@ -35,6 +38,15 @@ var RPCTimeout = DefaultRPCTimeout
 
 const ChaosDaemonServerName = "chaos-daemon.chaos-mesh.org"
 
+const (
+	// DefaultRetryMaxAttempts is the max attempts (including the first one) of a failed RPC
+	DefaultRetryMaxAttempts = 3
+	// DefaultRetryBackoff is the backoff before the first retry, it doubles for every retry
+	DefaultRetryBackoff = 100 * time.Millisecond
+	// DefaultRetryMaxBackoff is the upper bound of the backoff between the retries
+	DefaultRetryMaxBackoff = time.Second
+)
+
 type TLSRaw struct {
 	CaCert []byte
 	Cert   []byte
@@ -125,6 +137,30 @@ func (it *GrpcBuilder) WithTimeout(timeout time.Duration) *GrpcBuilder {
 	return it
 }
 
+// WithDefaultRetry retries the given methods with the default attempts and backoff.
+func (it *GrpcBuilder) WithDefaultRetry(methods ...string) *GrpcBuilder {
+	return it.WithRetry(DefaultRetryMaxAttempts, DefaultRetryBackoff, methods...)
+}
+
+// WithRetry sets the retry policy of the given methods in the default service config, the RPC of them is retried
+// transparently when it fails with UNAVAILABLE, and the other methods are never retried. The request may have been
+// handled before the connection is broken, so only the idempotent methods should be given. The timeout interceptor
+// wraps all the attempts, so the retries never exceed the RPC timeout.
+//
+// gRPC-Go only applies the retry policy when the GRPC_GO_RETRY environment variable is "on" at the start of the
+// process, otherwise the policy is ignored and every RPC is attempted once.
+func (it *GrpcBuilder) WithRetry(maxAttempts uint, backoff time.Duration, methods ...string) *GrpcBuilder {
+	it.options = append(it.options, grpc.WithDefaultServiceConfig(RetryServiceConfig(maxAttempts, backoff, methods...)))
+	return it
+}
+
+// WithInflightLimit holds one of the slots during the RPC, including all its retries. The slots are shared by the
+// connections to the same server, so at most cap(slots) RPCs are inflight on it at the same time.
+func (it *GrpcBuilder) WithInflightLimit(slots chan struct{}) *GrpcBuilder {
+	it.options = append(it.options, grpc.WithChainUnaryInterceptor(InflightClientInterceptor(slots)))
+	return it
+}
+
 func (it *GrpcBuilder) Insecure() *GrpcBuilder {
 	it.credentialProvider = &InsecureProvider{}
 	return it
@@ -176,6 +212,89 @@ func TimeoutClientInterceptor(timeout time.Duration) func(context.Context, strin
 	}
 }
 
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          uint     `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	RetryPolicy retryPolicy  `json:"retryPolicy"`
+}
+
+type serviceConfig struct {
+	MethodConfig []methodConfig `json:"methodConfig"`
+}
+
+// serviceConfigDuration formats the duration as the service config requires, e.g. "0.100000000s"
+func serviceConfigDuration(d time.Duration) string {
+	return fmt.Sprintf("%d.%09ds", d/time.Second, d%time.Second)
+}
+
+// RetryServiceConfig returns the service config in JSON which retries the RPC of the given methods (in the form of
+// "/service/method") with exponential backoff if it fails with UNAVAILABLE. UNAVAILABLE doesn't tell whether the
+// server has handled the request, so the methods must be idempotent. gRPC-Go caps the max attempts at 5.
+func RetryServiceConfig(maxAttempts uint, backoff time.Duration, methods ...string) string {
+	maxBackoff := DefaultRetryMaxBackoff
+	if backoff > maxBackoff {
+		maxBackoff = backoff
+	}
+
+	names := make([]methodName, 0, len(methods))
+	for _, method := range methods {
+		service := strings.TrimPrefix(method, "/")
+		name := ""
+		if i := strings.LastIndex(service, "/"); i >= 0 {
+			service, name = service[:i], service[i+1:]
+		}
+		names = append(names, methodName{Service: service, Method: name})
+	}
+
+	config := serviceConfig{}
+	if len(names) > 0 {
+		config.MethodConfig = []methodConfig{{
+			Name: names,
+			RetryPolicy: retryPolicy{
+				MaxAttempts:          maxAttempts,
+				InitialBackoff:       serviceConfigDuration(backoff),
+				MaxBackoff:           serviceConfigDuration(maxBackoff),
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{"UNAVAILABLE"},
+			},
+		}}
+	}
+
+	// it's always marshalled successfully, as there are only strings and numbers
+	data, _ := json.Marshal(config)
+	return string(data)
+}
+
+// InflightClientInterceptor waits for a free slot before the RPC and frees it after the RPC is done. The waiting is
+// bounded by the context, so the RPC fails with its error if no slot is freed in time.
+func InflightClientInterceptor(slots chan struct{}) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		defer func() {
+			<-slots
+		}()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
 // TimeoutServerInterceptor ensures the context is intact before handling over the
 // request to application.
 func TimeoutServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
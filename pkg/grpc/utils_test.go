@@ -0,0 +1,167 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+func failingInvoker(calls *int, failures int, code codes.Code) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= failures {
+			return status.Error(code, "injected error")
+		}
+		return nil
+	}
+}
+
+// fakeDaemon fails the RPCs with UNAVAILABLE until the failures are used up
+type fakeDaemon struct {
+	pb.UnimplementedChaosDaemonServer
+
+	lock     sync.Mutex
+	failures int
+	calls    map[string]int
+}
+
+func (d *fakeDaemon) handle(method string) (*empty.Empty, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.calls[method]++
+	if d.failures > 0 {
+		d.failures--
+		return nil, status.Error(codes.Unavailable, "injected error")
+	}
+	return &empty.Empty{}, nil
+}
+
+func (d *fakeDaemon) SetTcs(context.Context, *pb.TcsRequest) (*empty.Empty, error) {
+	return d.handle("SetTcs")
+}
+
+func (d *fakeDaemon) ContainerKill(context.Context, *pb.ContainerRequest) (*empty.Empty, error) {
+	return d.handle("ContainerKill")
+}
+
+func TestRetryServiceConfig(t *testing.T) {
+	// gRPC-Go reads GRPC_GO_RETRY only once at the start of the process, so run the test in a new process with it
+	if os.Getenv("GRPC_GO_RETRY") != "on" {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestRetryServiceConfig$")
+		cmd.Env = append(os.Environ(), "GRPC_GO_RETRY=on")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%v\n%s", err, out)
+		}
+		return
+	}
+
+	g := NewGomegaWithT(t)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).ToNot(HaveOccurred())
+	daemon := &fakeDaemon{calls: make(map[string]int)}
+	server := grpc.NewServer()
+	pb.RegisterChaosDaemonServer(server, daemon)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	addr := lis.Addr().(*net.TCPAddr)
+	cc, err := Builder(addr.IP.String(), addr.Port).
+		Insecure().
+		WithRetry(3, time.Millisecond, "/pb.ChaosDaemon/SetTcs").
+		Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	defer cc.Close()
+	client := pb.NewChaosDaemonClient(cc)
+
+	daemon.failures = 2
+	_, err = client.SetTcs(context.TODO(), &pb.TcsRequest{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(daemon.calls["SetTcs"]).To(Equal(3))
+
+	daemon.failures = 3
+	_, err = client.SetTcs(context.TODO(), &pb.TcsRequest{})
+	g.Expect(status.Code(err)).To(Equal(codes.Unavailable))
+	g.Expect(daemon.calls["SetTcs"]).To(Equal(6))
+
+	// the method which isn't listed may be not idempotent, so it's never retried
+	daemon.failures = 1
+	_, err = client.ContainerKill(context.TODO(), &pb.ContainerRequest{})
+	g.Expect(status.Code(err)).To(Equal(codes.Unavailable))
+	g.Expect(daemon.calls["ContainerKill"]).To(Equal(1))
+}
+
+func TestInflightClientInterceptor(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// the connections to the same server share the slots
+	slots := make(chan struct{}, 2)
+	interceptors := []grpc.UnaryClientInterceptor{InflightClientInterceptor(slots), InflightClientInterceptor(slots)}
+
+	var lock sync.Mutex
+	inflight, maxInflight := 0, 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		lock.Lock()
+		inflight++
+		if inflight > maxInflight {
+			maxInflight = inflight
+		}
+		lock.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		lock.Lock()
+		inflight--
+		lock.Unlock()
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		interceptor := interceptors[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Expect(interceptor(context.TODO(), "/test", nil, nil, nil, invoker)).To(Succeed())
+		}()
+	}
+	wg.Wait()
+	g.Expect(maxInflight).To(Equal(2))
+	g.Expect(slots).To(BeEmpty())
+
+	// the RPC waiting for a slot fails when its context is done
+	slots <- struct{}{}
+	slots <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	calls := 0
+	err := interceptors[0](ctx, "/test", nil, nil, nil, failingInvoker(&calls, 0, codes.OK))
+	g.Expect(status.Code(err)).To(Equal(codes.DeadlineExceeded))
+	g.Expect(calls).To(BeZero())
+}
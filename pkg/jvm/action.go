@@ -40,9 +40,10 @@ func ToSandboxAction(suid string, chaos *v1alpha1.JVMChaos) ([]byte, error) {
 	}
 
 	kv := make(map[string]string)
+	parameter := chaos.Spec.ResolvedParameter()
 	flags := v1alpha1.JvmSpec[chaos.Spec.Target][chaos.Spec.Action].Flags
 	if flags != nil {
-		for k, v := range chaos.Spec.Flags {
+		for k, v := range parameter.Flags {
 			for _, rule := range flags {
 				if rule.Name != k {
 					continue
@@ -66,7 +67,7 @@ func ToSandboxAction(suid string, chaos *v1alpha1.JVMChaos) ([]byte, error) {
 
 	matchers := v1alpha1.JvmSpec[chaos.Spec.Target][chaos.Spec.Action].Matchers
 	if matchers != nil {
-		for k, v := range chaos.Spec.Matchers {
+		for k, v := range parameter.Matchers {
 			for _, rule := range matchers {
 				if rule.Name != k {
 					continue
@@ -93,3 +94,13 @@ func ToSandboxAction(suid string, chaos *v1alpha1.JVMChaos) ([]byte, error) {
 	kv[TARGET] = fmt.Sprint(chaos.Spec.Target)
 	return json.Marshal(kv)
 }
+
+// ToSandboxRecoverAction converts chaos to the sandbox action which destroys exactly the rule installed
+// with the suid, so the other chaos on the same JVM keep their rules
+func ToSandboxRecoverAction(suid string, chaos *v1alpha1.JVMChaos) ([]byte, error) {
+	return json.Marshal(map[string]string{
+		SUID:   suid,
+		ACTION: fmt.Sprint(chaos.Spec.Action),
+		TARGET: fmt.Sprint(chaos.Spec.Target),
+	})
+}
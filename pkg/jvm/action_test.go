@@ -0,0 +1,64 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jvm
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func TestToSandboxActionWithThrowException(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	probability := int32(30)
+	chaos := &v1alpha1.JVMChaos{
+		Spec: v1alpha1.JVMChaosSpec{
+			Action:         v1alpha1.JVMExceptionAction,
+			Target:         v1alpha1.JVM,
+			Class:          "com.foo.Service",
+			Method:         "doThing",
+			ThrowException: "java.io.IOException",
+			Probability:    &probability,
+		},
+	}
+
+	data, err := ToSandboxAction("p0:tce:jvm:exception:default", chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	kv := make(map[string]string)
+	g.Expect(json.Unmarshal(data, &kv)).To(Succeed())
+	g.Expect(kv).To(Equal(map[string]string{
+		SUID:             "p0:tce:jvm:exception:default",
+		ACTION:           "tce",
+		TARGET:           "jvm",
+		"classname":      "com.foo.Service",
+		"methodname":     "doThing",
+		"exception":      "java.io.IOException",
+		"effect-percent": "30",
+	}))
+
+	// the recovery destroys exactly the rule with the suid
+	data, err = ToSandboxRecoverAction("p0:tce:jvm:exception:default", chaos)
+	g.Expect(err).ToNot(HaveOccurred())
+	kv = make(map[string]string)
+	g.Expect(json.Unmarshal(data, &kv)).To(Succeed())
+	g.Expect(kv).To(Equal(map[string]string{
+		SUID:   "p0:tce:jvm:exception:default",
+		ACTION: "tce",
+		TARGET: "jvm",
+	}))
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jvm
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// fakeSandbox keeps the rules by their suid as the chaosblade module of the sandbox does, it destroys the rule of
+// the suid, and refuses the destroy request whose action or target mismatches the rule
+type fakeSandbox struct {
+	sync.Mutex
+	rules map[string]map[string]string
+}
+
+func (s *fakeSandbox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv := make(map[string]string)
+	if err := json.NewDecoder(r.Body).Decode(&kv); err != nil || kv[SUID] == "" {
+		http.Error(w, "malformed request", http.StatusBadRequest)
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/chaosblade/create"):
+		s.rules[kv[SUID]] = kv
+	case strings.HasSuffix(r.URL.Path, "/chaosblade/destroy"):
+		rule, ok := s.rules[kv[SUID]]
+		if !ok {
+			http.Error(w, "the experiment is not found", http.StatusBadRequest)
+			return
+		}
+		for _, key := range []string{ACTION, TARGET} {
+			if value, ok := kv[key]; ok && value != rule[key] {
+				http.Error(w, key+" mismatches the experiment", http.StatusBadRequest)
+				return
+			}
+		}
+		delete(s.rules, kv[SUID])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func startFakeSandbox(t *testing.T) (*fakeSandbox, string, int, func()) {
+	sandbox := &fakeSandbox{rules: make(map[string]map[string]string)}
+	server := httptest.NewServer(sandbox)
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sandbox, host, port, server.Close
+}
+
+func newJVMChaos(action v1alpha1.JVMChaosAction, flags map[string]string) *v1alpha1.JVMChaos {
+	return &v1alpha1.JVMChaos{
+		Spec: v1alpha1.JVMChaosSpec{
+			Action: action,
+			Target: v1alpha1.JVM,
+			JVMParameter: v1alpha1.JVMParameter{
+				Flags: flags,
+				Matchers: map[string]string{
+					"classname":  "com.foo.Service",
+					"methodname": "doThing",
+				},
+			},
+		},
+	}
+}
+
+func TestRecoverExistingActions(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sandbox, host, port, stop := startFakeSandbox(t)
+	defer stop()
+
+	for _, chaos := range []*v1alpha1.JVMChaos{
+		newJVMChaos(v1alpha1.JVMDelayAction, map[string]string{"time": "1000"}),
+		newJVMChaos(v1alpha1.JVMReturnAction, map[string]string{"value": "null"}),
+	} {
+		suid := "p0:" + string(chaos.Spec.Action) + ":jvm:test:default"
+
+		data, err := ToSandboxAction(suid, chaos)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(InjectChaos(host, port, data)).To(Succeed())
+		g.Expect(sandbox.rules).To(HaveKey(suid))
+
+		// the recovery only carries the suid, the action and the target, but the rule with its flags is removed
+		data, err = ToSandboxRecoverAction(suid, chaos)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(RecoverChaos(host, port, data)).To(Succeed())
+		g.Expect(sandbox.rules).ToNot(HaveKey(suid))
+	}
+}
+
+func TestRecoverOneOfChaosOnSameJVM(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sandbox, host, port, stop := startFakeSandbox(t)
+	defer stop()
+
+	delay := newJVMChaos(v1alpha1.JVMDelayAction, map[string]string{"time": "1000"})
+	delaySUID := "p0:delay:jvm:delay:default"
+	ret := newJVMChaos(v1alpha1.JVMReturnAction, map[string]string{"value": "null"})
+	retSUID := "p0:return:jvm:return:default"
+
+	data, err := ToSandboxAction(delaySUID, delay)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(InjectChaos(host, port, data)).To(Succeed())
+	data, err = ToSandboxAction(retSUID, ret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(InjectChaos(host, port, data)).To(Succeed())
+	retRule := sandbox.rules[retSUID]
+
+	data, err = ToSandboxRecoverAction(delaySUID, delay)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(RecoverChaos(host, port, data)).To(Succeed())
+
+	// the rule of the other chaos on the same JVM is kept as it's installed
+	g.Expect(sandbox.rules).ToNot(HaveKey(delaySUID))
+	g.Expect(sandbox.rules).To(HaveKeyWithValue(retSUID, retRule))
+	g.Expect(retRule).To(HaveKeyWithValue("value", "null"))
+}
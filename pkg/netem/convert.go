@@ -14,6 +14,7 @@
 package netem
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -27,12 +28,18 @@ func FromDelay(in *v1alpha1.DelaySpec) (*chaosdaemonpb.Netem, error) {
 	if err != nil {
 		return nil, err
 	}
+	if delayTime < 0 || delayTime > v1alpha1.MaxNetemDelay {
+		return nil, fmt.Errorf("latency %s should be in [0, %s]", in.Latency, v1alpha1.MaxNetemDelay)
+	}
 	jitter, err := time.ParseDuration(in.Jitter)
 	if err != nil {
 		return nil, err
 	}
+	if jitter < 0 || jitter > v1alpha1.MaxNetemDelay {
+		return nil, fmt.Errorf("jitter %s should be in [0, %s]", in.Jitter, v1alpha1.MaxNetemDelay)
+	}
 
-	corr, err := strconv.ParseFloat(in.Correlation, 32)
+	corr, err := parseCorrelation(in.Correlation)
 	if err != nil {
 		return nil, err
 	}
@@ -49,11 +56,15 @@ func FromDelay(in *v1alpha1.DelaySpec) (*chaosdaemonpb.Netem, error) {
 			return nil, err
 		}
 
-		corr, err := strconv.ParseFloat(in.Reorder.Correlation, 32)
+		corr, err := parseCorrelation(in.Reorder.Correlation)
 		if err != nil {
 			return nil, err
 		}
 
+		if in.Reorder.Gap < 0 {
+			return nil, fmt.Errorf("invalid reorder gap %d", in.Reorder.Gap)
+		}
+
 		netem.Reorder = float32(reorderPercentage)
 		netem.ReorderCorr = float32(corr)
 		netem.Gap = uint32(in.Reorder.Gap)
@@ -69,7 +80,7 @@ func FromLoss(in *v1alpha1.LossSpec) (*chaosdaemonpb.Netem, error) {
 		return nil, err
 	}
 
-	corr, err := strconv.ParseFloat(in.Correlation, 32)
+	corr, err := parseCorrelation(in.Correlation)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +98,7 @@ func FromDuplicate(in *v1alpha1.DuplicateSpec) (*chaosdaemonpb.Netem, error) {
 		return nil, err
 	}
 
-	corr, err := strconv.ParseFloat(in.Correlation, 32)
+	corr, err := parseCorrelation(in.Correlation)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +116,7 @@ func FromCorrupt(in *v1alpha1.CorruptSpec) (*chaosdaemonpb.Netem, error) {
 		return nil, err
 	}
 
-	corr, err := strconv.ParseFloat(in.Correlation, 32)
+	corr, err := parseCorrelation(in.Correlation)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +127,14 @@ func FromCorrupt(in *v1alpha1.CorruptSpec) (*chaosdaemonpb.Netem, error) {
 	}, nil
 }
 
+// parseCorrelation parses the correlation of netem, the omitted one defaults to v1alpha1.DefaultCorrelation
+func parseCorrelation(correlation string) (float64, error) {
+	if correlation == "" {
+		correlation = v1alpha1.DefaultCorrelation
+	}
+	return strconv.ParseFloat(correlation, 32)
+}
+
 // FromBandwidth converts BandwidthSpec to *chaosdaemonpb.Tbf
 // Bandwidth action use TBF under the hood.
 // TBF stands for Token Bucket Filter, is a classful queueing discipline available
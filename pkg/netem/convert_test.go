@@ -0,0 +1,72 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netem
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func TestFromDelay(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	netem, err := FromDelay(&v1alpha1.DelaySpec{
+		Latency:     "90ms",
+		Jitter:      "10ms",
+		Correlation: "25",
+		Reorder: &v1alpha1.ReorderSpec{
+			Reorder:     "50",
+			Correlation: "20",
+			Gap:         5,
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(netem.Time).To(Equal(uint32(90000)))
+	g.Expect(netem.Jitter).To(Equal(uint32(10000)))
+	g.Expect(netem.DelayCorr).To(Equal(float32(25)))
+	g.Expect(netem.Reorder).To(Equal(float32(50)))
+	g.Expect(netem.ReorderCorr).To(Equal(float32(20)))
+	g.Expect(netem.Gap).To(Equal(uint32(5)))
+
+	netem, err = FromDelay(&v1alpha1.DelaySpec{
+		Latency:     v1alpha1.MaxNetemDelay.String(),
+		Jitter:      "0ms",
+		Correlation: "0",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(netem.Time).To(Equal(uint32(math.MaxUint32)))
+}
+
+func TestFromDelayRejectsOverLargeDelay(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// 72m is larger than the max uint32 in microseconds, which would wrap to about 25s
+	_, err := FromDelay(&v1alpha1.DelaySpec{
+		Latency:     "72m",
+		Jitter:      "0ms",
+		Correlation: "0",
+	})
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = FromDelay(&v1alpha1.DelaySpec{
+		Latency:     "10ms",
+		Jitter:      "72m",
+		Correlation: "0",
+	})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -15,7 +15,10 @@ package container
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 
+	"github.com/pkg/errors"
 	"go.uber.org/fx"
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,8 +44,20 @@ func (c *Container) Id() string {
 	return c.Pod.Namespace + "/" + c.Pod.Name + "/" + c.ContainerName
 }
 
+// ContainerNotFoundError is returned along with the selected containers, if none of the named containers
+// is found in some of the selected pods. These pods are skipped, rather than failing the whole selection.
+type ContainerNotFoundError struct {
+	// Pods are the namespaced names of the skipped pods
+	Pods           []string
+	ContainerNames []string
+}
+
+func (e *ContainerNotFoundError) Error() string {
+	return fmt.Sprintf("containers %v are not found in pods %v", e.ContainerNames, e.Pods)
+}
+
 func (impl *SelectImpl) Select(ctx context.Context, cs *v1alpha1.ContainerSelector) ([]*Container, error) {
-	pods, err := pod.SelectAndFilterPods(ctx, impl.c, impl.r, &cs.PodSelector, impl.ClusterScoped, impl.TargetNamespace, impl.EnableFilterNamespace)
+	pods, err := pod.SelectAndFilterPods(ctx, impl.c, impl.r, &cs.PodSelector, impl.ClusterScoped, impl.TargetNamespace, impl.EnableFilterNamespace, impl.RoundingMode)
 	if err != nil {
 		return nil, err
 	}
@@ -52,9 +67,18 @@ func (impl *SelectImpl) Select(ctx context.Context, cs *v1alpha1.ContainerSelect
 		containerNameMap[name] = struct{}{}
 	}
 
+	var imagePattern *regexp.Regexp
+	if len(cs.ContainerImage) != 0 {
+		imagePattern, err = regexp.Compile(cs.ContainerImage)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse container image %s", cs.ContainerImage)
+		}
+	}
+
 	var result []*Container
+	var notFound []string
 	for _, pod := range pods {
-		if len(cs.ContainerNames) == 0 {
+		if len(cs.ContainerNames) == 0 && imagePattern == nil {
 			result = append(result, &Container{
 				Pod:           pod,
 				ContainerName: pod.Spec.Containers[0].Name,
@@ -62,16 +86,34 @@ func (impl *SelectImpl) Select(ctx context.Context, cs *v1alpha1.ContainerSelect
 			continue
 		}
 
+		found := false
 		for _, container := range pod.Spec.Containers {
-			if _, ok := containerNameMap[container.Name]; ok {
-				result = append(result, &Container{
-					Pod:           pod,
-					ContainerName: container.Name,
-				})
+			if len(cs.ContainerNames) != 0 {
+				if _, ok := containerNameMap[container.Name]; !ok {
+					continue
+				}
+			}
+			found = true
+			if imagePattern != nil && !imagePattern.MatchString(container.Image) {
+				continue
 			}
+
+			result = append(result, &Container{
+				Pod:           pod,
+				ContainerName: container.Name,
+			})
+		}
+		if !found {
+			notFound = append(notFound, pod.Namespace+"/"+pod.Name)
 		}
 	}
 
+	if len(notFound) != 0 {
+		return result, &ContainerNotFoundError{
+			Pods:           notFound,
+			ContainerNames: cs.ContainerNames,
+		}
+	}
 	return result, nil
 }
 
@@ -90,6 +132,7 @@ func New(params Params) *SelectImpl {
 			ClusterScoped:         config.ControllerCfg.ClusterScoped,
 			TargetNamespace:       config.ControllerCfg.TargetNamespace,
 			EnableFilterNamespace: config.ControllerCfg.EnableFilterNamespace,
+			RoundingMode:          pod.RoundingMode(config.ControllerCfg.PercentRoundingMode),
 		},
 	}
 }
@@ -0,0 +1,101 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestSelectByImage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newPod := func(name string, containers ...v1.Container) runtime.Object {
+		p := NewPod(PodArg{Name: name, Labels: map[string]string{"app": "db"}})
+		p.Spec.Containers = containers
+		return &p
+	}
+	// the containers running the same image are named differently in each pod
+	c := fake.NewFakeClient(
+		newPod("p0",
+			v1.Container{Name: "sidecar", Image: "envoyproxy/envoy:v1.17"},
+			v1.Container{Name: "mysql-primary", Image: "mysql:8.0"},
+		),
+		newPod("p1",
+			v1.Container{Name: "db", Image: "mysql:8.0"},
+		),
+		newPod("p2",
+			v1.Container{Name: "mysql", Image: "mysql:5.7"},
+		),
+	)
+	var r client.Reader
+	impl := &SelectImpl{c, r, pod.Option{ClusterScoped: true}}
+
+	selectIds := func(cs *v1alpha1.ContainerSelector) []string {
+		containers, err := impl.Select(context.Background(), cs)
+		var notFound *ContainerNotFoundError
+		if !errors.As(err, &notFound) {
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+		var ids []string
+		for _, container := range containers {
+			ids = append(ids, container.Id())
+		}
+		return ids
+	}
+	newSelector := func(image string, names ...string) *v1alpha1.ContainerSelector {
+		return &v1alpha1.ContainerSelector{
+			PodSelector: v1alpha1.PodSelector{
+				Selector: v1alpha1.PodSelectorSpec{
+					Namespaces:     []string{metav1.NamespaceDefault},
+					LabelSelectors: map[string]string{"app": "db"},
+				},
+				Mode: v1alpha1.AllPodMode,
+			},
+			ContainerNames: names,
+			ContainerImage: image,
+		}
+	}
+
+	g.Expect(selectIds(newSelector("^mysql:8\\.0$"))).To(ConsistOf("default/p0/mysql-primary", "default/p1/db"))
+	g.Expect(selectIds(newSelector("mysql"))).To(ConsistOf("default/p0/mysql-primary", "default/p1/db", "default/p2/mysql"))
+
+	// the containers must match both the names and the image
+	g.Expect(selectIds(newSelector("mysql", "db", "mysql"))).To(ConsistOf("default/p1/db", "default/p2/mysql"))
+
+	// the pod without the named containers is skipped
+	_, err := impl.Select(context.Background(), newSelector("mysql", "db", "mysql"))
+	g.Expect(err).To(Equal(&ContainerNotFoundError{
+		Pods:           []string{"default/p0"},
+		ContainerNames: []string{"db", "mysql"},
+	}))
+
+	// the first container is selected without the names and the image
+	g.Expect(selectIds(newSelector(""))).To(ConsistOf("default/p0/sidecar", "default/p1/db", "default/p2/mysql"))
+
+	_, err = impl.Select(context.Background(), newSelector("mysql:[8"))
+	g.Expect(err).To(HaveOccurred())
+}
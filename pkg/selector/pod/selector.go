@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	mathrand "math/rand"
+	"sort"
 	"strconv"
 	"strings"
 
 	"go.uber.org/fx"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,10 +47,35 @@ var log = ctrl.Log.WithName("selector")
 
 const injectAnnotationKey = "chaos-mesh.org/inject"
 
+// RoundingMode is how the number of pods in a percentage is rounded to an integer
+type RoundingMode string
+
+const (
+	// RoundingModeRound rounds half away from zero, e.g. 10% of 15 pods is 2, while 10% of 14 pods is 1
+	RoundingModeRound RoundingMode = "round"
+	// RoundingModeCeil rounds up, e.g. 10% of 11 pods is 2
+	RoundingModeCeil RoundingMode = "ceil"
+	// RoundingModeFloor rounds down, e.g. 10% of 19 pods is 1
+	RoundingModeFloor RoundingMode = "floor"
+)
+
 type Option struct {
 	ClusterScoped         bool
 	TargetNamespace       string
 	EnableFilterNamespace bool
+	// RoundingMode is how the number of pods is rounded in the fixed-percent and random-max-percent modes,
+	// it's RoundingModeFloor if empty
+	RoundingMode RoundingMode
+}
+
+// MinMatchesNotMetError is returned if fewer pods than the minimum matches of the selector are selected
+type MinMatchesNotMetError struct {
+	Matched  int
+	Required int
+}
+
+func (e *MinMatchesNotMetError) Error() string {
+	return fmt.Sprintf("only %d pods are selected, fewer than the minimum matches %d", e.Matched, e.Required)
 }
 
 type SelectImpl struct {
@@ -73,7 +101,7 @@ func (impl *SelectImpl) Select(ctx context.Context, ps *v1alpha1.PodSelector) ([
 		return []*Pod{}, nil
 	}
 
-	pods, err := SelectAndFilterPods(ctx, impl.c, impl.r, ps, impl.ClusterScoped, impl.TargetNamespace, impl.EnableFilterNamespace)
+	pods, err := SelectAndFilterPods(ctx, impl.c, impl.r, ps, impl.ClusterScoped, impl.TargetNamespace, impl.EnableFilterNamespace, impl.RoundingMode)
 	if err != nil {
 		return nil, err
 	}
@@ -103,12 +131,13 @@ func New(params Params) *SelectImpl {
 			config.ControllerCfg.ClusterScoped,
 			config.ControllerCfg.TargetNamespace,
 			config.ControllerCfg.EnableFilterNamespace,
+			RoundingMode(config.ControllerCfg.PercentRoundingMode),
 		},
 	}
 }
 
 // SelectAndFilterPods returns the list of pods that filtered by selector and PodMode
-func SelectAndFilterPods(ctx context.Context, c client.Client, r client.Reader, spec *v1alpha1.PodSelector, clusterScoped bool, targetNamespace string, enableFilterNamespace bool) ([]v1.Pod, error) {
+func SelectAndFilterPods(ctx context.Context, c client.Client, r client.Reader, spec *v1alpha1.PodSelector, clusterScoped bool, targetNamespace string, enableFilterNamespace bool, roundingMode RoundingMode) ([]v1.Pod, error) {
 	if pods := mock.On("MockSelectAndFilterPods"); pods != nil {
 		return pods.(func() []v1.Pod)(), nil
 	}
@@ -130,11 +159,23 @@ func SelectAndFilterPods(ctx context.Context, c client.Client, r client.Reader,
 		return nil, err
 	}
 
-	filteredPod, err := filterPodsByMode(pods, mode, value)
+	// the pods are chosen from the same order, so that the same seed chooses the same pods
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	filteredPod, err := filterPodsByMode(pods, mode, value, roundingMode, newRandom(spec.Seed))
 	if err != nil {
 		return nil, err
 	}
 
+	// the minimum matches is checked against the pods chosen by the mode, which are the pods to be injected
+	if len(filteredPod) < spec.MinMatches {
+		return nil, &MinMatchesNotMetError{Matched: len(filteredPod), Required: spec.MinMatches}
+	}
+
 	return filteredPod, nil
 }
 
@@ -176,7 +217,16 @@ func SelectPods(ctx context.Context, c client.Client, r client.Reader, selector
 			}
 		}
 
-		return pods, nil
+		return filterByWorkloads(ctx, c, pods, selector.Workloads)
+	}
+
+	// pods are the backends of the services
+	if len(selector.Services) > 0 {
+		pods, err := selectServiceEndpoints(ctx, c, selector.Services, clusterScoped, targetNamespace)
+		if err != nil {
+			return nil, err
+		}
+		return filterByWorkloads(ctx, c, pods, selector.Workloads)
 	}
 
 	if !clusterScoped {
@@ -274,7 +324,7 @@ func SelectPods(ctx context.Context, c client.Client, r client.Reader, selector
 		return nil, err
 	}
 
-	annotationsSelector, err := parseSelector(label.Label(selector.AnnotationSelectors).String())
+	annotationsSelector, err := parseAnnotationSelector(selector)
 	if err != nil {
 		return nil, err
 	}
@@ -289,11 +339,149 @@ func SelectPods(ctx context.Context, c client.Client, r client.Reader, selector
 		return nil, err
 	}
 
-	return pods, nil
+	return filterByWorkloads(ctx, c, pods, selector.Workloads)
 }
 
 //revive:enable:flag-parameter
 
+// selectServiceEndpoints returns the pods which are the current endpoints of the services, including the not ready ones
+func selectServiceEndpoints(ctx context.Context, c client.Client, services map[string][]string, clusterScoped bool, targetNamespace string) ([]v1.Pod, error) {
+	var pods []v1.Pod
+	selected := make(map[types.NamespacedName]struct{})
+	for ns, names := range services {
+		if !clusterScoped {
+			if targetNamespace != ns {
+				log.Info("skip namespace because ns is out of scope within namespace scoped mode", "namespace", ns)
+				continue
+			}
+		}
+		for _, name := range names {
+			// the service must exist, though it may have no endpoints for now
+			if _, err := GetService(ctx, c, ns, "", name); err != nil {
+				return nil, fmt.Errorf("fail to get service %s/%s: %w", ns, name, err)
+			}
+
+			var endpoints v1.Endpoints
+			err := c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &endpoints)
+			if apierrors.IsNotFound(err) {
+				log.Info("service has no endpoints", "namespace", ns, "service", name)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			for _, subset := range endpoints.Subsets {
+				addresses := make([]v1.EndpointAddress, 0, len(subset.Addresses)+len(subset.NotReadyAddresses))
+				addresses = append(addresses, subset.Addresses...)
+				addresses = append(addresses, subset.NotReadyAddresses...)
+				for _, address := range addresses {
+					if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+						continue
+					}
+					key := types.NamespacedName{Namespace: address.TargetRef.Namespace, Name: address.TargetRef.Name}
+					if len(key.Namespace) == 0 {
+						key.Namespace = ns
+					}
+					if _, ok := selected[key]; ok {
+						continue
+					}
+
+					var pod v1.Pod
+					err := c.Get(ctx, key, &pod)
+					if apierrors.IsNotFound(err) {
+						log.Error(err, "Pod is not found", "namespace", key.Namespace, "pod name", key.Name)
+						continue
+					}
+					if err != nil {
+						return nil, err
+					}
+					selected[key] = struct{}{}
+					pods = append(pods, pod)
+				}
+			}
+		}
+	}
+
+	return pods, nil
+}
+
+// filterByWorkloads returns the pods owned by any of the workloads. The controller of the pods owned by
+// a Deployment is its ReplicaSet, so the controller of the ReplicaSet is looked up as well.
+func filterByWorkloads(ctx context.Context, c client.Client, pods []v1.Pod, workloads []v1alpha1.WorkloadReference) ([]v1.Pod, error) {
+	if len(workloads) == 0 {
+		return pods, nil
+	}
+
+	// the controllers of the ReplicaSets, which are shared by the pods of the same ReplicaSet
+	replicaSetControllers := make(map[types.NamespacedName]*metav1.OwnerReference)
+	var filteredList []v1.Pod
+	for _, pod := range pods {
+		owner := metav1.GetControllerOf(&pod)
+		if owner == nil {
+			continue
+		}
+		owners := []*metav1.OwnerReference{owner}
+
+		if owner.Kind == "ReplicaSet" {
+			key := types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}
+			controller, ok := replicaSetControllers[key]
+			if !ok {
+				var replicaSet appsv1.ReplicaSet
+				err := c.Get(ctx, key, &replicaSet)
+				if err != nil && !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+				if err == nil {
+					controller = metav1.GetControllerOf(&replicaSet)
+				}
+				replicaSetControllers[key] = controller
+			}
+			if controller != nil {
+				owners = append(owners, controller)
+			}
+		}
+
+		owned, err := ownedByWorkloads(&pod, owners, workloads)
+		if err != nil {
+			return nil, err
+		}
+		if owned {
+			filteredList = append(filteredList, pod)
+		}
+	}
+
+	return filteredList, nil
+}
+
+func ownedByWorkloads(pod *v1.Pod, owners []*metav1.OwnerReference, workloads []v1alpha1.WorkloadReference) (bool, error) {
+	for _, owner := range owners {
+		for _, workload := range workloads {
+			if owner.Kind != workload.Kind || owner.Name != workload.Name {
+				continue
+			}
+			if len(workload.Ordinals) == 0 {
+				return true, nil
+			}
+
+			// the pods of the StatefulSet are named as `<statefulset>-<ordinal>`
+			ordinal, err := strconv.Atoi(strings.TrimPrefix(pod.Name, owner.Name+"-"))
+			if err != nil || ordinal < 0 {
+				log.Info("skip the pod without ordinal", "pod", pod.Name, "statefulset", owner.Name)
+				continue
+			}
+			contained, err := workload.ContainsOrdinal(ordinal)
+			if err != nil {
+				return false, err
+			}
+			if contained {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // GetService get k8s service by service name
 func GetService(ctx context.Context, c client.Client, namespace, controllerNamespace string, serviceName string) (*v1.Service, error) {
 	// use the environment value if namespace is empty
@@ -313,9 +501,10 @@ func GetService(ctx context.Context, c client.Client, namespace, controllerNames
 	return service, nil
 }
 
-// CheckPodMeetSelector checks if this pod meets the selection criteria.
+// CheckPodMeetSelector checks if this pod meets the selection criteria. The client is used to look up
+// the owners of the pod if the selector selects the workloads.
 // TODO: support to check fieldsSelector
-func CheckPodMeetSelector(pod v1.Pod, selector v1alpha1.PodSelectorSpec) (bool, error) {
+func CheckPodMeetSelector(ctx context.Context, c client.Client, pod v1.Pod, selector v1alpha1.PodSelectorSpec) (bool, error) {
 	if len(selector.Pods) > 0 {
 		meet := false
 		for ns, names := range selector.Pods {
@@ -371,7 +560,7 @@ func CheckPodMeetSelector(pod v1.Pod, selector v1alpha1.PodSelectorSpec) (bool,
 		return false, err
 	}
 
-	annotationsSelector, err := parseSelector(label.Label(selector.AnnotationSelectors).String())
+	annotationsSelector, err := parseAnnotationSelector(selector)
 	if err != nil {
 		return false, err
 	}
@@ -387,6 +576,11 @@ func CheckPodMeetSelector(pod v1.Pod, selector v1alpha1.PodSelectorSpec) (bool,
 		return false, err
 	}
 
+	pods, err = filterByWorkloads(ctx, c, pods, selector.Workloads)
+	if err != nil {
+		return false, err
+	}
+
 	if len(pods) > 0 {
 		return true, nil
 	}
@@ -410,14 +604,14 @@ func filterPodByNode(pods []v1.Pod, nodes []v1.Node) []v1.Pod {
 }
 
 // filterPodsByMode filters pods by mode from pod list
-func filterPodsByMode(pods []v1.Pod, mode v1alpha1.PodMode, value string) ([]v1.Pod, error) {
+func filterPodsByMode(pods []v1.Pod, mode v1alpha1.PodMode, value string, roundingMode RoundingMode, random randomNumber) ([]v1.Pod, error) {
 	if len(pods) == 0 {
 		return nil, errors.New("cannot generate pods from empty list")
 	}
 
 	switch mode {
 	case v1alpha1.OnePodMode:
-		index := getRandomNumber(len(pods))
+		index := random(len(pods))
 		pod := pods[index]
 
 		return []v1.Pod{pod}, nil
@@ -437,42 +631,26 @@ func filterPodsByMode(pods []v1.Pod, mode v1alpha1.PodMode, value string) ([]v1.
 			return nil, errors.New("cannot select any pod as value below or equal 0")
 		}
 
-		return getFixedSubListFromPodList(pods, num), nil
+		return getFixedSubListFromPodList(pods, num, random), nil
 	case v1alpha1.FixedPercentPodMode:
-		percentage, err := strconv.Atoi(value)
+		num, err := percentOfPods(len(pods), value, roundingMode)
 		if err != nil {
 			return nil, err
 		}
 
-		if percentage == 0 {
-			return nil, errors.New("cannot select any pod as value below or equal 0")
-		}
-
-		if percentage < 0 || percentage > 100 {
-			return nil, fmt.Errorf("fixed percentage value of %d is invalid, Must be (0,100]", percentage)
-		}
-
-		num := int(math.Floor(float64(len(pods)) * float64(percentage) / 100))
-
-		return getFixedSubListFromPodList(pods, num), nil
+		return getFixedSubListFromPodList(pods, num, random), nil
 	case v1alpha1.RandomMaxPercentPodMode:
-		maxPercentage, err := strconv.Atoi(value)
+		maxPercentage, err := parsePercentage(value)
 		if err != nil {
 			return nil, err
 		}
 
-		if maxPercentage == 0 {
-			return nil, errors.New("cannot select any pod as value below or equal 0")
-		}
-
-		if maxPercentage < 0 || maxPercentage > 100 {
-			return nil, fmt.Errorf("fixed percentage value of %d is invalid, Must be [0-100]", maxPercentage)
-		}
-
-		percentage := getRandomNumber(maxPercentage + 1) // + 1 because Intn works with half open interval [0,n) and we want [0,n]
-		num := int(math.Floor(float64(len(pods)) * float64(percentage) / 100))
+		// the percentage is drawn in the precision of the value, e.g. in steps of 0.1 for "0.5"
+		scale := percentageScale(maxPercentage)
+		units := random(int(math.Round(maxPercentage*scale)) + 1) // + 1 because Intn works with half open interval [0,n) and we want [0,n]
+		num := roundPercentOfPods(len(pods), float64(units)/scale, roundingMode)
 
-		return getFixedSubListFromPodList(pods, num), nil
+		return getFixedSubListFromPodList(pods, num, random), nil
 	default:
 		return nil, fmt.Errorf("mode %s not supported", mode)
 	}
@@ -654,8 +832,83 @@ func parseSelector(str string) (labels.Selector, error) {
 	return selector, nil
 }
 
-func getFixedSubListFromPodList(pods []v1.Pod, num int) []v1.Pod {
-	indexes := RandomFixedIndexes(0, uint(len(pods)), uint(num))
+// parseAnnotationSelector parses the annotation selectors and the annotation expression selectors to a selector
+func parseAnnotationSelector(selector v1alpha1.PodSelectorSpec) (labels.Selector, error) {
+	annotationsSelector, err := parseSelector(label.Label(selector.AnnotationSelectors).String())
+	if err != nil {
+		return nil, err
+	}
+	if len(selector.AnnotationExpressionSelectors) == 0 {
+		return annotationsSelector, nil
+	}
+
+	expressions, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchExpressions: selector.AnnotationExpressionSelectors,
+	})
+	if err != nil {
+		return nil, err
+	}
+	requirements, _ := expressions.Requirements()
+	return annotationsSelector.Add(requirements...), nil
+}
+
+// percentOfPods returns the number of pods in the percentage, which could be fractional, e.g. "0.5".
+// The number is rounded to an integer by the rounding mode, which is validated when the controller starts,
+// and it's rounded down by default.
+func percentOfPods(total int, value string, roundingMode RoundingMode) (int, error) {
+	percentage, err := parsePercentage(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return roundPercentOfPods(total, percentage, roundingMode), nil
+}
+
+// parsePercentage parses the percentage in (0,100], which could be fractional, e.g. "0.5"
+func parsePercentage(value string) (float64, error) {
+	percentage, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if percentage == 0 {
+		return 0, errors.New("cannot select any pod as value below or equal 0")
+	}
+
+	// the NaN is rejected as well
+	if !(percentage > 0 && percentage <= 100) {
+		return 0, fmt.Errorf("percentage value of %s is invalid, Must be (0,100]", value)
+	}
+
+	return percentage, nil
+}
+
+// percentageScale returns the scale of the precision of the percentage, e.g. 10 for 0.5 and 1 for 50
+func percentageScale(percentage float64) float64 {
+	formatted := strconv.FormatFloat(percentage, 'f', -1, 64)
+	if i := strings.IndexByte(formatted, '.'); i >= 0 {
+		return math.Pow10(len(formatted) - i - 1)
+	}
+	return 1
+}
+
+// roundPercentOfPods returns the number of pods in the percentage of total, rounded by the rounding mode
+func roundPercentOfPods(total int, percentage float64, roundingMode RoundingMode) int {
+	// the tolerance keeps the exact numbers from being rounded away by the floating-point error, e.g. 7% of 100 pods
+	const tolerance = 1e-9
+	num := float64(total) * percentage / 100
+	switch roundingMode {
+	case RoundingModeRound:
+		return int(math.Round(num))
+	case RoundingModeCeil:
+		return int(math.Ceil(num - tolerance))
+	default:
+		return int(math.Floor(num + tolerance))
+	}
+}
+
+func getFixedSubListFromPodList(pods []v1.Pod, num int, random randomNumber) []v1.Pod {
+	indexes := randomFixedIndexes(0, uint(len(pods)), uint(num), random)
 
 	var filteredPods []v1.Pod
 
@@ -670,6 +923,10 @@ func getFixedSubListFromPodList(pods []v1.Pod, num int) []v1.Pod {
 // RandomFixedIndexes returns the `count` random indexes between `start` and `end`.
 // [start, end)
 func RandomFixedIndexes(start, end, count uint) []uint {
+	return randomFixedIndexes(start, end, count, getRandomNumber)
+}
+
+func randomFixedIndexes(start, end, count uint, random randomNumber) []uint {
 	var indexes []uint
 	m := make(map[uint]uint, count)
 
@@ -686,7 +943,7 @@ func RandomFixedIndexes(start, end, count uint) []uint {
 	}
 
 	for i := 0; i < int(count); {
-		index := uint(getRandomNumber(int(end-start))) + start
+		index := uint(random(int(end-start))) + start
 
 		_, exist := m[index]
 		if exist {
@@ -701,6 +958,22 @@ func RandomFixedIndexes(start, end, count uint) []uint {
 	return indexes
 }
 
+// randomNumber returns a random number in [0, max)
+type randomNumber func(max int) uint64
+
+// newRandom returns the random numbers generated from the seed, which are reproducible. Without a seed, the
+// numbers are cryptographically random.
+func newRandom(seed *int64) randomNumber {
+	if seed == nil {
+		return getRandomNumber
+	}
+
+	r := mathrand.New(mathrand.NewSource(*seed))
+	return func(max int) uint64 {
+		return uint64(r.Int63n(int64(max)))
+	}
+}
+
 func getRandomNumber(max int) uint64 {
 	num, _ := rand.Int(rand.Reader, big.NewInt(int64(max)))
 	return num.Uint64()
@@ -15,18 +15,24 @@ package pod
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
 
 	. "github.com/onsi/gomega"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/label"
 	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -161,6 +167,330 @@ func TestSelectPods(t *testing.T) {
 	}
 }
 
+func TestSelectAndFilterPodsWithMinMatches(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects, _ := GenerateNPods("p", 2, PodArg{Labels: map[string]string{"l1": "l1"}})
+	c := fake.NewFakeClient(objects...)
+	var r client.Reader
+
+	spec := &v1alpha1.PodSelector{
+		Selector: v1alpha1.PodSelectorSpec{
+			LabelSelectors: map[string]string{"l1": "l1"},
+		},
+		Mode:       v1alpha1.AllPodMode,
+		MinMatches: 3,
+	}
+
+	filteredPods, err := SelectAndFilterPods(context.Background(), c, r, spec, true, "", false, RoundingModeRound)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(filteredPods).To(BeEmpty())
+
+	spec.MinMatches = 2
+	filteredPods, err = SelectAndFilterPods(context.Background(), c, r, spec, true, "", false, RoundingModeRound)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filteredPods).To(HaveLen(2))
+
+	// the pods chosen by the mode are fewer than the matched pods
+	spec.Mode = v1alpha1.OnePodMode
+	filteredPods, err = SelectAndFilterPods(context.Background(), c, r, spec, true, "", false, RoundingModeRound)
+	g.Expect(err).To(Equal(&MinMatchesNotMetError{Matched: 1, Required: 2}))
+	g.Expect(filteredPods).To(BeEmpty())
+}
+
+func TestSelectAndFilterPodsWithSeed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects, _ := GenerateNPods("p", 20, PodArg{Labels: map[string]string{"l1": "l1"}})
+	c := fake.NewFakeClient(objects...)
+	var r client.Reader
+
+	selectWithSeed := func(mode v1alpha1.PodMode, value string, seed int64) []string {
+		spec := &v1alpha1.PodSelector{
+			Selector: v1alpha1.PodSelectorSpec{
+				LabelSelectors: map[string]string{"l1": "l1"},
+			},
+			Mode:  mode,
+			Value: value,
+			Seed:  &seed,
+		}
+		pods, err := SelectAndFilterPods(context.Background(), c, r, spec, true, "", false, RoundingModeRound)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	for _, tc := range []struct {
+		mode  v1alpha1.PodMode
+		value string
+	}{
+		{v1alpha1.OnePodMode, ""},
+		{v1alpha1.FixedPodMode, "5"},
+		{v1alpha1.FixedPercentPodMode, "30"},
+		{v1alpha1.RandomMaxPercentPodMode, "50"},
+	} {
+		// the same seed reproduces the same selection
+		selected := selectWithSeed(tc.mode, tc.value, 42)
+		for i := 0; i < 5; i++ {
+			g.Expect(selectWithSeed(tc.mode, tc.value, 42)).To(Equal(selected), string(tc.mode))
+		}
+	}
+
+	// the other seeds choose the other pods
+	selected := selectWithSeed(v1alpha1.FixedPodMode, "5", 42)
+	different := false
+	for seed := int64(0); seed < 10; seed++ {
+		if !reflect.DeepEqual(selectWithSeed(v1alpha1.FixedPodMode, "5", seed), selected) {
+			different = true
+		}
+	}
+	g.Expect(different).To(BeTrue())
+}
+
+func TestSelectPodsByServices(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects, _ := GenerateNPods("p", 3, PodArg{Labels: map[string]string{"app": "web"}})
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "web"},
+	}
+	podRef := func(name string) v1.EndpointAddress {
+		return v1.EndpointAddress{
+			TargetRef: &v1.ObjectReference{Kind: "Pod", Namespace: metav1.NamespaceDefault, Name: name},
+		}
+	}
+	// p2 isn't a backend of the service, though it has the same labels
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "web"},
+		Subsets: []v1.EndpointSubset{
+			{
+				Addresses:         []v1.EndpointAddress{podRef("p0"), {IP: "10.0.0.1"}},
+				NotReadyAddresses: []v1.EndpointAddress{podRef("p1")},
+			},
+			{
+				Addresses: []v1.EndpointAddress{podRef("p0")},
+			},
+		},
+	}
+	c := fake.NewFakeClient(append(objects, service, endpoints)...)
+	var r client.Reader
+
+	selectNames := func(services map[string][]string) ([]string, error) {
+		pods, err := SelectPods(context.Background(), c, r, v1alpha1.PodSelectorSpec{Services: services}, true, "", false)
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		return names, err
+	}
+
+	names, err := selectNames(map[string][]string{metav1.NamespaceDefault: {"web"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(ConsistOf("p0", "p1"))
+
+	// the endpoints are resolved again on each selection
+	endpoints.Subsets = []v1.EndpointSubset{{Addresses: []v1.EndpointAddress{podRef("p2")}}}
+	g.Expect(c.Update(context.Background(), endpoints)).To(Succeed())
+	names, err = selectNames(map[string][]string{metav1.NamespaceDefault: {"web"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(ConsistOf("p2"))
+
+	// the service must exist
+	_, err = selectNames(map[string][]string{metav1.NamespaceDefault: {"db"}})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("default/db"))
+}
+
+func TestSelectPodsByWorkloads(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	controlledBy := func(kind, name string) []metav1.OwnerReference {
+		controller := true
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, UID: types.UID(name), Controller: &controller}}
+	}
+	newPod := func(name string, labels map[string]string, owners []metav1.OwnerReference) runtime.Object {
+		pod := NewPod(PodArg{Name: name, Labels: labels})
+		pod.OwnerReferences = owners
+		return &pod
+	}
+	// w0 and w1 are owned by the deployment web through its replica set, and r0 is owned by a bare replica set
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "web-5d4f", OwnerReferences: controlledBy("Deployment", "web")},
+	}
+	bareReplicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "bare"},
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(),
+		replicaSet, bareReplicaSet,
+		newPod("w0", map[string]string{"version": "v1"}, controlledBy("ReplicaSet", "web-5d4f")),
+		newPod("w1", map[string]string{"version": "v2"}, controlledBy("ReplicaSet", "web-5d4f")),
+		newPod("r0", nil, controlledBy("ReplicaSet", "bare")),
+		newPod("d0", nil, controlledBy("StatefulSet", "db")),
+		newPod("p0", nil, nil),
+	)
+	var r client.Reader
+
+	selectNames := func(selector v1alpha1.PodSelectorSpec) []string {
+		pods, err := SelectPods(context.Background(), c, r, selector, true, "", false)
+		g.Expect(err).ToNot(HaveOccurred())
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	g.Expect(selectNames(v1alpha1.PodSelectorSpec{
+		Workloads: []v1alpha1.WorkloadReference{{Kind: "Deployment", Name: "web"}},
+	})).To(ConsistOf("w0", "w1"))
+	g.Expect(selectNames(v1alpha1.PodSelectorSpec{
+		Workloads: []v1alpha1.WorkloadReference{{Kind: "ReplicaSet", Name: "web-5d4f"}, {Kind: "StatefulSet", Name: "db"}},
+	})).To(ConsistOf("w0", "w1", "d0"))
+	g.Expect(selectNames(v1alpha1.PodSelectorSpec{
+		Workloads: []v1alpha1.WorkloadReference{{Kind: "ReplicaSet", Name: "bare"}},
+	})).To(ConsistOf("r0"))
+
+	// the pods must match the label selectors as well
+	g.Expect(selectNames(v1alpha1.PodSelectorSpec{
+		LabelSelectors: map[string]string{"version": "v2"},
+		Workloads:      []v1alpha1.WorkloadReference{{Kind: "Deployment", Name: "web"}},
+	})).To(ConsistOf("w1"))
+
+	// the kind of the workload should match
+	g.Expect(selectNames(v1alpha1.PodSelectorSpec{
+		Workloads: []v1alpha1.WorkloadReference{{Kind: "Deployment", Name: "db"}},
+	})).To(BeEmpty())
+
+	// the pods specified by names must be owned by the workloads as well
+	g.Expect(selectNames(v1alpha1.PodSelectorSpec{
+		Pods:      map[string][]string{metav1.NamespaceDefault: {"w0", "r0", "p0"}},
+		Workloads: []v1alpha1.WorkloadReference{{Kind: "Deployment", Name: "web"}},
+	})).To(ConsistOf("w0"))
+
+	// so are the backends of the services
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "web"},
+		Subsets: []v1.EndpointSubset{{Addresses: []v1.EndpointAddress{
+			{TargetRef: &v1.ObjectReference{Kind: "Pod", Namespace: metav1.NamespaceDefault, Name: "w1"}},
+			{TargetRef: &v1.ObjectReference{Kind: "Pod", Namespace: metav1.NamespaceDefault, Name: "d0"}},
+		}}},
+	}
+	g.Expect(c.Create(context.Background(), &v1.Service{ObjectMeta: endpoints.ObjectMeta})).To(Succeed())
+	g.Expect(c.Create(context.Background(), endpoints)).To(Succeed())
+	g.Expect(selectNames(v1alpha1.PodSelectorSpec{
+		Services:  map[string][]string{metav1.NamespaceDefault: {"web"}},
+		Workloads: []v1alpha1.WorkloadReference{{Kind: "StatefulSet", Name: "db"}},
+	})).To(ConsistOf("d0"))
+
+	// the single pod is checked against the workloads
+	for name, expected := range map[string]bool{"w0": true, "r0": false, "p0": false} {
+		var pod v1.Pod
+		g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: name}, &pod)).To(Succeed())
+		meet, err := CheckPodMeetSelector(context.Background(), c, pod, v1alpha1.PodSelectorSpec{
+			Workloads: []v1alpha1.WorkloadReference{{Kind: "Deployment", Name: "web"}},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(meet).To(Equal(expected), name)
+	}
+}
+
+func TestSelectPodsByOrdinals(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	controller := true
+	var objects []runtime.Object
+	for _, name := range []string{"db-0", "db-1", "db-2"} {
+		pod := NewPod(PodArg{Name: name})
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", UID: "db", Controller: &controller}}
+		objects = append(objects, &pod)
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), objects...)
+	var r client.Reader
+
+	selectNames := func(ordinals string) []string {
+		pods, err := SelectPods(context.Background(), c, r, v1alpha1.PodSelectorSpec{
+			Workloads: []v1alpha1.WorkloadReference{{Kind: "StatefulSet", Name: "db", Ordinals: ordinals}},
+		}, true, "", false)
+		g.Expect(err).ToNot(HaveOccurred())
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	g.Expect(selectNames("1-2")).To(ConsistOf("db-1", "db-2"))
+	g.Expect(selectNames("0")).To(ConsistOf("db-0"))
+	g.Expect(selectNames("1-")).To(ConsistOf("db-1", "db-2"))
+	g.Expect(selectNames("0,2")).To(ConsistOf("db-0", "db-2"))
+	g.Expect(selectNames("3-5")).To(BeEmpty())
+	g.Expect(selectNames("")).To(ConsistOf("db-0", "db-1", "db-2"))
+
+	_, err := SelectPods(context.Background(), c, r, v1alpha1.PodSelectorSpec{
+		Workloads: []v1alpha1.WorkloadReference{{Kind: "StatefulSet", Name: "db", Ordinals: "2-1"}},
+	}, true, "", false)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestSelectPodsByAnnotationExpressions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var objects []runtime.Object
+	for name, annotations := range map[string]map[string]string{
+		"opted-out":   {"chaos-mesh.org/opt-out": "true"},
+		"opted-in":    {"chaos-mesh.org/opt-out": "false"},
+		"unannotated": nil,
+	} {
+		pod := NewPod(PodArg{Name: name, Labels: map[string]string{"app": "web"}, Ans: annotations})
+		objects = append(objects, &pod)
+	}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), objects...)
+	var r client.Reader
+
+	selectNames := func(expressions ...metav1.LabelSelectorRequirement) []string {
+		selector := v1alpha1.PodSelectorSpec{
+			LabelSelectors:                map[string]string{"app": "web"},
+			AnnotationExpressionSelectors: expressions,
+		}
+		pods, err := SelectPods(context.Background(), c, r, selector, true, "", false)
+		g.Expect(err).ToNot(HaveOccurred())
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+
+			meet, err := CheckPodMeetSelector(context.Background(), c, pod, selector)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(meet).To(BeTrue())
+		}
+		return names
+	}
+
+	// the pod with the excluded annotation is filtered out
+	g.Expect(selectNames(metav1.LabelSelectorRequirement{
+		Key:      "chaos-mesh.org/opt-out",
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{"true"},
+	})).To(ConsistOf("opted-in", "unannotated"))
+	g.Expect(selectNames(metav1.LabelSelectorRequirement{
+		Key:      "chaos-mesh.org/opt-out",
+		Operator: metav1.LabelSelectorOpDoesNotExist,
+	})).To(ConsistOf("unannotated"))
+	g.Expect(selectNames()).To(ConsistOf("opted-out", "opted-in", "unannotated"))
+
+	meet, err := CheckPodMeetSelector(context.Background(), c, NewPod(PodArg{Name: "opted-out", Ans: map[string]string{"chaos-mesh.org/opt-out": "true"}}), v1alpha1.PodSelectorSpec{
+		AnnotationExpressionSelectors: []metav1.LabelSelectorRequirement{{
+			Key:      "chaos-mesh.org/opt-out",
+			Operator: metav1.LabelSelectorOpDoesNotExist,
+		}},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(meet).To(BeFalse())
+}
+
 func TestCheckPodMeetSelector(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -322,8 +652,9 @@ func TestCheckPodMeetSelector(t *testing.T) {
 		},
 	}
 
+	c := fake.NewFakeClient()
 	for _, tc := range tcs {
-		meet, err := CheckPodMeetSelector(tc.pod, tc.selector)
+		meet, err := CheckPodMeetSelector(context.Background(), c, tc.pod, tc.selector)
 		g.Expect(err).ShouldNot(HaveOccurred(), tc.name)
 		g.Expect(meet).To(Equal(tc.expectedValue), tc.name)
 	}
@@ -615,3 +946,131 @@ func TestFilterPodByNode(t *testing.T) {
 	}
 
 }
+
+func TestFilterPodsByPercentMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pods := make([]v1.Pod, 10000)
+	for i := range pods {
+		pods[i] = NewPod(PodArg{Name: fmt.Sprintf("p%d", i)})
+	}
+
+	// 1% granularity selects 100 pods at least, the fractional percentage is finer
+	filtered, err := filterPodsByMode(pods, v1alpha1.FixedPercentPodMode, "0.5", RoundingModeRound, getRandomNumber)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filtered).To(HaveLen(50))
+
+	// the number is rounded, e.g. 0.25% of 1000 pods is 2.5
+	filtered, err = filterPodsByMode(pods[:1000], v1alpha1.FixedPercentPodMode, "0.25", RoundingModeRound, getRandomNumber)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filtered).To(HaveLen(3))
+
+	// the number is not truncated by the floating-point error, e.g. 1000 * 32.3 / 100 is 322.99999999999994
+	filtered, err = filterPodsByMode(pods[:1000], v1alpha1.FixedPercentPodMode, "32.3", RoundingModeRound, getRandomNumber)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filtered).To(HaveLen(323))
+
+	for i := 0; i < 10; i++ {
+		filtered, err = filterPodsByMode(pods, v1alpha1.RandomMaxPercentPodMode, "0.5", RoundingModeRound, getRandomNumber)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(len(filtered)).To(BeNumerically("<=", 50))
+	}
+
+	for _, value := range []string{"0", "-0.5", "100.5", "NaN", "half"} {
+		_, err = filterPodsByMode(pods, v1alpha1.FixedPercentPodMode, value, RoundingModeRound, getRandomNumber)
+		g.Expect(err).Should(HaveOccurred(), value)
+	}
+}
+
+func TestFilterPodsByRandomMaxPercentMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pods := make([]v1.Pod, 1000)
+	for i := range pods {
+		pods[i] = NewPod(PodArg{Name: fmt.Sprintf("p%d", i)})
+	}
+
+	// drawRandom draws the percentage as the given units, and picks the pods one after another
+	drawRandom := func(units uint64, bound *int) randomNumber {
+		calls := 0
+		return func(max int) uint64 {
+			calls++
+			if calls == 1 {
+				*bound = max
+				return units
+			}
+			return uint64(calls) % uint64(max)
+		}
+	}
+
+	for _, tc := range []struct {
+		value    string
+		units    uint64
+		bound    int
+		expected int
+	}{
+		// the percentage is drawn in [0,50], and 25% of 1000 pods is 250
+		{"50", 25, 51, 250},
+		{"50", 50, 51, 500},
+		{"50", 0, 51, 0},
+		// the percentage is drawn in steps of 0.1 in [0,0.5], and 0.3% of 1000 pods is 3
+		{"0.5", 3, 6, 3},
+		{"0.25", 25, 26, 2},
+	} {
+		var bound int
+		filtered, err := filterPodsByMode(pods, v1alpha1.RandomMaxPercentPodMode, tc.value, RoundingModeFloor, drawRandom(tc.units, &bound))
+		g.Expect(err).ShouldNot(HaveOccurred(), tc.value)
+		g.Expect(bound).To(Equal(tc.bound), tc.value)
+		g.Expect(filtered).To(HaveLen(tc.expected), tc.value)
+	}
+}
+
+func TestFilterPodsByPercentModeWithRoundingMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pods := make([]v1.Pod, 15)
+	for i := range pods {
+		pods[i] = NewPod(PodArg{Name: fmt.Sprintf("p%d", i)})
+	}
+
+	// 10% of 15 pods is 1.5
+	for mode, expected := range map[RoundingMode]int{
+		RoundingModeCeil:  2,
+		RoundingModeFloor: 1,
+		RoundingModeRound: 2,
+		"":                1,
+	} {
+		filtered, err := filterPodsByMode(pods, v1alpha1.FixedPercentPodMode, "10", mode, getRandomNumber)
+		g.Expect(err).ShouldNot(HaveOccurred(), string(mode))
+		g.Expect(filtered).To(HaveLen(expected), string(mode))
+	}
+
+	// 30% of 1 pod is 0.3, only the ceil mode selects it
+	for mode, expected := range map[RoundingMode]int{
+		RoundingModeCeil:  1,
+		RoundingModeFloor: 0,
+		RoundingModeRound: 0,
+	} {
+		num, err := percentOfPods(1, "30", mode)
+		g.Expect(err).ShouldNot(HaveOccurred(), string(mode))
+		g.Expect(num).To(Equal(expected), string(mode))
+	}
+
+	// the exact number is kept in spite of the floating-point error, e.g. 100 * 7 / 100 is 7.000000000000001
+	num, err := percentOfPods(100, "7", RoundingModeCeil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(num).To(Equal(7))
+	num, err = percentOfPods(1000, "32.3", RoundingModeFloor)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(num).To(Equal(323))
+
+	// no pods are selected from none, and the empty list is rejected
+	for _, mode := range []RoundingMode{RoundingModeCeil, RoundingModeFloor, RoundingModeRound} {
+		num, err := percentOfPods(0, "50", mode)
+		g.Expect(err).ShouldNot(HaveOccurred(), string(mode))
+		g.Expect(num).To(BeZero(), string(mode))
+
+		_, err = filterPodsByMode(nil, v1alpha1.FixedPercentPodMode, "50", mode, getRandomNumber)
+		g.Expect(err).Should(HaveOccurred(), string(mode))
+	}
+}
@@ -65,3 +65,29 @@ func NewDBStore(lc fx.Lifecycle, conf *config.ChaosDashboardConfig) (*DB, error)
 
 	return db, nil
 }
+
+// DeleteInBatches deletes the records matched by the query, at most batchSize records at a time,
+// to avoid holding the table for a long time. The records are soft deleted unless the query is unscoped.
+// A non-positive batchSize deletes all the matched records at once.
+func DeleteInBatches(query *gorm.DB, value interface{}, batchSize int) error {
+	if batchSize <= 0 {
+		return query.Delete(value).Error
+	}
+
+	for {
+		var ids []uint
+		if err := query.Limit(batchSize).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := query.Where("id IN (?)", ids).Delete(value).Error; err != nil {
+			return err
+		}
+		if len(ids) < batchSize {
+			return nil
+		}
+	}
+}
@@ -157,6 +157,11 @@ func (e *eventStore) ListByFilter(_ context.Context, filter core.Filter) ([]*cor
 			return nil, fmt.Errorf("the format of the createTime is wrong")
 		}
 	}
+	if filter.Pod != "" {
+		if parts := strings.Split(filter.Pod, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("the format of the pod is wrong, it should be namespace/name")
+		}
+	}
 
 	query, args := constructQueryArgs(filter.Name, filter.Namespace, filter.ObjectID, filter.Kind, filter.CreateTimeStr)
 	// List all events
@@ -165,6 +170,9 @@ func (e *eventStore) ListByFilter(_ context.Context, filter core.Filter) ([]*cor
 	} else {
 		db = &dbstore.DB{DB: e.db.Where(query, args...)}
 	}
+	if filter.Pod != "" {
+		db = &dbstore.DB{DB: db.Where("pod = ?", filter.Pod)}
+	}
 	if filter.LimitStr != "" {
 		db = &dbstore.DB{DB: db.Order("created_at desc").Limit(limit)}
 	}
@@ -176,21 +184,18 @@ func (e *eventStore) ListByFilter(_ context.Context, filter core.Filter) ([]*cor
 	return resList, err
 }
 
-// DeleteByCreateTime deletes events whose time difference is greater than the given time from CreateTime.
-func (e *eventStore) DeleteByCreateTime(_ context.Context, ttl time.Duration) error {
-	eventList, err := e.List(context.Background())
-	if err != nil {
-		return err
-	}
-	nowTime := time.Now()
-	for _, et := range eventList {
-		if et.CreatedAt.Add(ttl).Before(nowTime) {
-			if err := e.db.Model(core.Event{}).Unscoped().Delete(*et).Error; err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+// DeleteByCreateTime soft deletes events whose time difference is greater than the given time from CreateTime.
+func (e *eventStore) DeleteByCreateTime(_ context.Context, ttl time.Duration, batchSize int) error {
+	query := e.db.Model(core.Event{}).Where("created_at < ?", time.Now().Add(-ttl))
+
+	return dbstore.DeleteInBatches(query, core.Event{}, batchSize)
+}
+
+// PurgeDeleted hard deletes events which have been soft deleted for longer than the grace period.
+func (e *eventStore) PurgeDeleted(_ context.Context, grace time.Duration, batchSize int) error {
+	query := e.db.Unscoped().Model(core.Event{}).Where("deleted_at < ?", time.Now().Add(-grace))
+
+	return dbstore.DeleteInBatches(query, core.Event{}, batchSize)
 }
 
 // DeleteByUID deletes events by the uid of the experiment.
@@ -27,6 +27,7 @@ import (
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -111,7 +112,7 @@ var _ = Describe("event", func() {
 				AddRow(event0.ID, event0.CreatedAt, event0.Kind, event0.Type, event0.Reason,
 					event0.Message, event0.Name, event0.Namespace, event0.ObjectID)
 
-			sqlSelect := `SELECT * FROM "events"`
+			sqlSelect := `SELECT * FROM "events" WHERE "events"."deleted_at" IS NULL`
 			mock.ExpectQuery(regexp.QuoteMeta(sqlSelect)).WillReturnRows(rows)
 
 			events, err := es.List(context.TODO())
@@ -140,7 +141,7 @@ var _ = Describe("event", func() {
 						event1.Message, event1.Name, event1.Namespace, event1.ObjectID),
 			}
 
-			sqlSelect := `SELECT * FROM "events" WHERE (object_id = ?)`
+			sqlSelect := `SELECT * FROM "events" WHERE "events"."deleted_at" IS NULL AND ((object_id = ?))`
 			mock.ExpectQuery(regexp.QuoteMeta(sqlSelect)).WithArgs(event0.ObjectID).WillReturnRows(mockedRow[0])
 
 			events, err := es.ListByUID(context.TODO(), event0.ObjectID)
@@ -169,7 +170,7 @@ var _ = Describe("event", func() {
 						event1.Message, event1.Name, event1.Namespace, event1.ObjectID),
 			}
 
-			sqlSelect := `SELECT * FROM "events" WHERE (namespace = ? and name = ? and kind = ?)`
+			sqlSelect := `SELECT * FROM "events" WHERE "events"."deleted_at" IS NULL AND ((namespace = ? and name = ? and kind = ?))`
 			mock.ExpectQuery(regexp.QuoteMeta(sqlSelect)).WithArgs(event0.Namespace, event0.Name, event0.Kind).WillReturnRows(mockedRow[0])
 
 			events, err := es.ListByExperiment(context.TODO(), event0.Namespace, event0.Name, event0.Kind)
@@ -198,7 +199,7 @@ var _ = Describe("event", func() {
 						event1.Message, event1.Name, event1.Namespace, event1.ObjectID),
 			}
 
-			sqlSelect := `SELECT * FROM "events" WHERE (id = ?)`
+			sqlSelect := `SELECT * FROM "events" WHERE "events"."deleted_at" IS NULL AND ((id = ?))`
 			mock.ExpectQuery(regexp.QuoteMeta(sqlSelect)).WithArgs(event0.ID).WillReturnRows(mockedRow[0])
 
 			event, err := es.Find(context.TODO(), event0.ID)
@@ -238,7 +239,7 @@ var _ = Describe("event", func() {
 					"namespace", "object_id"}).
 				AddRow(event0.ID, event0.CreatedAt, event0.Kind, event0.Type, event0.Reason,
 					event0.Message, event0.Name, event0.Namespace, event0.ObjectID)
-			sqlSelect := `SELECT * FROM "events"`
+			sqlSelect := `SELECT * FROM "events" WHERE "events"."deleted_at" IS NULL`
 			mock.ExpectQuery(regexp.QuoteMeta(sqlSelect)).WillReturnRows(rows)
 
 			filter := core.Filter{}
@@ -246,6 +247,15 @@ var _ = Describe("event", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(events[0]).Should(Equal(event0))
 		})
+
+		It("pod wrong", func() {
+			filter := core.Filter{
+				Pod: "testPod",
+			}
+			_, err := es.ListByFilter(context.TODO(), filter)
+			Expect(err).Should(HaveOccurred())
+			Expect(strings.Contains(err.Error(), "the format of the pod is wrong")).To(Equal(true))
+		})
 	})
 })
 
@@ -374,3 +384,72 @@ func TestConstructQueryArgs(t *testing.T) {
 		}
 	}
 }
+
+func TestListByFilterWithPod(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	gdb, err := gorm.Open("sqlite3", ":memory:")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	defer gdb.Close()
+	es := NewStore(&dbstore.DB{DB: gdb})
+
+	for _, event := range []*core.Event{
+		{Reason: "Applied", Namespace: "testNamespace", Pod: "testNamespace/testPod"},
+		{Reason: "Failed", Namespace: "testNamespace", Pod: "testNamespace/testPod"},
+		{Reason: "Applied", Namespace: "testNamespace", Pod: "testNamespace/testPod-1"},
+		{Reason: "Applied", Namespace: "testNamespace", Pod: "anotherNamespace/testPod"},
+		{Reason: "Updated", Namespace: "testNamespace"},
+	} {
+		g.Expect(es.Create(context.TODO(), event)).Should(Succeed())
+	}
+
+	events, err := es.ListByFilter(context.TODO(), core.Filter{
+		Namespace: "testNamespace",
+		Pod:       "testNamespace/testPod",
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(events).Should(HaveLen(2))
+	for _, event := range events {
+		g.Expect(event.Pod).Should(Equal("testNamespace/testPod"))
+	}
+}
+
+func TestDeleteByCreateTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	gdb, err := gorm.Open("sqlite3", ":memory:")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	defer gdb.Close()
+	es := NewStore(&dbstore.DB{DB: gdb})
+
+	expired := time.Now().Add(-2 * time.Hour)
+	for _, event := range []*core.Event{
+		{Reason: "Applied", CreatedAt: expired},
+		{Reason: "Recovered", CreatedAt: expired},
+		{Reason: "Failed", CreatedAt: expired},
+		{Reason: "Applied"},
+	} {
+		g.Expect(es.Create(context.TODO(), event)).Should(Succeed())
+	}
+
+	countAll := func() int {
+		count := 0
+		g.Expect(gdb.Unscoped().Model(core.Event{}).Count(&count).Error).ShouldNot(HaveOccurred())
+		return count
+	}
+
+	// the expired events are soft deleted in batches
+	g.Expect(es.DeleteByCreateTime(context.TODO(), time.Hour, 2)).Should(Succeed())
+	events, err := es.List(context.TODO())
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(events).Should(HaveLen(1))
+	g.Expect(events[0].CreatedAt.After(expired)).Should(BeTrue())
+	g.Expect(countAll()).Should(Equal(4))
+
+	// they are kept during the grace period
+	g.Expect(es.PurgeDeleted(context.TODO(), time.Hour, 2)).Should(Succeed())
+	g.Expect(countAll()).Should(Equal(4))
+
+	g.Expect(es.PurgeDeleted(context.TODO(), 0, 2)).Should(Succeed())
+	g.Expect(countAll()).Should(Equal(1))
+}
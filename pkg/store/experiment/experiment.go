@@ -15,6 +15,7 @@ package experiment
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -57,6 +58,45 @@ func (e *experimentStore) ListMeta(_ context.Context, kind, namespace, name stri
 	return experiments, nil
 }
 
+// ListMetaByFilter implements the core.ExperimentStore.ListMetaByFilter method.
+func (e *experimentStore) ListMetaByFilter(_ context.Context, filter core.ExperimentFilter) ([]*core.ExperimentMeta, error) {
+	db := e.db.Table("experiments").Where("archived = ?", filter.Archived)
+	experiments := make([]*core.ExperimentMeta, 0)
+
+	if filter.Kind != "" {
+		db = db.Where("kind = ?", filter.Kind)
+	}
+	if filter.Namespace != "" {
+		db = db.Where("namespace = ?", filter.Namespace)
+	}
+	if filter.Name != "" {
+		db = db.Where("name = ?", filter.Name)
+	}
+	if !filter.StartTime.IsZero() {
+		db = db.Where("start_time >= ?", filter.StartTime)
+	}
+	if !filter.FinishTime.IsZero() {
+		db = db.Where("finish_time <= ?", filter.FinishTime)
+	}
+
+	// the pages are taken in the order the experiments are saved, as ListMeta returns them
+	db = db.Order("id")
+	if filter.Limit > 0 || filter.Offset > 0 {
+		limit := filter.Limit
+		if limit <= 0 {
+			// OFFSET can't be used without LIMIT
+			limit = math.MaxInt32
+		}
+		db = db.Limit(limit).Offset(filter.Offset)
+	}
+
+	if err := db.Find(&experiments).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+
+	return experiments, nil
+}
+
 // FindByUID implements the core.ExperimentStore.FindByUID method.
 func (e *experimentStore) FindByUID(_ context.Context, uid string) (*core.Experiment, error) {
 	experiment := new(core.Experiment)
@@ -102,23 +142,18 @@ func (e *experimentStore) Delete(_ context.Context, exp *core.Experiment) error
 	return err
 }
 
-// DeleteByFinishTime deletes experiments whose time difference is greater than the given time from FinishTime.
-func (e *experimentStore) DeleteByFinishTime(_ context.Context, ttl time.Duration) error {
-	experiments, err := e.ListMeta(context.Background(), "", "", "", true)
-	if err != nil {
-		return err
-	}
+// DeleteByFinishTime soft deletes archives whose time difference is greater than the given time from FinishTime.
+func (e *experimentStore) DeleteByFinishTime(_ context.Context, ttl time.Duration, batchSize int) error {
+	query := e.db.Model(core.Experiment{}).Where("archived = ? AND finish_time < ?", true, time.Now().Add(-ttl))
 
-	nowTime := time.Now()
-	for _, exp := range experiments {
-		if exp.FinishTime.Add(ttl).Before(nowTime) {
-			if err := e.db.Table("experiments").Unscoped().Delete(*exp).Error; err != nil {
-				return err
-			}
-		}
-	}
+	return dbstore.DeleteInBatches(query, core.Experiment{}, batchSize)
+}
 
-	return nil
+// PurgeDeleted hard deletes archives which have been soft deleted for longer than the grace period.
+func (e *experimentStore) PurgeDeleted(_ context.Context, grace time.Duration, batchSize int) error {
+	query := e.db.Unscoped().Model(core.Experiment{}).Where("deleted_at < ?", time.Now().Add(-grace))
+
+	return dbstore.DeleteInBatches(query, core.Experiment{}, batchSize)
 }
 
 // DeleteByUIDs deletes archives by the uid list.
@@ -14,8 +14,17 @@
 package experiment
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/core"
+	"github.com/chaos-mesh/chaos-mesh/pkg/store/dbstore"
 )
 
 func TestConstructQueryArgs(t *testing.T) {
@@ -135,3 +144,100 @@ func TestConstructQueryArgs(t *testing.T) {
 		}
 	}
 }
+
+func TestDeleteByFinishTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	gdb, err := gorm.Open("sqlite3", ":memory:")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	defer gdb.Close()
+	es := NewStore(&dbstore.DB{DB: gdb})
+
+	expired := time.Now().Add(-2 * time.Hour)
+	for _, exp := range []*core.Experiment{
+		{ExperimentMeta: core.ExperimentMeta{UID: "expired-0", Archived: true, FinishTime: expired}},
+		{ExperimentMeta: core.ExperimentMeta{UID: "expired-1", Archived: true, FinishTime: expired}},
+		{ExperimentMeta: core.ExperimentMeta{UID: "expired-2", Archived: true, FinishTime: expired}},
+		{ExperimentMeta: core.ExperimentMeta{UID: "fresh", Archived: true, FinishTime: time.Now()}},
+		// the experiment which isn't archived is never expired
+		{ExperimentMeta: core.ExperimentMeta{UID: "running", FinishTime: expired}},
+	} {
+		g.Expect(es.Set(context.TODO(), exp)).Should(Succeed())
+	}
+
+	countAll := func() int {
+		count := 0
+		g.Expect(gdb.Unscoped().Model(core.Experiment{}).Count(&count).Error).ShouldNot(HaveOccurred())
+		return count
+	}
+
+	// the expired archives are soft deleted in batches
+	g.Expect(es.DeleteByFinishTime(context.TODO(), time.Hour, 2)).Should(Succeed())
+	archives, err := es.ListMeta(context.TODO(), "", "", "", true)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(archives).Should(HaveLen(1))
+	g.Expect(archives[0].UID).Should(Equal("fresh"))
+	_, err = es.FindByUID(context.TODO(), "running")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(countAll()).Should(Equal(5))
+
+	// they can be recovered during the grace period
+	g.Expect(gdb.Unscoped().Model(core.Experiment{}).Where("uid = ?", "expired-0").
+		Update("deleted_at", gorm.Expr("NULL")).Error).ShouldNot(HaveOccurred())
+	_, err = es.FindByUID(context.TODO(), "expired-0")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(es.PurgeDeleted(context.TODO(), time.Hour, 2)).Should(Succeed())
+	g.Expect(countAll()).Should(Equal(5))
+
+	g.Expect(es.PurgeDeleted(context.TODO(), 0, 2)).Should(Succeed())
+	g.Expect(countAll()).Should(Equal(3))
+}
+
+func TestListMetaByFilter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	gdb, err := gorm.Open("sqlite3", ":memory:")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	defer gdb.Close()
+	es := NewStore(&dbstore.DB{DB: gdb})
+
+	day := func(d int, hour int) time.Time {
+		return time.Date(2021, 6, d, hour, 0, 0, 0, time.UTC)
+	}
+	for _, exp := range []*core.Experiment{
+		{ExperimentMeta: core.ExperimentMeta{UID: "day-2", Kind: "PodChaos", Namespace: "app", Archived: true, StartTime: day(2, 0), FinishTime: day(2, 12)}},
+		{ExperimentMeta: core.ExperimentMeta{UID: "day-3", Kind: "PodChaos", Namespace: "app", Archived: true, StartTime: day(3, 0), FinishTime: day(3, 12)}},
+		{ExperimentMeta: core.ExperimentMeta{UID: "day-1", Kind: "PodChaos", Namespace: "app", Archived: true, StartTime: day(1, 0), FinishTime: day(1, 12)}},
+		{ExperimentMeta: core.ExperimentMeta{UID: "other-kind", Kind: "IOChaos", Namespace: "app", Archived: true, StartTime: day(2, 0), FinishTime: day(2, 12)}},
+		{ExperimentMeta: core.ExperimentMeta{UID: "other-namespace", Kind: "PodChaos", Namespace: "other", Archived: true, StartTime: day(2, 0), FinishTime: day(2, 12)}},
+		{ExperimentMeta: core.ExperimentMeta{UID: "running", Kind: "PodChaos", Namespace: "app", StartTime: day(2, 0)}},
+	} {
+		g.Expect(es.Set(context.TODO(), exp)).Should(Succeed())
+	}
+
+	listUIDs := func(filter core.ExperimentFilter) []string {
+		filter.Kind = "PodChaos"
+		filter.Namespace = "app"
+		filter.Archived = true
+		metas, err := es.ListMetaByFilter(context.TODO(), filter)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		uids := make([]string, 0)
+		for _, meta := range metas {
+			uids = append(uids, meta.UID)
+		}
+		return uids
+	}
+
+	// the experiments are listed in the order they are saved
+	g.Expect(listUIDs(core.ExperimentFilter{})).Should(Equal([]string{"day-2", "day-3", "day-1"}))
+
+	g.Expect(listUIDs(core.ExperimentFilter{Limit: 2})).Should(Equal([]string{"day-2", "day-3"}))
+	g.Expect(listUIDs(core.ExperimentFilter{Limit: 2, Offset: 2})).Should(Equal([]string{"day-1"}))
+	g.Expect(listUIDs(core.ExperimentFilter{Offset: 1})).Should(Equal([]string{"day-3", "day-1"}))
+	g.Expect(listUIDs(core.ExperimentFilter{Offset: 5})).Should(BeEmpty())
+
+	g.Expect(listUIDs(core.ExperimentFilter{StartTime: day(2, 0)})).Should(Equal([]string{"day-2", "day-3"}))
+	g.Expect(listUIDs(core.ExperimentFilter{FinishTime: day(2, 12)})).Should(Equal([]string{"day-2", "day-1"}))
+	g.Expect(listUIDs(core.ExperimentFilter{StartTime: day(2, 0), FinishTime: day(2, 12)})).Should(Equal([]string{"day-2"}))
+}
@@ -43,6 +43,11 @@ type TTLconfig struct {
 	EventTTL time.Duration
 	// ArchiveExperimentTTL defines the ttl of archive experiments
 	ArchiveExperimentTTL time.Duration
+	// GracePeriod defines how long the expired data is soft deleted before it's deleted permanently,
+	// the data can be recovered from the database during this period.
+	GracePeriod time.Duration
+	// BatchSize defines the max number of rows deleted at a time, zero means no limit.
+	BatchSize int
 }
 
 // NewController returns a new database ttl controller
@@ -68,7 +73,20 @@ func Register(c *Controller, controllerRuntimeStopCh <-chan struct{}) {
 // runWorker is a long-running function that will call the
 // function in order to delete the events and archives.
 func (c *Controller) runWorker() {
+	ctx := context.Background()
+
 	log.Info("deleting expired data from the database")
-	c.event.DeleteByCreateTime(context.Background(), c.ttlconfig.EventTTL)
-	c.experiment.DeleteByFinishTime(context.Background(), c.ttlconfig.ArchiveExperimentTTL)
+	if err := c.event.DeleteByCreateTime(ctx, c.ttlconfig.EventTTL, c.ttlconfig.BatchSize); err != nil {
+		log.Error(err, "failed to delete expired events")
+	}
+	if err := c.experiment.DeleteByFinishTime(ctx, c.ttlconfig.ArchiveExperimentTTL, c.ttlconfig.BatchSize); err != nil {
+		log.Error(err, "failed to delete expired archives")
+	}
+
+	if err := c.event.PurgeDeleted(ctx, c.ttlconfig.GracePeriod, c.ttlconfig.BatchSize); err != nil {
+		log.Error(err, "failed to purge deleted events")
+	}
+	if err := c.experiment.PurgeDeleted(ctx, c.ttlconfig.GracePeriod, c.ttlconfig.BatchSize); err != nil {
+		log.Error(err, "failed to purge deleted archives")
+	}
 }
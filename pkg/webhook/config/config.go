@@ -31,6 +31,28 @@ var (
 	errMissingTemplateName = fmt.Errorf(`template field is required for template args config`)
 )
 
+// CommandPolicy defines how the injected commands are combined with the original command of a container
+type CommandPolicy string
+
+const (
+	// PrependCommandPolicy runs the injected commands before the original command
+	PrependCommandPolicy CommandPolicy = "prepend"
+	// AppendCommandPolicy runs the injected commands, then replaces the shell with the original command and args
+	// as they are
+	AppendCommandPolicy CommandPolicy = "append"
+	// ReplaceCommandPolicy runs the injected commands instead of the original command
+	ReplaceCommandPolicy CommandPolicy = "replace"
+)
+
+// Validate checks whether the policy is supported, an empty policy means PrependCommandPolicy
+func (p CommandPolicy) Validate() error {
+	switch p {
+	case "", PrependCommandPolicy, AppendCommandPolicy, ReplaceCommandPolicy:
+		return nil
+	}
+	return fmt.Errorf("unknown command policy %s", p)
+}
+
 const (
 	annotationNamespaceDefault = "admission-webhook.chaos-mesh.org"
 )
@@ -46,6 +68,19 @@ type ExecAction struct {
 	Command []string `json:"command,omitempty"`
 }
 
+// ResourcePatch describes the resources patched into an existing container
+type ResourcePatch struct {
+	// Limits are the resource limits patched into the container, the requests exceeding them are lowered to them.
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+	// Requests are the resource requests patched into the container.
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// Force overrides the resources already set in the container, otherwise only the missing ones are patched.
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
 // InjectionConfig is a specific instance of an injected config, for a given annotation
 type InjectionConfig struct {
 	Name string
@@ -65,6 +100,15 @@ type InjectionConfig struct {
 	// Value defines for the Commands for stating container.
 	// +optional
 	PostStart map[string]ExecAction `json:"postStart,omitempty"`
+	// CommandPolicy defines how the PostStart commands are combined with the original command and args
+	// of the container. Supported policy: prepend / append / replace, defaults to prepend.
+	// +optional
+	CommandPolicy CommandPolicy `json:"commandPolicy,omitempty"`
+	// ResourcePatches patches the resources of the existing containers.
+	// Key defines for the name of deployment container.
+	// Value defines for the resources patched into the container.
+	// +optional
+	ResourcePatches map[string]ResourcePatch `json:"resourcePatches,omitempty"`
 }
 
 // Config is a struct indicating how a given injection should be configured
@@ -105,6 +149,32 @@ func (c *Config) RequestInitAnnotationKey() string {
 	return c.AnnotationNamespace + "/init-request"
 }
 
+// EphemeralRequestAnnotationKey is the annotation of a running pod to request the config injected as ephemeral containers
+func (c *Config) EphemeralRequestAnnotationKey() string {
+	return c.AnnotationNamespace + "/ephemeral-request"
+}
+
+// InjectedConfigAnnotationKey is the annotation of an injected pod to record the name of the injected config
+func (c *Config) InjectedConfigAnnotationKey() string {
+	return c.AnnotationNamespace + "/injected-config"
+}
+
+// FailurePolicyAnnotationKey is the annotation of namespace to permit its pods when the injection fails,
+// the pods are denied on failure unless the value is "Ignore"
+func (c *Config) FailurePolicyAnnotationKey() string {
+	return c.AnnotationNamespace + "/failure-policy"
+}
+
+// RequestAnnotationKeyOverrideKey is the annotation of namespace to override RequestAnnotationKey for the pods in it
+func (c *Config) RequestAnnotationKeyOverrideKey() string {
+	return c.AnnotationNamespace + "/request-annotation-key"
+}
+
+// StatusAnnotationKeyOverrideKey is the annotation of namespace to override StatusAnnotationKey for the pods in it
+func (c *Config) StatusAnnotationKeyOverrideKey() string {
+	return c.AnnotationNamespace + "/status-annotation-key"
+}
+
 // GetRequestedConfig returns the InjectionConfig given a requested key
 func (c *Config) GetRequestedConfig(namespace, key string) (*InjectionConfig, error) {
 	c.RLock()
@@ -105,5 +105,17 @@ postStart:
 			Expect(res).To(Equal("/init-request"))
 		})
 
+		It("should return injected-config on InjectedConfigAnnotationKey", func() {
+			var cfg Config
+			res := cfg.InjectedConfigAnnotationKey()
+			Expect(res).To(Equal("/injected-config"))
+		})
+
+		It("should return failure-policy on FailurePolicyAnnotationKey", func() {
+			var cfg Config
+			res := cfg.FailurePolicyAnnotationKey()
+			Expect(res).To(Equal("/failure-policy"))
+		})
+
 	})
 })
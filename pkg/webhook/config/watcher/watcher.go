@@ -43,7 +43,9 @@ var kubernetesNewForConfig = kubernetes.NewForConfig
 
 const (
 	serviceAccountNamespaceFilePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
-	templateItemKey                 = "data"
+
+	// TemplateItemKey is the key of the template in the data of template configmaps
+	TemplateItemKey = "data"
 )
 
 // ErrWatchChannelClosed should restart watcher
@@ -239,32 +241,17 @@ func (c *K8sConfigMapWatcher) GetInjectionConfigs() (map[string][]*config.Inject
 			}
 			continue
 		}
-		yamlTemp, err := template.New("").Parse(temp)
-		if err != nil {
-			log.Error(err, "failed to parse template",
-				"template", conf.Template, "config", conf.Name)
-			continue
-		}
-
-		result, err := renderTemplateWithArgs(yamlTemp, conf.Arguments)
+		injectConfig, err := RenderInjectionConfig(temp, conf)
 		if err != nil {
-			log.Error(err, "failed to render template",
+			log.Error(err, "failed to render injection config",
 				"template", conf.Template, "config", conf.Name)
 			continue
 		}
 
-		var injectConfig config.InjectionConfig
-		if err := yaml.Unmarshal(result, &injectConfig); err != nil {
-			log.Error(err, "failed to unmarshal injection config", "injection config", string(result))
-			continue
-		}
-
-		injectConfig.Selector = conf.Selector
-		injectConfig.Name = conf.Name
 		if _, ok := injectionConfigs[conf.Namespace]; !ok {
 			injectionConfigs[conf.Namespace] = make([]*config.InjectionConfig, 0)
 		}
-		injectionConfigs[conf.Namespace] = append(injectionConfigs[conf.Namespace], &injectConfig)
+		injectionConfigs[conf.Namespace] = append(injectionConfigs[conf.Namespace], injectConfig)
 		if c.metrics != nil {
 			c.metrics.InjectionConfigs.WithLabelValues(conf.Namespace, conf.Template).Inc()
 		}
@@ -273,6 +260,31 @@ func (c *K8sConfigMapWatcher) GetInjectionConfigs() (map[string][]*config.Inject
 	return injectionConfigs, nil
 }
 
+// RenderInjectionConfig renders the template with the args of the config into an injection config
+func RenderInjectionConfig(temp string, conf *config.TemplateArgs) (*config.InjectionConfig, error) {
+	yamlTemp, err := template.New("").Parse(temp)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %s", err)
+	}
+
+	result, err := renderTemplateWithArgs(yamlTemp, conf.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("render template: %s", err)
+	}
+
+	var injectConfig config.InjectionConfig
+	if err := yaml.Unmarshal(result, &injectConfig); err != nil {
+		return nil, fmt.Errorf("unmarshal injection config %q: %s", string(result), err)
+	}
+	if err := injectConfig.CommandPolicy.Validate(); err != nil {
+		return nil, err
+	}
+
+	injectConfig.Selector = conf.Selector
+	injectConfig.Name = conf.Name
+	return &injectConfig, nil
+}
+
 // GetTemplates returns a map of common templates
 func (c *K8sConfigMapWatcher) GetTemplates() (map[string]string, error) {
 	log.Info("Fetching Template Configs...")
@@ -286,7 +298,7 @@ func (c *K8sConfigMapWatcher) GetTemplates() (map[string]string, error) {
 	log.Info("Fetched templates", "templates count", len(templateList.Items))
 	templates := make(map[string]string, len(templateList.Items))
 	for _, temp := range templateList.Items {
-		templates[temp.Name] = temp.Data[templateItemKey]
+		templates[temp.Name] = temp.Data[TemplateItemKey]
 	}
 	if c.metrics != nil {
 		c.metrics.SidecarTemplates.Set(float64(len(templates)))
@@ -13,7 +13,11 @@
 
 package inject
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
+)
 
 // MergeCommands merges injected commands and original commands for injecting commands to containers,
 // eg: inject: []string{"bash", "-c", "/check.sh"}, origin: []string{"bash", "-c", "/run.sh"}
@@ -28,6 +32,30 @@ func MergeCommands(inject []string, origin []string, args []string) []string {
 	return []string{"/bin/sh", "-ec", scripts}
 }
 
+// MergeCommandsWithPolicy merges injected commands and original commands according to the policy.
+// The original args are always merged into the returned commands, except for ReplaceCommandPolicy
+// which drops the original commands and args.
+// eg: inject: []string{"/check.sh"}, origin: []string{"/run.sh"}, args: []string{"--v 2"}, the append policy
+// merges them into []string{"/bin/sh", "-ec", "/check.sh\nexec \"$0\" \"$@\"", "/run.sh", "--v 2"}
+func MergeCommandsWithPolicy(policy config.CommandPolicy, inject []string, origin []string, args []string) []string {
+	switch policy {
+	case config.AppendCommandPolicy:
+		scripts := mergeCommandsAction(inject)
+		commands := append(append([]string{}, origin...), args...)
+		if len(commands) == 0 {
+			return []string{"/bin/sh", "-ec", scripts}
+		}
+
+		// the shell is replaced by the original command with its args as they are, so the original
+		// command is run only if the injected commands succeed, and it keeps the pid and the signals
+		return append([]string{"/bin/sh", "-ec", scripts + `exec "$0" "$@"`}, commands...)
+	case config.ReplaceCommandPolicy:
+		return []string{"/bin/sh", "-ec", mergeCommandsAction(inject)}
+	default:
+		return MergeCommands(inject, origin, args)
+	}
+}
+
 func mergeCommandsAction(commands []string) string {
 	scripts := ""
 
@@ -14,9 +14,12 @@
 package inject
 
 import (
+	"os/exec"
 	"testing"
 
 	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
 )
 
 func TestIsCommonScripts(t *testing.T) {
@@ -313,3 +316,81 @@ func TestMergeCommands(t *testing.T) {
 		g.Expect(MergeCommands(tc.inject, tc.origin, tc.args)).To(Equal(tc.expectedValue), tc.name)
 	}
 }
+
+func TestMergeCommandsWithPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type TestCase struct {
+		name          string
+		policy        config.CommandPolicy
+		expectedValue []string
+	}
+
+	inject := []string{"/check.sh -v 1"}
+	origin := []string{"/start.sh"}
+	args := []string{"--c t", "--v 2"}
+
+	tcs := []TestCase{
+		{
+			name:   "default policy",
+			policy: "",
+			expectedValue: []string{
+				"/bin/sh",
+				"-ec",
+				"/check.sh -v 1\n/start.sh --c t --v 2\n",
+			},
+		},
+		{
+			name:   "prepend policy",
+			policy: config.PrependCommandPolicy,
+			expectedValue: []string{
+				"/bin/sh",
+				"-ec",
+				"/check.sh -v 1\n/start.sh --c t --v 2\n",
+			},
+		},
+		{
+			name:   "append policy",
+			policy: config.AppendCommandPolicy,
+			expectedValue: []string{
+				"/bin/sh",
+				"-ec",
+				"/check.sh -v 1\nexec \"$0\" \"$@\"",
+				"/start.sh",
+				"--c t",
+				"--v 2",
+			},
+		},
+		{
+			name:   "replace policy",
+			policy: config.ReplaceCommandPolicy,
+			expectedValue: []string{
+				"/bin/sh",
+				"-ec",
+				"/check.sh -v 1\n",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		g.Expect(MergeCommandsWithPolicy(tc.policy, inject, origin, args)).To(Equal(tc.expectedValue), tc.name)
+	}
+}
+
+func TestMergeCommandsWithAppendPolicyOrder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// the injected commands run before the original command, which gets its args as they are
+	commands := MergeCommandsWithPolicy(config.AppendCommandPolicy,
+		[]string{"echo inject"}, []string{"echo", "origin"}, []string{"a  b", "$HOME"})
+	out, err := exec.Command(commands[0], commands[1:]...).Output()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(out)).To(Equal("inject\norigin a  b $HOME\n"))
+
+	// the original command isn't run if the injected commands fail
+	commands = MergeCommandsWithPolicy(config.AppendCommandPolicy,
+		[]string{"false"}, []string{"echo", "origin"}, nil)
+	out, err = exec.Command(commands[0], commands[1:]...).Output()
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(string(out)).To(BeEmpty())
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/chaos-mesh/chaos-mesh/controllers/metrics"
@@ -32,6 +33,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 var log = ctrl.Log.WithName("inject-webhook")
@@ -44,6 +46,10 @@ var ignoredNamespaces = []string{
 const (
 	// StatusInjected is the annotation value for /status that indicates an injection was already performed on this pod
 	StatusInjected = "injected"
+
+	// FailurePolicyIgnore is the annotation value for /failure-policy of namespace that permits its pods when the injection fails,
+	// it's case-sensitive as the failurePolicy of admission webhooks
+	FailurePolicyIgnore = "Ignore"
 )
 
 // Inject do pod template config inject
@@ -51,11 +57,14 @@ func Inject(res *v1beta1.AdmissionRequest, cli client.Client, cfg *config.Config
 	var pod corev1.Pod
 	if err := json.Unmarshal(res.Object.Raw, &pod); err != nil {
 		log.Error(err, "Could not unmarshal raw object")
-		return &v1beta1.AdmissionResponse{
-			Result: &metav1.Status{
-				Message: err.Error(),
-			},
+		ns, nsErr := fetchNamespace(res.Namespace, cli)
+		if nsErr != nil {
+			log.Error(nsErr, "Could not get the namespace, permit the pod as its failure policy is unknown", "namespace", res.Namespace)
+			return &v1beta1.AdmissionResponse{
+				Allowed: true,
+			}
 		}
+		return failureResponse(err, ns, cfg)
 	}
 
 	// Deal with potential empty fields, e.g., when the pod is created by a deployment
@@ -70,7 +79,39 @@ func Inject(res *v1beta1.AdmissionRequest, cli client.Client, cfg *config.Config
 	log.V(4).Info("OldObject", "OldObject", string(res.OldObject.Raw))
 	log.V(4).Info("Pod", "Pod", pod)
 
-	requiredKey, ok := injectRequired(&pod.ObjectMeta, cli, cfg, controllerCfg)
+	// the pods in the special namespaces are never injected, so they're permitted before anything could fail
+	if isIgnoredNamespace(pod.Namespace) {
+		log.Info("Skip mutation for it' in special namespace", "name", podName, "namespace", pod.Namespace)
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	// the namespace is fetched only once, as both the annotation keys and the namespace level requests depend on it.
+	// If it can't be fetched, the pod fails open: only its own request by the global annotation keys is checked,
+	// so the pods which never ask for injection are permitted, and the failures of the injection are permitted too.
+	ns, nsErr := fetchNamespace(pod.Namespace, cli)
+	if nsErr != nil {
+		log.Error(nsErr, "Could not get the namespace of pod, check the injection by the global annotation keys",
+			"namespace", pod.Namespace, "name", podName)
+	}
+	keys := namespaceAnnotationKeys(ns, cfg)
+	fail := func(err error) *v1beta1.AdmissionResponse {
+		if nsErr != nil {
+			log.Info("Permitting the pod despite the failure of injection as its namespace is unknown",
+				"namespace", pod.Namespace, "name", podName, "error", err.Error())
+			return &v1beta1.AdmissionResponse{
+				Allowed: true,
+			}
+		}
+		return failureResponse(err, ns, cfg)
+	}
+
+	requiredKey, ok, err := injectRequired(&pod.ObjectMeta, ns, keys, cli, cfg, controllerCfg)
+	if err != nil {
+		log.Error(err, "Could not check whether the pod requires injection", "namespace", pod.Namespace, "name", podName)
+		return fail(err)
+	}
 	if !ok {
 		log.Info("Skipping injection due to policy check", "namespace", pod.ObjectMeta.Namespace, "name", podName)
 		return &v1beta1.AdmissionResponse{
@@ -83,21 +124,15 @@ func Inject(res *v1beta1.AdmissionRequest, cli client.Client, cfg *config.Config
 	}
 	injectionConfig, err := cfg.GetRequestedConfig(pod.Namespace, requiredKey)
 	if err != nil {
-		log.Error(err, "Error getting injection config, permitting launch of pod with no sidecar injected", "injectionConfig",
-			injectionConfig)
-		// dont prevent pods from launching! just return allowed
-		return &v1beta1.AdmissionResponse{
-			Allowed: true,
-		}
+		log.Error(err, "Error getting injection config", "namespace", pod.Namespace, "name", podName, "config", requiredKey)
+		return fail(err)
 	}
 
 	if injectionConfig.Selector != nil {
-		meet, err := podselector.CheckPodMeetSelector(pod, *injectionConfig.Selector)
+		meet, err := podselector.CheckPodMeetSelector(context.TODO(), cli, pod, *injectionConfig.Selector)
 		if err != nil {
 			log.Error(err, "Failed to check pod selector", "namespace", pod.Namespace)
-			return &v1beta1.AdmissionResponse{
-				Allowed: true,
-			}
+			return fail(err)
 		}
 
 		if !meet {
@@ -109,15 +144,10 @@ func Inject(res *v1beta1.AdmissionRequest, cli client.Client, cfg *config.Config
 		}
 	}
 
-	annotations := map[string]string{cfg.StatusAnnotationKey(): StatusInjected}
-
-	patchBytes, err := createPatch(&pod, injectionConfig, annotations)
+	patchBytes, err := createInjectionPatch(&pod, injectionConfig, keys)
 	if err != nil {
-		return &v1beta1.AdmissionResponse{
-			Result: &metav1.Status{
-				Message: err.Error(),
-			},
-		}
+		log.Error(err, "Could not create injection patch", "namespace", pod.Namespace, "name", podName)
+		return fail(err)
 	}
 
 	log.Info("AdmissionResponse: patch", "patchBytes", string(patchBytes))
@@ -134,71 +164,159 @@ func Inject(res *v1beta1.AdmissionRequest, cli client.Client, cfg *config.Config
 	}
 }
 
-// Check whether the target resource need to be injected and return the required config name
-func injectRequired(metadata *metav1.ObjectMeta, cli client.Client, cfg *config.Config, controllerCfg *controllerCfg.ChaosControllerConfig) (string, bool) {
-	// skip special kubernetes system namespaces
+// failureResponse denies the pod with the error of injection, unless the namespace of the pod
+// tolerates the failure through its annotation, e.g. the critical namespaces whose pods should
+// always be scheduled.
+func failureResponse(err error, ns *corev1.Namespace, cfg *config.Config) *v1beta1.AdmissionResponse {
+	if ns != nil && ns.GetAnnotations()[cfg.FailurePolicyAnnotationKey()] == FailurePolicyIgnore {
+		log.Info("Permitting the pod despite the failure of injection due to the failure policy of namespace",
+			"namespace", ns.Name, "error", err.Error())
+		return &v1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	return &v1beta1.AdmissionResponse{
+		Result: &metav1.Status{
+			Message: err.Error(),
+		},
+	}
+}
+
+// isIgnoredNamespace returns true if the namespace is one of the special kubernetes system namespaces
+func isIgnoredNamespace(name string) bool {
 	for _, namespace := range ignoredNamespaces {
-		if metadata.Namespace == namespace {
-			log.Info("Skip mutation for it' in special namespace", "name", metadata.Name, "namespace", metadata.Namespace)
-			return "", false
+		if name == namespace {
+			return true
 		}
 	}
+	return false
+}
 
+// Check whether the target resource need to be injected and return the required config name
+func injectRequired(metadata *metav1.ObjectMeta, ns *corev1.Namespace, keys annotationKeys, cli client.Client, cfg *config.Config, controllerCfg *controllerCfg.ChaosControllerConfig) (string, bool, error) {
 	if controllerCfg.EnableFilterNamespace {
 		ok, err := podselector.IsAllowedNamespaces(context.Background(), cli, metadata.Namespace)
 		if err != nil {
-			log.Error(err, "fail to check whether this namespace should be injected", "namespace", metadata.Namespace)
+			return "", false, err
 		}
 
 		if !ok {
 			log.Info("Skip mutation for it' in special namespace", "name", metadata.Name, "namespace", metadata.Namespace)
-			return "", false
+			return "", false, nil
 		}
 	}
 
 	log.V(4).Info("meta", "meta", metadata)
 
-	if checkInjectStatus(metadata, cfg) {
+	if checkInjectStatus(metadata, keys) {
 		log.Info("Pod annotation indicates injection already satisfied, skipping",
 			"namespace", metadata.Namespace, "name", metadata.Name,
-			"annotationKey", cfg.StatusAnnotationKey(), "value", StatusInjected)
-		return "", false
+			"annotationKey", keys.status, "value", StatusInjected)
+		return "", false, nil
 	}
 
-	requiredConfig, ok := injectByPodRequired(metadata, cfg)
+	requiredConfig, ok := injectByPodRequired(metadata, keys)
 	if ok {
 		log.Info("Pod annotation requesting sidecar config",
 			"namespace", metadata.Namespace, "name", metadata.Name,
-			"annotation", cfg.RequestAnnotationKey(), "requiredConfig", requiredConfig)
-		return requiredConfig, true
+			"annotation", keys.request, "requiredConfig", requiredConfig)
+		return requiredConfig, true, nil
 	}
 
-	requiredConfig, ok = injectByNamespaceRequired(metadata, cli, cfg)
+	requiredConfig, ok = injectByNamespaceRequired(metadata, ns, keys)
 	if ok {
 		log.Info("Pod annotation requesting sidecar config",
 			"namespace", metadata.Namespace, "name", metadata.Name,
-			"annotation", cfg.RequestAnnotationKey(), "requiredConfig", requiredConfig)
-		return requiredConfig, true
+			"annotation", keys.request, "requiredConfig", requiredConfig)
+		return requiredConfig, true, nil
 	}
 
-	requiredConfig, ok = injectByNamespaceInitRequired(metadata, cli, cfg)
+	requiredConfig, ok = injectByNamespaceInitRequired(metadata, ns, cfg)
 	if ok {
 		log.Info("Pod annotation init requesting sidecar config",
 			"namespace", metadata.Namespace, "name", metadata.Name,
-			"annotation", cfg.RequestAnnotationKey(), "requiredConfig", requiredConfig)
-		return requiredConfig, true
+			"annotation", cfg.RequestInitAnnotationKey(), "requiredConfig", requiredConfig)
+		return requiredConfig, true, nil
+	}
+
+	return "", false, nil
+}
+
+// annotationKeys are the keys of pod annotations to request and record the injection
+type annotationKeys struct {
+	request string
+	status  string
+	// config records the name of the injected config, it cannot be overridden by namespace
+	config string
+}
+
+func defaultAnnotationKeys(cfg *config.Config) annotationKeys {
+	return annotationKeys{
+		request: cfg.RequestAnnotationKey(),
+		status:  cfg.StatusAnnotationKey(),
+		config:  cfg.InjectedConfigAnnotationKey(),
+	}
+}
+
+// fetchNamespace returns the namespace of the pod, or nil if the pod has no namespace
+func fetchNamespace(name string, cli client.Client) (*corev1.Namespace, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	var ns corev1.Namespace
+	if err := cli.Get(context.Background(), types.NamespacedName{Name: name}, &ns); err != nil {
+		return nil, err
+	}
+	return &ns, nil
+}
+
+// getNamespace returns the namespace of the pod, or nil if it can't be fetched
+func getNamespace(name string, cli client.Client) *corev1.Namespace {
+	ns, err := fetchNamespace(name, cli)
+	if err != nil {
+		log.Error(err, "failed to get namespace", "namespace", name)
+	}
+	return ns
+}
+
+// namespaceAnnotationKeys returns the annotation keys for the pods in the namespace.
+// The global keys can be overridden through the annotations of the namespace, to avoid
+// collisions with other tools in multi-tenant clusters. The overrides which are not
+// valid annotation keys are ignored.
+func namespaceAnnotationKeys(ns *corev1.Namespace, cfg *config.Config) annotationKeys {
+	keys := defaultAnnotationKeys(cfg)
+	if ns == nil {
+		return keys
+	}
+
+	annotations := ns.GetAnnotations()
+	overrideKey := func(overrideKey string, key *string) {
+		override := annotations[overrideKey]
+		if override == "" {
+			return
+		}
+		if errs := validation.IsQualifiedName(override); len(errs) > 0 {
+			log.Info("ignore the invalid annotation key overridden by namespace, use the global annotation key",
+				"namespace", ns.Name, "annotation", overrideKey, "value", override, "errors", errs)
+			return
+		}
+		*key = override
 	}
+	overrideKey(cfg.RequestAnnotationKeyOverrideKey(), &keys.request)
+	overrideKey(cfg.StatusAnnotationKeyOverrideKey(), &keys.status)
 
-	return "", false
+	return keys
 }
 
-func checkInjectStatus(metadata *metav1.ObjectMeta, cfg *config.Config) bool {
+func checkInjectStatus(metadata *metav1.ObjectMeta, keys annotationKeys) bool {
 	annotations := metadata.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
 
-	status, ok := annotations[cfg.StatusAnnotationKey()]
+	status, ok := annotations[keys.status]
 	if ok && strings.ToLower(status) == StatusInjected {
 		return true
 	}
@@ -206,10 +324,8 @@ func checkInjectStatus(metadata *metav1.ObjectMeta, cfg *config.Config) bool {
 	return false
 }
 
-func injectByNamespaceRequired(metadata *metav1.ObjectMeta, cli client.Client, cfg *config.Config) (string, bool) {
-	var ns corev1.Namespace
-	if err := cli.Get(context.Background(), types.NamespacedName{Name: metadata.Namespace}, &ns); err != nil {
-		log.Error(err, "failed to get namespace", "namespace", metadata.Namespace)
+func injectByNamespaceRequired(metadata *metav1.ObjectMeta, ns *corev1.Namespace, keys annotationKeys) (string, bool) {
+	if ns == nil {
 		return "", false
 	}
 	annotations := ns.GetAnnotations()
@@ -217,7 +333,7 @@ func injectByNamespaceRequired(metadata *metav1.ObjectMeta, cli client.Client, c
 		annotations = make(map[string]string)
 	}
 
-	required, ok := annotations[annotation.GenKeyForWebhook(cfg.RequestAnnotationKey(), metadata.Name)]
+	required, ok := annotations[annotation.GenKeyForWebhook(keys.request, metadata.Name)]
 	if !ok {
 		log.Info("Pod annotation by namespace is missing, skipping injection",
 			"namespace", metadata.Namespace, "pod", metadata.Name, "config", required)
@@ -229,10 +345,8 @@ func injectByNamespaceRequired(metadata *metav1.ObjectMeta, cli client.Client, c
 	return strings.ToLower(required), true
 }
 
-func injectByNamespaceInitRequired(metadata *metav1.ObjectMeta, cli client.Client, cfg *config.Config) (string, bool) {
-	var ns corev1.Namespace
-	if err := cli.Get(context.Background(), types.NamespacedName{Name: metadata.Namespace}, &ns); err != nil {
-		log.Error(err, "failed to get namespace", "namespace", metadata.Namespace)
+func injectByNamespaceInitRequired(metadata *metav1.ObjectMeta, ns *corev1.Namespace, cfg *config.Config) (string, bool) {
+	if ns == nil {
 		return "", false
 	}
 
@@ -253,16 +367,16 @@ func injectByNamespaceInitRequired(metadata *metav1.ObjectMeta, cli client.Clien
 	return strings.ToLower(required), true
 }
 
-func injectByPodRequired(metadata *metav1.ObjectMeta, cfg *config.Config) (string, bool) {
+func injectByPodRequired(metadata *metav1.ObjectMeta, keys annotationKeys) (string, bool) {
 	annotations := metadata.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
 
-	required, ok := annotations[cfg.RequestAnnotationKey()]
+	required, ok := annotations[keys.request]
 	if !ok {
 		log.Info("Pod annotation is missing, skipping injection",
-			"namespace", metadata.Namespace, "name", metadata.Name, "annotation", cfg.RequestAnnotationKey())
+			"namespace", metadata.Namespace, "name", metadata.Name, "annotation", keys.request)
 		return "", false
 	}
 
@@ -271,6 +385,95 @@ func injectByPodRequired(metadata *metav1.ObjectMeta, cfg *config.Config) (strin
 	return strings.ToLower(required), true
 }
 
+// CreatePatch returns the JSON patch which injects the config into the pod and marks the pod as injected.
+// It doesn't check whether the pod requires the injection.
+func CreatePatch(pod *corev1.Pod, inj *config.InjectionConfig, cli client.Client, cfg *config.Config) ([]byte, error) {
+	return createInjectionPatch(pod, inj, namespaceAnnotationKeys(getNamespace(pod.Namespace, cli), cfg))
+}
+
+// StatusAnnotationKey returns the annotation key which marks the pods in the namespace as injected
+func StatusAnnotationKey(namespace string, cli client.Client, cfg *config.Config) string {
+	return namespaceAnnotationKeys(getNamespace(namespace, cli), cfg).status
+}
+
+// EphemeralContainers returns the containers of the config as the ephemeral containers of a running pod.
+// The ephemeral containers cannot declare ports, probes or resources, so they are dropped. The config which
+// changes the pod itself, e.g. adds volumes, init containers or commands, cannot be injected into a running
+// pod, and it's rejected instead of being injected partially. The containers which have been injected are skipped.
+func EphemeralContainers(pod *corev1.Pod, inj *config.InjectionConfig) ([]corev1.EphemeralContainer, error) {
+	switch {
+	case len(inj.Volumes) > 0:
+		return nil, fmt.Errorf("config %s adds volumes, which is not supported by the ephemeral containers", inj.Name)
+	case len(inj.InitContainers) > 0:
+		return nil, fmt.Errorf("config %s adds init containers, which is not supported by the ephemeral containers", inj.Name)
+	case len(inj.PostStart) > 0:
+		return nil, fmt.Errorf("config %s changes the commands, which is not supported by the ephemeral containers", inj.Name)
+	case len(inj.ResourcePatches) > 0:
+		return nil, fmt.Errorf("config %s patches the resources, which is not supported by the ephemeral containers", inj.Name)
+	case len(inj.HostAliases) > 0 || inj.ShareProcessNamespace:
+		return nil, fmt.Errorf("config %s changes the pod spec, which is not supported by the ephemeral containers", inj.Name)
+	}
+
+	volumes := make(map[string]bool)
+	for _, volume := range pod.Spec.Volumes {
+		volumes[volume.Name] = true
+	}
+	injected := make(map[string]bool)
+	for _, container := range pod.Spec.EphemeralContainers {
+		injected[container.Name] = true
+	}
+
+	containers := mergeEnvVars(inj.Environment, inj.Containers)
+	containers = mergeVolumeMounts(inj.VolumeMounts, containers)
+
+	var ephemeralContainers []corev1.EphemeralContainer
+	for _, c := range containers {
+		if injected[c.Name] {
+			continue
+		}
+
+		for _, vm := range c.VolumeMounts {
+			if !volumes[vm.Name] {
+				return nil, fmt.Errorf("container %s mounts volume %s, which is not in the running pod", c.Name, vm.Name)
+			}
+		}
+
+		ephemeralContainers = append(ephemeralContainers, corev1.EphemeralContainer{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:                     c.Name,
+				Image:                    c.Image,
+				Command:                  c.Command,
+				Args:                     c.Args,
+				WorkingDir:               c.WorkingDir,
+				EnvFrom:                  c.EnvFrom,
+				Env:                      c.Env,
+				VolumeMounts:             c.VolumeMounts,
+				VolumeDevices:            c.VolumeDevices,
+				TerminationMessagePath:   c.TerminationMessagePath,
+				TerminationMessagePolicy: c.TerminationMessagePolicy,
+				ImagePullPolicy:          c.ImagePullPolicy,
+				SecurityContext:          c.SecurityContext,
+				Stdin:                    c.Stdin,
+				StdinOnce:                c.StdinOnce,
+				TTY:                      c.TTY,
+			},
+		})
+	}
+
+	return ephemeralContainers, nil
+}
+
+// createInjectionPatch returns the JSON patch which injects the config into the pod and marks it
+// as injected with the status annotation key, along with the name of the config for auditing.
+func createInjectionPatch(pod *corev1.Pod, inj *config.InjectionConfig, keys annotationKeys) ([]byte, error) {
+	annotations := map[string]string{
+		keys.status: StatusInjected,
+		keys.config: inj.Name,
+	}
+
+	return createPatch(pod, inj, annotations)
+}
+
 // create mutation patch for resource
 func createPatch(pod *corev1.Pod, inj *config.InjectionConfig, annotations map[string]string) ([]byte, error) {
 	var patch []patchOperation
@@ -307,12 +510,76 @@ func createPatch(pod *corev1.Pod, inj *config.InjectionConfig, annotations map[s
 
 	// TODO: remove injecting commands when sidecar container supported
 	// set commands and args
-	patch = append(patch, setCommands(pod.Spec.Containers, inj.PostStart)...)
+	patch = append(patch, setCommands(pod.Spec.Containers, inj.PostStart, inj.CommandPolicy)...)
+
+	// patch resources of the existing containers
+	patch = append(patch, setResources(pod.Spec.Containers, inj.ResourcePatches)...)
 
 	return json.Marshal(patch)
 }
 
-func setCommands(target []corev1.Container, postStart map[string]config.ExecAction) (patch []patchOperation) {
+func setResources(target []corev1.Container, resourcePatches map[string]config.ResourcePatch) (patch []patchOperation) {
+	if resourcePatches == nil {
+		return
+	}
+
+	for containerIndex, container := range target {
+		resourcePatch, ok := resourcePatches[container.Name]
+		if !ok {
+			continue
+		}
+
+		resources := *container.Resources.DeepCopy()
+		resources.Limits = mergeResourceList(resources.Limits, resourcePatch.Limits, resourcePatch.Force)
+		resources.Requests = mergeResourceList(resources.Requests, resourcePatch.Requests, resourcePatch.Force)
+		clampRequests(container.Name, &resources)
+
+		log.Info("Inject resources", "container", container.Name, "resources", resources)
+
+		path := fmt.Sprintf("/spec/containers/%d/resources", containerIndex)
+		patch = append(patch, patchOperation{
+			Op:    "replace",
+			Path:  path,
+			Value: resources,
+		})
+	}
+	return patch
+}
+
+// clampRequests lowers the requests which exceed the limits to the limits, as such a container is rejected
+// by the api server. The requests may exceed the limits when only the limits are patched.
+func clampRequests(containerName string, resources *corev1.ResourceRequirements) {
+	for name, limit := range resources.Limits {
+		request, ok := resources.Requests[name]
+		if !ok || request.Cmp(limit) <= 0 {
+			continue
+		}
+		log.Info("Lower the request to the limit", "container", containerName, "resource", name,
+			"request", request.String(), "limit", limit.String())
+		resources.Requests[name] = limit.DeepCopy()
+	}
+}
+
+// mergeResourceList adds the patched resources into the origin list,
+// the resources already set in the origin list are kept unless force is true
+func mergeResourceList(origin, patched corev1.ResourceList, force bool) corev1.ResourceList {
+	if len(patched) == 0 {
+		return origin
+	}
+	if origin == nil {
+		origin = corev1.ResourceList{}
+	}
+
+	for name, quantity := range patched {
+		if _, ok := origin[name]; ok && !force {
+			continue
+		}
+		origin[name] = quantity.DeepCopy()
+	}
+	return origin
+}
+
+func setCommands(target []corev1.Container, postStart map[string]config.ExecAction, policy config.CommandPolicy) (patch []patchOperation) {
 	if postStart == nil {
 		return
 	}
@@ -325,7 +592,7 @@ func setCommands(target []corev1.Container, postStart map[string]config.ExecActi
 
 		path := fmt.Sprintf("/spec/containers/%d/command", containerIndex)
 
-		commands := MergeCommands(execCmd.Command, container.Command, container.Args)
+		commands := MergeCommandsWithPolicy(policy, execCmd.Command, container.Command, container.Args)
 
 		log.Info("Inject command", "command", commands)
 
@@ -526,23 +793,31 @@ func mergeVolumeMounts(volumeMounts []corev1.VolumeMount, containers []corev1.Co
 }
 
 func updateAnnotations(target map[string]string, added map[string]string) (patch []patchOperation) {
-	for key, value := range added {
-		if target == nil || target[key] == "" {
-			target = map[string]string{}
-			patch = append(patch, patchOperation{
-				Op:   "add",
-				Path: "/metadata/annotations",
-				Value: map[string]string{
-					key: value,
-				},
-			})
-		} else {
-			patch = append(patch, patchOperation{
-				Op:    "replace",
-				Path:  "/metadata/annotations/" + key,
-				Value: value,
-			})
+	// the whole map is added at once, as the keys cannot be added one by one to a missing map
+	if len(target) == 0 {
+		return []patchOperation{{
+			Op:    "add",
+			Path:  "/metadata/annotations",
+			Value: added,
+		}}
+	}
+
+	keys := make([]string, 0, len(added))
+	for key := range added {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		op := "add"
+		if _, ok := target[key]; ok {
+			op = "replace"
 		}
+		patch = append(patch, patchOperation{
+			Op: op,
+			// the "/" in the annotation keys is escaped as "~1" in JSON pointer
+			Path:  "/metadata/annotations/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key),
+			Value: added[key],
+		})
 	}
 	return patch
 }
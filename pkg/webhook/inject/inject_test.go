@@ -14,19 +14,35 @@
 package inject
 
 import (
+	"context"
+	"encoding/json"
+	"testing"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	controllerCfg "github.com/chaos-mesh/chaos-mesh/pkg/config"
 	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// checkInjectRequired resolves the namespace of the pod like Inject does
+func checkInjectRequired(metadata *metav1.ObjectMeta, cli client.Client, cfg *config.Config, controllerCfg *controllerCfg.ChaosControllerConfig) (string, bool) {
+	ns := getNamespace(metadata.Namespace, cli)
+	required, ok, err := injectRequired(metadata, ns, namespaceAnnotationKeys(ns, cfg), cli, cfg, controllerCfg)
+	Expect(err).ToNot(HaveOccurred())
+	return required, ok
+}
+
 var _ = Describe("webhook inject", func() {
 
 	Context("Inject", func() {
@@ -37,6 +53,73 @@ var _ = Describe("webhook inject", func() {
 			res := Inject(&admissionv1beta1.AdmissionRequest{}, testClient, cfg, controllerCfg, nil)
 			Expect(res.Result.Message).To(ContainSubstring("unexpected end of JSON input"))
 		})
+
+		It("should allow the pod despite the error in the namespace ignoring the failures", func() {
+			cfg := config.NewConfigWatcherConf()
+			testClient := fake.NewFakeClientWithScheme(scheme.Scheme,
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:        "critical",
+					Annotations: map[string]string{cfg.FailurePolicyAnnotationKey(): "Ignore"},
+				}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			)
+
+			res := Inject(&admissionv1beta1.AdmissionRequest{Namespace: "critical"}, testClient, cfg, &controllerCfg.ChaosControllerConfig{}, nil)
+			Expect(res.Allowed).To(BeTrue())
+			Expect(res.Result).To(BeNil())
+
+			res = Inject(&admissionv1beta1.AdmissionRequest{Namespace: "app"}, testClient, cfg, &controllerCfg.ChaosControllerConfig{}, nil)
+			Expect(res.Allowed).To(BeFalse())
+			Expect(res.Result.Message).To(ContainSubstring("unexpected end of JSON input"))
+		})
+
+		It("should apply the failure policy to every error of the injection", func() {
+			cfg := config.NewConfigWatcherConf()
+			testClient := fake.NewFakeClientWithScheme(scheme.Scheme,
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:        "critical",
+					Annotations: map[string]string{cfg.FailurePolicyAnnotationKey(): "Ignore"},
+				}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			)
+			inject := func(namespace string, controllerConfig *controllerCfg.ChaosControllerConfig) *admissionv1beta1.AdmissionResponse {
+				raw, err := json.Marshal(corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   namespace,
+						Name:        "p0",
+						Annotations: map[string]string{cfg.RequestAnnotationKey(): "not-exist"},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				return Inject(&admissionv1beta1.AdmissionRequest{
+					Namespace: namespace,
+					Object:    runtime.RawExtension{Raw: raw},
+				}, testClient, cfg, controllerConfig, nil)
+			}
+
+			// the requested config is not loaded
+			res := inject("critical", &controllerCfg.ChaosControllerConfig{})
+			Expect(res.Allowed).To(BeTrue())
+			res = inject("app", &controllerCfg.ChaosControllerConfig{})
+			Expect(res.Allowed).To(BeFalse())
+			Expect(res.Result.Message).To(ContainSubstring("no injection config"))
+
+			// the failure policy is case-sensitive
+			testClient = fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "critical",
+				Annotations: map[string]string{cfg.FailurePolicyAnnotationKey(): "ignore"},
+			}})
+			res = inject("critical", &controllerCfg.ChaosControllerConfig{})
+			Expect(res.Allowed).To(BeFalse())
+
+			// the namespace can't be fetched, so its failure policy is unknown and the pod fails open
+			res = inject("missing", &controllerCfg.ChaosControllerConfig{})
+			Expect(res.Allowed).To(BeTrue())
+
+			// the pods in the special namespaces are permitted before the namespace is fetched
+			res = inject("kube-system", &controllerCfg.ChaosControllerConfig{})
+			Expect(res.Allowed).To(BeTrue())
+		})
 	})
 
 	Context("checkInjectStatus", func() {
@@ -44,7 +127,7 @@ var _ = Describe("webhook inject", func() {
 			var metadata metav1.ObjectMeta
 			metadata.Annotations = make(map[string]string)
 			var cfg config.Config
-			res := checkInjectStatus(&metadata, &cfg)
+			res := checkInjectStatus(&metadata, defaultAnnotationKeys(&cfg))
 			Expect(res).To(Equal(false))
 		})
 
@@ -54,7 +137,7 @@ var _ = Describe("webhook inject", func() {
 			metadata.Annotations["testNamespace/status"] = StatusInjected
 			var cfg config.Config
 			cfg.AnnotationNamespace = "testNamespace"
-			res := checkInjectStatus(&metadata, &cfg)
+			res := checkInjectStatus(&metadata, defaultAnnotationKeys(&cfg))
 			Expect(res).To(Equal(true))
 		})
 	})
@@ -64,7 +147,7 @@ var _ = Describe("webhook inject", func() {
 			var metadata metav1.ObjectMeta
 			metadata.Annotations = make(map[string]string)
 			var cfg config.Config
-			str, flag := injectByPodRequired(&metadata, &cfg)
+			str, flag := injectByPodRequired(&metadata, defaultAnnotationKeys(&cfg))
 			Expect(str).To(Equal(""))
 			Expect(flag).To(Equal(false))
 		})
@@ -75,23 +158,17 @@ var _ = Describe("webhook inject", func() {
 			metadata.Annotations["testNamespace/request"] = "test"
 			var cfg config.Config
 			cfg.AnnotationNamespace = "testNamespace"
-			str, flag := injectByPodRequired(&metadata, &cfg)
+			str, flag := injectByPodRequired(&metadata, defaultAnnotationKeys(&cfg))
 			Expect(str).To(Equal("test"))
 			Expect(flag).To(Equal(true))
 		})
 	})
 
 	Context("injectRequired", func() {
-		It("should return ignore", func() {
-			var metadata metav1.ObjectMeta
-			metadata.Annotations = make(map[string]string)
-			metadata.Namespace = "kube-system"
-			var cli client.Client
-			var cfg config.Config
-			var controllerCfg controllerCfg.ChaosControllerConfig
-			str, flag := injectRequired(&metadata, cli, &cfg, &controllerCfg)
-			Expect(str).To(Equal(""))
-			Expect(flag).To(Equal(false))
+		It("should ignore the special namespaces", func() {
+			Expect(isIgnoredNamespace("kube-system")).To(BeTrue())
+			Expect(isIgnoredNamespace("kube-public")).To(BeTrue())
+			Expect(isIgnoredNamespace("default")).To(BeFalse())
 		})
 
 		It("should return ignore", func() {
@@ -102,7 +179,7 @@ var _ = Describe("webhook inject", func() {
 			var controllerCfg controllerCfg.ChaosControllerConfig
 			cfg.AnnotationNamespace = "testNamespace"
 			var cli client.Client
-			str, flag := injectRequired(&metadata, cli, &cfg, &controllerCfg)
+			str, flag := checkInjectRequired(&metadata, cli, &cfg, &controllerCfg)
 			Expect(str).To(Equal(""))
 			Expect(flag).To(Equal(false))
 		})
@@ -115,7 +192,7 @@ var _ = Describe("webhook inject", func() {
 			var controllerCfg controllerCfg.ChaosControllerConfig
 			cfg.AnnotationNamespace = "testNamespace"
 			var cli client.Client
-			str, flag := injectRequired(&metadata, cli, &cfg, &controllerCfg)
+			str, flag := checkInjectRequired(&metadata, cli, &cfg, &controllerCfg)
 			Expect(str).To(Equal(""))
 			Expect(flag).To(Equal(false))
 		})
@@ -128,7 +205,7 @@ var _ = Describe("webhook inject", func() {
 			var cfg config.Config
 			var controllerCfg controllerCfg.ChaosControllerConfig
 			cfg.AnnotationNamespace = "testNamespace"
-			str, flag := injectRequired(&metadata, k8sClient, &cfg, &controllerCfg)
+			str, flag := checkInjectRequired(&metadata, k8sClient, &cfg, &controllerCfg)
 			Expect(str).To(Equal("test"))
 			Expect(flag).To(Equal(true))
 		})
@@ -138,9 +215,35 @@ var _ = Describe("webhook inject", func() {
 			metadata.Annotations = make(map[string]string)
 			var cfg config.Config
 			var controllerCfg controllerCfg.ChaosControllerConfig
-			_, flag := injectRequired(&metadata, k8sClient, &cfg, &controllerCfg)
+			_, flag := checkInjectRequired(&metadata, k8sClient, &cfg, &controllerCfg)
 			Expect(flag).To(Equal(false))
 		})
+
+		It("should use the request annotation key overridden by namespace", func() {
+			var cfg config.Config
+			var controllerCfg controllerCfg.ChaosControllerConfig
+			cfg.AnnotationNamespace = "testNamespace"
+			ns := corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "override",
+					Annotations: map[string]string{
+						cfg.RequestAnnotationKeyOverrideKey(): "example.com/chaos-request",
+					},
+				},
+			}
+			cli := fake.NewFakeClientWithScheme(scheme.Scheme, &ns)
+
+			var metadata metav1.ObjectMeta
+			metadata.Namespace = "override"
+			metadata.Annotations = map[string]string{"testNamespace/request": "test"}
+			_, flag := checkInjectRequired(&metadata, cli, &cfg, &controllerCfg)
+			Expect(flag).To(Equal(false))
+
+			metadata.Annotations = map[string]string{"example.com/chaos-request": "test"}
+			str, flag := checkInjectRequired(&metadata, cli, &cfg, &controllerCfg)
+			Expect(str).To(Equal("test"))
+			Expect(flag).To(Equal(true))
+		})
 	})
 
 	Context("injectByNamespaceRequired", func() {
@@ -149,7 +252,7 @@ var _ = Describe("webhook inject", func() {
 			metadata.Annotations = make(map[string]string)
 			metadata.Namespace = "testNamespace"
 			var cfg config.Config
-			str, flag := injectByNamespaceRequired(&metadata, k8sClient, &cfg)
+			str, flag := injectByNamespaceRequired(&metadata, getNamespace(metadata.Namespace, k8sClient), defaultAnnotationKeys(&cfg))
 			Expect(str).To(Equal(""))
 			Expect(flag).To(Equal(false))
 		})
@@ -165,6 +268,52 @@ var _ = Describe("webhook inject", func() {
 		})
 	})
 
+	Context("EphemeralContainers", func() {
+		It("should drop the ports and skip the injected containers", func() {
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{Name: "datadir"}},
+					EphemeralContainers: []corev1.EphemeralContainer{
+						{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "injected"}},
+					},
+				},
+			}
+			inj := config.InjectionConfig{
+				Containers: []corev1.Container{
+					{Name: "injected"},
+					{Name: "chaosfs", Ports: []corev1.ContainerPort{{ContainerPort: 65534}}},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "datadir", MountPath: "/var/run/data"},
+				},
+			}
+			containers, err := EphemeralContainers(&pod, &inj)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Name).To(Equal("chaosfs"))
+			Expect(containers[0].Ports).To(BeEmpty())
+			Expect(containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{{Name: "datadir", MountPath: "/var/run/data"}}))
+		})
+
+		It("should reject the config which cannot be injected into the running pod", func() {
+			pod := corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{Name: "datadir"}},
+				},
+			}
+			containers := []corev1.Container{{Name: "chaosfs"}}
+			for _, inj := range []config.InjectionConfig{
+				{Containers: containers, Volumes: []corev1.Volume{{Name: "fuse"}}},
+				{Containers: containers, InitContainers: []corev1.Container{{Name: "init"}}},
+				{Containers: containers, PostStart: map[string]config.ExecAction{"app": {Command: []string{"wait"}}}},
+				{Containers: containers, VolumeMounts: []corev1.VolumeMount{{Name: "fuse", MountPath: "/dev/fuse"}}},
+			} {
+				_, err := EphemeralContainers(&pod, &inj)
+				Expect(err).To(HaveOccurred())
+			}
+		})
+	})
+
 	Context("setCommands", func() {
 		It("should return", func() {
 			var target []corev1.Container = []corev1.Container{
@@ -172,7 +321,7 @@ var _ = Describe("webhook inject", func() {
 					Name: "testContainerName",
 				}}
 			postStart := make(map[string]config.ExecAction)
-			patch := setCommands(target, postStart)
+			patch := setCommands(target, postStart, config.PrependCommandPolicy)
 			Expect(patch).To(BeNil())
 		})
 
@@ -186,11 +335,88 @@ var _ = Describe("webhook inject", func() {
 				Command: []string{"nil"},
 			}
 			postStart["testContainerName"] = ce
-			patch := setCommands(target, postStart)
+			patch := setCommands(target, postStart, config.PrependCommandPolicy)
 			Expect(patch).ToNot(BeNil())
 		})
 	})
 
+	Context("setResources", func() {
+		It("should return nil", func() {
+			target := []corev1.Container{{Name: "testContainerName"}}
+			patch := setResources(target, map[string]config.ResourcePatch{
+				"anotherContainerName": {Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}},
+			})
+			Expect(patch).To(BeNil())
+		})
+
+		It("should keep the resources set by user", func() {
+			target := []corev1.Container{{
+				Name: "testContainerName",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			}}
+			patch := setResources(target, map[string]config.ResourcePatch{
+				"testContainerName": {
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+			})
+			Expect(patch).To(HaveLen(1))
+			Expect(patch[0].Op).To(Equal("replace"))
+			Expect(patch[0].Path).To(Equal("/spec/containers/0/resources"))
+			resources := patch[0].Value.(corev1.ResourceRequirements)
+			Expect(resources.Limits.Cpu().String()).To(Equal("1"))
+			Expect(resources.Limits.Memory().String()).To(Equal("128Mi"))
+			Expect(target[0].Resources.Limits).To(HaveLen(1))
+		})
+
+		It("should override the resources set by user if forced", func() {
+			target := []corev1.Container{{
+				Name: "testContainerName",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			}}
+			patch := setResources(target, map[string]config.ResourcePatch{
+				"testContainerName": {
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					Force:  true,
+				},
+			})
+			Expect(patch).To(HaveLen(1))
+			resources := patch[0].Value.(corev1.ResourceRequirements)
+			Expect(resources.Limits.Cpu().String()).To(Equal("100m"))
+		})
+
+		It("should lower the requests exceeding the patched limits", func() {
+			target := []corev1.Container{{
+				Name: "testContainerName",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				},
+			}}
+			patch := setResources(target, map[string]config.ResourcePatch{
+				"testContainerName": {
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+			})
+			Expect(patch).To(HaveLen(1))
+			resources := patch[0].Value.(corev1.ResourceRequirements)
+			Expect(resources.Requests.Cpu().String()).To(Equal("100m"))
+			Expect(resources.Requests.Memory().String()).To(Equal("64Mi"))
+			Expect(target[0].Resources.Requests.Cpu().String()).To(Equal("500m"))
+		})
+	})
+
 	Context("setEnvironment", func() {
 		It("should return not nil", func() {
 			var target []corev1.Container = []corev1.Container{
@@ -427,6 +653,40 @@ var _ = Describe("webhook inject", func() {
 			patch := updateAnnotations(target, added)
 			Expect(patch).ToNot(BeNil())
 		})
+
+		It("should keep the existing annotations", func() {
+			target := map[string]string{"admission-webhook.chaos-mesh.org/request": "chaosfs-sidecar"}
+			added := map[string]string{
+				"admission-webhook.chaos-mesh.org/status":          StatusInjected,
+				"admission-webhook.chaos-mesh.org/injected-config": "chaosfs-sidecar",
+			}
+			patch := updateAnnotations(target, added)
+			Expect(patch).To(Equal([]patchOperation{
+				{Op: "add", Path: "/metadata/annotations/admission-webhook.chaos-mesh.org~1injected-config", Value: "chaosfs-sidecar"},
+				{Op: "add", Path: "/metadata/annotations/admission-webhook.chaos-mesh.org~1status", Value: StatusInjected},
+			}))
+		})
+	})
+
+	Context("createInjectionPatch", func() {
+		It("should record the name of the injected config", func() {
+			cfg := config.NewConfigWatcherConf()
+			pod := corev1.Pod{}
+			inj := config.InjectionConfig{Name: "chaosfs-sidecar"}
+			patchBytes, err := createInjectionPatch(&pod, &inj, defaultAnnotationKeys(cfg))
+			Expect(err).To(BeNil())
+
+			var patch []patchOperation
+			Expect(json.Unmarshal(patchBytes, &patch)).To(Succeed())
+			Expect(patch).To(ContainElement(patchOperation{
+				Op:   "add",
+				Path: "/metadata/annotations",
+				Value: map[string]interface{}{
+					cfg.StatusAnnotationKey():         StatusInjected,
+					cfg.InjectedConfigAnnotationKey(): "chaosfs-sidecar",
+				},
+			}))
+		})
 	})
 
 	Context("potentialPodName", func() {
@@ -453,3 +713,74 @@ var _ = Describe("webhook inject", func() {
 		})
 	})
 })
+
+// namespaceGetCounter counts the namespaces fetched through the client
+type namespaceGetCounter struct {
+	client.Client
+	count int
+}
+
+func (c *namespaceGetCounter) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if _, ok := obj.(*corev1.Namespace); ok {
+		c.count++
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func TestNamespaceAnnotationKeys(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cfg := config.NewConfigWatcherConf()
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app",
+			Annotations: map[string]string{
+				cfg.RequestAnnotationKeyOverrideKey(): "example.com/chaos-request",
+				cfg.StatusAnnotationKeyOverrideKey():  "not a valid/annotation/key",
+			},
+		},
+	}
+
+	keys := namespaceAnnotationKeys(ns, cfg)
+	g.Expect(keys.request).To(Equal("example.com/chaos-request"))
+	g.Expect(keys.status).To(Equal(cfg.StatusAnnotationKey()))
+
+	g.Expect(namespaceAnnotationKeys(nil, cfg)).To(Equal(defaultAnnotationKeys(cfg)))
+}
+
+func TestInjectFetchesNamespaceOnce(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cfg := config.NewConfigWatcherConf()
+	cfg.ReplaceInjectionConfigs(map[string][]*config.InjectionConfig{
+		"app": {{Name: "test-sidecar"}},
+	})
+	cli := &namespaceGetCounter{
+		Client: fake.NewFakeClientWithScheme(scheme.Scheme, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "app",
+				Annotations: map[string]string{
+					cfg.RequestAnnotationKeyOverrideKey(): "example.com/chaos-request",
+					cfg.StatusAnnotationKeyOverrideKey():  "example.com/chaos-status",
+				},
+			},
+		}),
+	}
+
+	raw, err := json.Marshal(corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "app",
+			Name:        "p0",
+			Annotations: map[string]string{"example.com/chaos-request": "test-sidecar"},
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	res := Inject(&admissionv1beta1.AdmissionRequest{
+		Namespace: "app",
+		Object:    runtime.RawExtension{Raw: raw},
+	}, cli, cfg, &controllerCfg.ChaosControllerConfig{}, nil)
+	g.Expect(res.Allowed).To(BeTrue())
+	g.Expect(string(res.Patch)).To(ContainSubstring(`{"op":"add","path":"/metadata/annotations/example.com~1chaos-status","value":"injected"}`))
+	g.Expect(cli.count).To(Equal(1))
+}
@@ -20,16 +20,24 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/propagation"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 )
 
+// errChaosCRCreateFailed means the chaos custom resource could not be created, the failure has been
+// recorded as an event, and it would be reflected in the conditions of the chaos node.
+var errChaosCRCreateFailed = errors.New("failed to create chaos custom resource")
+
 type ChaosNodeReconciler struct {
 	kubeClient    client.Client
 	eventRecorder recorder.ChaosRecorder
@@ -65,15 +73,19 @@ func (it *ChaosNodeReconciler) Reconcile(request reconcile.Request) (reconcile.R
 	it.logger.V(4).Info("resolve chaos node", "node", request)
 
 	if node.Spec.Type == v1alpha1.TypeSchedule {
-		err := it.syncSchedule(ctx, node)
-		if err != nil {
-			return reconcile.Result{}, err
-		}
+		err = it.syncSchedule(ctx, node)
 	} else {
 		err = it.syncChaosResources(ctx, node)
-		if err != nil {
-			return reconcile.Result{}, err
-		}
+	}
+	if err != nil && err != errChaosCRCreateFailed {
+		return reconcile.Result{}, err
+	}
+
+	// the reason of the condition when there is no chaos custom resource
+	notExistsReason := v1alpha1.ChaosCRNotExists
+	// the finished node keeps the failure of creating, so it could be resumed from later
+	if err == errChaosCRCreateFailed || WorkflowNodeFailed(node.Status) {
+		notExistsReason = v1alpha1.ChaosCRCreateFailed
 	}
 
 	updateError := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -114,7 +126,7 @@ func (it *ChaosNodeReconciler) Reconcile(request reconcile.Request) (reconcile.R
 				SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
 					Type:   v1alpha1.ConditionChaosInjected,
 					Status: corev1.ConditionFalse,
-					Reason: v1alpha1.ChaosCRNotExists,
+					Reason: notExistsReason,
 				})
 			}
 
@@ -152,7 +164,7 @@ func (it *ChaosNodeReconciler) Reconcile(request reconcile.Request) (reconcile.R
 			SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
 				Type:   v1alpha1.ConditionChaosInjected,
 				Status: corev1.ConditionFalse,
-				Reason: v1alpha1.ChaosCRNotExists,
+				Reason: notExistsReason,
 			})
 		}
 
@@ -167,7 +179,7 @@ func (it *ChaosNodeReconciler) syncSchedule(ctx context.Context, node v1alpha1.W
 	if err != nil {
 		return err
 	}
-	if WorkflowNodeFinished(node.Status) {
+	if WorkflowNodeFinished(node.Status) || node.DeletionTimestamp != nil {
 		// make the number of schedule to 0
 		for _, item := range scheduleList {
 			item := item
@@ -232,7 +244,8 @@ func (it *ChaosNodeReconciler) syncChaosResources(ctx context.Context, node v1al
 		return err
 	}
 
-	if WorkflowNodeFinished(node.Status) {
+	// the deleting node is kept until its chaos is deleted, the chaos should never be respawned
+	if WorkflowNodeFinished(node.Status) || node.DeletionTimestamp != nil {
 		// make the number of chaos resource to 0
 		for _, item := range chaosList {
 			// best efforts deletion
@@ -301,12 +314,18 @@ func (it *ChaosNodeReconciler) createChaos(ctx context.Context, node v1alpha1.Wo
 	if err != nil {
 		return err
 	}
+	if node.Spec.TargetPod != nil {
+		err := narrowToTargetPod(chaosObject, *node.Spec.TargetPod)
+		if err != nil {
+			return err
+		}
+	}
 
 	meta.SetGenerateName(fmt.Sprintf("%s-", node.Name))
 	meta.SetNamespace(node.Namespace)
 	meta.SetOwnerReferences(append(meta.GetOwnerReferences(), metav1.OwnerReference{
-		APIVersion:         node.APIVersion,
-		Kind:               node.Kind,
+		APIVersion:         ApiVersion,
+		Kind:               KindWorkflowNode,
 		Name:               node.Name,
 		UID:                node.UID,
 		Controller:         &isController,
@@ -316,12 +335,13 @@ func (it *ChaosNodeReconciler) createChaos(ctx context.Context, node v1alpha1.Wo
 		v1alpha1.LabelControlledBy: node.Name,
 		v1alpha1.LabelWorkflow:     node.Spec.WorkflowName,
 	})
+	propagation.Propagate(&node, meta)
 
 	err = it.kubeClient.Create(ctx, chaosObject)
 	if err != nil {
 		it.eventRecorder.Event(&node, recorder.ChaosCustomResourceCreateFailed{})
 		it.logger.Error(err, "failed to create chaos")
-		return nil
+		return errChaosCRCreateFailed
 	}
 	it.logger.Info("chaos object created", "namespace", meta.GetNamespace(), "name", meta.GetName())
 	it.eventRecorder.Event(&node, recorder.ChaosCustomResourceCreated{
@@ -374,8 +394,8 @@ func (it ChaosNodeReconciler) createSchedule(ctx context.Context, node v1alpha1.
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
-					APIVersion:         node.APIVersion,
-					Kind:               node.Kind,
+					APIVersion:         ApiVersion,
+					Kind:               KindWorkflowNode,
 					Name:               node.Name,
 					UID:                node.UID,
 					Controller:         &isController,
@@ -385,11 +405,12 @@ func (it ChaosNodeReconciler) createSchedule(ctx context.Context, node v1alpha1.
 		},
 		Spec: *node.Spec.Schedule,
 	}
+	propagation.Propagate(&node, &scheduleToCreate)
 	err := it.kubeClient.Create(ctx, &scheduleToCreate)
 	if err != nil {
 		it.eventRecorder.Event(&node, recorder.ChaosCustomResourceCreateFailed{})
 		it.logger.Error(err, "failed to create schedule CR")
-		return nil
+		return errChaosCRCreateFailed
 	}
 	it.logger.Info("schedule CR created", "namespace", scheduleToCreate.GetNamespace(), "name", scheduleToCreate.GetName())
 	it.eventRecorder.Event(&node, recorder.ChaosCustomResourceCreated{
@@ -450,3 +471,32 @@ func (it SortScheduleByCreationTimestamp) Less(i, j int) bool {
 func (it SortScheduleByCreationTimestamp) Swap(i, j int) {
 	it[i], it[j] = it[j], it[i]
 }
+
+// narrowToTargetPod makes the chaos only take effect on the given pod, by replacing the selector of the pods which
+// the chaos is injected into. The other selectors, like the target of NetworkChaos, are kept.
+func narrowToTargetPod(chaos runtime.Object, targetPod string) error {
+	selectorSpecs, ok := chaos.(interface {
+		GetSelectorSpecs() map[string]interface{}
+	})
+	if !ok {
+		return errors.Errorf("chaos %T does not select pods", chaos)
+	}
+
+	key := controller.ParseNamespacedName(targetPod)
+	selector := v1alpha1.PodSelectorSpec{
+		Pods: map[string][]string{key.Namespace: {key.Name}},
+	}
+	switch spec := selectorSpecs.GetSelectorSpecs()["."].(type) {
+	case *v1alpha1.PodSelector:
+		spec.Selector = selector
+		spec.Mode = v1alpha1.AllPodMode
+		spec.Value = ""
+	case *v1alpha1.ContainerSelector:
+		spec.Selector = selector
+		spec.Mode = v1alpha1.AllPodMode
+		spec.Value = ""
+	default:
+		return errors.Errorf("chaos %T does not select pods", chaos)
+	}
+	return nil
+}
@@ -56,6 +56,10 @@ func (it *DeadlineReconciler) Reconcile(request reconcile.Request) (reconcile.Re
 	}
 
 	if node.Spec.Deadline == nil {
+		if ConditionEqualsTo(node.Status, v1alpha1.ConditionDeadlineExceed, corev1.ConditionTrue) {
+			// the deadline is propagated from the parent node, keep propagating to the children
+			return reconcile.Result{}, it.propagateDeadlineToChildren(ctx, &node)
+		}
 		return reconcile.Result{}, nil
 	}
 
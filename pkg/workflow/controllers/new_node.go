@@ -17,9 +17,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/propagation"
 )
 
 var (
@@ -41,16 +43,10 @@ func renderNodesByTemplates(workflow *v1alpha1.Workflow, parent *v1alpha1.Workfl
 		if template, ok := templateNameSet[name]; ok {
 
 			now := metav1.NewTime(time.Now())
-			var deadline *metav1.Time = nil
-
-			if template.Deadline != nil {
-				duration, err := time.ParseDuration(*template.Deadline)
-				if err != nil {
-					// TODO: logger
-					return nil, err
-				}
-				copiedDuration := metav1.NewTime(now.DeepCopy().Add(duration))
-				deadline = &copiedDuration
+			deadline, err := renderDeadline(template, now.Time)
+			if err != nil {
+				// TODO: logger
+				return nil, err
 			}
 
 			renderedNode := v1alpha1.WorkflowNode{
@@ -65,6 +61,8 @@ func renderNodesByTemplates(workflow *v1alpha1.Workflow, parent *v1alpha1.Workfl
 					StartTime:           &now,
 					Deadline:            deadline,
 					Children:            template.Children,
+					ForEach:             template.ForEach,
+					FailFast:            template.FailFast,
 					Task:                template.Task,
 					ConditionalBranches: template.ConditionalBranches,
 					EmbedChaos:          template.EmbedChaos,
@@ -102,6 +100,7 @@ func renderNodesByTemplates(workflow *v1alpha1.Workflow, parent *v1alpha1.Workfl
 			}
 
 			renderedNode.Labels[v1alpha1.LabelWorkflow] = workflow.Name
+			propagation.Propagate(workflow, &renderedNode)
 			renderedNode.Finalizers = append(renderedNode.Finalizers, metav1.FinalizerDeleteDependents)
 
 			result = append(result, &renderedNode)
@@ -116,12 +115,51 @@ func renderNodesByTemplates(workflow *v1alpha1.Workflow, parent *v1alpha1.Workfl
 	return result, nil
 }
 
+// renderWorkflowDeadline returns the deadline of the whole workflow which started at the given time, it returns nil
+// if the workflow has no deadline.
+func renderWorkflowDeadline(workflow v1alpha1.Workflow, startTime time.Time) (*metav1.Time, error) {
+	if workflow.Spec.Deadline == nil {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(*workflow.Spec.Deadline)
+	if err != nil {
+		return nil, err
+	}
+	deadline := metav1.NewTime(startTime.Add(duration))
+	return &deadline, nil
+}
+
+// renderDeadline returns the deadline of the node rendered from the template at the given time. The suspend
+// node with cron holds until the next occurrence of the cron expression.
+func renderDeadline(template v1alpha1.Template, now time.Time) (*metav1.Time, error) {
+	if template.Deadline != nil {
+		duration, err := time.ParseDuration(*template.Deadline)
+		if err != nil {
+			return nil, err
+		}
+		deadline := metav1.NewTime(now.Add(duration))
+		return &deadline, nil
+	}
+
+	if template.Type == v1alpha1.TypeSuspend && template.Cron != nil {
+		schedule, err := cron.ParseStandard(*template.Cron)
+		if err != nil {
+			return nil, err
+		}
+		deadline := metav1.NewTime(schedule.Next(now))
+		return &deadline, nil
+	}
+
+	return nil, nil
+}
+
 func conversionSchedule(origin *v1alpha1.ChaosOnlyScheduleSpec) *v1alpha1.ScheduleSpec {
 	if origin == nil {
 		return nil
 	}
 	return &v1alpha1.ScheduleSpec{
 		Schedule:                origin.Schedule,
+		Schedules:               origin.Schedules,
 		StartingDeadlineSeconds: origin.StartingDeadlineSeconds,
 		ConcurrencyPolicy:       origin.ConcurrencyPolicy,
 		HistoryLimit:            origin.HistoryLimit,
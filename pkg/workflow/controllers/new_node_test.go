@@ -0,0 +1,122 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func Test_conversionSchedule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(conversionSchedule(nil)).To(BeNil())
+
+	deadline := int64(10)
+	schedule := conversionSchedule(&v1alpha1.ChaosOnlyScheduleSpec{
+		Schedule:                "@every 1m",
+		Schedules:               []string{"0 12 * * *"},
+		StartingDeadlineSeconds: &deadline,
+		ConcurrencyPolicy:       v1alpha1.AllowConcurrent,
+		HistoryLimit:            2,
+		Type:                    v1alpha1.ScheduleTypePodChaos,
+		EmbedChaos: v1alpha1.EmbedChaos{
+			PodChaos: &v1alpha1.PodChaosSpec{Action: v1alpha1.PodKillAction},
+		},
+	})
+	g.Expect(schedule.CronExpressions()).To(Equal([]string{"@every 1m", "0 12 * * *"}))
+	g.Expect(schedule.StartingDeadlineSeconds).To(Equal(&deadline))
+	g.Expect(schedule.ConcurrencyPolicy).To(Equal(v1alpha1.AllowConcurrent))
+	g.Expect(schedule.HistoryLimit).To(Equal(2))
+	g.Expect(schedule.Type).To(Equal(v1alpha1.ScheduleTypePodChaos))
+	g.Expect(schedule.PodChaos.Action).To(Equal(v1alpha1.PodKillAction))
+}
+
+func Test_renderDeadline(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Date(2021, 6, 1, 10, 30, 0, 0, time.Local)
+	deadline := "1h"
+	wakeAtNine := "0 9 * * *"
+	invalid := "not a cron"
+
+	rendered, err := renderDeadline(v1alpha1.Template{Type: v1alpha1.TypeSuspend, Deadline: &deadline}, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rendered.Time).To(Equal(now.Add(time.Hour)))
+
+	rendered, err = renderDeadline(v1alpha1.Template{Type: v1alpha1.TypeSuspend, Cron: &wakeAtNine}, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rendered.Time).To(Equal(time.Date(2021, 6, 2, 9, 0, 0, 0, time.Local)))
+
+	_, err = renderDeadline(v1alpha1.Template{Type: v1alpha1.TypeSuspend, Cron: &invalid}, now)
+	g.Expect(err).To(HaveOccurred())
+
+	rendered, err = renderDeadline(v1alpha1.Template{Type: v1alpha1.TypeSerial}, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rendered).To(BeNil())
+}
+
+func TestSuspendNodeHoldsUntilCron(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	wakeAtNine := "0 9 * * *"
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "workflow"},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "wake-at-nine",
+			Templates: []v1alpha1.Template{
+				{Name: "wake-at-nine", Type: v1alpha1.TypeSuspend, Cron: &wakeAtNine},
+			},
+		},
+	}
+	nodes, err := renderNodesByTemplates(workflow, nil, "wake-at-nine")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(nodes).To(HaveLen(1))
+
+	node := nodes[0]
+	schedule, err := cron.ParseStandard(wakeAtNine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(node.Spec.Deadline.Time).To(Equal(schedule.Next(node.Spec.StartTime.Time)))
+
+	node.Name = "wake-at-nine-0"
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), node)
+	reconciler := NewDeadlineReconciler(kubeClient, recorder.NewDebugRecorder(), zap.New(zap.UseDevMode(true)))
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: node.Namespace, Name: node.Name}}
+
+	// the node holds until the next occurrence of the cron expression
+	result, err := reconciler.Reconcile(request)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+	g.Expect(result.RequeueAfter).To(BeNumerically("<=", time.Until(node.Spec.Deadline.Time)+time.Second))
+
+	updatedNode := v1alpha1.WorkflowNode{}
+	g.Expect(kubeClient.Get(ctx, request.NamespacedName, &updatedNode)).To(Succeed())
+	g.Expect(ConditionEqualsTo(updatedNode.Status, v1alpha1.ConditionDeadlineExceed, corev1.ConditionFalse)).To(BeTrue())
+	g.Expect(WorkflowNodeFinished(updatedNode.Status)).To(BeFalse())
+}
@@ -26,7 +26,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	ccfg "github.com/chaos-mesh/chaos-mesh/controllers/config"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+)
+
+const (
+	// outdatedChildNodesCheckInterval is the interval to check whether the outdated children nodes have been cleaned up
+	outdatedChildNodesCheckInterval = 5 * time.Second
+	// forEachReselectInterval is the interval to select the pods again for the parallel node with ForEach
+	forEachReselectInterval = 30 * time.Second
+	// forEachSelectTimeout is how long the parallel node with ForEach waits for the first pod to be selected
+	forEachSelectTimeout = 5 * time.Minute
 )
 
 // ParallelNodeReconciler watches on nodes which type is Parallel
@@ -72,12 +83,20 @@ func (it *ParallelNodeReconciler) Reconcile(request reconcile.Request) (reconcil
 	it.logger.V(4).Info("resolve parallel node", "node", request)
 
 	// make effects, create/remove children nodes
-	err = it.syncChildNodes(ctx, node)
+	var waitingForCleanup bool
+	var selectedPods []string
+	if node.Spec.ForEach != nil {
+		selectedPods, err = it.syncChildNodesForEachPod(ctx, node)
+	} else {
+		waitingForCleanup, err = it.syncChildNodes(ctx, node)
+	}
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	// update status
+	reselect := false
+	failedFast := false
 	updateError := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		nodeNeedUpdate := v1alpha1.WorkflowNode{}
 		err := it.kubeClient.Get(ctx, request.NamespacedName, &nodeNeedUpdate)
@@ -106,8 +125,66 @@ func (it *ParallelNodeReconciler) Reconcile(request reconcile.Request) (reconcil
 				})
 		}
 
+		var failedChildren []string
+		nodeNeedUpdate.Status.FailedChildren = nil
+		for _, child := range append(activeChildren, finishedChildren...) {
+			if childFailed(child) {
+				failedChildren = append(failedChildren, child.Name)
+				nodeNeedUpdate.Status.FailedChildren = append(nodeNeedUpdate.Status.FailedChildren,
+					corev1.LocalObjectReference{
+						Name: child.Name,
+					})
+			}
+		}
+
 		// TODO: also check the consistent between spec in task and the spec in child node
-		if len(finishedChildren) == len(nodeNeedUpdate.Spec.Children) {
+		accomplished := len(finishedChildren) == len(nodeNeedUpdate.Spec.Children)
+		if nodeNeedUpdate.Spec.ForEach != nil && len(activeChildren)+len(finishedChildren) == 0 {
+			// the node which never spawns any child in time is failed, rather than being accomplished without
+			// doing anything
+			if WorkflowNodeFailing(nodeNeedUpdate.Status) {
+				return it.kubeClient.Status().Update(ctx, &nodeNeedUpdate)
+			}
+			if len(selectedPods) == 0 && !WorkflowNodeFinished(nodeNeedUpdate.Status) &&
+				time.Since(nodeNeedUpdate.CreationTimestamp.Time) < forEachSelectTimeout {
+				// the pods may be not created or labeled yet, so they are selected again later
+				SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
+					Type:   v1alpha1.ConditionAccomplished,
+					Status: corev1.ConditionFalse,
+					Reason: "",
+				})
+				reselect = true
+				return it.kubeClient.Status().Update(ctx, &nodeNeedUpdate)
+			}
+			if len(selectedPods) == 0 && !WorkflowNodeFinished(nodeNeedUpdate.Status) {
+				SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
+					Type:   v1alpha1.ConditionAccomplished,
+					Status: corev1.ConditionTrue,
+					Reason: v1alpha1.NoPodSelected,
+				})
+				it.eventRecorder.Event(&nodeNeedUpdate, recorder.NoPodSelected{})
+				return it.kubeClient.Status().Update(ctx, &nodeNeedUpdate)
+			}
+		}
+		if nodeNeedUpdate.Spec.ForEach != nil {
+			// the pods selected after all the children are finished don't bring the node back
+			accomplished = ConditionEqualsTo(nodeNeedUpdate.Status, v1alpha1.ConditionAccomplished, corev1.ConditionTrue) ||
+				allPodsFinished(selectedPods, finishedChildren)
+		}
+		// the node with FailFast doesn't wait for the other children once any of them fails
+		if nodeNeedUpdate.Spec.FailFast && len(failedChildren) > 0 {
+			accomplished = true
+		}
+		if accomplished && len(failedChildren) > 0 {
+			if !WorkflowNodeChildFailed(nodeNeedUpdate.Status) {
+				it.eventRecorder.Event(&nodeNeedUpdate, recorder.ChildNodesFailed{ChildNodes: failedChildren})
+			}
+			SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
+				Type:   v1alpha1.ConditionAccomplished,
+				Status: corev1.ConditionTrue,
+				Reason: v1alpha1.ChildNodeFailed,
+			})
+		} else if accomplished {
 			SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
 				Type:   v1alpha1.ConditionAccomplished,
 				Status: corev1.ConditionTrue,
@@ -122,6 +199,10 @@ func (it *ParallelNodeReconciler) Reconcile(request reconcile.Request) (reconcil
 			})
 		}
 
+		// the pods are selected again until the node is finished
+		reselect = nodeNeedUpdate.Spec.ForEach != nil && !WorkflowNodeFinished(nodeNeedUpdate.Status)
+		failedFast = nodeNeedUpdate.Spec.FailFast && WorkflowNodeChildFailed(nodeNeedUpdate.Status)
+
 		return it.kubeClient.Status().Update(ctx, &nodeNeedUpdate)
 	})
 
@@ -130,26 +211,42 @@ func (it *ParallelNodeReconciler) Reconcile(request reconcile.Request) (reconcil
 		return reconcile.Result{}, updateError
 	}
 
+	if failedFast {
+		err := it.abortActiveChildren(ctx, node)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if waitingForCleanup {
+		return reconcile.Result{RequeueAfter: outdatedChildNodesCheckInterval}, nil
+	}
+	if reselect {
+		return reconcile.Result{RequeueAfter: forEachReselectInterval}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
-func (it *ParallelNodeReconciler) syncChildNodes(ctx context.Context, node v1alpha1.WorkflowNode) error {
+// syncChildNodes creates or removes the children nodes, it returns true if the outdated children nodes are
+// still being deleted, and the new children nodes will be spawned after they are gone.
+func (it *ParallelNodeReconciler) syncChildNodes(ctx context.Context, node v1alpha1.WorkflowNode) (bool, error) {
 
 	// empty parallel node
 	if len(node.Spec.Children) == 0 {
 		it.logger.V(4).Info("empty parallel node, NOOP",
 			"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
 		)
-		return nil
+		return false, nil
 	}
 
 	if WorkflowNodeFinished(node.Status) {
-		return nil
+		return false, nil
 	}
 
 	activeChildNodes, finishedChildNodes, err := it.fetchChildNodes(ctx, node)
 	if err != nil {
-		return err
+		return false, err
 	}
 	existsChildNodes := append(activeChildNodes, finishedChildNodes...)
 
@@ -173,9 +270,12 @@ func (it *ParallelNodeReconciler) syncChildNodes(ctx context.Context, node v1alp
 		it.eventRecorder.Event(&node, recorder.RerunBySpecChanged{CleanedChildrenNode: nodesToCleanup})
 
 		for _, childNode := range existsChildNodes {
+			if childNode.DeletionTimestamp != nil {
+				continue
+			}
 			// best effort deletion
 			err := it.kubeClient.Delete(ctx, &childNode)
-			if err != nil {
+			if client.IgnoreNotFound(err) != nil {
 				it.logger.Error(err, "failed to delete outdated child node",
 					"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
 					"child node", fmt.Sprintf("%s/%s", childNode.Namespace, childNode.Name),
@@ -183,11 +283,25 @@ func (it *ParallelNodeReconciler) syncChildNodes(ctx context.Context, node v1alp
 			}
 		}
 
+		// the child nodes are deleted in foreground, they are kept until the chaos injected by
+		// them has been recovered. The new child nodes should not be spawned before that,
+		// otherwise the outdated chaos and the new one will take effect at the same time.
+		if len(existsChildNodes) > 0 {
+			activeChildNodes, finishedChildNodes, err := it.fetchChildNodes(ctx, node)
+			if err != nil {
+				return false, err
+			}
+			if len(activeChildNodes)+len(finishedChildNodes) > 0 {
+				it.logger.Info("waiting for outdated child nodes to be cleaned up",
+					"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name))
+				return true, nil
+			}
+		}
 	}
 
 	if len(tasksToStartup) == 0 {
 		it.logger.Info("no need to spawn new child node", "node", fmt.Sprintf("%s/%s", node.Namespace, node.Name))
-		return nil
+		return false, nil
 	}
 
 	parentWorkflow := v1alpha1.Workflow{}
@@ -199,14 +313,14 @@ func (it *ParallelNodeReconciler) syncChildNodes(ctx context.Context, node v1alp
 		it.logger.Error(err, "failed to fetch parent workflow",
 			"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
 			"workflow name", node.Spec.WorkflowName)
-		return err
+		return false, err
 	}
 
 	childNodes, err := renderNodesByTemplates(&parentWorkflow, &node, tasksToStartup...)
 	if err != nil {
 		it.logger.Error(err, "failed to render children childNodes",
 			"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name))
-		return err
+		return false, err
 	}
 
 	var childrenNames []string
@@ -216,7 +330,7 @@ func (it *ParallelNodeReconciler) syncChildNodes(ctx context.Context, node v1alp
 			it.logger.Error(err, "failed to create child node",
 				"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
 				"child node", childNode)
-			return err
+			return false, err
 		}
 		childrenNames = append(childrenNames, childNode.Name)
 	}
@@ -225,5 +339,165 @@ func (it *ParallelNodeReconciler) syncChildNodes(ctx context.Context, node v1alp
 		"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
 		"child node", childrenNames)
 
+	return false, nil
+}
+
+// syncChildNodesForEachPod selects the pods for the parallel node with ForEach, then keeps one child for each of
+// them: the children of the pods which are no longer selected are removed, and the children for the newly selected
+// pods are spawned. It returns the selected pods in the form of "namespace/name".
+func (it *ParallelNodeReconciler) syncChildNodesForEachPod(ctx context.Context, node v1alpha1.WorkflowNode) ([]string, error) {
+	selector := node.Spec.ForEach.Selector.DeepCopy()
+	selector.DefaultNamespace(&node)
+	pods, err := pod.SelectPods(ctx, it.kubeClient, it.kubeClient, *selector,
+		ccfg.ControllerCfg.ClusterScoped, ccfg.ControllerCfg.TargetNamespace, ccfg.ControllerCfg.EnableFilterNamespace)
+	if err != nil {
+		it.logger.Error(err, "failed to select pods",
+			"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name))
+		return nil, err
+	}
+	var selectedPods []string
+	podsToStartup := make(map[string]struct{})
+	for _, item := range pods {
+		key := types.NamespacedName{Namespace: item.Namespace, Name: item.Name}.String()
+		selectedPods = append(selectedPods, key)
+		podsToStartup[key] = struct{}{}
+	}
+
+	// the children of the finished node are kept as they are
+	if WorkflowNodeFinished(node.Status) {
+		return selectedPods, nil
+	}
+
+	activeChildNodes, finishedChildNodes, err := it.fetchChildNodes(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	for _, childNode := range append(activeChildNodes, finishedChildNodes...) {
+		if childNode.Spec.TargetPod != nil {
+			if _, ok := podsToStartup[*childNode.Spec.TargetPod]; ok {
+				delete(podsToStartup, *childNode.Spec.TargetPod)
+				continue
+			}
+		}
+		if childNode.DeletionTimestamp != nil {
+			continue
+		}
+		// the pod is no longer selected, or it has another child already
+		err := it.kubeClient.Delete(ctx, &childNode)
+		if client.IgnoreNotFound(err) != nil {
+			it.logger.Error(err, "failed to delete the child node of unselected pod",
+				"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
+				"child node", fmt.Sprintf("%s/%s", childNode.Namespace, childNode.Name),
+			)
+			return nil, err
+		}
+	}
+
+	if len(podsToStartup) == 0 {
+		return selectedPods, nil
+	}
+
+	parentWorkflow := v1alpha1.Workflow{}
+	err = it.kubeClient.Get(ctx, types.NamespacedName{
+		Namespace: node.Namespace,
+		Name:      node.Spec.WorkflowName,
+	}, &parentWorkflow)
+	if err != nil {
+		it.logger.Error(err, "failed to fetch parent workflow",
+			"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
+			"workflow name", node.Spec.WorkflowName)
+		return nil, err
+	}
+
+	var childrenNames []string
+	for _, targetPod := range selectedPods {
+		if _, ok := podsToStartup[targetPod]; !ok {
+			continue
+		}
+		childNodes, err := renderNodesByTemplates(&parentWorkflow, &node, node.Spec.ForEach.Template)
+		if err != nil {
+			it.logger.Error(err, "failed to render children childNodes",
+				"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name))
+			return nil, err
+		}
+		targetPod := targetPod
+		childNode := childNodes[0]
+		childNode.Spec.TargetPod = &targetPod
+		err = it.kubeClient.Create(ctx, childNode)
+		if err != nil {
+			it.logger.Error(err, "failed to create child node",
+				"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
+				"child node", childNode)
+			return nil, err
+		}
+		childrenNames = append(childrenNames, childNode.Name)
+	}
+	it.eventRecorder.Event(&node, recorder.NodesCreated{ChildNodes: childrenNames})
+	it.logger.Info("parallel node spawn new child node for each pod",
+		"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
+		"child node", childrenNames)
+
+	return selectedPods, nil
+}
+
+// abortActiveChildren aborts the children of the node which are still active, along with their own active
+// descendants, so the chaos injected by them is recovered.
+func (it *ParallelNodeReconciler) abortActiveChildren(ctx context.Context, node v1alpha1.WorkflowNode) error {
+	activeChildNodes, _, err := it.fetchChildNodes(ctx, node)
+	if err != nil {
+		return err
+	}
+	for _, childNode := range activeChildNodes {
+		childNode := childNode
+
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			nodeNeedUpdate := v1alpha1.WorkflowNode{}
+			err := it.kubeClient.Get(ctx, types.NamespacedName{
+				Namespace: childNode.Namespace,
+				Name:      childNode.Name,
+			}, &nodeNeedUpdate)
+			if err != nil {
+				return err
+			}
+			if WorkflowNodeFinished(nodeNeedUpdate.Status) {
+				return nil
+			}
+			SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
+				Type:   v1alpha1.ConditionAborted,
+				Status: corev1.ConditionTrue,
+				Reason: v1alpha1.ParentNodeFailedFast,
+			})
+			it.eventRecorder.Event(&nodeNeedUpdate, recorder.ParentNodeFailedFast{ParentNodeName: node.Name})
+			return it.kubeClient.Status().Update(ctx, &nodeNeedUpdate)
+		})
+		if client.IgnoreNotFound(err) != nil {
+			it.logger.Error(err, "failed to abort child node",
+				"node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
+				"child node", fmt.Sprintf("%s/%s", childNode.Namespace, childNode.Name),
+			)
+			return err
+		}
+
+		err = it.abortActiveChildren(ctx, childNode)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// allPodsFinished returns true if each of the selected pods has a finished child
+func allPodsFinished(selectedPods []string, finishedChildNodes []v1alpha1.WorkflowNode) bool {
+	finishedPods := make(map[string]struct{})
+	for _, childNode := range finishedChildNodes {
+		if childNode.Spec.TargetPod != nil {
+			finishedPods[*childNode.Spec.TargetPod] = struct{}{}
+		}
+	}
+	for _, selectedPod := range selectedPods {
+		if _, ok := finishedPods[selectedPod]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -26,10 +26,16 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 )
 
 // unit tests
@@ -126,7 +132,731 @@ func Test_relativeComplementSet(t *testing.T) {
 	}
 }
 
+func TestParallelNodeRecoversOutdatedChaosBeforeSpawning(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	podChaosSpec := &v1alpha1.PodChaosSpec{
+		ContainerSelector: v1alpha1.ContainerSelector{
+			PodSelector: v1alpha1.PodSelector{
+				Selector: v1alpha1.PodSelectorSpec{
+					Namespaces: []string{metav1.NamespaceDefault},
+				},
+				Mode: v1alpha1.AllPodMode,
+			},
+		},
+		Action: v1alpha1.PodKillAction,
+	}
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "workflow",
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "parallel",
+			Templates: []v1alpha1.Template{
+				{
+					Name:     "parallel",
+					Type:     v1alpha1.TypeParallel,
+					Children: []string{"pod-chaos-b"},
+				}, {
+					Name:       "pod-chaos-a",
+					Type:       v1alpha1.TypePodChaos,
+					EmbedChaos: &v1alpha1.EmbedChaos{PodChaos: podChaosSpec},
+				}, {
+					Name:       "pod-chaos-b",
+					Type:       v1alpha1.TypePodChaos,
+					EmbedChaos: &v1alpha1.EmbedChaos{PodChaos: podChaosSpec},
+				},
+			},
+		},
+	}
+	// the task "pod-chaos-a" has been removed from the parallel node
+	parallelNode := &v1alpha1.WorkflowNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "parallel-0",
+		},
+		Spec: v1alpha1.WorkflowNodeSpec{
+			TemplateName: "parallel",
+			WorkflowName: workflow.Name,
+			Type:         v1alpha1.TypeParallel,
+			Children:     []string{"pod-chaos-b"},
+		},
+	}
+	// the outdated child node is being deleted in foreground
+	now := metav1.Now()
+	outdatedNode := &v1alpha1.WorkflowNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         metav1.NamespaceDefault,
+			Name:              "pod-chaos-a-0",
+			Labels:            map[string]string{v1alpha1.LabelControlledBy: parallelNode.Name},
+			DeletionTimestamp: &now,
+			Finalizers:        []string{metav1.FinalizerDeleteDependents},
+		},
+		Spec: v1alpha1.WorkflowNodeSpec{
+			TemplateName: "pod-chaos-a",
+			WorkflowName: workflow.Name,
+			Type:         v1alpha1.TypePodChaos,
+			EmbedChaos:   &v1alpha1.EmbedChaos{PodChaos: podChaosSpec},
+		},
+	}
+	outdatedChaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "pod-chaos-a-0-0",
+			Labels:    map[string]string{v1alpha1.LabelControlledBy: outdatedNode.Name},
+		},
+		Spec: *podChaosSpec,
+	}
+
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), workflow, parallelNode, outdatedNode, outdatedChaos)
+	logger := zap.New(zap.UseDevMode(true))
+	parallelReconciler := NewParallelNodeReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+	chaosNodeReconciler := NewChaosNodeReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+
+	listChildren := func() []v1alpha1.WorkflowNode {
+		nodes := v1alpha1.WorkflowNodeList{}
+		g.Expect(kubeClient.List(ctx, &nodes, client.MatchingLabels{v1alpha1.LabelControlledBy: parallelNode.Name})).To(Succeed())
+		return nodes.Items
+	}
+
+	// the new child is not spawned while the outdated one is still recovering its chaos
+	result, err := parallelReconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: parallelNode.Namespace,
+		Name:      parallelNode.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+	children := listChildren()
+	g.Expect(children).To(HaveLen(1))
+	g.Expect(children[0].Name).To(Equal(outdatedNode.Name))
+
+	// the chaos of the deleting node is removed, and never respawned
+	_, err = chaosNodeReconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: outdatedNode.Namespace,
+		Name:      outdatedNode.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	podChaosList := v1alpha1.PodChaosList{}
+	g.Expect(kubeClient.List(ctx, &podChaosList)).To(Succeed())
+	g.Expect(podChaosList.Items).To(BeEmpty())
+
+	// the outdated node is gone after its chaos has been recovered, then the new child is spawned
+	g.Expect(kubeClient.Delete(ctx, outdatedNode)).To(Succeed())
+	result, err = parallelReconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: parallelNode.Namespace,
+		Name:      parallelNode.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	children = listChildren()
+	g.Expect(children).To(HaveLen(1))
+	g.Expect(children[0].Spec.TemplateName).To(Equal("pod-chaos-b"))
+}
+
+func TestChildrenAreCollectedWithTheirOwners(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	podChaosSpec := &v1alpha1.PodChaosSpec{
+		ContainerSelector: v1alpha1.ContainerSelector{
+			PodSelector: v1alpha1.PodSelector{
+				Selector: v1alpha1.PodSelectorSpec{
+					Namespaces: []string{metav1.NamespaceDefault},
+				},
+				Mode: v1alpha1.AllPodMode,
+			},
+		},
+		Action: v1alpha1.PodKillAction,
+	}
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "workflow",
+			UID:       "workflow-uid",
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "parallel",
+			Templates: []v1alpha1.Template{
+				{
+					Name:     "parallel",
+					Type:     v1alpha1.TypeParallel,
+					Children: []string{"pod-chaos"},
+				}, {
+					Name:       "pod-chaos",
+					Type:       v1alpha1.TypePodChaos,
+					EmbedChaos: &v1alpha1.EmbedChaos{PodChaos: podChaosSpec},
+				},
+			},
+		},
+	}
+	ownedBy := func(apiVersion string, kind string, name string, uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion:         apiVersion,
+			Kind:               kind,
+			Name:               name,
+			UID:                uid,
+			Controller:         &isController,
+			BlockOwnerDeletion: &blockOwnerDeletion,
+		}}
+	}
+
+	nodes, err := renderNodesByTemplates(workflow, nil, workflow.Spec.Entry)
+	g.Expect(err).ToNot(HaveOccurred())
+	parallelNode := nodes[0]
+	parallelNode.Name = "parallel-0"
+	parallelNode.UID = "parallel-uid"
+	g.Expect(parallelNode.OwnerReferences).To(Equal(ownedBy(ApiVersion, KindWorkflow, workflow.Name, workflow.UID)))
+
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), workflow, parallelNode)
+	logger := zap.New(zap.UseDevMode(true))
+
+	// the children spawned by the parallel node are owned by it
+	_, err = NewParallelNodeReconciler(kubeClient, recorder.NewDebugRecorder(), logger).Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: parallelNode.Namespace,
+		Name:      parallelNode.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	children := v1alpha1.WorkflowNodeList{}
+	g.Expect(kubeClient.List(ctx, &children, client.MatchingLabels{v1alpha1.LabelControlledBy: parallelNode.Name})).To(Succeed())
+	g.Expect(children.Items).To(HaveLen(1))
+	chaosNode := children.Items[0]
+	g.Expect(chaosNode.OwnerReferences).To(Equal(ownedBy(ApiVersion, KindWorkflowNode, parallelNode.Name, parallelNode.UID)))
+
+	// the fake client does not generate the name and uid, so name the child by hand
+	g.Expect(kubeClient.Delete(ctx, &chaosNode)).To(Succeed())
+	chaosNode.Name = "pod-chaos-0"
+	chaosNode.UID = "pod-chaos-uid"
+	chaosNode.ResourceVersion = ""
+	g.Expect(kubeClient.Create(ctx, &chaosNode)).To(Succeed())
+
+	// the chaos spawned by the chaos node is owned by it
+	_, err = NewChaosNodeReconciler(kubeClient, recorder.NewDebugRecorder(), logger).Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: chaosNode.Namespace,
+		Name:      chaosNode.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	podChaosList := v1alpha1.PodChaosList{}
+	g.Expect(kubeClient.List(ctx, &podChaosList)).To(Succeed())
+	g.Expect(podChaosList.Items).To(HaveLen(1))
+	g.Expect(podChaosList.Items[0].OwnerReferences).To(Equal(ownedBy(ApiVersion, KindWorkflowNode, chaosNode.Name, chaosNode.UID)))
+
+	// deleting the workflow cascades to all the nodes and the chaos, like the garbage collector of kubernetes does
+	g.Expect(kubeClient.Delete(ctx, workflow)).To(Succeed())
+	collectGarbage(g, kubeClient, workflow.UID)
+
+	allNodes := v1alpha1.WorkflowNodeList{}
+	g.Expect(kubeClient.List(ctx, &allNodes)).To(Succeed())
+	g.Expect(allNodes.Items).To(BeEmpty())
+	g.Expect(kubeClient.List(ctx, &podChaosList)).To(Succeed())
+	g.Expect(podChaosList.Items).To(BeEmpty())
+}
+
+func TestParallelNodeSpawnsChildForEachPod(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	podChaosSpec := &v1alpha1.PodChaosSpec{
+		ContainerSelector: v1alpha1.ContainerSelector{
+			PodSelector: v1alpha1.PodSelector{
+				Selector: v1alpha1.PodSelectorSpec{
+					Namespaces: []string{metav1.NamespaceDefault},
+				},
+				Mode: v1alpha1.OnePodMode,
+			},
+		},
+		Action: v1alpha1.PodKillAction,
+	}
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "workflow",
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "parallel",
+			Templates: []v1alpha1.Template{
+				{
+					Name: "parallel",
+					Type: v1alpha1.TypeParallel,
+					ForEach: &v1alpha1.ForEachPod{
+						Selector: v1alpha1.PodSelectorSpec{
+							LabelSelectors: map[string]string{"app": "web"},
+						},
+						Template: "pod-chaos",
+					},
+				}, {
+					Name:       "pod-chaos",
+					Type:       v1alpha1.TypePodChaos,
+					EmbedChaos: &v1alpha1.EmbedChaos{PodChaos: podChaosSpec},
+				},
+			},
+		},
+	}
+	nodes, err := renderNodesByTemplates(workflow, nil, workflow.Spec.Entry)
+	g.Expect(err).ToNot(HaveOccurred())
+	parallelNode := nodes[0]
+	parallelNode.Name = "parallel-0"
+
+	newPod := func(name string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name, Labels: labels},
+		}
+	}
+	web := map[string]string{"app": "web"}
+	kubeClient := &generateNameClient{Client: fake.NewFakeClientWithScheme(provider.NewScheme(), workflow, parallelNode,
+		newPod("web-0", web), newPod("web-1", web), newPod("web-2", web), newPod("db-0", map[string]string{"app": "db"}))}
+	logger := zap.New(zap.UseDevMode(true))
+	parallelReconciler := NewParallelNodeReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+
+	reconcileParallelNode := func() reconcile.Result {
+		result, err := parallelReconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: parallelNode.Namespace,
+			Name:      parallelNode.Name,
+		}})
+		g.Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+	listChildren := func() []v1alpha1.WorkflowNode {
+		children := v1alpha1.WorkflowNodeList{}
+		g.Expect(kubeClient.List(ctx, &children, client.MatchingLabels{v1alpha1.LabelControlledBy: parallelNode.Name})).To(Succeed())
+		return children.Items
+	}
+	targetPodsOf := func(children []v1alpha1.WorkflowNode) []string {
+		var targetPods []string
+		for _, child := range children {
+			g.Expect(child.Spec.TemplateName).To(Equal("pod-chaos"))
+			targetPods = append(targetPods, *child.Spec.TargetPod)
+		}
+		return targetPods
+	}
+
+	// N matched pods produce N children, and reconciling again spawns no more
+	result := reconcileParallelNode()
+	g.Expect(result.RequeueAfter).To(Equal(forEachReselectInterval))
+	g.Expect(targetPodsOf(listChildren())).To(ConsistOf("default/web-0", "default/web-1", "default/web-2"))
+	reconcileParallelNode()
+	g.Expect(listChildren()).To(HaveLen(3))
+
+	// the chaos of each child only takes effect on its own pod
+	var child v1alpha1.WorkflowNode
+	for _, item := range listChildren() {
+		if *item.Spec.TargetPod == "default/web-1" {
+			child = item
+		}
+	}
+	_, err = NewChaosNodeReconciler(kubeClient, recorder.NewDebugRecorder(), logger).Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: child.Namespace,
+		Name:      child.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	podChaosList := v1alpha1.PodChaosList{}
+	g.Expect(kubeClient.List(ctx, &podChaosList)).To(Succeed())
+	g.Expect(podChaosList.Items).To(HaveLen(1))
+	g.Expect(podChaosList.Items[0].Spec.Selector).To(Equal(v1alpha1.PodSelectorSpec{
+		Pods: map[string][]string{metav1.NamespaceDefault: {"web-1"}},
+	}))
+	g.Expect(podChaosList.Items[0].Spec.Mode).To(Equal(v1alpha1.AllPodMode))
+	g.Expect(child.Spec.PodChaos.Selector.Namespaces).To(Equal([]string{metav1.NamespaceDefault}))
+
+	// the pods are selected again, the child of the gone pod is removed and the new pod gets its child
+	g.Expect(kubeClient.Delete(ctx, newPod("web-2", web))).To(Succeed())
+	g.Expect(kubeClient.Create(ctx, newPod("web-3", web))).To(Succeed())
+	reconcileParallelNode()
+	children := listChildren()
+	g.Expect(targetPodsOf(children)).To(ConsistOf("default/web-0", "default/web-1", "default/web-3"))
+
+	// the parallel node is accomplished after the children of all the selected pods are accomplished
+	for i := range children {
+		SetCondition(&children[i].Status, v1alpha1.WorkflowNodeCondition{
+			Type:   v1alpha1.ConditionAccomplished,
+			Status: corev1.ConditionTrue,
+		})
+		g.Expect(kubeClient.Status().Update(ctx, &children[i])).To(Succeed())
+	}
+	result = reconcileParallelNode()
+	g.Expect(result.RequeueAfter).To(BeZero())
+	updatedNode := v1alpha1.WorkflowNode{}
+	g.Expect(kubeClient.Get(ctx, types.NamespacedName{Namespace: parallelNode.Namespace, Name: parallelNode.Name}, &updatedNode)).To(Succeed())
+	g.Expect(ConditionEqualsTo(updatedNode.Status, v1alpha1.ConditionAccomplished, corev1.ConditionTrue)).To(BeTrue())
+}
+
+func TestParallelNodeFailsWithoutSelectedPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "workflow",
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "parallel",
+			Templates: []v1alpha1.Template{
+				{
+					Name: "parallel",
+					Type: v1alpha1.TypeParallel,
+					ForEach: &v1alpha1.ForEachPod{
+						Selector: v1alpha1.PodSelectorSpec{
+							LabelSelectors: map[string]string{"app": "web"},
+						},
+						Template: "pod-chaos",
+					},
+				}, {
+					Name: "pod-chaos",
+					Type: v1alpha1.TypePodChaos,
+					EmbedChaos: &v1alpha1.EmbedChaos{PodChaos: &v1alpha1.PodChaosSpec{
+						Action: v1alpha1.PodKillAction,
+					}},
+				},
+			},
+		},
+	}
+	nodes, err := renderNodesByTemplates(workflow, nil, workflow.Spec.Entry)
+	g.Expect(err).ToNot(HaveOccurred())
+	parallelNode := nodes[0]
+	parallelNode.Name = "parallel-0"
+	parallelNode.CreationTimestamp = metav1.NewTime(time.Now().Add(-forEachSelectTimeout))
+
+	kubeClient := &generateNameClient{Client: fake.NewFakeClientWithScheme(provider.NewScheme(), workflow, parallelNode)}
+	debugRecorder := recorder.NewDebugRecorder()
+	parallelReconciler := NewParallelNodeReconciler(kubeClient, debugRecorder, zap.New(zap.UseDevMode(true)))
+	key := types.NamespacedName{Namespace: parallelNode.Namespace, Name: parallelNode.Name}
+
+	// the node selecting no pod in time is failed instead of being accomplished without any child
+	for i := 0; i < 2; i++ {
+		result, err := parallelReconciler.Reconcile(reconcile.Request{NamespacedName: key})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(BeZero())
+
+		updatedNode := v1alpha1.WorkflowNode{}
+		g.Expect(kubeClient.Get(ctx, key, &updatedNode)).To(Succeed())
+		g.Expect(WorkflowNodeFailed(updatedNode.Status)).To(BeTrue())
+		g.Expect(GetCondition(updatedNode.Status, v1alpha1.ConditionAccomplished).Reason).To(Equal(v1alpha1.NoPodSelected))
+	}
+	g.Expect(debugRecorder.Events[key]).To(Equal([]recorder.ChaosEvent{recorder.NoPodSelected{}}))
+}
+
+func TestParallelNodeWaitsForSelectedPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "workflow",
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "parallel",
+			Templates: []v1alpha1.Template{
+				{
+					Name: "parallel",
+					Type: v1alpha1.TypeParallel,
+					ForEach: &v1alpha1.ForEachPod{
+						Selector: v1alpha1.PodSelectorSpec{
+							LabelSelectors: map[string]string{"app": "web"},
+						},
+						Template: "pod-chaos",
+					},
+				}, {
+					Name: "pod-chaos",
+					Type: v1alpha1.TypePodChaos,
+					EmbedChaos: &v1alpha1.EmbedChaos{PodChaos: &v1alpha1.PodChaosSpec{
+						Action: v1alpha1.PodKillAction,
+					}},
+				},
+			},
+		},
+	}
+	nodes, err := renderNodesByTemplates(workflow, nil, workflow.Spec.Entry)
+	g.Expect(err).ToNot(HaveOccurred())
+	parallelNode := nodes[0]
+	parallelNode.Name = "parallel-0"
+	parallelNode.CreationTimestamp = metav1.Now()
+
+	kubeClient := &generateNameClient{Client: fake.NewFakeClientWithScheme(provider.NewScheme(), workflow, parallelNode)}
+	debugRecorder := recorder.NewDebugRecorder()
+	parallelReconciler := NewParallelNodeReconciler(kubeClient, debugRecorder, zap.New(zap.UseDevMode(true)))
+	key := types.NamespacedName{Namespace: parallelNode.Namespace, Name: parallelNode.Name}
+
+	// the node selecting no pod at first waits for the pods to be selected again
+	result, err := parallelReconciler.Reconcile(reconcile.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(forEachReselectInterval))
+	updatedNode := v1alpha1.WorkflowNode{}
+	g.Expect(kubeClient.Get(ctx, key, &updatedNode)).To(Succeed())
+	g.Expect(WorkflowNodeFinished(updatedNode.Status)).To(BeFalse())
+	g.Expect(debugRecorder.Events[key]).To(BeEmpty())
+
+	// and spawns the child for the pod selected later
+	g.Expect(kubeClient.Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "web-0",
+			Labels:    map[string]string{"app": "web"},
+		},
+	})).To(Succeed())
+	result, err = parallelReconciler.Reconcile(reconcile.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(forEachReselectInterval))
+	children := v1alpha1.WorkflowNodeList{}
+	g.Expect(kubeClient.List(ctx, &children, client.MatchingLabels{v1alpha1.LabelControlledBy: parallelNode.Name})).To(Succeed())
+	g.Expect(children.Items).To(HaveLen(1))
+	g.Expect(*children.Items[0].Spec.TargetPod).To(Equal("default/web-0"))
+}
+
+// generateNameClient names the created objects by their GenerateName like the api server, since the fake client doesn't.
+type generateNameClient struct {
+	client.Client
+	generated int
+}
+
+func (c *generateNameClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	if meta, ok := obj.(metav1.Object); ok && meta.GetName() == "" && meta.GetGenerateName() != "" {
+		meta.SetName(fmt.Sprintf("%s%d", meta.GetGenerateName(), c.generated))
+		c.generated++
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// collectGarbage deletes the objects owned by the deleted owner recursively, since the fake client has no garbage collector.
+func collectGarbage(g *WithT, kubeClient client.Client, owner types.UID) {
+	ctx := context.TODO()
+
+	nodes := v1alpha1.WorkflowNodeList{}
+	g.Expect(kubeClient.List(ctx, &nodes)).To(Succeed())
+	podChaosList := v1alpha1.PodChaosList{}
+	g.Expect(kubeClient.List(ctx, &podChaosList)).To(Succeed())
+
+	var dependents []metav1.Object
+	for i := range nodes.Items {
+		dependents = append(dependents, &nodes.Items[i])
+	}
+	for i := range podChaosList.Items {
+		dependents = append(dependents, &podChaosList.Items[i])
+	}
+	for _, dependent := range dependents {
+		for _, ref := range dependent.GetOwnerReferences() {
+			if ref.UID == owner {
+				g.Expect(kubeClient.Delete(ctx, dependent.(runtime.Object))).To(Succeed())
+				collectGarbage(g, kubeClient, dependent.GetUID())
+			}
+		}
+	}
+}
+
 // integration tests
+func TestParallelNodeFailsFast(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	podChaosSpec := &v1alpha1.PodChaosSpec{
+		ContainerSelector: v1alpha1.ContainerSelector{
+			PodSelector: v1alpha1.PodSelector{
+				Selector: v1alpha1.PodSelectorSpec{
+					Namespaces: []string{metav1.NamespaceDefault},
+				},
+				Mode: v1alpha1.AllPodMode,
+			},
+		},
+		Action: v1alpha1.PodFailureAction,
+	}
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "workflow",
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "parallel",
+			Templates: []v1alpha1.Template{
+				{
+					Name:     "parallel",
+					Type:     v1alpha1.TypeParallel,
+					Children: []string{"pod-chaos-a", "pod-chaos-b"},
+					FailFast: true,
+				}, {
+					Name:       "pod-chaos-a",
+					Type:       v1alpha1.TypePodChaos,
+					EmbedChaos: &v1alpha1.EmbedChaos{PodChaos: podChaosSpec},
+				}, {
+					Name:       "pod-chaos-b",
+					Type:       v1alpha1.TypePodChaos,
+					EmbedChaos: &v1alpha1.EmbedChaos{PodChaos: podChaosSpec},
+				},
+			},
+		},
+	}
+	parallelNode := &v1alpha1.WorkflowNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "parallel-0",
+			Labels:    map[string]string{v1alpha1.LabelControlledBy: workflow.Name},
+		},
+		Spec: v1alpha1.WorkflowNodeSpec{
+			TemplateName: "parallel",
+			WorkflowName: workflow.Name,
+			Type:         v1alpha1.TypeParallel,
+			Children:     []string{"pod-chaos-a", "pod-chaos-b"},
+			FailFast:     true,
+		},
+	}
+	newChild := func(name string, templateName string) *v1alpha1.WorkflowNode {
+		return &v1alpha1.WorkflowNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{v1alpha1.LabelControlledBy: parallelNode.Name},
+			},
+			Spec: v1alpha1.WorkflowNodeSpec{
+				TemplateName: templateName,
+				WorkflowName: workflow.Name,
+				Type:         v1alpha1.TypePodChaos,
+				EmbedChaos:   &v1alpha1.EmbedChaos{PodChaos: podChaosSpec},
+			},
+		}
+	}
+	// the chaos of the first child could not be created, and the second one is running
+	failingNode := newChild("pod-chaos-a-0", "pod-chaos-a")
+	failingNode.Status.Conditions = []v1alpha1.WorkflowNodeCondition{{
+		Type:   v1alpha1.ConditionChaosInjected,
+		Status: corev1.ConditionFalse,
+		Reason: v1alpha1.ChaosCRCreateFailed,
+	}}
+	runningNode := newChild("pod-chaos-b-0", "pod-chaos-b")
+	runningChaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "pod-chaos-b-0-0",
+			Labels:    map[string]string{v1alpha1.LabelControlledBy: runningNode.Name},
+		},
+		Spec: *podChaosSpec,
+	}
+
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), workflow, parallelNode, failingNode, runningNode, runningChaos)
+	logger := zap.New(zap.UseDevMode(true))
+	parallelReconciler := NewParallelNodeReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+	chaosNodeReconciler := NewChaosNodeReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+	getNode := func(name string) v1alpha1.WorkflowNode {
+		node := v1alpha1.WorkflowNode{}
+		g.Expect(kubeClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: name}, &node)).To(Succeed())
+		return node
+	}
+
+	// the parallel node fails without waiting for the running child, and aborts all its children
+	_, err := parallelReconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: parallelNode.Namespace,
+		Name:      parallelNode.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	updatedParallelNode := getNode(parallelNode.Name)
+	g.Expect(WorkflowNodeChildFailed(updatedParallelNode.Status)).To(BeTrue())
+	g.Expect(updatedParallelNode.Status.FailedChildren).To(Equal([]corev1.LocalObjectReference{{Name: failingNode.Name}}))
+	g.Expect(WorkflowNodeFailed(getNode(failingNode.Name).Status)).To(BeTrue())
+	updatedRunningNode := getNode(runningNode.Name)
+	g.Expect(WorkflowNodeAborted(updatedRunningNode.Status)).To(BeTrue())
+	g.Expect(WorkflowNodeFailed(updatedRunningNode.Status)).To(BeFalse())
+
+	// the chaos of the aborted child is recovered
+	_, err = chaosNodeReconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: runningNode.Namespace,
+		Name:      runningNode.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	podChaosList := v1alpha1.PodChaosList{}
+	g.Expect(kubeClient.List(ctx, &podChaosList)).To(Succeed())
+	g.Expect(podChaosList.Items).To(BeEmpty())
+
+	// and the failure is propagated to the workflow
+	entryReconciler := NewWorkflowEntryReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+	_, err = entryReconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: workflow.Namespace,
+		Name:      workflow.Name,
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	updatedWorkflow := v1alpha1.Workflow{}
+	g.Expect(kubeClient.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, &updatedWorkflow)).To(Succeed())
+	accomplished := GetWorkflowCondition(updatedWorkflow.Status, v1alpha1.WorkflowConditionAccomplished)
+	g.Expect(accomplished).ToNot(BeNil())
+	g.Expect(accomplished.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(accomplished.Reason).To(Equal(v1alpha1.WorkflowFailed))
+}
+
+func TestParallelNodeWaitsForAllChildrenWithoutFailFast(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	parallelNode := &v1alpha1.WorkflowNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "parallel-0",
+		},
+		Spec: v1alpha1.WorkflowNodeSpec{
+			TemplateName: "parallel",
+			WorkflowName: "workflow",
+			Type:         v1alpha1.TypeParallel,
+			Children:     []string{"task-a", "task-b"},
+		},
+	}
+	newChild := func(name string, templateName string, conditions ...v1alpha1.WorkflowNodeCondition) *v1alpha1.WorkflowNode {
+		return &v1alpha1.WorkflowNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{v1alpha1.LabelControlledBy: parallelNode.Name},
+			},
+			Spec: v1alpha1.WorkflowNodeSpec{
+				TemplateName: templateName,
+				WorkflowName: "workflow",
+				Type:         v1alpha1.TypeTask,
+			},
+			Status: v1alpha1.WorkflowNodeStatus{Conditions: conditions},
+		}
+	}
+	// the pod of the first task could never be spawned before its deadline, and the second one is running
+	failedNode := newChild("task-a-0", "task-a", v1alpha1.WorkflowNodeCondition{
+		Type:   v1alpha1.ConditionAccomplished,
+		Status: corev1.ConditionFalse,
+		Reason: v1alpha1.TaskPodSpawnFailed,
+	}, v1alpha1.WorkflowNodeCondition{
+		Type:   v1alpha1.ConditionDeadlineExceed,
+		Status: corev1.ConditionTrue,
+		Reason: v1alpha1.NodeDeadlineExceed,
+	})
+	runningNode := newChild("task-b-0", "task-b")
+
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), parallelNode, failedNode, runningNode)
+	parallelReconciler := NewParallelNodeReconciler(kubeClient, recorder.NewDebugRecorder(), zap.New(zap.UseDevMode(true)))
+	reconcileParallelNode := func() v1alpha1.WorkflowNode {
+		_, err := parallelReconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: parallelNode.Namespace,
+			Name:      parallelNode.Name,
+		}})
+		g.Expect(err).ToNot(HaveOccurred())
+		node := v1alpha1.WorkflowNode{}
+		g.Expect(kubeClient.Get(ctx, types.NamespacedName{Namespace: parallelNode.Namespace, Name: parallelNode.Name}, &node)).To(Succeed())
+		return node
+	}
+
+	// the failed child is distinguished, but the running one is not aborted
+	updatedParallelNode := reconcileParallelNode()
+	g.Expect(WorkflowNodeFinished(updatedParallelNode.Status)).To(BeFalse())
+	g.Expect(updatedParallelNode.Status.FailedChildren).To(Equal([]corev1.LocalObjectReference{{Name: failedNode.Name}}))
+	updatedRunningNode := v1alpha1.WorkflowNode{}
+	g.Expect(kubeClient.Get(ctx, types.NamespacedName{Namespace: runningNode.Namespace, Name: runningNode.Name}, &updatedRunningNode)).To(Succeed())
+	g.Expect(WorkflowNodeAborted(updatedRunningNode.Status)).To(BeFalse())
+
+	// the parallel node fails after all the children are finished
+	updatedRunningNode.Status.Conditions = []v1alpha1.WorkflowNodeCondition{{
+		Type:   v1alpha1.ConditionAccomplished,
+		Status: corev1.ConditionTrue,
+	}}
+	g.Expect(kubeClient.Status().Update(ctx, &updatedRunningNode)).To(Succeed())
+	updatedParallelNode = reconcileParallelNode()
+	g.Expect(WorkflowNodeChildFailed(updatedParallelNode.Status)).To(BeTrue())
+	g.Expect(updatedParallelNode.Status.FinishedChildren).To(HaveLen(2))
+	g.Expect(updatedParallelNode.Status.FailedChildren).To(HaveLen(1))
+}
+
 var _ = Describe("Workflow", func() {
 	var ns string
 	BeforeEach(func() {
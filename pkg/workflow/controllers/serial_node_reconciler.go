@@ -126,8 +126,30 @@ func (it *SerialNodeReconciler) Reconcile(request reconcile.Request) (reconcile.
 			it.logger.Info("warning: serial node has more than 1 active children", "namespace", nodeNeedUpdate.Namespace, "name", nodeNeedUpdate.Name, "children", nodeNeedUpdate.Status.ActiveChildren)
 		}
 
+		var failedChildren []string
+		nodeNeedUpdate.Status.FailedChildren = nil
+		for _, child := range append(activeChildren, finishedChildren...) {
+			if childFailed(child) {
+				failedChildren = append(failedChildren, child.Name)
+				nodeNeedUpdate.Status.FailedChildren = append(nodeNeedUpdate.Status.FailedChildren,
+					corev1.LocalObjectReference{
+						Name: child.Name,
+					})
+			}
+		}
+
 		// TODO: also check the consistent between spec in task and the spec in child node
-		if len(finishedChildren) == len(nodeNeedUpdate.Spec.Children) {
+		accomplished := len(finishedChildren) == len(nodeNeedUpdate.Spec.Children)
+		if accomplished && len(failedChildren) > 0 {
+			if !WorkflowNodeChildFailed(nodeNeedUpdate.Status) {
+				it.eventRecorder.Event(&nodeNeedUpdate, recorder.ChildNodesFailed{ChildNodes: failedChildren})
+			}
+			SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
+				Type:   v1alpha1.ConditionAccomplished,
+				Status: corev1.ConditionTrue,
+				Reason: v1alpha1.ChildNodeFailed,
+			})
+		} else if accomplished {
 			SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
 				Type:   v1alpha1.ConditionAccomplished,
 				Status: corev1.ConditionTrue,
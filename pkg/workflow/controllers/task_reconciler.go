@@ -97,9 +97,11 @@ func (it *TaskReconciler) Reconcile(request reconcile.Request) (reconcile.Result
 			if err != nil {
 				it.logger.Error(err, "failed to spawn pod for Task Node", "node", request)
 				it.eventRecorder.Event(&node, recorder.TaskPodSpawnFailed{})
+				it.updateAccomplishedReason(ctx, request, v1alpha1.TaskPodSpawnFailed)
 				return reconcile.Result{}, err
 			}
 			it.eventRecorder.Event(&node, recorder.TaskPodSpawned{PodName: spawnedPod.Name})
+			it.updateAccomplishedReason(ctx, request, v1alpha1.TaskPodSpawned)
 		} else {
 			return reconcile.Result{}, errors.Errorf("node %s/%s does not contains label %s", node.Namespace, node.Name, v1alpha1.LabelWorkflow)
 		}
@@ -120,8 +122,10 @@ func (it *TaskReconciler) Reconcile(request reconcile.Request) (reconcile.Result
 
 	// update the status about conditional tasks
 	if len(pods) > 0 && (pods[0].Status.Phase == corev1.PodFailed || pods[0].Status.Phase == corev1.PodSucceeded) {
-		if !conditionalBranchesEvaluated(node) {
+		if !ConditionalBranchesEvaluated(node) {
 			it.eventRecorder.Event(&node, recorder.TaskPodPodCompleted{PodName: pods[0].Name})
+			// mark the task as completed before the evaluation, so it could be recognized as evaluating
+			it.updateAccomplishedReason(ctx, request, v1alpha1.TaskPodPodCompleted)
 			// task pod is terminated
 			updateError := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 				nodeNeedUpdate := v1alpha1.WorkflowNode{}
@@ -224,7 +228,7 @@ func (it *TaskReconciler) Reconcile(request reconcile.Request) (reconcile.Result
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if conditionalBranchesEvaluated(evaluatedNode) {
+	if ConditionalBranchesEvaluated(evaluatedNode) {
 		err = it.syncChildNodes(ctx, evaluatedNode)
 		if err != nil {
 			return reconcile.Result{}, err
@@ -267,7 +271,7 @@ func (it *TaskReconciler) Reconcile(request reconcile.Request) (reconcile.Result
 
 			// TODO: also check the consistent between spec in task and the spec in child node
 
-			if conditionalBranchesEvaluated(nodeNeedUpdate) && len(finishedChildren) == len(tasks) {
+			if ConditionalBranchesEvaluated(nodeNeedUpdate) && len(finishedChildren) == len(tasks) {
 				SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
 					Type:   v1alpha1.ConditionAccomplished,
 					Status: corev1.ConditionTrue,
@@ -292,6 +296,30 @@ func (it *TaskReconciler) Reconcile(request reconcile.Request) (reconcile.Result
 
 }
 
+// updateAccomplishedReason records the progress of the task node with the reason of its unaccomplished condition.
+func (it *TaskReconciler) updateAccomplishedReason(ctx context.Context, request reconcile.Request, reason string) {
+	updateError := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		nodeNeedUpdate := v1alpha1.WorkflowNode{}
+		err := it.kubeClient.Get(ctx, request.NamespacedName, &nodeNeedUpdate)
+		if err != nil {
+			return err
+		}
+		if ConditionEqualsTo(nodeNeedUpdate.Status, v1alpha1.ConditionAccomplished, corev1.ConditionTrue) {
+			return nil
+		}
+		SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
+			Type:   v1alpha1.ConditionAccomplished,
+			Status: corev1.ConditionFalse,
+			Reason: reason,
+		})
+		return it.kubeClient.Status().Update(ctx, &nodeNeedUpdate)
+	})
+	if client.IgnoreNotFound(updateError) != nil {
+		it.logger.Error(updateError, "failed to update the accomplished condition of task",
+			"task", request, "reason", reason)
+	}
+}
+
 func (it *TaskReconciler) syncChildNodes(ctx context.Context, evaluatedNode v1alpha1.WorkflowNode) error {
 
 	var tasks []string
@@ -446,7 +474,8 @@ func (it *TaskReconciler) SpawnTaskPod(ctx context.Context, node *v1alpha1.Workf
 	return &taskPod, nil
 }
 
-func conditionalBranchesEvaluated(node v1alpha1.WorkflowNode) bool {
+// ConditionalBranchesEvaluated returns true if the result of every conditional branch of the task node is known.
+func ConditionalBranchesEvaluated(node v1alpha1.WorkflowNode) bool {
 	if node.Status.ConditionalBranchesStatus == nil {
 		return false
 	}
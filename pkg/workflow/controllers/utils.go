@@ -66,7 +66,49 @@ func filterOutCondition(conditions []v1alpha1.WorkflowNodeCondition, except v1al
 
 func WorkflowNodeFinished(status v1alpha1.WorkflowNodeStatus) bool {
 	return ConditionEqualsTo(status, v1alpha1.ConditionAccomplished, corev1.ConditionTrue) ||
-		ConditionEqualsTo(status, v1alpha1.ConditionDeadlineExceed, corev1.ConditionTrue)
+		ConditionEqualsTo(status, v1alpha1.ConditionDeadlineExceed, corev1.ConditionTrue) ||
+		WorkflowNodeAborted(status)
+}
+
+// WorkflowNodeFailed returns true if the node is finished without doing its work, that is, the chaos custom resource
+// of the chaos node could never be created, or the pod of the task node could never be spawned.
+func WorkflowNodeFailed(status v1alpha1.WorkflowNodeStatus) bool {
+	return WorkflowNodeFinished(status) && WorkflowNodeFailing(status)
+}
+
+// WorkflowNodeFailing returns true if the chaos custom resource of the chaos node could not be created, or the pod of
+// the task node could not be spawned so far, or the parallel node spawning children for each pod selects none.
+// The node is failed once it's finished in this way.
+func WorkflowNodeFailing(status v1alpha1.WorkflowNodeStatus) bool {
+	if condition := GetCondition(status, v1alpha1.ConditionChaosInjected); condition != nil &&
+		condition.Status == corev1.ConditionFalse && condition.Reason == v1alpha1.ChaosCRCreateFailed {
+		return true
+	}
+	if condition := GetCondition(status, v1alpha1.ConditionAccomplished); condition != nil &&
+		condition.Status == corev1.ConditionFalse && condition.Reason == v1alpha1.TaskPodSpawnFailed {
+		return true
+	}
+	if condition := GetCondition(status, v1alpha1.ConditionAccomplished); condition != nil &&
+		condition.Status == corev1.ConditionTrue && condition.Reason == v1alpha1.NoPodSelected {
+		return true
+	}
+	return false
+}
+
+// WorkflowNodeChildFailed returns true if the serial or parallel node is accomplished with any of its children failed.
+func WorkflowNodeChildFailed(status v1alpha1.WorkflowNodeStatus) bool {
+	condition := GetCondition(status, v1alpha1.ConditionAccomplished)
+	return condition != nil && condition.Status == corev1.ConditionTrue && condition.Reason == v1alpha1.ChildNodeFailed
+}
+
+// WorkflowNodeAborted returns true if the node is finished by its ancestor which has failed fast.
+func WorkflowNodeAborted(status v1alpha1.WorkflowNodeStatus) bool {
+	return ConditionEqualsTo(status, v1alpha1.ConditionAborted, corev1.ConditionTrue)
+}
+
+// childFailed returns true if the child node is failing, or it's accomplished with any failed child of its own.
+func childFailed(child v1alpha1.WorkflowNode) bool {
+	return WorkflowNodeFailing(child.Status) || WorkflowNodeChildFailed(child.Status)
 }
 
 func SetWorkflowCondition(status *v1alpha1.WorkflowStatus, condition v1alpha1.WorkflowCondition) {
@@ -58,6 +58,34 @@ func (it *WorkflowEntryReconciler) Reconcile(request reconcile.Request) (reconci
 		return reconcile.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if workflow.Annotations[v1alpha1.ResumeFromFailedAnnotationKey] == "true" {
+		failedNodes, err := it.resumeFromFailedNodes(ctx, workflow, startTime)
+		if err != nil {
+			it.logger.Error(err, "failed to resume workflow from failed nodes",
+				"workflow", request.NamespacedName)
+			return reconcile.Result{}, err
+		}
+		if len(failedNodes) > 0 {
+			it.eventRecorder.Event(&workflow, recorder.WorkflowResumed{FailedNodes: failedNodes})
+		}
+
+		// the annotation is removed once handled, so the workflow would be resumed only once
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			workflowNeedUpdate := v1alpha1.Workflow{}
+			err := it.kubeClient.Get(ctx, request.NamespacedName, &workflowNeedUpdate)
+			if err != nil {
+				return err
+			}
+			delete(workflowNeedUpdate.Annotations, v1alpha1.ResumeFromFailedAnnotationKey)
+			return it.kubeClient.Update(ctx, &workflowNeedUpdate)
+		})
+		if err != nil {
+			it.logger.Error(err, "failed to remove the annotation of resuming workflow",
+				"workflow", request.NamespacedName)
+			return reconcile.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
 	entryNodes, err := it.fetchEntryNode(ctx, workflow)
 	if err != nil {
 		it.logger.Error(err, "failed to list entry nodes of workflow",
@@ -68,7 +96,7 @@ func (it *WorkflowEntryReconciler) Reconcile(request reconcile.Request) (reconci
 	if len(entryNodes) == 0 {
 		func() {
 			// Not scheduled yet, spawn the entry workflow node
-			spawnedEntryNode, err := it.spawnEntryNode(ctx, workflow)
+			spawnedEntryNode, err := it.spawnEntryNode(ctx, workflow, startTime)
 			if err != nil {
 				it.eventRecorder.Event(&workflow, recorder.InvalidEntry{
 					EntryTemplate: workflow.Spec.Entry,
@@ -150,11 +178,22 @@ func (it *WorkflowEntryReconciler) Reconcile(request reconcile.Request) (reconci
 			})
 
 			if WorkflowNodeFinished(entryNodes[0].Status) {
-				SetWorkflowCondition(&workflowNeedUpdate.Status, v1alpha1.WorkflowCondition{
-					Type:   v1alpha1.WorkflowConditionAccomplished,
-					Status: corev1.ConditionTrue,
-					Reason: "",
-				})
+				if !WorkflowConditionEqualsTo(workflowNeedUpdate.Status, v1alpha1.WorkflowConditionAccomplished, corev1.ConditionTrue) {
+					reason := ""
+					if workflowDeadlineExceed(workflowNeedUpdate, entryNodes[0], time.Now()) {
+						it.logger.Info("deadline of workflow exceed", "workflow", request.NamespacedName)
+						reason = v1alpha1.WorkflowDeadlineExceed
+					} else if WorkflowNodeFailed(entryNodes[0].Status) || WorkflowNodeChildFailed(entryNodes[0].Status) {
+						it.logger.Info("workflow accomplished with failed nodes", "workflow", request.NamespacedName)
+						reason = v1alpha1.WorkflowFailed
+						it.eventRecorder.Event(&workflow, recorder.WorkflowFailed{})
+					}
+					SetWorkflowCondition(&workflowNeedUpdate.Status, v1alpha1.WorkflowCondition{
+						Type:   v1alpha1.WorkflowConditionAccomplished,
+						Status: corev1.ConditionTrue,
+						Reason: reason,
+					})
+				}
 				if workflowNeedUpdate.Status.EndTime == nil {
 					now := metav1.NewTime(time.Now())
 					workflowNeedUpdate.Status.EndTime = &now
@@ -231,8 +270,9 @@ func (it *WorkflowEntryReconciler) fetchEntryNode(ctx context.Context, workflow
 	return sortedEntryNodes, nil
 }
 
-// spawnEntryNode will create **one** entry workflow node for current workflow
-func (it *WorkflowEntryReconciler) spawnEntryNode(ctx context.Context, workflow v1alpha1.Workflow) (*v1alpha1.WorkflowNode, error) {
+// spawnEntryNode will create **one** entry workflow node for current workflow, the deadline of the entry node is
+// limited by the deadline of the whole workflow.
+func (it *WorkflowEntryReconciler) spawnEntryNode(ctx context.Context, workflow v1alpha1.Workflow, startTime time.Time) (*v1alpha1.WorkflowNode, error) {
 	// This workflow is just created, create entry node
 	nodes, err := renderNodesByTemplates(&workflow, nil, workflow.Spec.Entry)
 	if err != nil {
@@ -248,6 +288,19 @@ func (it *WorkflowEntryReconciler) spawnEntryNode(ctx context.Context, workflow
 	}
 
 	entryNode := nodes[0]
+
+	if workflow.Status.StartTime != nil {
+		startTime = workflow.Status.StartTime.Time
+	}
+	workflowDeadline, err := renderWorkflowDeadline(workflow, startTime)
+	if err != nil {
+		it.logger.Error(err, "failed to render the deadline of workflow", "workflow", workflow.Name, "deadline", workflow.Spec.Deadline)
+		return nil, err
+	}
+	if workflowDeadline != nil && (entryNode.Spec.Deadline == nil || workflowDeadline.Before(entryNode.Spec.Deadline)) {
+		entryNode.Spec.Deadline = workflowDeadline
+	}
+
 	err = it.kubeClient.Create(ctx, entryNode)
 	if err != nil {
 		it.logger.Info("failed to create workflow nodes")
@@ -260,3 +313,19 @@ func (it *WorkflowEntryReconciler) spawnEntryNode(ctx context.Context, workflow
 
 	return entryNode, nil
 }
+
+// workflowDeadlineExceed returns true if the entry node is finished because the deadline of the whole workflow is exceeded.
+func workflowDeadlineExceed(workflow v1alpha1.Workflow, entryNode v1alpha1.WorkflowNode, now time.Time) bool {
+	if workflow.Status.StartTime == nil {
+		return false
+	}
+	if ConditionEqualsTo(entryNode.Status, v1alpha1.ConditionAccomplished, corev1.ConditionTrue) ||
+		!ConditionEqualsTo(entryNode.Status, v1alpha1.ConditionDeadlineExceed, corev1.ConditionTrue) {
+		return false
+	}
+	deadline, err := renderWorkflowDeadline(workflow, workflow.Status.StartTime.Time)
+	if err != nil || deadline == nil {
+		return false
+	}
+	return !now.Before(deadline.Time)
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func TestWorkflowDeadlineExceed(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	deadline := "1m"
+	startTime := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "workflow"},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry:    "entry",
+			Deadline: &deadline,
+			Templates: []v1alpha1.Template{
+				{Name: "entry", Type: v1alpha1.TypeSerial, Children: []string{"child"}},
+				{Name: "child", Type: v1alpha1.TypeSerial, Children: []string{"network-delay"}},
+				{Name: "network-delay", Type: v1alpha1.TypeNetworkChaos},
+			},
+		},
+		Status: v1alpha1.WorkflowStatus{StartTime: &startTime},
+	}
+
+	workflowDeadline, err := renderWorkflowDeadline(*workflow, startTime.Time)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(workflowDeadline.Time).To(Equal(startTime.Add(time.Minute)))
+
+	newNode := func(name string, templateName string, templateType v1alpha1.TemplateType, controlledBy string) *v1alpha1.WorkflowNode {
+		return &v1alpha1.WorkflowNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{v1alpha1.LabelControlledBy: controlledBy},
+			},
+			Spec: v1alpha1.WorkflowNodeSpec{
+				TemplateName: templateName,
+				WorkflowName: workflow.Name,
+				Type:         templateType,
+				StartTime:    &startTime,
+			},
+		}
+	}
+	// the deadline of the entry node is limited by the workflow, and the nodes below it have no deadline
+	entryNode := newNode("entry-0", "entry", v1alpha1.TypeSerial, workflow.Name)
+	entryNode.Spec.Deadline = workflowDeadline
+	childNode := newNode("child-0", "child", v1alpha1.TypeSerial, entryNode.Name)
+	chaosNode := newNode("network-delay-0", "network-delay", v1alpha1.TypeNetworkChaos, childNode.Name)
+
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), workflow, entryNode, childNode, chaosNode)
+	logger := zap.New(zap.UseDevMode(true))
+	deadlineReconciler := NewDeadlineReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+	// the entry node is reconciled again once its deadline exceeds, and then propagates it down
+	for _, node := range []*v1alpha1.WorkflowNode{entryNode, entryNode, childNode} {
+		_, err := deadlineReconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: node.Namespace, Name: node.Name}})
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	// the running chaos node is finished, so the chaos would be recovered
+	updatedChaosNode := v1alpha1.WorkflowNode{}
+	g.Expect(kubeClient.Get(ctx, types.NamespacedName{Namespace: chaosNode.Namespace, Name: chaosNode.Name}, &updatedChaosNode)).To(Succeed())
+	g.Expect(WorkflowNodeFinished(updatedChaosNode.Status)).To(BeTrue())
+
+	entryReconciler := NewWorkflowEntryReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+	_, err = entryReconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	updatedWorkflow := v1alpha1.Workflow{}
+	g.Expect(kubeClient.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, &updatedWorkflow)).To(Succeed())
+	accomplished := GetWorkflowCondition(updatedWorkflow.Status, v1alpha1.WorkflowConditionAccomplished)
+	g.Expect(accomplished).ToNot(BeNil())
+	g.Expect(accomplished.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(accomplished.Reason).To(Equal(v1alpha1.WorkflowDeadlineExceed))
+	g.Expect(updatedWorkflow.Status.EndTime).ToNot(BeNil())
+}
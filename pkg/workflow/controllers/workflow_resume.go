@@ -0,0 +1,235 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// resumeFromFailedNodes creates the failed nodes of the workflow again, and returns the names of the failed nodes.
+// The nodes aborted by the parallel node which failed fast are resumed as the failed ones.
+//
+// The failed node is replaced with a new one rendered from its spec, the nodes after it or after its ancestors under
+// the serial nodes are removed, so they would be spawned again once the new one finishes. The ancestors of the failed node are marked
+// as unaccomplished, the succeeded nodes are kept as they are. The failed node could not be resumed if the deadline
+// of any of its ancestors is exceeded.
+func (it *WorkflowEntryReconciler) resumeFromFailedNodes(ctx context.Context, workflow v1alpha1.Workflow, now time.Time) ([]string, error) {
+	var workflowDeadline *metav1.Time
+	if workflow.Status.StartTime != nil {
+		deadline, err := renderWorkflowDeadline(workflow, workflow.Status.StartTime.Time)
+		if err != nil {
+			return nil, err
+		}
+		if deadline != nil && !now.Before(deadline.Time) {
+			it.logger.Info("deadline of workflow exceed, could not resume it",
+				"workflow", fmt.Sprintf("%s/%s", workflow.Namespace, workflow.Name))
+			return nil, nil
+		}
+		workflowDeadline = deadline
+	}
+
+	nodes, err := it.fetchNodes(ctx, workflow)
+	if err != nil {
+		return nil, err
+	}
+	nodesByName := make(map[string]v1alpha1.WorkflowNode)
+	positions := make(map[string]int)
+	for index, node := range nodes {
+		nodesByName[node.Name] = node
+		positions[node.Name] = index
+	}
+
+	removed := make(map[string]struct{})
+	var failedNodes []string
+	for _, node := range nodes {
+		if !WorkflowNodeFailed(node.Status) && !WorkflowNodeAborted(node.Status) {
+			continue
+		}
+
+		ancestors := ancestorsOf(node, nodesByName)
+		resumable := true
+		for _, ancestor := range append([]v1alpha1.WorkflowNode{node}, ancestors...) {
+			if _, ok := removed[ancestor.Name]; ok {
+				// it's removed with the nodes after the failed node, or with its removed ancestor
+				resumable = false
+				break
+			}
+		}
+		for _, ancestor := range ancestors {
+			if ancestor.Spec.Deadline != nil && !now.Before(ancestor.Spec.Deadline.Time) {
+				it.logger.Info("deadline of the ancestor of failed node exceed, could not resume it",
+					"failed node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
+					"ancestor", ancestor.Name,
+				)
+				resumable = false
+				break
+			}
+		}
+		if !resumable {
+			continue
+		}
+
+		resumedNode := renderResumedNode(node, workflowDeadline, now)
+		err := it.kubeClient.Create(ctx, resumedNode)
+		if err != nil {
+			it.logger.Error(err, "failed to create the node to resume failed node",
+				"failed node", fmt.Sprintf("%s/%s", node.Namespace, node.Name))
+			return failedNodes, err
+		}
+
+		// the failed node is removed after its replacement is created, so its parent would never spawn it again
+		nodesToRemove := []v1alpha1.WorkflowNode{node}
+		descendant := node
+		for _, ancestor := range ancestors {
+			if ancestor.Spec.Type == v1alpha1.TypeSerial {
+				for _, sibling := range nodes[positions[descendant.Name]+1:] {
+					if sibling.Labels[v1alpha1.LabelControlledBy] == ancestor.Name {
+						nodesToRemove = append(nodesToRemove, sibling)
+					}
+				}
+			}
+			descendant = ancestor
+		}
+		for _, item := range nodesToRemove {
+			item := item
+			err := it.kubeClient.Delete(ctx, &item)
+			if client.IgnoreNotFound(err) != nil {
+				it.logger.Error(err, "failed to remove the node to resume failed node",
+					"failed node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
+					"node", item.Name,
+				)
+				return failedNodes, err
+			}
+			removed[item.Name] = struct{}{}
+		}
+
+		for _, ancestor := range ancestors {
+			err := it.markUnaccomplished(ctx, ancestor)
+			if err != nil {
+				return failedNodes, err
+			}
+		}
+
+		it.logger.Info("failed node resumed",
+			"failed node", fmt.Sprintf("%s/%s", node.Namespace, node.Name),
+			"resumed node", resumedNode.Name,
+		)
+		failedNodes = append(failedNodes, node.Name)
+	}
+
+	return failedNodes, nil
+}
+
+// fetchNodes returns all the workflow nodes of the workflow, sorted by the creation timestamp.
+func (it *WorkflowEntryReconciler) fetchNodes(ctx context.Context, workflow v1alpha1.Workflow) ([]v1alpha1.WorkflowNode, error) {
+	nodeList := v1alpha1.WorkflowNodeList{}
+	ofWorkflow, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			v1alpha1.LabelWorkflow: workflow.Name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = it.kubeClient.List(ctx, &nodeList, &client.ListOptions{
+		Namespace:     workflow.Namespace,
+		LabelSelector: ofWorkflow,
+	})
+	if err != nil {
+		it.logger.Error(err, "failed to list workflow nodes of workflow",
+			"workflow", fmt.Sprintf("%s/%s", workflow.Namespace, workflow.Name))
+		return nil, err
+	}
+
+	sortedNodes := SortByCreationTimestamp(nodeList.Items)
+	sort.Stable(sortedNodes)
+
+	return sortedNodes, nil
+}
+
+// markUnaccomplished marks the node as unaccomplished, so it would sync its children again.
+func (it *WorkflowEntryReconciler) markUnaccomplished(ctx context.Context, node v1alpha1.WorkflowNode) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		nodeNeedUpdate := v1alpha1.WorkflowNode{}
+		err := it.kubeClient.Get(ctx, client.ObjectKey{Namespace: node.Namespace, Name: node.Name}, &nodeNeedUpdate)
+		if err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		SetCondition(&nodeNeedUpdate.Status, v1alpha1.WorkflowNodeCondition{
+			Type:   v1alpha1.ConditionAccomplished,
+			Status: corev1.ConditionFalse,
+			Reason: "",
+		})
+		return client.IgnoreNotFound(it.kubeClient.Status().Update(ctx, &nodeNeedUpdate))
+	})
+}
+
+// ancestorsOf returns the ancestors of the node, from its parent to the entry node.
+func ancestorsOf(node v1alpha1.WorkflowNode, nodesByName map[string]v1alpha1.WorkflowNode) []v1alpha1.WorkflowNode {
+	var ancestors []v1alpha1.WorkflowNode
+	for {
+		parent, ok := nodesByName[node.Labels[v1alpha1.LabelControlledBy]]
+		if !ok {
+			return ancestors
+		}
+		ancestors = append(ancestors, parent)
+		node = parent
+	}
+}
+
+// renderResumedNode renders the node to replace the failed node. It runs as long as the failed node was planned to,
+// but never beyond the deadline of the whole workflow.
+func renderResumedNode(failedNode v1alpha1.WorkflowNode, workflowDeadline *metav1.Time, now time.Time) *v1alpha1.WorkflowNode {
+	spec := failedNode.Spec.DeepCopy()
+	if spec.Deadline != nil && spec.StartTime != nil {
+		deadline := metav1.NewTime(now.Add(spec.Deadline.Sub(spec.StartTime.Time)))
+		spec.Deadline = &deadline
+	}
+	if workflowDeadline != nil && (spec.Deadline == nil || workflowDeadline.Before(spec.Deadline)) {
+		spec.Deadline = workflowDeadline.DeepCopy()
+	}
+	startTime := metav1.NewTime(now)
+	spec.StartTime = &startTime
+
+	resumedNode := &v1alpha1.WorkflowNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       failedNode.Namespace,
+			Name:            names.SimpleNameGenerator.GenerateName(fmt.Sprintf("%s-", spec.TemplateName)),
+			Labels:          make(map[string]string),
+			Annotations:     make(map[string]string),
+			OwnerReferences: failedNode.OwnerReferences,
+			Finalizers:      failedNode.Finalizers,
+		},
+		Spec: *spec,
+	}
+	for key, value := range failedNode.Labels {
+		resumedNode.Labels[key] = value
+	}
+	for key, value := range failedNode.Annotations {
+		resumedNode.Annotations[key] = value
+	}
+	return resumedNode
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func TestResumeWorkflowFromFailedNodes(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+
+	startTime := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   metav1.NamespaceDefault,
+			Name:        "workflow",
+			Annotations: map[string]string{v1alpha1.ResumeFromFailedAnnotationKey: "true"},
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Entry: "entry",
+			Templates: []v1alpha1.Template{
+				{Name: "entry", Type: v1alpha1.TypeSerial, Children: []string{"prepare", "inject", "cleanup"}},
+				{Name: "prepare", Type: v1alpha1.TypeNetworkChaos},
+				{Name: "inject", Type: v1alpha1.TypeParallel, Children: []string{"pod-kill", "io-delay"}},
+				{Name: "pod-kill", Type: v1alpha1.TypePodChaos},
+				{Name: "io-delay", Type: v1alpha1.TypeIOChaos},
+				{Name: "cleanup", Type: v1alpha1.TypeNetworkChaos},
+			},
+		},
+		Status: v1alpha1.WorkflowStatus{StartTime: &startTime},
+	}
+
+	created := 0
+	newNode := func(name string, templateName string, templateType v1alpha1.TemplateType, controlledBy string, conditions ...v1alpha1.WorkflowNodeCondition) *v1alpha1.WorkflowNode {
+		// the nodes are created one by one
+		created++
+		creationTimestamp := metav1.NewTime(startTime.Add(time.Duration(created) * time.Second))
+		deadline := metav1.NewTime(creationTimestamp.Add(time.Minute))
+		return &v1alpha1.WorkflowNode{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         metav1.NamespaceDefault,
+				Name:              name,
+				CreationTimestamp: creationTimestamp,
+				Labels: map[string]string{
+					v1alpha1.LabelControlledBy: controlledBy,
+					v1alpha1.LabelWorkflow:     workflow.Name,
+				},
+			},
+			Spec: v1alpha1.WorkflowNodeSpec{
+				TemplateName: templateName,
+				WorkflowName: workflow.Name,
+				Type:         templateType,
+				StartTime:    &creationTimestamp,
+				Deadline:     &deadline,
+			},
+			Status: v1alpha1.WorkflowNodeStatus{Conditions: conditions},
+		}
+	}
+	accomplished := v1alpha1.WorkflowNodeCondition{Type: v1alpha1.ConditionAccomplished, Status: corev1.ConditionTrue}
+	deadlineExceed := v1alpha1.WorkflowNodeCondition{Type: v1alpha1.ConditionDeadlineExceed, Status: corev1.ConditionTrue, Reason: v1alpha1.NodeDeadlineExceed}
+	chaosCreateFailed := v1alpha1.WorkflowNodeCondition{Type: v1alpha1.ConditionChaosInjected, Status: corev1.ConditionFalse, Reason: v1alpha1.ChaosCRCreateFailed}
+
+	entryNode := newNode("entry-0", "entry", v1alpha1.TypeSerial, workflow.Name, accomplished)
+	entryNode.Spec.Deadline = nil
+	prepareNode := newNode("prepare-0", "prepare", v1alpha1.TypeNetworkChaos, entryNode.Name, deadlineExceed)
+	injectNode := newNode("inject-0", "inject", v1alpha1.TypeParallel, entryNode.Name, accomplished)
+	injectNode.Spec.Deadline = nil
+	podKillNode := newNode("pod-kill-0", "pod-kill", v1alpha1.TypePodChaos, injectNode.Name, deadlineExceed)
+	ioDelayNode := newNode("io-delay-0", "io-delay", v1alpha1.TypeIOChaos, injectNode.Name, deadlineExceed, chaosCreateFailed)
+	cleanupNode := newNode("cleanup-0", "cleanup", v1alpha1.TypeNetworkChaos, entryNode.Name, deadlineExceed)
+
+	g.Expect(WorkflowNodeFailed(ioDelayNode.Status)).To(BeTrue())
+	g.Expect(WorkflowNodeFailed(podKillNode.Status)).To(BeFalse())
+
+	kubeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), workflow, entryNode, prepareNode, injectNode, podKillNode, ioDelayNode, cleanupNode)
+	logger := zap.New(zap.UseDevMode(true))
+	entryReconciler := NewWorkflowEntryReconciler(kubeClient, recorder.NewDebugRecorder(), logger)
+	_, err := entryReconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	nodeList := v1alpha1.WorkflowNodeList{}
+	g.Expect(kubeClient.List(ctx, &nodeList, client.InNamespace(workflow.Namespace))).To(Succeed())
+	nodes := make(map[string]v1alpha1.WorkflowNode)
+	var resumedNodes []v1alpha1.WorkflowNode
+	for _, node := range nodeList.Items {
+		nodes[node.Name] = node
+		if node.CreationTimestamp.IsZero() {
+			resumedNodes = append(resumedNodes, node)
+		}
+	}
+
+	// the succeeded nodes are kept as they are
+	g.Expect(nodes).To(HaveKey(prepareNode.Name))
+	g.Expect(nodes[prepareNode.Name].Status).To(Equal(prepareNode.Status))
+	g.Expect(nodes).To(HaveKey(podKillNode.Name))
+	g.Expect(nodes[podKillNode.Name].Status).To(Equal(podKillNode.Status))
+
+	// the failed node is replaced, and the node after its parent would be spawned again
+	g.Expect(nodes).ToNot(HaveKey(ioDelayNode.Name))
+	g.Expect(nodes).ToNot(HaveKey(cleanupNode.Name))
+	g.Expect(resumedNodes).To(HaveLen(1))
+	resumedNode := resumedNodes[0]
+	g.Expect(getTaskNameFromGeneratedName(resumedNode.Name)).To(Equal("io-delay"))
+	g.Expect(resumedNode.Labels[v1alpha1.LabelControlledBy]).To(Equal(injectNode.Name))
+	g.Expect(resumedNode.Spec.Type).To(Equal(v1alpha1.TypeIOChaos))
+	g.Expect(resumedNode.Spec.Deadline.Sub(resumedNode.Spec.StartTime.Time)).To(Equal(time.Minute))
+	g.Expect(WorkflowNodeFinished(resumedNode.Status)).To(BeFalse())
+
+	// the ancestors of the failed node run again
+	g.Expect(WorkflowNodeFinished(nodes[injectNode.Name].Status)).To(BeFalse())
+	g.Expect(WorkflowNodeFinished(nodes[entryNode.Name].Status)).To(BeFalse())
+
+	updatedWorkflow := v1alpha1.Workflow{}
+	g.Expect(kubeClient.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: workflow.Name}, &updatedWorkflow)).To(Succeed())
+	g.Expect(updatedWorkflow.Annotations).ToNot(HaveKey(v1alpha1.ResumeFromFailedAnnotationKey))
+	g.Expect(WorkflowConditionEqualsTo(updatedWorkflow.Status, v1alpha1.WorkflowConditionAccomplished, corev1.ConditionFalse)).To(BeTrue())
+	g.Expect(updatedWorkflow.Status.EndTime).To(BeNil())
+}
@@ -19,6 +19,8 @@ var (
 	ErrNoSuchTemplate                 = New("no such template")
 	ErrParseTemplateFailed            = New("failed to parse certain type of template")
 	ErrNoMoreTemplateInSerialTemplate = New("no more template could schedule in serial template")
+	ErrUnknownTemplateType            = New("unknown type of template")
+	ErrInvalidTemplate                = New("invalid template")
 )
 
 type WorkflowError struct {
@@ -0,0 +1,41 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+type InvalidTemplateError struct {
+	Op  string
+	Err error
+
+	WorkflowName string
+	TemplateName string
+	Reason       string
+}
+
+func (e *InvalidTemplateError) Error() string {
+	return toJsonOrFallbackToError(e)
+}
+
+func (e *InvalidTemplateError) Unwrap() error {
+	return e.Err
+}
+
+func NewInvalidTemplateError(op, workflowName, templateName, reason string) *InvalidTemplateError {
+	return &InvalidTemplateError{
+		Op:           op,
+		Err:          ErrInvalidTemplate,
+		WorkflowName: workflowName,
+		TemplateName: templateName,
+		Reason:       reason,
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+type UnknownTemplateTypeError struct {
+	Op  string
+	Err error
+
+	WorkflowName string
+	TemplateName string
+	TemplateType string
+}
+
+func (e *UnknownTemplateTypeError) Error() string {
+	return toJsonOrFallbackToError(e)
+}
+
+func (e *UnknownTemplateTypeError) Unwrap() error {
+	return e.Err
+}
+
+func NewUnknownTemplateTypeError(op, workflowName, templateName, templateType string) *UnknownTemplateTypeError {
+	return &UnknownTemplateTypeError{
+		Op:           op,
+		Err:          ErrUnknownTemplateType,
+		WorkflowName: workflowName,
+		TemplateName: templateName,
+		TemplateType: templateType,
+	}
+}
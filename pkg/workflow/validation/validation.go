@@ -0,0 +1,78 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/workflow/errors"
+)
+
+// ValidateWorkflow checks the spec of workflow statically, before any node of it is spawned. It returns all the
+// problems found: the entry or the children referring to templates which don't exist, the templates with unknown
+// types, and the templates with invalid embedded chaos.
+func ValidateWorkflow(workflow v1alpha1.Workflow) []error {
+	op := "validation.ValidateWorkflow"
+
+	var result []error
+	templates := make(map[string]v1alpha1.Template)
+	for _, template := range workflow.Spec.Templates {
+		templates[template.Name] = template
+	}
+	mustExist := func(templateName string) {
+		if _, ok := templates[templateName]; !ok {
+			result = append(result, errors.NewNoSuchTemplateError(op, workflow.Name, templateName))
+		}
+	}
+
+	mustExist(workflow.Spec.Entry)
+	for _, template := range workflow.Spec.Templates {
+		switch {
+		case template.Type == v1alpha1.TypeSerial, template.Type == v1alpha1.TypeParallel:
+			for _, child := range template.Children {
+				mustExist(child)
+			}
+			if template.ForEach != nil {
+				mustExist(template.ForEach.Template)
+			}
+		case template.Type == v1alpha1.TypeTask:
+			for _, branch := range template.ConditionalBranches {
+				mustExist(branch.Target)
+			}
+		case template.Type == v1alpha1.TypeSuspend:
+		case template.Type == v1alpha1.TypeSchedule:
+			if template.Schedule == nil {
+				result = append(result, errors.NewInvalidTemplateError(op, workflow.Name, template.Name, "the spec of schedule is missing"))
+				continue
+			}
+			result = append(result, validateEmbedChaos(op, workflow.Name, template.Name, template.Schedule.EmbedChaos, string(template.Schedule.Type))...)
+		case v1alpha1.IsChaosTemplateType(template.Type):
+			if template.EmbedChaos == nil {
+				result = append(result, errors.NewInvalidTemplateError(op, workflow.Name, template.Name, "the spec of chaos is missing"))
+				continue
+			}
+			result = append(result, validateEmbedChaos(op, workflow.Name, template.Name, *template.EmbedChaos, string(template.Type))...)
+		default:
+			result = append(result, errors.NewUnknownTemplateTypeError(op, workflow.Name, template.Name, string(template.Type)))
+		}
+	}
+	return result
+}
+
+func validateEmbedChaos(op, workflowName, templateName string, chaos v1alpha1.EmbedChaos, chaosType string) []error {
+	// validating the chaos fills the defaults into its spec, so validate a copy of it
+	if errs := chaos.DeepCopy().Validate(chaosType); len(errs) > 0 {
+		return []error{errors.NewInvalidTemplateError(op, workflowName, templateName, errs.ToAggregate().Error())}
+	}
+	return nil
+}
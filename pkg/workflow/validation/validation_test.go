@@ -0,0 +1,89 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	workflowerrors "github.com/chaos-mesh/chaos-mesh/pkg/workflow/errors"
+)
+
+func TestValidateWorkflow(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deadline := "1m"
+	newWorkflow := func(entry string, templates ...v1alpha1.Template) v1alpha1.Workflow {
+		return v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "workflow"},
+			Spec: v1alpha1.WorkflowSpec{
+				Entry:     entry,
+				Templates: templates,
+			},
+		}
+	}
+	podKill := v1alpha1.Template{
+		Name:     "pod-kill",
+		Type:     v1alpha1.TypePodChaos,
+		Deadline: &deadline,
+		EmbedChaos: &v1alpha1.EmbedChaos{
+			PodChaos: &v1alpha1.PodChaosSpec{
+				ContainerSelector: v1alpha1.ContainerSelector{
+					PodSelector: v1alpha1.PodSelector{Mode: v1alpha1.OnePodMode},
+				},
+				Action: v1alpha1.PodKillAction,
+			},
+		},
+	}
+
+	errs := ValidateWorkflow(newWorkflow("entry",
+		v1alpha1.Template{Name: "entry", Type: v1alpha1.TypeSerial, Children: []string{"pod-kill"}},
+		podKill,
+	))
+	g.Expect(errs).To(BeEmpty())
+
+	// the serial template refers to a template which doesn't exist
+	errs = ValidateWorkflow(newWorkflow("entry",
+		v1alpha1.Template{Name: "entry", Type: v1alpha1.TypeSerial, Children: []string{"pod-kill", "dangling"}},
+		podKill,
+	))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errors.Is(errs[0], workflowerrors.ErrNoSuchTemplate)).To(BeTrue())
+	g.Expect(errs[0].(*workflowerrors.NoSuchTemplateError).TemplateName).To(Equal("dangling"))
+
+	// the entry doesn't exist
+	errs = ValidateWorkflow(newWorkflow("dangling", podKill))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errors.Is(errs[0], workflowerrors.ErrNoSuchTemplate)).To(BeTrue())
+
+	errs = ValidateWorkflow(newWorkflow("entry", v1alpha1.Template{Name: "entry", Type: "Unknown"}))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errors.Is(errs[0], workflowerrors.ErrUnknownTemplateType)).To(BeTrue())
+
+	// the embedded chaos is missing, or invalid as the container-kill without container names
+	errs = ValidateWorkflow(newWorkflow("pod-kill", v1alpha1.Template{Name: "pod-kill", Type: v1alpha1.TypePodChaos}))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errors.Is(errs[0], workflowerrors.ErrInvalidTemplate)).To(BeTrue())
+
+	containerKillWithoutNames := podKill
+	containerKillWithoutNames.EmbedChaos = podKill.EmbedChaos.DeepCopy()
+	containerKillWithoutNames.PodChaos.Action = v1alpha1.ContainerKillAction
+	errs = ValidateWorkflow(newWorkflow("pod-kill", containerKillWithoutNames))
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errors.Is(errs[0], workflowerrors.ErrInvalidTemplate)).To(BeTrue())
+}
@@ -12,6 +12,9 @@ export const state = (namespace = null) =>
 
 export const newExperiment = (data: Experiment) => http.post('/experiments/new', data)
 
+export const clone = (namespace: string, kind: string, name: string, data: { namespace: string; name?: string }) =>
+  http.post(`/experiments/${namespace}/${kind}/${name}/clone`, data)
+
 export const experiments = (namespace = null, name = null, kind = null) =>
   http.get<ExperimentResponse[]>('/experiments', {
     params: {
@@ -23,6 +23,7 @@ interface ConditionalBranch {
   name: string
   template: string
   Expression: string
+  evaluation_result?: 'True' | 'False' | 'Unknown'
 }
 
 export interface Node {
@@ -35,9 +36,15 @@ export interface Node {
   conditional_branches?: Array<ConditionalBranch>
 }
 
+export interface TreeNode {
+  name: string
+  children?: TreeNode[]
+}
+
 export interface WorkflowSingle extends Workflow {
   topology: {
     nodes: Node[]
+    tree: TreeNode[]
   }
   kube_object: any
 }
@@ -282,7 +282,7 @@ export const constructWorkflowTopology = (
   function updateElements(detail: WorkflowSingle) {
     clearInterval(flashRunning)
     flashRunning = window.setInterval(() => {
-      const nodes = cy.$('node.Running')
+      const nodes = cy.$('node.Running, node.Holding, node.WaitingForChild, node.Evaluating')
 
       if (nodes.length) {
         nodes